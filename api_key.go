@@ -0,0 +1,41 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+)
+
+// APIKeySource provides the API key of each request, such as a key read
+// from a secret store and rotated there. It must be safe for concurrent use,
+// and fast or caching, as it is called for every request.
+type APIKeySource interface {
+	APIKey(ctx context.Context) (string, error)
+}
+
+// APIKeySourceFunc adapts a function to the APIKeySource interface.
+type APIKeySourceFunc func(ctx context.Context) (string, error)
+
+func (f APIKeySourceFunc) APIKey(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// SetAPIKey replaces the API key of the client, for the requests made from
+// then on. Requests in flight keep the key they were sent with. It is safe to
+// call concurrently with requests, and has no effect when
+// ClientConfig.APIKeySource is set.
+func (c *Client) SetAPIKey(key string) {
+	c.authToken.Store(key)
+}
+
+// apiKey returns the API key of a request made with ctx.
+func (c *Client) apiKey(ctx context.Context) (string, error) {
+	if c.config.APIKeySource != nil {
+		key, err := c.config.APIKeySource.APIKey(ctx)
+		if err != nil {
+			return "", fmt.Errorf("error, getting API key: %w", err)
+		}
+		return key, nil
+	}
+	key, _ := c.authToken.Load().(string)
+	return key, nil
+}
@@ -0,0 +1,109 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+// setupAPIKeyServer returns a config for a server recording the API keys it
+// receives.
+func setupAPIKeyServer(t *testing.T) (openai.ClientConfig, func() []string) {
+	t.Helper()
+	var (
+		mu   sync.Mutex
+		keys []string
+	)
+	// The test server only accepts the test token.
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys = append(keys, r.Header.Get("Authorization"))
+		mu.Unlock()
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	}))
+	t.Cleanup(ts.Close)
+
+	config := openai.DefaultConfig("key-1")
+	config.BaseURL = ts.URL + "/v1"
+	return config, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), keys...)
+	}
+}
+
+func TestSetAPIKey(t *testing.T) {
+	config, keys := setupAPIKeyServer(t)
+	client := openai.NewClientWithConfig(config)
+
+	_, err := client.ListModels(context.Background())
+	checks.NoError(t, err)
+	client.SetAPIKey("key-2")
+	_, err = client.ListModels(context.Background())
+	checks.NoError(t, err)
+
+	got := keys()
+	if len(got) != 2 || got[0] != "Bearer key-1" || got[1] != "Bearer key-2" {
+		t.Errorf("keys = %v", got)
+	}
+}
+
+func TestSetAPIKeyConcurrent(t *testing.T) {
+	config, keys := setupAPIKeyServer(t)
+	client := openai.NewClientWithConfig(config)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			client.SetAPIKey(fmt.Sprintf("key-%d", i))
+		}(i)
+		go func() {
+			defer wg.Done()
+			_, err := client.ListModels(context.Background())
+			checks.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	for _, key := range keys() {
+		if key == "" || key == "Bearer " {
+			t.Errorf("request sent without a key: %q", key)
+		}
+	}
+}
+
+func TestAPIKeySource(t *testing.T) {
+	config, keys := setupAPIKeyServer(t)
+	errVault := errors.New("vault unavailable")
+	current := "vault-1"
+	config.APIKeySource = openai.APIKeySourceFunc(func(context.Context) (string, error) {
+		if current == "" {
+			return "", errVault
+		}
+		return current, nil
+	})
+	client := openai.NewClientWithConfig(config)
+	client.SetAPIKey("ignored")
+
+	_, err := client.ListModels(context.Background())
+	checks.NoError(t, err)
+	current = "vault-2"
+	_, err = client.ListModels(context.Background())
+	checks.NoError(t, err)
+	current = ""
+	_, err = client.ListModels(context.Background())
+	checks.ErrorIs(t, err, errVault)
+
+	got := keys()
+	if len(got) != 2 || got[0] != "Bearer vault-1" || got[1] != "Bearer vault-2" {
+		t.Errorf("keys = %v", got)
+	}
+}
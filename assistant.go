@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/url"
 )
 
 const (
@@ -42,8 +41,18 @@ const (
 )
 
 type AssistantTool struct {
-	Type     AssistantToolType   `json:"type"`
-	Function *FunctionDefinition `json:"function,omitempty"`
+	Type       AssistantToolType      `json:"type"`
+	Function   *FunctionDefinition    `json:"function,omitempty"`
+	FileSearch *FileSearchToolOptions `json:"file_search,omitempty"`
+}
+
+// FileSearchToolOptions tunes the file_search tool of assistants and runs.
+type FileSearchToolOptions struct {
+	// MaxNumResults caps the number of chunks returned, between 1 and 50.
+	MaxNumResults int `json:"max_num_results,omitempty"`
+	// RankingOptions sets the ranker and the minimum relevance score of the
+	// chunks returned.
+	RankingOptions *FileSearchRankingOptions `json:"ranking_options,omitempty"`
 }
 
 type AssistantToolFileSearch struct {
@@ -136,8 +145,7 @@ type AssistantFilesList struct {
 
 // CreateAssistant creates a new assistant.
 func (c *Client) CreateAssistant(ctx context.Context, request AssistantRequest) (response Assistant, err error) {
-	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(assistantsSuffix), withBody(request),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(assistantsSuffix), withBody(request))
 	if err != nil {
 		return
 	}
@@ -152,8 +160,7 @@ func (c *Client) RetrieveAssistant(
 	assistantID string,
 ) (response Assistant, err error) {
 	urlSuffix := fmt.Sprintf("%s/%s", assistantsSuffix, assistantID)
-	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
 	if err != nil {
 		return
 	}
@@ -169,8 +176,7 @@ func (c *Client) ModifyAssistant(
 	request AssistantRequest,
 ) (response Assistant, err error) {
 	urlSuffix := fmt.Sprintf("%s/%s", assistantsSuffix, assistantID)
-	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix), withBody(request),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix), withBody(request))
 	if err != nil {
 		return
 	}
@@ -185,8 +191,7 @@ func (c *Client) DeleteAssistant(
 	assistantID string,
 ) (response AssistantDeleteResponse, err error) {
 	urlSuffix := fmt.Sprintf("%s/%s", assistantsSuffix, assistantID)
-	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL(urlSuffix))
 	if err != nil {
 		return
 	}
@@ -203,28 +208,17 @@ func (c *Client) ListAssistants(
 	after *string,
 	before *string,
 ) (response AssistantsList, err error) {
-	urlValues := url.Values{}
-	if limit != nil {
-		urlValues.Add("limit", fmt.Sprintf("%d", *limit))
-	}
-	if order != nil {
-		urlValues.Add("order", *order)
-	}
-	if after != nil {
-		urlValues.Add("after", *after)
-	}
-	if before != nil {
-		urlValues.Add("before", *before)
-	}
-
-	encodedValues := ""
-	if len(urlValues) > 0 {
-		encodedValues = "?" + urlValues.Encode()
-	}
+	return c.ListAssistantsWithOptions(ctx, ListOptions{Limit: limit, Order: order, After: after, Before: before})
+}
 
-	urlSuffix := fmt.Sprintf("%s%s", assistantsSuffix, encodedValues)
-	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+// ListAssistantsWithOptions lists the currently available assistants, one
+// page at a time.
+func (c *Client) ListAssistantsWithOptions(
+	ctx context.Context,
+	opts ListOptions,
+) (response AssistantsList, err error) {
+	urlSuffix := withQuery(assistantsSuffix, opts.values())
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
 	if err != nil {
 		return
 	}
@@ -241,8 +235,7 @@ func (c *Client) CreateAssistantFile(
 ) (response AssistantFile, err error) {
 	urlSuffix := fmt.Sprintf("%s/%s%s", assistantsSuffix, assistantID, assistantsFilesSuffix)
 	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix),
-		withBody(request),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBody(request))
 	if err != nil {
 		return
 	}
@@ -258,8 +251,7 @@ func (c *Client) RetrieveAssistantFile(
 	fileID string,
 ) (response AssistantFile, err error) {
 	urlSuffix := fmt.Sprintf("%s/%s%s/%s", assistantsSuffix, assistantID, assistantsFilesSuffix, fileID)
-	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
 	if err != nil {
 		return
 	}
@@ -275,8 +267,7 @@ func (c *Client) DeleteAssistantFile(
 	fileID string,
 ) (err error) {
 	urlSuffix := fmt.Sprintf("%s/%s%s/%s", assistantsSuffix, assistantID, assistantsFilesSuffix, fileID)
-	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL(urlSuffix))
 	if err != nil {
 		return
 	}
@@ -294,28 +285,19 @@ func (c *Client) ListAssistantFiles(
 	after *string,
 	before *string,
 ) (response AssistantFilesList, err error) {
-	urlValues := url.Values{}
-	if limit != nil {
-		urlValues.Add("limit", fmt.Sprintf("%d", *limit))
-	}
-	if order != nil {
-		urlValues.Add("order", *order)
-	}
-	if after != nil {
-		urlValues.Add("after", *after)
-	}
-	if before != nil {
-		urlValues.Add("before", *before)
-	}
-
-	encodedValues := ""
-	if len(urlValues) > 0 {
-		encodedValues = "?" + urlValues.Encode()
-	}
+	return c.ListAssistantFilesWithOptions(ctx, assistantID,
+		ListOptions{Limit: limit, Order: order, After: after, Before: before})
+}
 
-	urlSuffix := fmt.Sprintf("%s/%s%s%s", assistantsSuffix, assistantID, assistantsFilesSuffix, encodedValues)
-	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+// ListAssistantFilesWithOptions lists the files of an assistant, one page at
+// a time.
+func (c *Client) ListAssistantFilesWithOptions(
+	ctx context.Context,
+	assistantID string,
+	opts ListOptions,
+) (response AssistantFilesList, err error) {
+	urlSuffix := withQuery(fmt.Sprintf("%s/%s%s", assistantsSuffix, assistantID, assistantsFilesSuffix), opts.values())
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
 	if err != nil {
 		return
 	}
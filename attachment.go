@@ -0,0 +1,63 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrAttachmentMissingFileID    = errors.New("attachment has no file_id")
+	ErrAttachmentMissingTools     = errors.New("attachment has no tools")
+	ErrAttachmentToolNotSupported = errors.New("attachment tool must be code_interpreter or file_search")
+	ErrAttachmentToolNotEnabled   = errors.New("attachment tool is not enabled on the assistant")
+)
+
+// AttachmentValidationError reports the attachment, and the tool within it,
+// that failed validation. Tool is -1 when the attachment itself is invalid.
+type AttachmentValidationError struct {
+	Index int
+	Tool  int
+	Err   error
+}
+
+func (e *AttachmentValidationError) Error() string {
+	if e.Tool < 0 {
+		return fmt.Sprintf("attachment %d: %s", e.Index, e.Err)
+	}
+	return fmt.Sprintf("attachment %d, tool %d: %s", e.Index, e.Tool, e.Err)
+}
+
+func (e *AttachmentValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateAttachments checks that every attachment names a file and only uses
+// tools that the assistant has enabled, which the API would otherwise only
+// report once the run fails.
+func ValidateAttachments(assistant Assistant, attachments []ThreadAttachment) error {
+	enabled := make(map[AssistantToolType]bool, len(assistant.Tools))
+	for _, tool := range assistant.Tools {
+		enabled[tool.Type] = true
+	}
+
+	for i, attachment := range attachments {
+		if attachment.FileID == "" {
+			return &AttachmentValidationError{Index: i, Tool: -1, Err: ErrAttachmentMissingFileID}
+		}
+		if len(attachment.Tools) == 0 {
+			return &AttachmentValidationError{Index: i, Tool: -1, Err: ErrAttachmentMissingTools}
+		}
+		for j, tool := range attachment.Tools {
+			toolType := AssistantToolType(tool.Type)
+			switch toolType {
+			case AssistantToolTypeCodeInterpreter, AssistantToolTypeFileSearch:
+			default:
+				return &AttachmentValidationError{Index: i, Tool: j, Err: ErrAttachmentToolNotSupported}
+			}
+			if !enabled[toolType] {
+				return &AttachmentValidationError{Index: i, Tool: j, Err: ErrAttachmentToolNotEnabled}
+			}
+		}
+	}
+	return nil
+}
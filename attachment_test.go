@@ -0,0 +1,56 @@
+package openai_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestValidateAttachments(t *testing.T) {
+	assistant := openai.Assistant{Tools: []openai.AssistantTool{{Type: openai.AssistantToolTypeFileSearch}}}
+	fileSearch := []openai.ThreadAttachmentTool{openai.NewThreadAttachmentTool(openai.AssistantToolTypeFileSearch)}
+
+	tests := []struct {
+		name        string
+		attachments []openai.ThreadAttachment
+		want        error
+	}{
+		{"valid", []openai.ThreadAttachment{{FileID: "file_1", Tools: fileSearch}}, nil},
+		{"missing file", []openai.ThreadAttachment{{Tools: fileSearch}}, openai.ErrAttachmentMissingFileID},
+		{"missing tools", []openai.ThreadAttachment{{FileID: "file_1"}}, openai.ErrAttachmentMissingTools},
+		{"unsupported tool", []openai.ThreadAttachment{{
+			FileID: "file_1",
+			Tools:  []openai.ThreadAttachmentTool{{Type: "function"}},
+		}}, openai.ErrAttachmentToolNotSupported},
+		{"tool not enabled", []openai.ThreadAttachment{{
+			FileID: "file_1",
+			Tools:  []openai.ThreadAttachmentTool{openai.NewThreadAttachmentTool(openai.AssistantToolTypeCodeInterpreter)},
+		}}, openai.ErrAttachmentToolNotEnabled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := openai.ValidateAttachments(assistant, tt.attachments)
+			if tt.want == nil {
+				checks.NoError(t, err)
+				return
+			}
+			checks.ErrorIs(t, err, tt.want)
+			var validationErr *openai.AttachmentValidationError
+			if !errors.As(err, &validationErr) || validationErr.Index != 0 {
+				t.Errorf("expected an AttachmentValidationError for attachment 0, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMessageAttachmentsRoundTrip(t *testing.T) {
+	var msg openai.Message
+	data := `{"id":"msg_1","attachments":[{"file_id":"file_1","tools":[{"type":"code_interpreter"}]}]}`
+	checks.NoError(t, json.Unmarshal([]byte(data), &msg))
+	if len(msg.Attachments) != 1 || msg.Attachments[0].Tools[0].Type != string(openai.AssistantToolTypeCodeInterpreter) {
+		t.Errorf("unexpected attachments %+v", msg.Attachments)
+	}
+}
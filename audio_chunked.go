@@ -0,0 +1,161 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// defaultTranscriptionPromptTail keeps continuation prompts within the 224
+// tokens whisper-1 considers.
+const defaultTranscriptionPromptTail = 800
+
+var ErrChunkedTranscriptionFormat = errors.New("chunked transcription does not support subtitle formats")
+
+// AudioChunk is a piece of a long recording, such as a part split with
+// ffmpeg to stay under the upload limit, transcribed by
+// CreateChunkedTranscription.
+type AudioChunk struct {
+	// FilePath and Reader are used as in AudioRequest.
+	FilePath string
+	Reader   io.Reader
+	// Offset is the start of the chunk in the recording, in seconds, added
+	// to the timestamps of its segments and words. When zero, chunks after
+	// the first are assumed to follow the previous one, whose duration is
+	// only known with AudioResponseFormatVerboseJSON.
+	Offset float64
+}
+
+// ChunkedTranscriptionOptions configures CreateChunkedTranscription.
+type ChunkedTranscriptionOptions struct {
+	// PromptTail is the maximum length in bytes of the transcript so far
+	// passed as the prompt of the next chunk, 800 by default. Negative values
+	// disable the continuation.
+	PromptTail int
+}
+
+// TranscriptionContinuationPrompt returns the end of transcript, at most
+// maxBytes long, to pass as the prompt of the next chunk of a recording. The
+// model then continues the style and vocabulary of the transcript, and words
+// cut at the chunk boundary are more likely to be recognized.
+//
+// The tail starts at a word boundary when it contains whitespace. Otherwise,
+// as in Chinese, Japanese or Thai text, it starts at the first complete rune.
+func TranscriptionContinuationPrompt(transcript string, maxBytes int) string {
+	transcript = strings.TrimSpace(transcript)
+	if maxBytes <= 0 {
+		return ""
+	}
+	if len(transcript) <= maxBytes {
+		return transcript
+	}
+	start := len(transcript) - maxBytes
+	if i := strings.IndexFunc(transcript[start:], unicode.IsSpace); i >= 0 {
+		return strings.TrimSpace(transcript[start+i:])
+	}
+	for start < len(transcript) && !utf8.RuneStart(transcript[start]) {
+		start++
+	}
+	return transcript[start:]
+}
+
+// CreateChunkedTranscription transcribes the chunks of a long recording in
+// order and merges the results into a single response: texts are joined,
+// and segments and words are renumbered and shifted by the offset of their
+// chunk. Each chunk after the first is prompted with the end of the
+// transcript so far, after request.Prompt, which improves the accuracy at
+// chunk boundaries.
+//
+// request configures every chunk; its FilePath and Reader are ignored. The
+// subtitle formats cannot be merged and are rejected. On error, the
+// transcript of the chunks before the failing one is returned along with the
+// error.
+func (c *Client) CreateChunkedTranscription(
+	ctx context.Context,
+	request AudioRequest,
+	chunks []AudioChunk,
+	opts ChunkedTranscriptionOptions,
+) (response AudioResponse, err error) {
+	if request.Format == AudioResponseFormatSRT || request.Format == AudioResponseFormatVTT {
+		err = ErrChunkedTranscriptionFormat
+		return
+	}
+	promptTail := opts.PromptTail
+	if promptTail == 0 {
+		promptTail = defaultTranscriptionPromptTail
+	}
+
+	var texts []string
+	for i, chunk := range chunks {
+		chunkRequest := request
+		chunkRequest.FilePath = chunk.FilePath
+		chunkRequest.Reader = chunk.Reader
+		if i > 0 {
+			chunkRequest.Prompt = continuationPrompt(request.Prompt, strings.Join(texts, " "), promptTail)
+		}
+
+		var chunkResponse AudioResponse
+		chunkResponse, err = c.CreateTranscription(ctx, chunkRequest)
+		if err != nil {
+			err = fmt.Errorf("error, transcribing chunk %d: %w", i, err)
+			return
+		}
+
+		offset := chunk.Offset
+		if offset == 0 && i > 0 {
+			offset = response.Duration
+		}
+		mergeTranscription(&response, chunkResponse, offset)
+		if text := strings.TrimSpace(chunkResponse.Text); text != "" {
+			texts = append(texts, text)
+		}
+		response.Text = strings.Join(texts, " ")
+	}
+	return
+}
+
+// continuationPrompt appends the end of the transcript to the prompt of the
+// request, within maxBytes.
+func continuationPrompt(prompt, transcript string, maxBytes int) string {
+	if maxBytes < 0 {
+		return prompt
+	}
+	if prompt == "" {
+		return TranscriptionContinuationPrompt(transcript, maxBytes)
+	}
+	tail := TranscriptionContinuationPrompt(transcript, maxBytes-len(prompt)-1)
+	if tail == "" {
+		return prompt
+	}
+	return prompt + " " + tail
+}
+
+// mergeTranscription appends the segments and words of a chunk, starting at
+// offset seconds in the recording, to response.
+func mergeTranscription(response *AudioResponse, chunk AudioResponse, offset float64) {
+	if response.Task == "" {
+		response.Task = chunk.Task
+	}
+	if response.Language == "" {
+		response.Language = chunk.Language
+	}
+	if end := offset + chunk.Duration; end > response.Duration {
+		response.Duration = end
+	}
+	for _, segment := range chunk.Segments {
+		segment.ID = len(response.Segments)
+		segment.Start += offset
+		segment.End += offset
+		response.Segments = append(response.Segments, segment)
+	}
+	for _, word := range chunk.Words {
+		word.Start += offset
+		word.End += offset
+		response.Words = append(response.Words, word)
+	}
+	response.httpHeader = chunk.httpHeader
+}
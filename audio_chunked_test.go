@@ -0,0 +1,110 @@
+package openai_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestTranscriptionContinuationPrompt(t *testing.T) {
+	cases := []struct {
+		transcript string
+		maxBytes   int
+		want       string
+	}{
+		{"short transcript", 100, "short transcript"},
+		{"the quick brown fox jumps", 12, "fox jumps"},
+		{"supercalifragilistic", 5, "istic"},
+		// Each of these runes is 3 bytes long: the tail must not start
+		// in the middle of one.
+		{"これは長い文字起こしです", 10, "しです"},
+		{"这是一段很长的转录", 8, "转录"},
+		{"anything", 0, ""},
+	}
+	for _, tc := range cases {
+		if got := openai.TranscriptionContinuationPrompt(tc.transcript, tc.maxBytes); got != tc.want {
+			t.Errorf("TranscriptionContinuationPrompt(%q, %d) = %q, want %q", tc.transcript, tc.maxBytes, got, tc.want)
+		}
+	}
+}
+
+func TestCreateChunkedTranscription(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var prompts []string
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		checks.NoError(t, r.ParseMultipartForm(1<<20), "ParseMultipartForm error")
+		prompts = append(prompts, r.FormValue("prompt"))
+		chunk := len(prompts)
+		fmt.Fprintf(w, `{"task":"transcribe","language":"english","duration":10,"text":" part %d of the talk.",`+
+			`"segments":[{"id":0,"start":1,"end":4,"text":"part %d"}],"words":[{"word":"part","start":1,"end":2}]}`,
+			chunk, chunk)
+	})
+
+	chunks := []openai.AudioChunk{
+		{FilePath: "a.mp3", Reader: strings.NewReader("a")},
+		{FilePath: "b.mp3", Reader: strings.NewReader("b")},
+		{FilePath: "c.mp3", Reader: strings.NewReader("c"), Offset: 25},
+	}
+	response, err := client.CreateChunkedTranscription(context.Background(), openai.AudioRequest{
+		Model:  openai.Whisper1,
+		Prompt: "Go, gRPC.",
+		Format: openai.AudioResponseFormatVerboseJSON,
+	}, chunks, openai.ChunkedTranscriptionOptions{PromptTail: 30})
+	checks.NoError(t, err, "CreateChunkedTranscription error")
+
+	wantPrompts := []string{"Go, gRPC.", "Go, gRPC. part 1 of the talk.", "Go, gRPC. part 2 of the talk."}
+	if fmt.Sprint(prompts) != fmt.Sprint(wantPrompts) {
+		t.Errorf("prompts = %q, want %q", prompts, wantPrompts)
+	}
+	if response.Text != "part 1 of the talk. part 2 of the talk. part 3 of the talk." {
+		t.Errorf("text = %q", response.Text)
+	}
+	if response.Duration != 35 || response.Language != "english" {
+		t.Errorf("duration = %v, language = %q", response.Duration, response.Language)
+	}
+	if len(response.Segments) != 3 || response.Segments[1].ID != 1 || response.Segments[1].Start != 11 ||
+		response.Segments[2].End != 29 {
+		t.Errorf("segments = %+v", response.Segments)
+	}
+	if len(response.Words) != 3 || response.Words[2].Start != 26 {
+		t.Errorf("words = %+v", response.Words)
+	}
+}
+
+func TestCreateChunkedTranscriptionErrors(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	calls := 0
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		if calls == 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"error":{"message":"failed","type":"server_error"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"text":"first"}`)
+	})
+
+	chunks := []openai.AudioChunk{
+		{FilePath: "a.mp3", Reader: strings.NewReader("a")},
+		{FilePath: "b.mp3", Reader: strings.NewReader("b")},
+	}
+	response, err := client.CreateChunkedTranscription(context.Background(),
+		openai.AudioRequest{Model: openai.Whisper1}, chunks, openai.ChunkedTranscriptionOptions{})
+	checks.ErrorIs(t, err, openai.ErrServerError, "chunk error not returned")
+	if response.Text != "first" {
+		t.Errorf("partial text = %q", response.Text)
+	}
+
+	_, err = client.CreateChunkedTranscription(context.Background(),
+		openai.AudioRequest{Format: openai.AudioResponseFormatSRT}, chunks, openai.ChunkedTranscriptionOptions{})
+	checks.ErrorIs(t, err, openai.ErrChunkedTranscriptionFormat, "subtitle format accepted")
+}
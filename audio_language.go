@@ -0,0 +1,99 @@
+package openai
+
+import (
+	"math"
+	"strings"
+)
+
+// Thresholds whisper uses to tell silence from speech: a segment is silent
+// when its no-speech probability is high and its tokens are unlikely.
+const (
+	noSpeechThreshold   = 0.6
+	silenceLogprobLimit = -1.0
+)
+
+// whisperLanguageCodes maps the language names returned by whisper-1 to
+// their ISO 639-1 codes.
+var whisperLanguageCodes = map[string]string{
+	"afrikaans": "af", "arabic": "ar", "armenian": "hy", "azerbaijani": "az", "belarusian": "be",
+	"bosnian": "bs", "bulgarian": "bg", "catalan": "ca", "chinese": "zh", "croatian": "hr",
+	"czech": "cs", "danish": "da", "dutch": "nl", "english": "en", "estonian": "et",
+	"finnish": "fi", "french": "fr", "galician": "gl", "german": "de", "greek": "el",
+	"hebrew": "he", "hindi": "hi", "hungarian": "hu", "icelandic": "is", "indonesian": "id",
+	"italian": "it", "japanese": "ja", "kannada": "kn", "kazakh": "kk", "korean": "ko",
+	"latvian": "lv", "lithuanian": "lt", "macedonian": "mk", "malay": "ms", "marathi": "mr",
+	"maori": "mi", "nepali": "ne", "norwegian": "no", "persian": "fa", "polish": "pl",
+	"portuguese": "pt", "romanian": "ro", "russian": "ru", "serbian": "sr", "slovak": "sk",
+	"slovenian": "sl", "spanish": "es", "swahili": "sw", "swedish": "sv", "tagalog": "tl",
+	"tamil": "ta", "thai": "th", "turkish": "tr", "ukrainian": "uk", "urdu": "ur",
+	"vietnamese": "vi", "welsh": "cy",
+}
+
+// LanguageCode returns the ISO 639-1 code of the detected language, such as
+// "en", or an empty string when the language is unknown. Codes returned as
+// is by other models and providers are passed through.
+func (r AudioResponse) LanguageCode() string {
+	language := strings.ToLower(strings.TrimSpace(r.Language))
+	if code, ok := whisperLanguageCodes[language]; ok {
+		return code
+	}
+	if len(language) == 2 {
+		return language
+	}
+	return ""
+}
+
+// NoSpeechProbability returns the probability that the audio holds no
+// speech, averaged over the segments weighted by their duration. It is zero
+// without segments.
+func (r AudioResponse) NoSpeechProbability() float64 {
+	return r.segmentAverage(func(s TranscriptionSegment) float64 { return s.NoSpeechProb })
+}
+
+// Confidence returns the average probability of the transcribed tokens, from
+// 0 to 1, weighted by the duration of the segments. It is zero without
+// segments.
+func (r AudioResponse) Confidence() float64 {
+	return r.segmentAverage(TranscriptionSegment.Confidence)
+}
+
+// SpeechSegments returns the segments that hold speech according to
+// IsSpeech, dropping the text whisper hallucinates over silence.
+func (r AudioResponse) SpeechSegments() []TranscriptionSegment {
+	segments := make([]TranscriptionSegment, 0, len(r.Segments))
+	for _, segment := range r.Segments {
+		if segment.IsSpeech() {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+func (r AudioResponse) segmentAverage(value func(TranscriptionSegment) float64) float64 {
+	var sum, total float64
+	for _, segment := range r.Segments {
+		duration := segment.End - segment.Start
+		if duration <= 0 {
+			continue
+		}
+		sum += value(segment) * duration
+		total += duration
+	}
+	if total == 0 {
+		return 0
+	}
+	return sum / total
+}
+
+// Confidence returns the average probability of the tokens of the segment,
+// from 0 to 1.
+func (s TranscriptionSegment) Confidence() float64 {
+	return math.Exp(s.AvgLogprob)
+}
+
+// IsSpeech reports whether the segment holds speech, using the rule whisper
+// applies to skip silent windows: a segment is silent when its no-speech
+// probability exceeds 0.6 and its average token log probability is below -1.
+func (s TranscriptionSegment) IsSpeech() bool {
+	return s.NoSpeechProb <= noSpeechThreshold || s.AvgLogprob >= silenceLogprobLimit
+}
@@ -0,0 +1,43 @@
+package openai_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestAudioResponseLanguageCode(t *testing.T) {
+	cases := map[string]string{"english": "en", "Portuguese": "pt", "de": "de", "klingon": "", "": ""}
+	for language, want := range cases {
+		if got := (openai.AudioResponse{Language: language}).LanguageCode(); got != want {
+			t.Errorf("LanguageCode() for %q = %q, want %q", language, got, want)
+		}
+	}
+}
+
+func TestAudioResponseConfidence(t *testing.T) {
+	var response openai.AudioResponse
+	err := json.Unmarshal([]byte(`{"task":"transcribe","language":"french","duration":4,"text":"bonjour merci",`+
+		`"segments":[{"id":0,"start":0,"end":3,"text":"bonjour","avg_logprob":-0.1,"no_speech_prob":0.1},`+
+		`{"id":1,"start":3,"end":4,"text":"merci","avg_logprob":-1.5,"no_speech_prob":0.9}]}`), &response)
+	checks.NoError(t, err, "Unmarshal error")
+
+	if response.LanguageCode() != "fr" {
+		t.Errorf("LanguageCode() = %q", response.LanguageCode())
+	}
+	if got, want := response.NoSpeechProbability(), (0.1*3+0.9)/4; math.Abs(got-want) > 1e-9 {
+		t.Errorf("NoSpeechProbability() = %v, want %v", got, want)
+	}
+	if got, want := response.Confidence(), (math.Exp(-0.1)*3+math.Exp(-1.5))/4; math.Abs(got-want) > 1e-9 {
+		t.Errorf("Confidence() = %v, want %v", got, want)
+	}
+	if speech := response.SpeechSegments(); len(speech) != 1 || speech[0].Text != "bonjour" {
+		t.Errorf("SpeechSegments() = %+v", speech)
+	}
+	if (openai.AudioResponse{}).Confidence() != 0 {
+		t.Error("Confidence() without segments is not zero")
+	}
+}
@@ -0,0 +1,80 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+)
+
+// AudioPreprocessor transforms audio before it is uploaded for transcription
+// or translation, for example to trim silence, resample or transcode long
+// recordings so they upload faster and cost less. This package ships no
+// implementation; wrap a VAD or ffmpeg binding to implement it.
+//
+// Preprocess receives the file name and content of the audio and returns the
+// ones to upload instead. The name should reflect the format of the output,
+// such as "talk.flac" when transcoding to FLAC. The returned reader is
+// closed after upload when it implements io.Closer.
+type AudioPreprocessor interface {
+	Preprocess(ctx context.Context, name string, audio io.Reader) (string, io.Reader, error)
+}
+
+// AudioPreprocessorFunc adapts a function to the AudioPreprocessor interface.
+type AudioPreprocessorFunc func(ctx context.Context, name string, audio io.Reader) (string, io.Reader, error)
+
+func (f AudioPreprocessorFunc) Preprocess(
+	ctx context.Context,
+	name string,
+	audio io.Reader,
+) (string, io.Reader, error) {
+	return f(ctx, name, audio)
+}
+
+// ChainAudioPreprocessors returns an AudioPreprocessor applying the given
+// ones in order, such as trimming silence before transcoding.
+func ChainAudioPreprocessors(preprocessors ...AudioPreprocessor) AudioPreprocessor {
+	return AudioPreprocessorFunc(func(ctx context.Context, name string, audio io.Reader) (string, io.Reader, error) {
+		var err error
+		for _, preprocessor := range preprocessors {
+			if name, audio, err = preprocessor.Preprocess(ctx, name, audio); err != nil {
+				return "", nil, err
+			}
+		}
+		return name, audio, nil
+	})
+}
+
+// preprocessAudio applies the AudioPreprocessor of the client to the audio
+// of the request, opening FilePath when the request has no Reader. The
+// returned function releases the files opened along the way.
+func (c *Client) preprocessAudio(ctx context.Context, request AudioRequest) (AudioRequest, func(), error) {
+	var closers []io.Closer
+	release := func() {
+		for _, closer := range closers {
+			closer.Close()
+		}
+	}
+
+	audio := request.Reader
+	if audio == nil {
+		f, err := os.Open(request.FilePath)
+		if err != nil {
+			return request, release, fmt.Errorf("opening audio file: %w", err)
+		}
+		closers = append(closers, f)
+		audio = f
+	}
+
+	name, audio, err := c.config.AudioPreprocessor.Preprocess(ctx, request.FilePath, audio)
+	if err != nil {
+		release()
+		return request, func() {}, fmt.Errorf("error, preprocessing audio: %w", err)
+	}
+	if closer, ok := audio.(io.Closer); ok {
+		closers = append(closers, closer)
+	}
+	request.FilePath = name
+	request.Reader = audio
+	return request, release, nil
+}
@@ -0,0 +1,93 @@
+package openai_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+// trackedReader records whether it was closed.
+type trackedReader struct {
+	io.Reader
+	closed bool
+}
+
+func (r *trackedReader) Close() error {
+	r.closed = true
+	return nil
+}
+
+func preprocessingClient(t *testing.T, preprocessor openai.AudioPreprocessor) (*openai.Client, *[]string) {
+	t.Helper()
+	var uploads []string
+	client, server := setupOpenAITestServerWithConfig(t, func(config *openai.ClientConfig) {
+		config.AudioPreprocessor = preprocessor
+	})
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		data, _ := io.ReadAll(file)
+		uploads = append(uploads, header.Filename+":"+string(data))
+		fmt.Fprint(w, `{"text":"ok"}`)
+	})
+	return client, &uploads
+}
+
+func TestAudioPreprocessor(t *testing.T) {
+	var output *trackedReader
+	trim := openai.AudioPreprocessorFunc(func(_ context.Context, name string, audio io.Reader) (string, io.Reader, error) {
+		data, err := io.ReadAll(audio)
+		return name, bytes.NewReader(bytes.ReplaceAll(data, []byte(" "), nil)), err
+	})
+	transcode := openai.AudioPreprocessorFunc(func(_ context.Context, name string, audio io.Reader) (string, io.Reader, error) {
+		output = &trackedReader{Reader: audio}
+		return strings.TrimSuffix(name, filepath.Ext(name)) + ".flac", output, nil
+	})
+	client, uploads := preprocessingClient(t, openai.ChainAudioPreprocessors(trim, transcode))
+
+	path := filepath.Join(t.TempDir(), "talk.mp3")
+	checks.NoError(t, os.WriteFile(path, []byte("a  b   c"), 0o600), "WriteFile error")
+	_, err := client.CreateTranscription(context.Background(),
+		openai.AudioRequest{Model: openai.Whisper1, FilePath: path})
+	checks.NoError(t, err, "CreateTranscription from file error")
+
+	_, err = client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model: openai.Whisper1, FilePath: "memo.wav", Reader: strings.NewReader("x y"),
+	})
+	checks.NoError(t, err, "CreateTranscription from reader error")
+
+	want := []string{"talk.flac:abc", "memo.flac:xy"}
+	if fmt.Sprint(*uploads) != fmt.Sprint(want) {
+		t.Errorf("uploads = %q, want %q", *uploads, want)
+	}
+	if !output.closed {
+		t.Error("preprocessed reader was not closed")
+	}
+}
+
+func TestAudioPreprocessorError(t *testing.T) {
+	errVAD := errors.New("vad failed")
+	client, uploads := preprocessingClient(t, openai.AudioPreprocessorFunc(
+		func(context.Context, string, io.Reader) (string, io.Reader, error) { return "", nil, errVAD }))
+
+	_, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model: openai.Whisper1, FilePath: "memo.wav", Reader: strings.NewReader("audio"),
+	})
+	checks.ErrorIs(t, err, errVAD, "preprocessor error not returned")
+	if len(*uploads) != 0 {
+		t.Errorf("audio uploaded despite the preprocessor error: %q", *uploads)
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/url"
 )
 
 const batchesSuffix = "/batches"
@@ -90,6 +89,18 @@ type Batch struct {
 	Metadata         map[string]any     `json:"metadata"`
 }
 
+// Statuses of a Batch.
+const (
+	BatchStatusValidating = "validating"
+	BatchStatusFailed     = "failed"
+	BatchStatusInProgress = "in_progress"
+	BatchStatusFinalizing = "finalizing"
+	BatchStatusCompleted  = "completed"
+	BatchStatusExpired    = "expired"
+	BatchStatusCancelling = "cancelling"
+	BatchStatusCancelled  = "cancelled"
+)
+
 type BatchRequestCounts struct {
 	Total     int `json:"total"`
 	Completed int `json:"completed"`
@@ -116,6 +127,9 @@ func (c *Client) CreateBatch(
 	if request.CompletionWindow == "" {
 		request.CompletionWindow = "24h"
 	}
+	if err = validateMetadata(request.Metadata); err != nil {
+		return
+	}
 
 	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(batchesSuffix), withBody(request))
 	if err != nil {
@@ -248,24 +262,67 @@ type ListBatchResponse struct {
 
 // ListBatch API call to List batch.
 func (c *Client) ListBatch(ctx context.Context, after *string, limit *int) (response ListBatchResponse, err error) {
-	urlValues := url.Values{}
-	if limit != nil {
-		urlValues.Add("limit", fmt.Sprintf("%d", *limit))
+	return c.ListBatchWithOptions(ctx, ListOptions{After: after, Limit: limit})
+}
+
+// ListBatchWithOptions lists batches, one page at a time.
+func (c *Client) ListBatchWithOptions(ctx context.Context, opts ListOptions) (response ListBatchResponse, err error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(withQuery(batchesSuffix, opts.values())))
+	if err != nil {
+		return
 	}
-	if after != nil {
-		urlValues.Add("after", *after)
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// BatchFilter selects batches by status and metadata. Empty fields match all
+// batches.
+type BatchFilter struct {
+	// Status matches batches with any of the given statuses.
+	Status []string
+	// Metadata matches batches having all the given metadata values.
+	Metadata map[string]string
+}
+
+func (f BatchFilter) match(batch Batch) bool {
+	if len(f.Status) > 0 {
+		found := false
+		for _, status := range f.Status {
+			found = found || batch.Status == status
+		}
+		if !found {
+			return false
+		}
 	}
-	encodedValues := ""
-	if len(urlValues) > 0 {
-		encodedValues = "?" + urlValues.Encode()
+	for key, want := range f.Metadata {
+		if value, _ := batch.Metadata[key].(string); value != want {
+			return false
+		}
 	}
+	return true
+}
 
-	urlSuffix := fmt.Sprintf("%s%s", batchesSuffix, encodedValues)
-	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
+// ListBatchWithFilter lists the batches of a page matching filter. The API
+// does not filter batches, so the filter is applied to each page, which may
+// come back with fewer batches than the limit, or none. FirstID, LastID and
+// HasMore describe the unfiltered page, so pagination continues with After
+// set to LastID.
+func (c *Client) ListBatchWithFilter(
+	ctx context.Context,
+	opts ListOptions,
+	filter BatchFilter,
+) (response ListBatchResponse, err error) {
+	response, err = c.ListBatchWithOptions(ctx, opts)
 	if err != nil {
 		return
 	}
-
-	err = c.sendRequest(req, &response)
+	data := response.Data[:0]
+	for _, batch := range response.Data {
+		if filter.match(batch) {
+			data = append(data, batch)
+		}
+	}
+	response.Data = data
 	return
 }
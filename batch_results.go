@@ -0,0 +1,179 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrBatchNotFinished is returned by DownloadBatchResults for batches that
+// are still running.
+var ErrBatchNotFinished = errors.New("batch is not finished")
+
+// BatchResult is the result of one request of a batch, read from its output
+// or error file.
+type BatchResult struct {
+	ID       string               `json:"id"`
+	CustomID string               `json:"custom_id"`
+	Response *BatchResultResponse `json:"response"`
+	Error    *BatchResultError    `json:"error"`
+}
+
+// BatchResultResponse is the HTTP response to a request of a batch.
+type BatchResultResponse struct {
+	StatusCode int             `json:"status_code"`
+	RequestID  string          `json:"request_id"`
+	Body       json.RawMessage `json:"body"`
+}
+
+// BatchResultError describes a request of a batch that got no response.
+type BatchResultError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *BatchResultError) Error() string {
+	return fmt.Sprintf("batch request failed: %s: %s", e.Code, e.Message)
+}
+
+// Err returns the error of a failed request: an *APIError for error
+// responses, or a *BatchResultError for requests that got no response.
+func (r BatchResult) Err() error {
+	if r.Error != nil {
+		return r.Error
+	}
+	if r.Response == nil {
+		return &BatchResultError{Message: "no response"}
+	}
+	if r.Response.StatusCode < http.StatusOK || r.Response.StatusCode >= http.StatusBadRequest {
+		var errRes ErrorResponse
+		if err := json.Unmarshal(r.Response.Body, &errRes); err != nil || errRes.Error == nil {
+			errRes.Error = &APIError{Message: string(r.Response.Body)}
+		}
+		errRes.Error.HTTPStatusCode = r.Response.StatusCode
+		errRes.Error.HTTPStatus = http.StatusText(r.Response.StatusCode)
+		return errRes.Error
+	}
+	return nil
+}
+
+// ChatCompletion decodes the response of a chat completion request.
+func (r BatchResult) ChatCompletion() (response ChatCompletionResponse, err error) {
+	err = r.decode(&response)
+	return
+}
+
+// Completion decodes the response of a completion request.
+func (r BatchResult) Completion() (response CompletionResponse, err error) {
+	err = r.decode(&response)
+	return
+}
+
+// Embedding decodes the response of an embedding request.
+func (r BatchResult) Embedding() (response EmbeddingResponse, err error) {
+	err = r.decode(&response)
+	return
+}
+
+func (r BatchResult) decode(v any) error {
+	if err := r.Err(); err != nil {
+		return err
+	}
+	return json.Unmarshal(r.Response.Body, v)
+}
+
+// BatchResults holds the results of a finished batch.
+type BatchResults struct {
+	Batch Batch
+	// Results holds the results of the output file, then those of the error
+	// file. Their order does not follow the input file: match them to their
+	// requests by CustomID.
+	Results []BatchResult
+}
+
+// ByCustomID returns the results indexed by their custom ID.
+func (r BatchResults) ByCustomID() map[string]BatchResult {
+	results := make(map[string]BatchResult, len(r.Results))
+	for _, result := range r.Results {
+		results[result.CustomID] = result
+	}
+	return results
+}
+
+// Failed returns the results of the requests that failed.
+func (r BatchResults) Failed() []BatchResult {
+	var failed []BatchResult
+	for _, result := range r.Results {
+		if result.Err() != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// DownloadBatchResults retrieves a batch and downloads the results of its
+// output and error files. Expired and cancelled batches return the results
+// of the requests completed before they stopped. It fails with
+// ErrBatchNotFinished for batches that are still running.
+func (c *Client) DownloadBatchResults(ctx context.Context, batchID string) (results BatchResults, err error) {
+	batch, err := c.RetrieveBatch(ctx, batchID)
+	if err != nil {
+		return
+	}
+	if !isBatchFinished(batch.Status) {
+		err = fmt.Errorf("%w: batch %s is %s", ErrBatchNotFinished, batchID, batch.Status)
+		return
+	}
+	return c.downloadBatchResults(ctx, batch.Batch)
+}
+
+func isBatchFinished(status string) bool {
+	switch status {
+	case BatchStatusCompleted, BatchStatusFailed, BatchStatusExpired, BatchStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// downloadBatchResults downloads the results of a finished batch.
+func (c *Client) downloadBatchResults(ctx context.Context, batch Batch) (results BatchResults, err error) {
+	results.Batch = batch
+	for _, fileID := range []*string{batch.OutputFileID, batch.ErrorFileID} {
+		if fileID == nil || *fileID == "" {
+			continue
+		}
+		var fileResults []BatchResult
+		fileResults, err = c.downloadBatchFile(ctx, *fileID)
+		if err != nil {
+			return
+		}
+		results.Results = append(results.Results, fileResults...)
+	}
+	return
+}
+
+// downloadBatchFile decodes the JSONL results of a batch file.
+func (c *Client) downloadBatchFile(ctx context.Context, fileID string) ([]BatchResult, error) {
+	content, err := c.GetFileContent(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	var results []BatchResult
+	decoder := c.jsonCodec().NewDecoder(content)
+	for {
+		var result BatchResult
+		err = decoder.Decode(&result)
+		if errors.Is(err, io.EOF) {
+			return results, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decoding batch file %s: %w", fileID, err)
+		}
+		results = append(results, result)
+	}
+}
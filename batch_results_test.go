@@ -0,0 +1,100 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestDownloadBatchResults(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/batches/batch_1", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"id":"batch_1","status":"completed","output_file_id":"file-out","error_file_id":"file-err"}`)
+	})
+	server.RegisterHandler("/v1/files/file-out/content", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, `{"id":"r1","custom_id":"req-1","response":{"status_code":200,"request_id":"x",`+
+			`"body":{"id":"chatcmpl-1","choices":[{"index":0,"message":{"role":"assistant","content":"Hi"}}]}},"error":null}`)
+		fmt.Fprintln(w, `{"id":"r2","custom_id":"req-2","response":{"status_code":200,"request_id":"y",`+
+			`"body":{"id":"chatcmpl-2","choices":[{"index":0,"message":{"role":"assistant","content":"Bye"}}]}},"error":null}`)
+	})
+	server.RegisterHandler("/v1/files/file-err/content", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"id":"r3","custom_id":"req-3","response":{"status_code":400,"request_id":"z",`+
+			`"body":{"error":{"message":"bad model","type":"invalid_request_error"}}},"error":null}`)
+	})
+
+	results, err := client.DownloadBatchResults(context.Background(), "batch_1")
+	checks.NoError(t, err)
+	if len(results.Results) != 3 || results.Batch.ID != "batch_1" {
+		t.Fatalf("results = %+v", results)
+	}
+
+	byID := results.ByCustomID()
+	chat, err := byID["req-2"].ChatCompletion()
+	checks.NoError(t, err)
+	if chat.Choices[0].Message.Content != "Bye" {
+		t.Errorf("content = %q", chat.Choices[0].Message.Content)
+	}
+
+	failed := results.Failed()
+	if len(failed) != 1 || failed[0].CustomID != "req-3" {
+		t.Fatalf("failed = %+v", failed)
+	}
+	_, err = failed[0].ChatCompletion()
+	var apiErr *openai.APIError
+	if !errors.As(err, &apiErr) || apiErr.HTTPStatusCode != http.StatusBadRequest || apiErr.Message != "bad model" {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestDownloadBatchResultsNotFinished(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/batches/batch_1", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"id":"batch_1","status":"in_progress"}`)
+	})
+	_, err := client.DownloadBatchResults(context.Background(), "batch_1")
+	checks.ErrorIs(t, err, openai.ErrBatchNotFinished)
+}
+
+func TestBatchResultErr(t *testing.T) {
+	result := openai.BatchResult{CustomID: "req-1", Error: &openai.BatchResultError{
+		Code: "batch_expired", Message: "not processed in time",
+	}}
+	var resultErr *openai.BatchResultError
+	if err := result.Err(); !errors.As(err, &resultErr) || resultErr.Code != "batch_expired" {
+		t.Errorf("err = %v", err)
+	}
+	if _, err := result.Embedding(); err == nil {
+		t.Error("expected an error decoding a failed result")
+	}
+}
+
+func TestListBatchWithFilter(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/batches", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"object":"list","data":[`+
+			`{"id":"b1","status":"completed","metadata":{"job":"nightly"}},`+
+			`{"id":"b2","status":"failed","metadata":{"job":"nightly"}},`+
+			`{"id":"b3","status":"completed","metadata":{"job":"adhoc"}}],`+
+			`"first_id":"b1","last_id":"b3","has_more":true}`)
+	})
+
+	list, err := client.ListBatchWithFilter(context.Background(), openai.ListOptions{}, openai.BatchFilter{
+		Status:   []string{openai.BatchStatusCompleted},
+		Metadata: map[string]string{"job": "nightly"},
+	})
+	checks.NoError(t, err)
+	if len(list.Data) != 1 || list.Data[0].ID != "b1" {
+		t.Errorf("data = %+v", list.Data)
+	}
+	if list.LastID != "b3" || !list.HasMore {
+		t.Errorf("pagination = %q, %v", list.LastID, list.HasMore)
+	}
+}
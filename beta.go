@@ -0,0 +1,75 @@
+package openai
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Beta features whose OpenAI-Beta header the client sets automatically.
+const (
+	// BetaAssistants covers the assistants, threads, runs and vector stores
+	// endpoints.
+	BetaAssistants = "assistants"
+	// BetaRealtime covers the realtime WebSocket API, see RealtimeHeader.
+	BetaRealtime = "realtime"
+)
+
+const betaHeader = "OpenAI-Beta"
+
+// defaultBetaVersions are the versions of the beta features this package
+// implements.
+var defaultBetaVersions = map[string]string{
+	BetaAssistants: defaultAssistantVersion,
+	BetaRealtime:   "v1",
+}
+
+// betaEndpoints maps the first path segment of beta REST endpoints, after
+// the base URL, to their feature.
+var betaEndpoints = map[string]string{
+	"assistants":    BetaAssistants,
+	"threads":       BetaAssistants,
+	"vector_stores": BetaAssistants,
+}
+
+// betaHeaderValue returns the OpenAI-Beta header of feature, such as
+// "assistants=v2", or "" when it is disabled.
+func (c *Client) betaHeaderValue(feature string) string {
+	version, ok := c.config.BetaVersions[feature]
+	if !ok {
+		version = defaultBetaVersions[feature]
+		if feature == BetaAssistants && c.config.AssistantVersion != "" {
+			version = c.config.AssistantVersion
+		}
+	}
+	if version == "" {
+		return ""
+	}
+	return feature + "=" + version
+}
+
+// setBetaHeader sets the OpenAI-Beta header of requests to beta endpoints.
+// A header set for the request, or attached to its context with
+// WithRequestHeaders, takes precedence.
+func (c *Client) setBetaHeader(req *http.Request) {
+	if req.Header.Get(betaHeader) != "" || requestHeadersFrom(req.Context()).Get(betaHeader) != "" {
+		return
+	}
+	feature := betaFeatureOf(c.endpointPath(req.URL))
+	if feature == "" {
+		return
+	}
+	if value := c.betaHeaderValue(feature); value != "" {
+		req.Header.Set(betaHeader, value)
+	}
+}
+
+// betaFeatureOf returns the beta feature of the endpoint at path, relative to
+// the base URL, or "". Only the first segment is matched, so that a base URL
+// or resource ID containing "threads" does not mark the request as beta.
+func betaFeatureOf(path string) string {
+	segment := strings.TrimPrefix(path, "/")
+	if i := strings.Index(segment, "/"); i >= 0 {
+		segment = segment[:i]
+	}
+	return betaEndpoints[segment]
+}
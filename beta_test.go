@@ -0,0 +1,99 @@
+package openai_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func betaHeaderClient(t *testing.T, configure func(*openai.ClientConfig)) (*openai.Client, *http.Header) {
+	t.Helper()
+	var header http.Header
+	client, server := setupOpenAITestServerWithConfig(t, configure)
+	server.RegisterHandler("/v1/assistants", func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	})
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	})
+	return client, &header
+}
+
+func TestBetaHeader(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*openai.ClientConfig)
+		want      string
+	}{
+		{"default", nil, "assistants=v2"},
+		{"assistant version", func(c *openai.ClientConfig) { c.AssistantVersion = "v1" }, "assistants=v1"},
+		{"override", func(c *openai.ClientConfig) {
+			c.AssistantVersion = "v1"
+			c.BetaVersions = map[string]string{openai.BetaAssistants: "v3"}
+		}, "assistants=v3"},
+		{"disabled", func(c *openai.ClientConfig) {
+			c.BetaVersions = map[string]string{openai.BetaAssistants: ""}
+		}, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, header := betaHeaderClient(t, tc.configure)
+			_, err := client.ListAssistants(context.Background(), nil, nil, nil, nil)
+			checks.NoError(t, err, "ListAssistants error")
+			if got := header.Get("OpenAI-Beta"); got != tc.want {
+				t.Errorf("OpenAI-Beta = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBetaHeaderNotSentToGAEndpoints(t *testing.T) {
+	client, header := betaHeaderClient(t, nil)
+	_, err := client.ListModels(context.Background())
+	checks.NoError(t, err, "ListModels error")
+	if got := header.Get("OpenAI-Beta"); got != "" {
+		t.Errorf("OpenAI-Beta = %q for a GA endpoint", got)
+	}
+}
+
+func TestBetaHeaderIgnoresBaseURLPath(t *testing.T) {
+	var header http.Header
+	client, server := setupOpenAITestServerWithConfig(t, func(config *openai.ClientConfig) {
+		config.BaseURL = strings.TrimSuffix(config.BaseURL, "/v1") + "/threads/v1"
+	})
+	server.RegisterHandler("/threads/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	})
+	_, err := client.ListModels(context.Background())
+	checks.NoError(t, err, "ListModels error")
+	if got := header.Get("OpenAI-Beta"); got != "" {
+		t.Errorf("OpenAI-Beta = %q for a GA endpoint behind a proxy path", got)
+	}
+}
+
+func TestBetaHeaderFromContext(t *testing.T) {
+	client, header := betaHeaderClient(t, nil)
+	ctx := openai.WithRequestHeaders(context.Background(), http.Header{"Openai-Beta": {"assistants=v9"}})
+	_, err := client.ListAssistants(ctx, nil, nil, nil, nil)
+	checks.NoError(t, err, "ListAssistants error")
+	if got := header.Values("OpenAI-Beta"); len(got) != 1 || got[0] != "assistants=v9" {
+		t.Errorf("OpenAI-Beta = %q, want the context header", got)
+	}
+}
+
+func TestRealtimeBetaHeader(t *testing.T) {
+	config := openai.DefaultConfig("key")
+	config.BetaVersions = map[string]string{openai.BetaRealtime: "v2"}
+	header := openai.NewClientWithConfig(config).RealtimeHeader()
+	if got := header.Get("OpenAI-Beta"); got != "realtime=v2" {
+		t.Errorf("OpenAI-Beta = %q, want realtime=v2", got)
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/sashabaranov/go-openai/jsonschema"
 )
@@ -25,6 +28,7 @@ var (
 	ErrChatCompletionInvalidModel       = errors.New("this model is not supported with this method, please use CreateCompletion client method instead") //nolint:lll
 	ErrChatCompletionStreamNotSupported = errors.New("streaming is not supported with this method, please use CreateChatCompletionStream")              //nolint:lll
 	ErrContentFieldsMisused             = errors.New("can't use both Content and MultiContent properties simultaneously")
+	ErrChatCompletionToolNotSupported   = errors.New("file_search tools are only supported by runs, not chat completions")
 )
 
 type Hate struct {
@@ -88,19 +92,34 @@ type ChatMessageFile struct {
 	FileData string `json:"file_data,omitempty"` // Base64 encoded file data
 }
 
+type ChatMessageInputAudioFormat string
+
+const (
+	ChatMessageInputAudioFormatWAV ChatMessageInputAudioFormat = "wav"
+	ChatMessageInputAudioFormatMP3 ChatMessageInputAudioFormat = "mp3"
+)
+
+// ChatMessageInputAudio is an audio input part, for audio capable models.
+type ChatMessageInputAudio struct {
+	Data   string                      `json:"data"` // Base64 encoded audio data
+	Format ChatMessageInputAudioFormat `json:"format"`
+}
+
 type ChatMessagePartType string
 
 const (
-	ChatMessagePartTypeText     ChatMessagePartType = "text"
-	ChatMessagePartTypeImageURL ChatMessagePartType = "image_url"
-	ChatMessagePartTypeFile     ChatMessagePartType = "file"
+	ChatMessagePartTypeText       ChatMessagePartType = "text"
+	ChatMessagePartTypeImageURL   ChatMessagePartType = "image_url"
+	ChatMessagePartTypeFile       ChatMessagePartType = "file"
+	ChatMessagePartTypeInputAudio ChatMessagePartType = "input_audio"
 )
 
 type ChatMessagePart struct {
-	Type     ChatMessagePartType  `json:"type,omitempty"`
-	Text     string               `json:"text,omitempty"`
-	ImageURL *ChatMessageImageURL `json:"image_url,omitempty"`
-	File     *ChatMessageFile     `json:"file,omitempty"`
+	Type       ChatMessagePartType    `json:"type,omitempty"`
+	Text       string                 `json:"text,omitempty"`
+	ImageURL   *ChatMessageImageURL   `json:"image_url,omitempty"`
+	File       *ChatMessageFile       `json:"file,omitempty"`
+	InputAudio *ChatMessageInputAudio `json:"input_audio,omitempty"`
 }
 
 type ChatCompletionMessage struct {
@@ -269,6 +288,8 @@ type ChatCompletionRequestExtensions struct {
 }
 
 // ChatCompletionRequest represents a request structure for chat completion API.
+// Zero sampling parameters are omitted; use SetTemperature and the like to
+// send an explicit zero.
 type ChatCompletionRequest struct {
 	Model    string                  `json:"model"`
 	Messages []ChatCompletionMessage `json:"messages"`
@@ -287,8 +308,12 @@ type ChatCompletionRequest struct {
 	Stop                []string                      `json:"stop,omitempty"`
 	PresencePenalty     float32                       `json:"presence_penalty,omitempty"`
 	ResponseFormat      *ChatCompletionResponseFormat `json:"response_format,omitempty"`
-	Seed                *int                          `json:"seed,omitempty"`
-	FrequencyPenalty    float32                       `json:"frequency_penalty,omitempty"`
+	// Seed makes sampling deterministic on a best-effort basis: repeated
+	// requests with the same seed and parameters should return the same
+	// result as long as the SystemFingerprint of the responses is unchanged.
+	// See ClientConfig.OnSystemFingerprintChange.
+	Seed             *int    `json:"seed,omitempty"`
+	FrequencyPenalty float32 `json:"frequency_penalty,omitempty"`
 	// LogitBias is must be a token id string (specified by their token ID in the tokenizer), not a word string.
 	// incorrect: `"logit_bias":{"You": 6}`, correct: `"logit_bias":{"1639": 6}`
 	// refs: https://platform.openai.com/docs/api-reference/chat/create#chat/create-logit_bias
@@ -342,6 +367,10 @@ type ChatCompletionRequest struct {
 	SafetyIdentifier string `json:"safety_identifier,omitempty"`
 	// Embedded struct for non-OpenAI extensions
 	ChatCompletionRequestExtensions
+
+	// explicit holds the fields set with SetTemperature and the like, which
+	// are sent even when zero.
+	explicit explicitFields
 }
 
 type StreamOptions struct {
@@ -356,11 +385,17 @@ type ToolType string
 
 const (
 	ToolTypeFunction ToolType = "function"
+	// ToolTypeFileSearch is only supported by runs; chat completion requests
+	// with it fail with ErrChatCompletionToolNotSupported.
+	ToolTypeFileSearch ToolType = "file_search"
 )
 
 type Tool struct {
 	Type     ToolType            `json:"type"`
 	Function *FunctionDefinition `json:"function,omitempty"`
+	// FileSearch configures the file_search tool of runs. It is not supported
+	// by chat completions.
+	FileSearch *FileSearchToolOptions `json:"file_search,omitempty"`
 }
 
 type ToolChoice struct {
@@ -441,6 +476,64 @@ func (r FinishReason) MarshalJSON() ([]byte, error) {
 	return []byte(`"` + string(r) + `"`), nil // best effort to not break future API changes
 }
 
+// UnmarshalJSON normalizes the finish reasons of servers using other casings,
+// such as "Stop" or "toolCalls" from some Azure deployments, and decodes null
+// and "null" as an empty reason.
+func (r *FinishReason) UnmarshalJSON(data []byte) error {
+	var s *string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == nil {
+		*r = ""
+		return nil
+	}
+	*r = normalizeFinishReason(*s)
+	if *r == FinishReasonNull {
+		*r = ""
+	}
+	return nil
+}
+
+// normalizeFinishReason converts camel case and upper case reasons to the
+// snake case used by the OpenAI API.
+func normalizeFinishReason(s string) FinishReason {
+	var b strings.Builder
+	for i, c := range s {
+		if unicode.IsUpper(c) {
+			if i > 0 && !unicode.IsUpper(rune(s[i-1])) && s[i-1] != '_' {
+				b.WriteByte('_')
+			}
+			c = unicode.ToLower(c)
+		}
+		b.WriteRune(c)
+	}
+	return FinishReason(b.String())
+}
+
+// IsFinished reports whether the choice is complete, which in a stream only
+// happens on the final chunk of the choice.
+func (r FinishReason) IsFinished() bool {
+	return r != "" && r != FinishReasonNull
+}
+
+// IsTruncated reports whether the output was cut off by max_tokens or the
+// context window, in which case it is incomplete.
+func (r FinishReason) IsTruncated() bool {
+	return r == FinishReasonLength
+}
+
+// IsToolCall reports whether the model stopped to call tools or, with the
+// deprecated functions, a function.
+func (r FinishReason) IsToolCall() bool {
+	return r == FinishReasonToolCalls || r == FinishReasonFunctionCall
+}
+
+// IsContentFiltered reports whether content was omitted by a content filter.
+func (r FinishReason) IsContentFiltered() bool {
+	return r == FinishReasonContentFilter
+}
+
 type ChatCompletionChoice struct {
 	Index   int                   `json:"index"`
 	Message ChatCompletionMessage `json:"message"`
@@ -471,6 +564,16 @@ type ChatCompletionResponse struct {
 	httpHeader
 }
 
+// validateChatTools rejects the tools that only runs support.
+func validateChatTools(tools []Tool) error {
+	for _, tool := range tools {
+		if tool.Type == ToolTypeFileSearch || tool.FileSearch != nil {
+			return ErrChatCompletionToolNotSupported
+		}
+	}
+	return nil
+}
+
 // CreateChatCompletion — API call to Create a completion for the chat message.
 func (c *Client) CreateChatCompletion(
 	ctx context.Context,
@@ -481,6 +584,16 @@ func (c *Client) CreateChatCompletion(
 		return
 	}
 
+	c.applyChatDefaults(&request)
+	c.applyChatExperiment(ctx, &request)
+	if err = c.mutateChatRequest(ctx, &request); err != nil {
+		return
+	}
+	if c.config.Policy != nil {
+		if err = c.config.Policy.CheckChatCompletion(request); err != nil {
+			return
+		}
+	}
 	urlSuffix := chatCompletionsSuffix
 	if !checkEndpointSupportsModel(urlSuffix, request.Model) {
 		err = ErrChatCompletionInvalidModel
@@ -492,6 +605,33 @@ func (c *Client) CreateChatCompletion(
 		return
 	}
 
+	if err = NewMessageValidator().Validate(request); err != nil {
+		return
+	}
+
+	if err = validateMetadata(request.Metadata); err != nil {
+		return
+	}
+
+	if err = validateChatTools(request.Tools); err != nil {
+		return
+	}
+
+	if err = c.checkContextLength(&request); err != nil {
+		return
+	}
+
+	request.SafetyIdentifier = c.resolveSafetyIdentifier(ctx, request.SafetyIdentifier, request.User)
+	request.User = c.resolveUser(ctx, request.User)
+	lookup, hit, err := c.lookupChatCompletionCache(ctx, request, &response)
+	if err != nil || hit {
+		return
+	}
+
+	if err = c.admit(ctx, request.Model); err != nil {
+		return
+	}
+
 	req, err := c.newRequest(
 		ctx,
 		http.MethodPost,
@@ -502,6 +642,20 @@ func (c *Client) CreateChatCompletion(
 		return
 	}
 
-	err = c.sendRequest(req, &response)
+	var key string
+	if c.flights != nil {
+		if key, err = HashChatCompletionRequest(request); err != nil {
+			return
+		}
+	}
+	start := time.Now()
+	err = c.sendRequestCoalesced(req, key, &response)
+	c.observeLatency(request.Model, start, err)
+	if err == nil {
+		c.recordTokens(req, response.Usage.PromptTokens, response.Usage.CompletionTokens)
+		c.fingerprints.record(response.Model, response.SystemFingerprint)
+		c.storeChatCompletionCache(ctx, lookup, response)
+		c.shadowChatCompletion(ctx, request, response, time.Since(start))
+	}
 	return
 }
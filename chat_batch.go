@@ -0,0 +1,153 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const defaultBatchPollInterval = 30 * time.Second
+
+// ErrEmptyBatch is returned by SubmitAsBatch when there are no requests.
+var ErrEmptyBatch = errors.New("no requests to batch")
+
+// ChatBatch is a batch of chat completion requests created by SubmitAsBatch.
+type ChatBatch struct {
+	// ID is the ID of the batch.
+	ID string
+	// PollInterval is the wait between status checks in Wait, 30s by default.
+	PollInterval time.Duration
+
+	client *Client
+	count  int
+}
+
+// SubmitAsBatch uploads the requests and creates a batch running them
+// within window, "24h" by default. Batches cost less than realtime requests
+// but take up to the completion window. Wait for their responses with
+// ChatBatch.Wait.
+func (c *Client) SubmitAsBatch(
+	ctx context.Context,
+	requests []ChatCompletionRequest,
+	window string,
+) (*ChatBatch, error) {
+	if len(requests) == 0 {
+		return nil, ErrEmptyBatch
+	}
+	upload := CreateBatchWithUploadFileRequest{
+		Endpoint:         BatchEndpointChatCompletions,
+		CompletionWindow: window,
+	}
+	for i, request := range requests {
+		request.Stream = false
+		request.StreamOptions = nil
+		upload.AddChatCompletion(chatBatchCustomID(i), request)
+	}
+	batch, err := c.CreateBatchWithUploadFile(ctx, upload)
+	if err != nil {
+		return nil, err
+	}
+	return &ChatBatch{ID: batch.ID, client: c, count: len(requests)}, nil
+}
+
+func chatBatchCustomID(i int) string {
+	return fmt.Sprintf("request-%d", i)
+}
+
+// Wait polls the batch until it finishes and returns the responses in
+// request order. Failed requests, including those left unprocessed by an
+// expired or cancelled batch, leave a zero response in their slot and are
+// reported through a *ParallelError.
+func (b *ChatBatch) Wait(ctx context.Context) ([]ChatCompletionResponse, error) {
+	interval := b.PollInterval
+	if interval <= 0 {
+		interval = defaultBatchPollInterval
+	}
+	for {
+		batch, err := b.client.RetrieveBatch(ctx, b.ID)
+		if err != nil {
+			return nil, err
+		}
+		if isBatchFinished(batch.Status) {
+			return b.collect(ctx, batch.Batch)
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// collect orders the results of the finished batch by request.
+func (b *ChatBatch) collect(ctx context.Context, batch Batch) ([]ChatCompletionResponse, error) {
+	if batch.Status == BatchStatusFailed && batch.Errors != nil && len(batch.Errors.Data) > 0 {
+		e := batch.Errors.Data[0]
+		return nil, &BatchResultError{Code: e.Code, Message: e.Message}
+	}
+	results, err := b.client.downloadBatchResults(ctx, batch)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := results.ByCustomID()
+	responses := make([]ChatCompletionResponse, b.count)
+	errs := make([]error, b.count)
+	failed := false
+	for i := range responses {
+		result, ok := byID[chatBatchCustomID(i)]
+		if !ok {
+			errs[i] = &BatchResultError{Code: batch.Status, Message: "request was not processed"}
+			failed = true
+			continue
+		}
+		if responses[i], errs[i] = result.ChatCompletion(); errs[i] != nil {
+			failed = true
+		}
+	}
+	if failed {
+		return responses, &ParallelError{Errors: errs}
+	}
+	return responses, nil
+}
+
+// ExecutionPolicy chooses how CreateChatCompletions runs requests.
+type ExecutionPolicy struct {
+	// Batch runs the requests through the Batch API, trading latency for
+	// cost. Otherwise they are sent right away with
+	// CreateChatCompletionsParallel.
+	Batch bool
+	// CompletionWindow is the completion window of batches, "24h" by default.
+	CompletionWindow string
+	// PollInterval is the wait between status checks of batches, 30s by
+	// default.
+	PollInterval time.Duration
+	// Parallel configures realtime execution.
+	Parallel ParallelOptions
+}
+
+// CreateChatCompletions runs the requests as realtime requests or as a batch
+// depending on policy, and returns the responses in request order. Failed
+// requests are reported through a *ParallelError either way.
+func (c *Client) CreateChatCompletions(
+	ctx context.Context,
+	requests []ChatCompletionRequest,
+	policy ExecutionPolicy,
+) ([]ChatCompletionResponse, error) {
+	if !policy.Batch {
+		return c.CreateChatCompletionsParallel(ctx, requests, policy.Parallel)
+	}
+	if len(requests) == 0 {
+		return nil, nil
+	}
+	batch, err := c.SubmitAsBatch(ctx, requests, policy.CompletionWindow)
+	if err != nil {
+		return nil, err
+	}
+	batch.PollInterval = policy.PollInterval
+	return batch.Wait(ctx)
+}
@@ -0,0 +1,140 @@
+package openai_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+// registerChatBatchServer serves a batch that answers every uploaded request
+// but the last one with its model name, in reverse order, and finishes with
+// status on the second poll.
+func registerChatBatchServer(t *testing.T, server *test.ServerTest, status string) {
+	t.Helper()
+	var lines []string
+	server.RegisterHandler("/v1/files", func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		fmt.Fprint(w, `{"id":"file-in","purpose":"batch"}`)
+	})
+	server.RegisterHandler("/v1/batches", func(w http.ResponseWriter, r *http.Request) {
+		var req openai.CreateBatchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.InputFileID != "file-in" {
+			http.Error(w, "bad batch", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `{"id":"batch_1","status":"validating"}`)
+	})
+	var polls int32
+	server.RegisterHandler("/v1/batches/batch_1", func(w http.ResponseWriter, _ *http.Request) {
+		if atomic.AddInt32(&polls, 1) < 2 {
+			fmt.Fprint(w, `{"id":"batch_1","status":"in_progress"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"id":"batch_1","status":%q,"output_file_id":"file-out"}`, status)
+	})
+	server.RegisterHandler("/v1/files/file-out/content", func(w http.ResponseWriter, _ *http.Request) {
+		for i := len(lines) - 2; i >= 0; i-- {
+			var item openai.BatchChatCompletionRequest
+			if err := json.Unmarshal([]byte(lines[i]), &item); err != nil {
+				t.Errorf("decoding batch line: %v", err)
+				return
+			}
+			fmt.Fprintf(w, `{"custom_id":%q,"response":{"status_code":200,"body":`+
+				`{"choices":[{"message":{"role":"assistant","content":%q}}]}}}`+"\n", item.CustomID, item.Body.Model)
+		}
+	})
+}
+
+func chatBatchRequests(models ...string) []openai.ChatCompletionRequest {
+	requests := make([]openai.ChatCompletionRequest, len(models))
+	for i, model := range models {
+		requests[i] = openai.ChatCompletionRequest{
+			Model:    model,
+			Messages: []openai.ChatCompletionMessage{openai.UserMessage("hi")},
+		}
+	}
+	return requests
+}
+
+func TestSubmitAsBatch(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	registerChatBatchServer(t, server, openai.BatchStatusExpired)
+
+	batch, err := client.SubmitAsBatch(context.Background(), chatBatchRequests("a", "b", "c"), "")
+	checks.NoError(t, err)
+	batch.PollInterval = time.Millisecond
+	responses, err := batch.Wait(context.Background())
+
+	var parallelErr *openai.ParallelError
+	if !errors.As(err, &parallelErr) {
+		t.Fatalf("err = %v, want a *ParallelError", err)
+	}
+	if parallelErr.Errors[0] != nil || parallelErr.Errors[1] != nil || parallelErr.Errors[2] == nil {
+		t.Errorf("errors = %v", parallelErr.Errors)
+	}
+	var got []string
+	for _, response := range responses[:2] {
+		got = append(got, response.Choices[0].Message.Content)
+	}
+	if strings.Join(got, ",") != "a,b" {
+		t.Errorf("responses = %v", got)
+	}
+}
+
+func TestCreateChatCompletionsBatchPolicy(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	registerChatBatchServer(t, server, openai.BatchStatusCompleted)
+
+	requests := append(chatBatchRequests("a", "b"), openai.ChatCompletionRequest{})
+	responses, err := client.CreateChatCompletions(context.Background(), requests, openai.ExecutionPolicy{
+		Batch:        true,
+		PollInterval: time.Millisecond,
+	})
+	// The server leaves the last request unanswered.
+	checks.HasError(t, err)
+	if len(responses) != 3 || responses[1].Choices[0].Message.Content != "b" {
+		t.Errorf("responses = %+v", responses)
+	}
+}
+
+func TestSubmitAsBatchEmpty(t *testing.T) {
+	client := openai.NewClient("token")
+	_, err := client.SubmitAsBatch(context.Background(), nil, "")
+	checks.ErrorIs(t, err, openai.ErrEmptyBatch)
+}
+
+func TestChatBatchWaitCanceled(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	registerChatBatchServer(t, server, openai.BatchStatusCompleted)
+
+	batch, err := client.SubmitAsBatch(context.Background(), chatBatchRequests("a"), "24h")
+	checks.NoError(t, err)
+	batch.PollInterval = time.Hour
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = batch.Wait(ctx)
+	checks.ErrorIs(t, err, context.DeadlineExceeded)
+}
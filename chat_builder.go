@@ -0,0 +1,406 @@
+package openai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/sashabaranov/go-openai/jsonschema"
+)
+
+const (
+	maxStopSequences        = 4
+	maxJSONSchemaNameLength = 64
+	defaultJSONSchemaName   = "response"
+)
+
+var (
+	ErrChatNoMessages              = errors.New("chat request has no messages")
+	ErrChatParameterOutOfRange     = errors.New("chat request parameter is out of range")
+	ErrChatToolInvalid             = errors.New("tools require a function definition with a name")
+	ErrChatToolDuplicate           = errors.New("chat request defines the tool more than once")
+	ErrChatToolChoiceUnknown       = errors.New("tool choice names a tool the request does not define")
+	ErrChatResponseFormatConflict  = errors.New("chat request sets more than one response format")
+	ErrChatJSONModeWithoutMention  = errors.New("JSON mode requires the messages to mention JSON")
+	ErrChatReasoningEffortNotValid = errors.New("reasoning effort is only supported by reasoning models")
+)
+
+// ChatBuildError reports the step of a ChatBuilder that failed.
+type ChatBuildError struct {
+	Step string
+	Err  error
+}
+
+func (e *ChatBuildError) Error() string {
+	return fmt.Sprintf("error, building chat request: %s: %v", e.Step, e.Err)
+}
+
+func (e *ChatBuildError) Unwrap() error {
+	return e.Err
+}
+
+// ChatBuilder builds a ChatCompletionRequest step by step, checking each step
+// against the model and the steps before it, so that inconsistent requests
+// fail locally rather than with a 400 response:
+//
+//	request, err := openai.NewChat(openai.GPT4oMini).
+//		System("You are a terse assistant.").
+//		User("Extract the invoice.").
+//		Temperature(0.2).
+//		JSONSchema(Invoice{}).
+//		Build()
+//
+// The first failing step stops the building: the following steps are
+// ignored and Build returns its *ChatBuildError.
+type ChatBuilder struct {
+	request    ChatCompletionRequest
+	toolChoice string
+	err        error
+}
+
+// NewChat starts building a chat completion request for model.
+func NewChat(model string) *ChatBuilder {
+	return &ChatBuilder{request: ChatCompletionRequest{Model: model}}
+}
+
+// fail records the error of a step unless an earlier step already failed.
+func (b *ChatBuilder) fail(step string, err error) *ChatBuilder {
+	if b.err == nil {
+		b.err = &ChatBuildError{Step: step, Err: err}
+	}
+	return b
+}
+
+// Message appends a message.
+func (b *ChatBuilder) Message(message ChatCompletionMessage) *ChatBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.request.Messages = append(b.request.Messages, message)
+	return b
+}
+
+// System appends a system message.
+func (b *ChatBuilder) System(content string) *ChatBuilder {
+	return b.Message(SystemMessage(content))
+}
+
+// Developer appends a developer message, which replaces system messages for
+// reasoning models.
+func (b *ChatBuilder) Developer(content string) *ChatBuilder {
+	return b.Message(DeveloperMessage(content))
+}
+
+// User appends a user message.
+func (b *ChatBuilder) User(content string) *ChatBuilder {
+	return b.Message(UserMessage(content))
+}
+
+// UserParts appends a user message made of several parts, such as text and
+// images.
+func (b *ChatBuilder) UserParts(parts ...ChatMessagePart) *ChatBuilder {
+	return b.Message(UserMessageParts(parts...))
+}
+
+// Assistant appends an assistant message, typically a previous answer.
+func (b *ChatBuilder) Assistant(content string) *ChatBuilder {
+	return b.Message(AssistantMessage(content))
+}
+
+// ToolResult appends the result of the tool call with the given ID.
+func (b *ChatBuilder) ToolResult(toolCallID, content string) *ChatBuilder {
+	return b.Message(ToolMessage(toolCallID, content))
+}
+
+// Tool adds a tool the model may call. Tool names must be unique.
+func (b *ChatBuilder) Tool(tool Tool) *ChatBuilder {
+	if b.err != nil {
+		return b
+	}
+	if tool.Function == nil || tool.Function.Name == "" {
+		return b.fail("Tool", ErrChatToolInvalid)
+	}
+	for _, existing := range b.request.Tools {
+		if existing.Function != nil && existing.Function.Name == tool.Function.Name {
+			return b.fail("Tool", fmt.Errorf("%w: %s", ErrChatToolDuplicate, tool.Function.Name))
+		}
+	}
+	if tool.Type == "" {
+		tool.Type = ToolTypeFunction
+	}
+	b.request.Tools = append(b.request.Tools, tool)
+	return b
+}
+
+// ToolChoice controls which tool the model calls: "auto", "none",
+// "required", or the name of a tool, which must be added with Tool before
+// Build.
+func (b *ChatBuilder) ToolChoice(choice string) *ChatBuilder {
+	if b.err != nil {
+		return b
+	}
+	if choice == "" {
+		return b.fail("ToolChoice", ErrChatToolChoiceUnknown)
+	}
+	b.toolChoice = choice
+	return b
+}
+
+// Temperature sets the sampling temperature, between 0 and 2. Zero is sent
+// rather than omitted. Reasoning models only accept 1.
+func (b *ChatBuilder) Temperature(v float32) *ChatBuilder {
+	if b.err != nil {
+		return b
+	}
+	if v < 0 || v > 2 {
+		return b.fail("Temperature", fmt.Errorf("%w: %v is not between 0 and 2", ErrChatParameterOutOfRange, v))
+	}
+	if isReasoningModel(b.request.Model) && v != 1 {
+		return b.fail("Temperature", ErrReasoningModelLimitationsOther)
+	}
+	b.request.SetTemperature(v)
+	return b
+}
+
+// TopP sets nucleus sampling, between 0 and 1. Zero is sent rather than
+// omitted. Reasoning models only accept 1.
+func (b *ChatBuilder) TopP(v float32) *ChatBuilder {
+	if b.err != nil {
+		return b
+	}
+	if v < 0 || v > 1 {
+		return b.fail("TopP", fmt.Errorf("%w: %v is not between 0 and 1", ErrChatParameterOutOfRange, v))
+	}
+	if isReasoningModel(b.request.Model) && v != 1 {
+		return b.fail("TopP", ErrReasoningModelLimitationsOther)
+	}
+	b.request.SetTopP(v)
+	return b
+}
+
+// N sets the number of choices to generate. Reasoning models only accept 1.
+func (b *ChatBuilder) N(n int) *ChatBuilder {
+	if b.err != nil {
+		return b
+	}
+	if n < 1 {
+		return b.fail("N", fmt.Errorf("%w: %d is less than 1", ErrChatParameterOutOfRange, n))
+	}
+	if isReasoningModel(b.request.Model) && n != 1 {
+		return b.fail("N", ErrReasoningModelLimitationsOther)
+	}
+	b.request.N = n
+	return b
+}
+
+// MaxCompletionTokens caps the number of generated tokens, including the
+// reasoning tokens of reasoning models.
+func (b *ChatBuilder) MaxCompletionTokens(n int) *ChatBuilder {
+	if b.err != nil {
+		return b
+	}
+	if n < 1 {
+		return b.fail("MaxCompletionTokens", fmt.Errorf("%w: %d is less than 1", ErrChatParameterOutOfRange, n))
+	}
+	b.request.MaxCompletionTokens = n
+	return b
+}
+
+// Stop sets up to 4 sequences that end the generation.
+func (b *ChatBuilder) Stop(sequences ...string) *ChatBuilder {
+	if b.err != nil {
+		return b
+	}
+	if len(b.request.Stop)+len(sequences) > maxStopSequences {
+		return b.fail("Stop", fmt.Errorf("%w: more than %d stop sequences", ErrChatParameterOutOfRange, maxStopSequences))
+	}
+	b.request.Stop = append(b.request.Stop, sequences...)
+	return b
+}
+
+// Seed makes sampling deterministic on a best-effort basis.
+func (b *ChatBuilder) Seed(seed int) *ChatBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.request.Seed = &seed
+	return b
+}
+
+// ReasoningEffort sets the reasoning effort of reasoning models, such as
+// "low" or "high".
+func (b *ChatBuilder) ReasoningEffort(effort string) *ChatBuilder {
+	if b.err != nil {
+		return b
+	}
+	if !isReasoningModel(b.request.Model) {
+		return b.fail("ReasoningEffort", fmt.Errorf("%w: %s", ErrChatReasoningEffortNotValid, b.request.Model))
+	}
+	b.request.ReasoningEffort = effort
+	return b
+}
+
+// JSONMode makes the model answer with a JSON object. The messages must
+// mention JSON, which Build checks.
+func (b *ChatBuilder) JSONMode() *ChatBuilder {
+	if b.err != nil {
+		return b
+	}
+	if b.request.ResponseFormat != nil {
+		return b.fail("JSONMode", ErrChatResponseFormatConflict)
+	}
+	b.request.ResponseFormat = &ChatCompletionResponseFormat{Type: ChatCompletionResponseFormatTypeJSONObject}
+	return b
+}
+
+// JSONSchema makes the model answer with JSON matching a strict schema.
+// schema is used as is when it is a jsonschema.Definition or a
+// json.Marshaler, such as json.RawMessage, and named "response". Otherwise
+// it is generated from the type of schema, typically a struct, and named
+// after the type.
+func (b *ChatBuilder) JSONSchema(schema any) *ChatBuilder {
+	if b.err != nil {
+		return b
+	}
+	if b.request.ResponseFormat != nil {
+		return b.fail("JSONSchema", ErrChatResponseFormatConflict)
+	}
+	if definition, ok := schema.(jsonschema.Definition); ok {
+		schema = &definition
+	}
+	name := defaultJSONSchemaName
+	marshaler, ok := schema.(json.Marshaler)
+	if !ok {
+		definition, err := jsonschema.GenerateSchemaForType(schema)
+		if err != nil {
+			return b.fail("JSONSchema", err)
+		}
+		marshaler = definition
+		name = jsonSchemaName(schema)
+	}
+	b.request.ResponseFormat = &ChatCompletionResponseFormat{
+		Type: ChatCompletionResponseFormatTypeJSONSchema,
+		JSONSchema: &ChatCompletionResponseFormatJSONSchema{
+			Name:   name,
+			Schema: marshaler,
+			Strict: true,
+		},
+	}
+	return b
+}
+
+// jsonSchemaName returns the name of the type of schema, or
+// defaultJSONSchemaName when it has none the API accepts, as for generic or
+// anonymous types.
+func jsonSchemaName(schema any) string {
+	t := reflect.TypeOf(schema)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Name() == "" || len(t.Name()) > maxJSONSchemaNameLength {
+		return defaultJSONSchemaName
+	}
+	for _, r := range t.Name() {
+		if !(r == '_' || r == '-' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			return defaultJSONSchemaName
+		}
+	}
+	return t.Name()
+}
+
+// Metadata attaches a key-value pair to the request, stored with it when
+// Store is set.
+func (b *ChatBuilder) Metadata(key, value string) *ChatBuilder {
+	if b.err != nil {
+		return b
+	}
+	metadata := make(map[string]string, len(b.request.Metadata)+1)
+	for k, v := range b.request.Metadata {
+		metadata[k] = v
+	}
+	metadata[key] = value
+	if err := validateMetadata(metadata); err != nil {
+		return b.fail("Metadata", err)
+	}
+	b.request.Metadata = metadata
+	return b
+}
+
+// Store stores the completion for distillation and evals.
+func (b *ChatBuilder) Store() *ChatBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.request.Store = true
+	return b
+}
+
+// Err returns the error of the first failing step, if any.
+func (b *ChatBuilder) Err() error {
+	return b.err
+}
+
+// Build checks the request as a whole and returns it. The returned request
+// does not share memory with the builder.
+func (b *ChatBuilder) Build() (ChatCompletionRequest, error) {
+	if b.err != nil {
+		return ChatCompletionRequest{}, b.err
+	}
+	request := b.request
+	request.Messages = append([]ChatCompletionMessage{}, b.request.Messages...)
+	request.Tools = append([]Tool(nil), b.request.Tools...)
+	request.Stop = append([]string(nil), b.request.Stop...)
+
+	if len(request.Messages) == 0 {
+		return ChatCompletionRequest{}, &ChatBuildError{Step: "Build", Err: ErrChatNoMessages}
+	}
+	if b.toolChoice != "" {
+		choice, err := b.buildToolChoice()
+		if err != nil {
+			return ChatCompletionRequest{}, &ChatBuildError{Step: "ToolChoice", Err: err}
+		}
+		request.ToolChoice = choice
+	}
+	format := request.ResponseFormat
+	if format != nil && format.Type == ChatCompletionResponseFormatTypeJSONObject && !mentionsJSON(request.Messages) {
+		return ChatCompletionRequest{}, &ChatBuildError{Step: "JSONMode", Err: ErrChatJSONModeWithoutMention}
+	}
+	if err := NewMessageValidator().Validate(request); err != nil {
+		return ChatCompletionRequest{}, &ChatBuildError{Step: "Build", Err: err}
+	}
+	if err := NewReasoningValidator().Validate(request); err != nil {
+		return ChatCompletionRequest{}, &ChatBuildError{Step: "Build", Err: err}
+	}
+	return request, nil
+}
+
+func (b *ChatBuilder) buildToolChoice() (any, error) {
+	switch b.toolChoice {
+	case "none", "auto":
+		return b.toolChoice, nil
+	case "required":
+		if len(b.request.Tools) == 0 {
+			return nil, fmt.Errorf("%w: required without tools", ErrChatToolChoiceUnknown)
+		}
+		return b.toolChoice, nil
+	}
+	for _, tool := range b.request.Tools {
+		if tool.Function.Name == b.toolChoice {
+			return ToolChoice{Type: ToolTypeFunction, Function: ToolFunction{Name: b.toolChoice}}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrChatToolChoiceUnknown, b.toolChoice)
+}
+
+// mentionsJSON reports whether a message mentions JSON, as JSON mode
+// requires.
+func mentionsJSON(messages []ChatCompletionMessage) bool {
+	for _, message := range messages {
+		if strings.Contains(strings.ToLower(message.Text()), "json") {
+			return true
+		}
+	}
+	return false
+}
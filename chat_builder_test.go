@@ -0,0 +1,141 @@
+package openai_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+	"github.com/sashabaranov/go-openai/jsonschema"
+)
+
+type builderInvoice struct {
+	Number string  `json:"number"`
+	Total  float64 `json:"total"`
+}
+
+var builderTool = openai.Tool{Function: &openai.FunctionDefinition{
+	Name:       "lookup",
+	Parameters: jsonschema.Definition{Type: jsonschema.Object},
+}}
+
+func TestChatBuilder(t *testing.T) {
+	request, err := openai.NewChat(openai.GPT4oMini).
+		System("You are terse.").
+		User("Extract the invoice.").
+		Tool(builderTool).
+		ToolChoice("lookup").
+		Temperature(0).
+		MaxCompletionTokens(100).
+		Stop("END").
+		Seed(7).
+		JSONSchema(builderInvoice{}).
+		Metadata("team", "billing").
+		Build()
+	checks.NoError(t, err, "Build error")
+
+	if len(request.Messages) != 2 || request.Messages[1].Role != openai.ChatMessageRoleUser {
+		t.Fatalf("messages = %+v", request.Messages)
+	}
+	if request.Tools[0].Type != openai.ToolTypeFunction {
+		t.Errorf("tool type = %q, want function", request.Tools[0].Type)
+	}
+	if choice, ok := request.ToolChoice.(openai.ToolChoice); !ok || choice.Function.Name != "lookup" {
+		t.Errorf("tool choice = %+v", request.ToolChoice)
+	}
+	if request.ResponseFormat.JSONSchema == nil || !request.ResponseFormat.JSONSchema.Strict ||
+		request.ResponseFormat.JSONSchema.Name != "builderInvoice" {
+		t.Fatalf("response format = %+v", request.ResponseFormat)
+	}
+
+	body, err := json.Marshal(request)
+	checks.NoError(t, err, "Marshal error")
+	wants := []string{`"temperature":0`, `"properties":{"number"`, `"seed":7`, `"metadata":{"team":"billing"}`}
+	for _, want := range wants {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("request body %s does not contain %s", body, want)
+		}
+	}
+}
+
+func TestChatBuilderJSONSchemaName(t *testing.T) {
+	cases := []struct {
+		name   string
+		schema any
+		want   string
+	}{
+		{"struct", builderInvoice{}, "builderInvoice"},
+		{"pointer", &builderInvoice{}, "builderInvoice"},
+		{"anonymous struct", struct {
+			A string `json:"a"`
+		}{}, "response"},
+		{"raw schema", json.RawMessage(`{"type":"object"}`), "response"},
+		{"definition", jsonschema.Definition{Type: jsonschema.Object}, "response"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			request, err := openai.NewChat(openai.GPT4oMini).User("json").JSONSchema(tc.schema).Build()
+			checks.NoError(t, err, "Build error")
+			if got := request.ResponseFormat.JSONSchema.Name; got != tc.want {
+				t.Errorf("schema name = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestChatBuilderErrors(t *testing.T) {
+	cases := []struct {
+		name    string
+		builder *openai.ChatBuilder
+		step    string
+		want    error
+	}{
+		{"no messages", openai.NewChat(openai.GPT4oMini), "Build", openai.ErrChatNoMessages},
+		{"temperature range", openai.NewChat(openai.GPT4oMini).User("hi").Temperature(3),
+			"Temperature", openai.ErrChatParameterOutOfRange},
+		{"reasoning temperature", openai.NewChat(openai.O3Mini).User("hi").Temperature(0.2),
+			"Temperature", openai.ErrReasoningModelLimitationsOther},
+		{"reasoning effort", openai.NewChat(openai.GPT4oMini).User("hi").ReasoningEffort("high"),
+			"ReasoningEffort", openai.ErrChatReasoningEffortNotValid},
+		{"duplicate tool", openai.NewChat(openai.GPT4oMini).User("hi").Tool(builderTool).Tool(builderTool),
+			"Tool", openai.ErrChatToolDuplicate},
+		{"unknown tool choice", openai.NewChat(openai.GPT4oMini).User("hi").Tool(builderTool).ToolChoice("search"),
+			"ToolChoice", openai.ErrChatToolChoiceUnknown},
+		{"required without tools", openai.NewChat(openai.GPT4oMini).User("hi").ToolChoice("required"),
+			"ToolChoice", openai.ErrChatToolChoiceUnknown},
+		{"two formats", openai.NewChat(openai.GPT4oMini).User("json").JSONMode().JSONSchema(builderInvoice{}),
+			"JSONSchema", openai.ErrChatResponseFormatConflict},
+		{"json mode without mention", openai.NewChat(openai.GPT4oMini).User("hi").JSONMode(),
+			"JSONMode", openai.ErrChatJSONModeWithoutMention},
+		{"too many stops", openai.NewChat(openai.GPT4oMini).User("hi").Stop("a", "b", "c").Stop("d", "e"),
+			"Stop", openai.ErrChatParameterOutOfRange},
+		{"invalid message", openai.NewChat(openai.GPT4oMini).User("hi").ToolResult("call_1", "42"),
+			"Build", openai.ErrToolMessageWithoutToolCall},
+		{"first error wins", openai.NewChat(openai.GPT4oMini).N(0).Temperature(5),
+			"N", openai.ErrChatParameterOutOfRange},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.builder.Build()
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("Build error = %v, want %v", err, tc.want)
+			}
+			var buildErr *openai.ChatBuildError
+			if !errors.As(err, &buildErr) || buildErr.Step != tc.step {
+				t.Errorf("error %v, want step %s", err, tc.step)
+			}
+		})
+	}
+}
+
+func TestChatBuilderBuildDoesNotShareMemory(t *testing.T) {
+	builder := openai.NewChat(openai.GPT4oMini).User("one")
+	first, err := builder.Build()
+	checks.NoError(t, err, "Build error")
+	builder.User("two")
+	if len(first.Messages) != 1 {
+		t.Errorf("earlier request was modified: %+v", first.Messages)
+	}
+}
@@ -0,0 +1,75 @@
+package openai
+
+import (
+	"context"
+	"errors"
+)
+
+const defaultContinuationPrompt = "Continue exactly where you left off, without repeating anything."
+
+// ErrContinuationMultipleChoices is returned by
+// CreateChatCompletionWithContinuation for requests with N > 1, whose
+// choices cannot be continued together.
+var ErrContinuationMultipleChoices = errors.New("continuation requires a single choice")
+
+// ContinuationOptions configures CreateChatCompletionWithContinuation.
+type ContinuationOptions struct {
+	// MaxContinuations is the number of follow-up requests sent when the
+	// output is truncated by the token limit.
+	MaxContinuations int
+	// Prompt is the user message asking the model to go on. It defaults to
+	// asking for a continuation without repetition.
+	Prompt string
+}
+
+// CreateChatCompletionWithContinuation creates a chat completion and, while
+// it stops with FinishReasonLength, re-prompts with the partial output to get
+// the rest, up to opts.MaxContinuations times. The returned response holds
+// the stitched content, the finish reason of the last request and the usage
+// summed over all requests. Its FinishReason is still FinishReasonLength if
+// the continuations ran out.
+func (c *Client) CreateChatCompletionWithContinuation(
+	ctx context.Context,
+	request ChatCompletionRequest,
+	opts ContinuationOptions,
+) (response ChatCompletionResponse, err error) {
+	if request.N > 1 {
+		err = ErrContinuationMultipleChoices
+		return
+	}
+	prompt := opts.Prompt
+	if prompt == "" {
+		prompt = defaultContinuationPrompt
+	}
+
+	// Copy the messages rather than appending to the caller's slice.
+	messages := append([]ChatCompletionMessage{}, request.Messages...)
+	var usage Usage
+	var content string
+	for attempt := 0; ; attempt++ {
+		request.Messages = messages
+		response, err = c.CreateChatCompletion(ctx, request)
+		if err != nil {
+			return
+		}
+		usage.PromptTokens += response.Usage.PromptTokens
+		usage.CompletionTokens += response.Usage.CompletionTokens
+		usage.TotalTokens += response.Usage.TotalTokens
+		if len(response.Choices) == 0 {
+			break
+		}
+
+		choice := response.Choices[0]
+		content += choice.Message.Content
+		if !choice.FinishReason.IsTruncated() || attempt >= opts.MaxContinuations {
+			break
+		}
+		messages = append(messages, AssistantMessage(choice.Message.Content), UserMessage(prompt))
+	}
+
+	if len(response.Choices) > 0 {
+		response.Choices[0].Message.Content = content
+	}
+	response.Usage = usage
+	return
+}
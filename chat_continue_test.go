@@ -0,0 +1,88 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestCreateChatCompletionWithContinuation(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	parts := []string{"Once upon ", "a time ", "there was."}
+	var requests []openai.ChatCompletionRequest
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		var req openai.ChatCompletionRequest
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		requests = append(requests, req)
+		i := len(requests) - 1
+		reason := openai.FinishReasonLength
+		if i == len(parts)-1 {
+			reason = openai.FinishReasonStop
+		}
+		fmt.Fprintf(w, `{"id":"chatcmpl-%d","choices":[{"index":0,"message":{"role":"assistant","content":%q},`+
+			`"finish_reason":%q}],"usage":{"prompt_tokens":10,"completion_tokens":3,"total_tokens":13}}`,
+			i, parts[i], reason)
+	})
+
+	messages := []openai.ChatCompletionMessage{openai.UserMessage("Tell me a story.")}
+	response, err := client.CreateChatCompletionWithContinuation(context.Background(), openai.ChatCompletionRequest{
+		Model:    openai.GPT4o,
+		Messages: messages,
+	}, openai.ContinuationOptions{MaxContinuations: 5, Prompt: "Go on."})
+	checks.NoError(t, err)
+
+	if got := response.Choices[0].Message.Content; got != "Once upon a time there was." {
+		t.Errorf("content = %q", got)
+	}
+	if response.Choices[0].FinishReason != openai.FinishReasonStop {
+		t.Errorf("finish reason = %q, want stop", response.Choices[0].FinishReason)
+	}
+	if response.Usage.TotalTokens != 39 || response.Usage.CompletionTokens != 9 {
+		t.Errorf("usage = %+v, want summed usage", response.Usage)
+	}
+	if len(requests) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(requests))
+	}
+	last := requests[2].Messages
+	if len(last) != 5 || last[3].Content != "a time " || last[4].Content != "Go on." {
+		t.Errorf("unexpected continuation messages %+v", last)
+	}
+	if len(messages) != 1 {
+		t.Error("the caller's messages should not be modified")
+	}
+}
+
+func TestCreateChatCompletionWithContinuationLimit(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	calls := 0
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"choices":[{"index":0,"message":{"role":"assistant","content":"more "},"finish_reason":"length"}]}`)
+	})
+
+	request := openai.ChatCompletionRequest{
+		Model:    openai.GPT4o,
+		Messages: []openai.ChatCompletionMessage{openai.UserMessage("Write a lot.")},
+	}
+	response, err := client.CreateChatCompletionWithContinuation(context.Background(), request,
+		openai.ContinuationOptions{MaxContinuations: 1})
+	checks.NoError(t, err)
+	if calls != 2 || response.Choices[0].Message.Content != "more more " {
+		t.Errorf("expected 2 stitched requests, got %d calls and %q", calls, response.Choices[0].Message.Content)
+	}
+	if !response.Choices[0].FinishReason.IsTruncated() {
+		t.Error("the response should still be truncated")
+	}
+
+	request.N = 2
+	_, err = client.CreateChatCompletionWithContinuation(context.Background(), request,
+		openai.ContinuationOptions{MaxContinuations: 1})
+	checks.ErrorIs(t, err, openai.ErrContinuationMultipleChoices)
+}
@@ -0,0 +1,100 @@
+package openai
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// SystemMessage creates a system message.
+func SystemMessage(content string) ChatCompletionMessage {
+	return ChatCompletionMessage{Role: ChatMessageRoleSystem, Content: content}
+}
+
+// DeveloperMessage creates a developer message, which replaces system
+// messages for reasoning models.
+func DeveloperMessage(content string) ChatCompletionMessage {
+	return ChatCompletionMessage{Role: ChatMessageRoleDeveloper, Content: content}
+}
+
+// UserMessage creates a plain text user message.
+func UserMessage(content string) ChatCompletionMessage {
+	return ChatCompletionMessage{Role: ChatMessageRoleUser, Content: content}
+}
+
+// UserMessageParts creates a user message made of several content parts,
+// such as text and images.
+//
+//	openai.UserMessageParts(
+//		openai.TextPart("What is in this image?"),
+//		openai.ImageURLPart("https://example.com/cat.png", openai.ImageURLDetailAuto),
+//	)
+func UserMessageParts(parts ...ChatMessagePart) ChatCompletionMessage {
+	return ChatCompletionMessage{Role: ChatMessageRoleUser, MultiContent: parts}
+}
+
+// AssistantMessage creates an assistant message, for example to replay a
+// previous answer in the conversation history.
+func AssistantMessage(content string) ChatCompletionMessage {
+	return ChatCompletionMessage{Role: ChatMessageRoleAssistant, Content: content}
+}
+
+// AssistantToolCallsMessage creates the assistant message that requested
+// toolCalls, which must precede the corresponding tool messages.
+func AssistantToolCallsMessage(toolCalls ...ToolCall) ChatCompletionMessage {
+	return ChatCompletionMessage{Role: ChatMessageRoleAssistant, ToolCalls: toolCalls}
+}
+
+// ToolMessage creates the message carrying the result of the tool call
+// identified by toolCallID.
+func ToolMessage(toolCallID, content string) ChatCompletionMessage {
+	return ChatCompletionMessage{Role: ChatMessageRoleTool, ToolCallID: toolCallID, Content: content}
+}
+
+// TextPart creates a text content part.
+func TextPart(text string) ChatMessagePart {
+	return ChatMessagePart{Type: ChatMessagePartTypeText, Text: text}
+}
+
+// ImageURLPart creates an image content part referencing url, which can be
+// an http(s) URL or a data URL.
+func ImageURLPart(url string, detail ImageURLDetail) ChatMessagePart {
+	return ChatMessagePart{
+		Type:     ChatMessagePartTypeImageURL,
+		ImageURL: &ChatMessageImageURL{URL: url, Detail: detail},
+	}
+}
+
+// ImageDataPart creates an image content part from raw image bytes of the
+// given MIME type (e.g. "image/png"), embedded as a base64 data URL.
+func ImageDataPart(data []byte, mimeType string, detail ImageURLDetail) ChatMessagePart {
+	return ImageURLPart(dataURL(mimeType, data), detail)
+}
+
+// InputAudioPart creates an audio content part from raw audio bytes.
+func InputAudioPart(data []byte, format ChatMessageInputAudioFormat) ChatMessagePart {
+	return ChatMessagePart{
+		Type: ChatMessagePartTypeInputAudio,
+		InputAudio: &ChatMessageInputAudio{
+			Data:   base64.StdEncoding.EncodeToString(data),
+			Format: format,
+		},
+	}
+}
+
+// FileIDPart creates a file content part referencing an uploaded file.
+func FileIDPart(fileID string) ChatMessagePart {
+	return ChatMessagePart{Type: ChatMessagePartTypeFile, File: &ChatMessageFile{FileID: fileID}}
+}
+
+// FileDataPart creates a file content part from raw file bytes of the given
+// MIME type (e.g. "application/pdf").
+func FileDataPart(filename, mimeType string, data []byte) ChatMessagePart {
+	return ChatMessagePart{
+		Type: ChatMessagePartTypeFile,
+		File: &ChatMessageFile{FileName: filename, FileData: dataURL(mimeType, data)},
+	}
+}
+
+func dataURL(mimeType string, data []byte) string {
+	return fmt.Sprintf("data:%s;base64,%s", mimeType, base64.StdEncoding.EncodeToString(data))
+}
@@ -0,0 +1,134 @@
+package openai
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrMessageContentNotText = errors.New("message content has parts other than text")
+
+// Rough sizes used by EstimateMessageTokens, which does not tokenize.
+const (
+	estimatedBytesPerToken = 4
+	// estimatedImageTokens is the cost of a low detail image; higher details
+	// cost more, depending on the image size.
+	estimatedImageTokens = 85
+)
+
+// Text returns the plain text of the message: its string content, or the
+// text parts of its multi-part content joined by newlines. Refusals are
+// returned when the message has no other text, and tool results are returned
+// like any other content. Images, audio and files are left out.
+func (m ChatCompletionMessage) Text() string {
+	if m.Content != "" {
+		return m.Content
+	}
+	var texts []string
+	for _, part := range m.MultiContent {
+		if part.Text != "" {
+			texts = append(texts, part.Text)
+		}
+	}
+	if len(texts) == 0 {
+		return m.Refusal
+	}
+	return strings.Join(texts, "\n")
+}
+
+// HasOnlyText reports whether the message content can be represented as a
+// plain string without loss.
+func (m ChatCompletionMessage) HasOnlyText() bool {
+	for _, part := range m.MultiContent {
+		if part.Type != "" && part.Type != ChatMessagePartTypeText {
+			return false
+		}
+	}
+	return true
+}
+
+// WithMultiContent returns a copy of the message whose string content is
+// converted into a single text part, for APIs and middlewares that only
+// handle the part form. Messages already in part form are returned as is.
+func (m ChatCompletionMessage) WithMultiContent() ChatCompletionMessage {
+	if m.Content == "" {
+		return m
+	}
+	m.MultiContent = []ChatMessagePart{TextPart(m.Content)}
+	m.Content = ""
+	return m
+}
+
+// WithStringContent returns a copy of the message whose text parts are
+// joined by newlines into its string content. It fails with
+// ErrMessageContentNotText when the message has image, audio or file parts.
+func (m ChatCompletionMessage) WithStringContent() (ChatCompletionMessage, error) {
+	if m.MultiContent == nil {
+		return m, nil
+	}
+	if !m.HasOnlyText() {
+		return m, ErrMessageContentNotText
+	}
+	texts := make([]string, 0, len(m.MultiContent))
+	for _, part := range m.MultiContent {
+		texts = append(texts, part.Text)
+	}
+	m.Content = strings.Join(texts, "\n")
+	m.MultiContent = nil
+	return m, nil
+}
+
+// ByteSize returns the number of bytes of the message fields that carry
+// content: text, names, tool calls, and the URLs and inline data of images,
+// audio and files. It approximates the size of the message on the wire
+// without encoding it.
+func (m ChatCompletionMessage) ByteSize() int {
+	size := len(m.Role) + len(m.Content) + len(m.Refusal) + len(m.Name) + len(m.ReasoningContent) + len(m.ToolCallID)
+	for _, part := range m.MultiContent {
+		size += len(part.Text)
+		if part.ImageURL != nil {
+			size += len(part.ImageURL.URL)
+		}
+		if part.InputAudio != nil {
+			size += len(part.InputAudio.Data)
+		}
+		if part.File != nil {
+			size += len(part.File.FileID) + len(part.File.FileName) + len(part.File.FileData)
+		}
+	}
+	if m.FunctionCall != nil {
+		size += len(m.FunctionCall.Name) + len(m.FunctionCall.Arguments)
+	}
+	for _, call := range m.ToolCalls {
+		size += len(call.ID) + len(call.Function.Name) + len(call.Function.Arguments)
+	}
+	return size
+}
+
+// EstimateMessageTokens estimates the number of prompt tokens the messages
+// use without a Tokenizer, counting about 4 bytes of text per token and 85
+// tokens per image. It is meant for budgeting and truncation decisions; use
+// CountMessageTokens when an exact count matters.
+func EstimateMessageTokens(messages []ChatCompletionMessage) int {
+	total := chatTokensReplyPriming
+	for _, message := range messages {
+		total += chatTokensPerMessage
+		if message.Name != "" {
+			total += chatTokensPerName
+		}
+		textBytes := message.ByteSize()
+		for _, part := range message.MultiContent {
+			if part.ImageURL != nil {
+				textBytes -= len(part.ImageURL.URL)
+				total += estimatedImageTokens
+			}
+			if part.InputAudio != nil {
+				textBytes -= len(part.InputAudio.Data)
+			}
+			if part.File != nil {
+				textBytes -= len(part.File.FileData)
+			}
+		}
+		total += (textBytes + estimatedBytesPerToken - 1) / estimatedBytesPerToken
+	}
+	return total
+}
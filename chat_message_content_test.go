@@ -0,0 +1,72 @@
+package openai_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestMessageText(t *testing.T) {
+	cases := []struct {
+		name    string
+		message openai.ChatCompletionMessage
+		want    string
+	}{
+		{"string", openai.UserMessage("hello"), "hello"},
+		{"parts", openai.UserMessageParts(
+			openai.TextPart("first"),
+			openai.ImageURLPart("https://example.com/a.png", ""),
+			openai.TextPart("second"),
+		), "first\nsecond"},
+		{"tool result", openai.ToolMessage("call_1", `{"temp":21}`), `{"temp":21}`},
+		{"refusal", openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Refusal: "no"}, "no"},
+		{"tool calls", openai.AssistantToolCallsMessage(openai.ToolCall{ID: "call_1"}), ""},
+	}
+	for _, tc := range cases {
+		if got := tc.message.Text(); got != tc.want {
+			t.Errorf("%s: Text() = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestMessageContentConversion(t *testing.T) {
+	parts := openai.UserMessage("hello").WithMultiContent()
+	if parts.Content != "" || len(parts.MultiContent) != 1 || parts.MultiContent[0].Text != "hello" {
+		t.Fatalf("WithMultiContent() = %+v", parts)
+	}
+
+	message, err := openai.UserMessageParts(openai.TextPart("a"), openai.TextPart("b")).WithStringContent()
+	checks.NoError(t, err, "WithStringContent error")
+	if message.Content != "a\nb" || message.MultiContent != nil {
+		t.Errorf("WithStringContent() = %+v", message)
+	}
+
+	image := openai.UserMessageParts(openai.TextPart("a"), openai.ImageURLPart("https://example.com/a.png", ""))
+	if image.HasOnlyText() {
+		t.Error("HasOnlyText() = true for a message with an image")
+	}
+	if _, err = image.WithStringContent(); !errors.Is(err, openai.ErrMessageContentNotText) {
+		t.Errorf("WithStringContent() error = %v, want ErrMessageContentNotText", err)
+	}
+}
+
+func TestMessageSize(t *testing.T) {
+	message := openai.AssistantToolCallsMessage(openai.ToolCall{
+		ID: "call_1", Function: openai.FunctionCall{Name: "f", Arguments: "{}"},
+	})
+	if got, want := message.ByteSize(), len("assistant")+len("call_1")+len("f")+len("{}"); got != want {
+		t.Errorf("ByteSize() = %d, want %d", got, want)
+	}
+
+	messages := []openai.ChatCompletionMessage{
+		openai.UserMessage("12345678"),
+		openai.UserMessageParts(openai.ImageDataPart(make([]byte, 4096), "image/png", "")),
+	}
+	// 3 for priming, 3 per message, 3 tokens for "user12345678", 1 for
+	// "user" and 85 for the image, whose data is not counted as text.
+	if got, want := openai.EstimateMessageTokens(messages), 3+3+3+3+1+85; got != want {
+		t.Errorf("EstimateMessageTokens() = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,52 @@
+package openai_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestMessageConstructors(t *testing.T) {
+	cases := []struct {
+		message openai.ChatCompletionMessage
+		want    string
+	}{
+		{openai.SystemMessage("be brief"), `{"role":"system","content":"be brief"}`},
+		{openai.DeveloperMessage("be brief"), `{"role":"developer","content":"be brief"}`},
+		{openai.UserMessage("hi"), `{"role":"user","content":"hi"}`},
+		{openai.AssistantMessage("hello"), `{"role":"assistant","content":"hello"}`},
+		{openai.ToolMessage("call_1", "42"), `{"role":"tool","content":"42","tool_call_id":"call_1"}`},
+		{
+			openai.AssistantToolCallsMessage(openai.ToolCall{
+				ID: "call_1", Type: openai.ToolTypeFunction, Function: openai.FunctionCall{Name: "f", Arguments: "{}"},
+			}),
+			`{"role":"assistant","tool_calls":[{"id":"call_1","type":"function","function":{"name":"f","arguments":"{}"}}]}`,
+		},
+		{
+			openai.UserMessageParts(
+				openai.TextPart("describe"),
+				openai.ImageURLPart("https://example.com/a.png", openai.ImageURLDetailLow),
+				openai.ImageDataPart([]byte("png"), "image/png", ""),
+				openai.InputAudioPart([]byte("wav"), openai.ChatMessageInputAudioFormatWAV),
+				openai.FileIDPart("file-1"),
+				openai.FileDataPart("a.pdf", "application/pdf", []byte("pdf")),
+			),
+			`{"role":"user","content":[` +
+				`{"type":"text","text":"describe"},` +
+				`{"type":"image_url","image_url":{"url":"https://example.com/a.png","detail":"low"}},` +
+				`{"type":"image_url","image_url":{"url":"data:image/png;base64,cG5n"}},` +
+				`{"type":"input_audio","input_audio":{"data":"d2F2","format":"wav"}},` +
+				`{"type":"file","file":{"file_id":"file-1"}},` +
+				`{"type":"file","file":{"filename":"a.pdf","file_data":"data:application/pdf;base64,cGRm"}}]}`,
+		},
+	}
+	for _, tc := range cases {
+		got, err := json.Marshal(tc.message)
+		checks.NoError(t, err, "Marshal returned error")
+		if string(got) != tc.want {
+			t.Errorf("unexpected JSON:\n got %s\nwant %s", got, tc.want)
+		}
+	}
+}
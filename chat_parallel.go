@@ -0,0 +1,175 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultParallelConcurrency  = 4
+	defaultParallelRetryBackoff = 500 * time.Millisecond
+)
+
+// ParallelOptions configures CreateChatCompletionsParallel.
+type ParallelOptions struct {
+	// Concurrency is the maximum number of requests in flight, 4 by default.
+	Concurrency int
+	// MaxRetries is the number of times a request failing with a rate limit,
+	// server or network error is retried.
+	MaxRetries int
+	// RetryBackoff is the wait before the first retry, doubled on every
+	// further attempt. It defaults to 500ms.
+	RetryBackoff time.Duration
+}
+
+// ParallelError is returned by CreateChatCompletionsParallel when some
+// requests failed. Errors is indexed like the requests, with nil entries for
+// the requests that succeeded.
+type ParallelError struct {
+	Errors []error
+}
+
+func (e *ParallelError) Error() string {
+	failed, first := 0, -1
+	for i, err := range e.Errors {
+		if err != nil {
+			failed++
+			if first < 0 {
+				first = i
+			}
+		}
+	}
+	return fmt.Sprintf("%d of %d requests failed, first error (request %d): %v",
+		failed, len(e.Errors), first, e.Errors[first])
+}
+
+// Unwrap returns the error of the first failed request. Is and As look
+// through the errors of all the failed requests, so that errors.Is and
+// errors.As match any of them, which Unwrap() []error only achieves from Go
+// 1.20 on.
+func (e *ParallelError) Unwrap() error {
+	for _, err := range e.Errors {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *ParallelError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if err != nil && errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *ParallelError) As(target any) bool {
+	for _, err := range e.Errors {
+		if err != nil && errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateChatCompletionsParallel sends the requests with bounded concurrency
+// and returns the responses in request order. Failed requests leave a zero
+// response in their slot and are reported through a *ParallelError once all
+// requests have completed.
+func (c *Client) CreateChatCompletionsParallel(
+	ctx context.Context,
+	requests []ChatCompletionRequest,
+	opts ParallelOptions,
+) ([]ChatCompletionResponse, error) {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultParallelConcurrency
+	}
+	backoff := opts.RetryBackoff
+	if backoff <= 0 {
+		backoff = defaultParallelRetryBackoff
+	}
+
+	responses := make([]ChatCompletionResponse, len(requests))
+	errs := make([]error, len(requests))
+	failed := false
+
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		sem = make(chan struct{}, concurrency)
+	)
+	for i := range requests {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			response, err := c.createChatCompletionWithRetries(ctx, requests[i], opts.MaxRetries, backoff)
+			responses[i] = response
+			if err != nil {
+				mu.Lock()
+				errs[i], failed = err, true
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if failed {
+		return responses, &ParallelError{Errors: errs}
+	}
+	return responses, nil
+}
+
+func (c *Client) createChatCompletionWithRetries(
+	ctx context.Context,
+	request ChatCompletionRequest,
+	maxRetries int,
+	backoff time.Duration,
+) (response ChatCompletionResponse, err error) {
+	for attempt := 0; ; attempt++ {
+		response, err = c.CreateChatCompletion(ctx, request)
+		if err == nil || attempt >= maxRetries || !isRetryableError(err) {
+			return
+		}
+
+		timer := time.NewTimer(backoff << attempt)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return response, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// isRetryableError reports whether err is a rate limit, server or network
+// error, or a preemption, which may succeed when retried.
+func isRetryableError(err error) bool {
+	if errors.Is(err, ErrRequestPreempted) {
+		return true
+	}
+	retryableStatus := func(code int) bool {
+		return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return retryableStatus(apiErr.HTTPStatusCode)
+	}
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) {
+		return retryableStatus(reqErr.HTTPStatusCode)
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,116 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestCreateChatCompletionsParallel(t *testing.T) {
+	var (
+		mu                  sync.Mutex
+		inFlight, maxFlight int
+		attempts            int
+	)
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts++
+		inFlight++
+		if inFlight > maxFlight {
+			maxFlight = inFlight
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+		time.Sleep(10 * time.Millisecond)
+
+		var req openai.ChatCompletionRequest
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		content := req.Messages[0].Content
+		if content == "bad" {
+			http.Error(w, `{"error":{"message":"invalid","type":"invalid_request_error"}}`, http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"object":"chat.completion","choices":[{"message":{"role":"assistant","content":"echo %s"}}]}`,
+			content)
+	})
+	server.InjectFaults("/v1/chat/completions", test.Repeat(2, test.ServerError())...)
+
+	var requests []openai.ChatCompletionRequest
+	for i := 0; i < 6; i++ {
+		requests = append(requests, openai.ChatCompletionRequest{
+			Model:    openai.GPT4oMini,
+			Messages: []openai.ChatCompletionMessage{openai.UserMessage(fmt.Sprint(i))},
+		})
+	}
+	responses, err := client.CreateChatCompletionsParallel(context.Background(), requests, openai.ParallelOptions{
+		Concurrency:  2,
+		MaxRetries:   2,
+		RetryBackoff: time.Millisecond,
+	})
+	checks.NoError(t, err)
+	for i, resp := range responses {
+		if want := fmt.Sprintf("echo %d", i); resp.Choices[0].Message.Content != want {
+			t.Errorf("response %d: expected %q, got %q", i, want, resp.Choices[0].Message.Content)
+		}
+	}
+	if maxFlight > 2 {
+		t.Errorf("expected at most 2 requests in flight, got %d", maxFlight)
+	}
+	// The two injected failures never reach the handler; their requests only
+	// succeeded thanks to the retries.
+	if attempts != 6 {
+		t.Errorf("expected 6 successful attempts, got %d", attempts)
+	}
+
+	requests[1].Messages[0].Content = "bad"
+	attempts = 0
+	responses, err = client.CreateChatCompletionsParallel(context.Background(), requests, openai.ParallelOptions{
+		MaxRetries: 3,
+	})
+	var parallelErr *openai.ParallelError
+	if !errors.As(err, &parallelErr) {
+		t.Fatalf("expected ParallelError, got %v", err)
+	}
+	if parallelErr.Errors[1] == nil || parallelErr.Errors[0] != nil || len(responses[1].Choices) != 0 {
+		t.Errorf("expected only request 1 to fail, got %v", parallelErr.Errors)
+	}
+	if attempts != 6 {
+		t.Errorf("expected client errors not to be retried, got %d attempts", attempts)
+	}
+}
+
+func TestParallelErrorMatchesEveryFailure(t *testing.T) {
+	errFirst := errors.New("first")
+	apiErr := &openai.APIError{HTTPStatusCode: http.StatusBadRequest, Message: "bad"}
+	err := error(&openai.ParallelError{Errors: []error{nil, errFirst, nil, fmt.Errorf("wrapped: %w", apiErr)}})
+
+	if !errors.Is(err, errFirst) {
+		t.Error("errors.Is should match the first failure")
+	}
+	var target *openai.APIError
+	if !errors.As(err, &target) || target != apiErr {
+		t.Errorf("errors.As should find the APIError of a later failure, got %v", target)
+	}
+	if errors.Is(err, io.EOF) {
+		t.Error("errors.Is should not match an unrelated error")
+	}
+	if got := errors.Unwrap(err); got != errFirst {
+		t.Errorf("Unwrap() = %v, want the first failure", got)
+	}
+}
@@ -37,6 +37,8 @@ type ChatCompletionTokenLogprobTopLogprob struct {
 	Logprob float64 `json:"logprob"`
 }
 
+// ChatCompletionStreamChoice is the delta of a choice in a stream chunk. Its
+// FinishReason is only set on the final chunk of the choice.
 type ChatCompletionStreamChoice struct {
 	Index                int                                 `json:"index"`
 	Delta                ChatCompletionStreamChoiceDelta     `json:"delta"`
@@ -65,21 +67,31 @@ type ChatCompletionStreamResponse struct {
 	Usage *Usage `json:"usage,omitempty"`
 }
 
+// reset clears the response for reuse by RecvInto, keeping the backing
+// array of its choices. Choices are zeroed since the JSON decoder would
+// otherwise merge the next event into stale values.
+func (r *ChatCompletionStreamResponse) reset() {
+	choices := r.Choices[:cap(r.Choices)]
+	for i := range choices {
+		choices[i] = ChatCompletionStreamChoice{}
+	}
+	*r = ChatCompletionStreamResponse{Choices: choices[:0]}
+}
+
 // ChatStreamReader is an interface for reading chat completion streams.
 type ChatStreamReader interface {
 	Recv() (ChatCompletionStreamResponse, error)
 	Close() error
 }
 
-// ChatCompletionStream
-// Note: Perhaps it is more elegant to abstract Stream using generics.
+// ChatCompletionStream is a stream of chat completion chunks.
 type ChatCompletionStream struct {
-	reader ChatStreamReader
+	*Stream[ChatCompletionStreamResponse]
 }
 
 // NewChatCompletionStream allows injecting a custom ChatStreamReader (for testing).
 func NewChatCompletionStream(reader ChatStreamReader) *ChatCompletionStream {
-	return &ChatCompletionStream{reader: reader}
+	return &ChatCompletionStream{Stream: NewStream[ChatCompletionStreamResponse](reader)}
 }
 
 // CreateChatCompletionStream — API call to create a chat completion w/ streaming
@@ -90,6 +102,16 @@ func (c *Client) CreateChatCompletionStream(
 	ctx context.Context,
 	request ChatCompletionRequest,
 ) (stream *ChatCompletionStream, err error) {
+	c.applyChatDefaults(&request)
+	c.applyChatExperiment(ctx, &request)
+	if err = c.mutateChatRequest(ctx, &request); err != nil {
+		return
+	}
+	if c.config.Policy != nil {
+		if err = c.config.Policy.CheckChatCompletion(request); err != nil {
+			return
+		}
+	}
 	urlSuffix := chatCompletionsSuffix
 	if !checkEndpointSupportsModel(urlSuffix, request.Model) {
 		err = ErrChatCompletionInvalidModel
@@ -102,6 +124,25 @@ func (c *Client) CreateChatCompletionStream(
 		return
 	}
 
+	if err = NewMessageValidator().Validate(request); err != nil {
+		return
+	}
+
+	if err = validateMetadata(request.Metadata); err != nil {
+		return
+	}
+
+	if err = validateChatTools(request.Tools); err != nil {
+		return
+	}
+
+	if err = c.checkContextLength(&request); err != nil {
+		return
+	}
+
+	request.SafetyIdentifier = c.resolveSafetyIdentifier(ctx, request.SafetyIdentifier, request.User)
+	request.User = c.resolveUser(ctx, request.User)
+
 	req, err := c.newRequest(
 		ctx,
 		http.MethodPost,
@@ -116,38 +157,33 @@ func (c *Client) CreateChatCompletionStream(
 	if err != nil {
 		return
 	}
-	stream = &ChatCompletionStream{
-		reader: resp,
+	stream = NewChatCompletionStream(resp)
+	stream.AddTransform(c.config.ChatCompletionStreamTransforms...)
+	if c.fingerprints != nil {
+		stream.AddTransform(c.fingerprints.streamTransform())
 	}
-	return
-}
-
-func (s *ChatCompletionStream) Recv() (ChatCompletionStreamResponse, error) {
-	return s.reader.Recv()
-}
-
-func (s *ChatCompletionStream) Close() error {
-	return s.reader.Close()
-}
-
-func (s *ChatCompletionStream) Header() http.Header {
-	if h, ok := s.reader.(interface{ Header() http.Header }); ok {
-		return h.Header()
+	if c.config.Metrics != nil {
+		stream.AddTransform(func(chunk *ChatCompletionStreamResponse) error {
+			if chunk.Usage != nil {
+				c.recordTokens(req, chunk.Usage.PromptTokens, chunk.Usage.CompletionTokens)
+			}
+			return nil
+		})
 	}
-	return http.Header{}
+	return
 }
 
 func (s *ChatCompletionStream) GetRateLimitHeaders() map[string]interface{} {
-	if h, ok := s.reader.(interface{ GetRateLimitHeaders() RateLimitHeaders }); ok {
-		headers := h.GetRateLimitHeaders()
-		return map[string]interface{}{
-			"x-ratelimit-limit-requests":     headers.LimitRequests,
-			"x-ratelimit-limit-tokens":       headers.LimitTokens,
-			"x-ratelimit-remaining-requests": headers.RemainingRequests,
-			"x-ratelimit-remaining-tokens":   headers.RemainingTokens,
-			"x-ratelimit-reset-requests":     headers.ResetRequests.String(),
-			"x-ratelimit-reset-tokens":       headers.ResetTokens.String(),
-		}
+	if _, ok := s.reader.(interface{ Header() http.Header }); !ok {
+		return map[string]interface{}{}
+	}
+	headers := s.Stream.GetRateLimitHeaders()
+	return map[string]interface{}{
+		"x-ratelimit-limit-requests":     headers.LimitRequests,
+		"x-ratelimit-limit-tokens":       headers.LimitTokens,
+		"x-ratelimit-remaining-requests": headers.RemainingRequests,
+		"x-ratelimit-remaining-tokens":   headers.RemainingTokens,
+		"x-ratelimit-reset-requests":     headers.ResetRequests.String(),
+		"x-ratelimit-reset-tokens":       headers.ResetTokens.String(),
 	}
-	return map[string]interface{}{}
 }
@@ -0,0 +1,211 @@
+package openai
+
+import (
+	"sort"
+	"strings"
+)
+
+// ChatCompletionChoiceAccumulator assembles the streamed deltas of a single
+// choice into a complete ChatCompletionChoice.
+type ChatCompletionChoiceAccumulator struct {
+	index int
+
+	role                 string
+	content              strings.Builder
+	refusal              strings.Builder
+	reasoningContent     strings.Builder
+	functionCall         *FunctionCall
+	toolCalls            *ToolCallAssembler
+	finishReason         FinishReason
+	logprobs             []ChatCompletionTokenLogprob
+	contentFilterResults ContentFilterResults
+}
+
+// NewChatCompletionChoiceAccumulator creates an accumulator for the choice
+// with the given index.
+func NewChatCompletionChoiceAccumulator(index int) *ChatCompletionChoiceAccumulator {
+	return &ChatCompletionChoiceAccumulator{
+		index:     index,
+		toolCalls: NewToolCallAssembler(),
+	}
+}
+
+// Add consumes a streamed delta of the choice.
+func (a *ChatCompletionChoiceAccumulator) Add(choice ChatCompletionStreamChoice) {
+	delta := choice.Delta
+	if delta.Role != "" {
+		a.role = delta.Role
+	}
+	a.content.WriteString(delta.Content)
+	a.refusal.WriteString(delta.Refusal)
+	a.reasoningContent.WriteString(delta.ReasoningContent)
+	if delta.FunctionCall != nil {
+		if a.functionCall == nil {
+			a.functionCall = &FunctionCall{}
+		}
+		a.functionCall.Name += delta.FunctionCall.Name
+		a.functionCall.Arguments += delta.FunctionCall.Arguments
+	}
+	a.toolCalls.AddDelta(delta)
+	if choice.FinishReason.IsFinished() {
+		a.finishReason = choice.FinishReason
+	}
+	if choice.Logprobs != nil {
+		a.logprobs = append(a.logprobs, choice.Logprobs.Content...)
+	}
+	if choice.ContentFilterResults != (ContentFilterResults{}) {
+		a.contentFilterResults = choice.ContentFilterResults
+	}
+}
+
+// Content returns the message content received so far.
+func (a *ChatCompletionChoiceAccumulator) Content() string {
+	return a.content.String()
+}
+
+// ToolCalls returns the tool call assembler of the choice.
+func (a *ChatCompletionChoiceAccumulator) ToolCalls() *ToolCallAssembler {
+	return a.toolCalls
+}
+
+// FinishReason returns the finish reason of the choice, or an empty string
+// while the choice is still streaming.
+func (a *ChatCompletionChoiceAccumulator) FinishReason() FinishReason {
+	return a.finishReason
+}
+
+// Choice returns the choice assembled so far.
+func (a *ChatCompletionChoiceAccumulator) Choice() ChatCompletionChoice {
+	role := a.role
+	if role == "" {
+		role = ChatMessageRoleAssistant
+	}
+	choice := ChatCompletionChoice{
+		Index: a.index,
+		Message: ChatCompletionMessage{
+			Role:             role,
+			Content:          a.content.String(),
+			Refusal:          a.refusal.String(),
+			ReasoningContent: a.reasoningContent.String(),
+			FunctionCall:     a.functionCall,
+		},
+		FinishReason:         a.finishReason,
+		ContentFilterResults: a.contentFilterResults,
+	}
+	if a.toolCalls.Len() > 0 {
+		choice.Message.ToolCalls = a.toolCalls.ToolCalls()
+		for i := range choice.Message.ToolCalls {
+			choice.Message.ToolCalls[i].Index = nil
+		}
+	}
+	if len(a.logprobs) > 0 {
+		choice.LogProbs = &LogProbs{Content: convertTokenLogprobs(a.logprobs)}
+	}
+	return choice
+}
+
+func convertTokenLogprobs(tokens []ChatCompletionTokenLogprob) []LogProb {
+	result := make([]LogProb, len(tokens))
+	for i, token := range tokens {
+		result[i] = LogProb{
+			Token:   token.Token,
+			LogProb: token.Logprob,
+			Bytes:   convertTokenBytes(token.Bytes),
+		}
+		for _, top := range token.TopLogprobs {
+			result[i].TopLogProbs = append(result[i].TopLogProbs, TopLogProbs{
+				Token:   top.Token,
+				LogProb: top.Logprob,
+				Bytes:   convertTokenBytes(top.Bytes),
+			})
+		}
+	}
+	return result
+}
+
+func convertTokenBytes(b []int64) []byte {
+	if b == nil {
+		return nil
+	}
+	result := make([]byte, len(b))
+	for i, v := range b {
+		result[i] = byte(v)
+	}
+	return result
+}
+
+// ChatCompletionAccumulator assembles a chat completion stream, including
+// streams with n > 1 whose chunks interleave choices, into the
+// ChatCompletionResponse a non-streaming request would have returned.
+type ChatCompletionAccumulator struct {
+	response ChatCompletionResponse
+	choices  map[int]*ChatCompletionChoiceAccumulator
+}
+
+// NewChatCompletionAccumulator creates an empty ChatCompletionAccumulator.
+func NewChatCompletionAccumulator() *ChatCompletionAccumulator {
+	return &ChatCompletionAccumulator{
+		choices: make(map[int]*ChatCompletionChoiceAccumulator),
+	}
+}
+
+// Add consumes a stream chunk.
+func (a *ChatCompletionAccumulator) Add(chunk ChatCompletionStreamResponse) {
+	if chunk.ID != "" {
+		a.response.ID = chunk.ID
+	}
+	if chunk.Created != 0 {
+		a.response.Created = chunk.Created
+	}
+	if chunk.Model != "" {
+		a.response.Model = chunk.Model
+	}
+	if chunk.SystemFingerprint != "" {
+		a.response.SystemFingerprint = chunk.SystemFingerprint
+	}
+	if len(chunk.PromptFilterResults) > 0 {
+		a.response.PromptFilterResults = chunk.PromptFilterResults
+	}
+	if chunk.Usage != nil {
+		a.response.Usage = *chunk.Usage
+	}
+	for _, choice := range chunk.Choices {
+		a.Choice(choice.Index).Add(choice)
+	}
+}
+
+// Choice returns the accumulator of the choice with the given index,
+// creating it if no delta has been received for it yet.
+func (a *ChatCompletionAccumulator) Choice(index int) *ChatCompletionChoiceAccumulator {
+	choice, ok := a.choices[index]
+	if !ok {
+		choice = NewChatCompletionChoiceAccumulator(index)
+		a.choices[index] = choice
+	}
+	return choice
+}
+
+// Response returns the response assembled so far, with choices ordered by index.
+func (a *ChatCompletionAccumulator) Response() ChatCompletionResponse {
+	response := a.response
+	response.Object = "chat.completion"
+	response.Choices = make([]ChatCompletionChoice, 0, len(a.choices))
+	for _, choice := range a.choices {
+		response.Choices = append(response.Choices, choice.Choice())
+	}
+	sort.Slice(response.Choices, func(i, j int) bool {
+		return response.Choices[i].Index < response.Choices[j].Index
+	})
+	return response
+}
+
+// Accumulate reads the remaining chunks of the stream and assembles them into
+// the ChatCompletionResponse a non-streaming request would have returned.
+// Set StreamOptions.IncludeUsage on the request to get Usage populated.
+func (s *ChatCompletionStream) Accumulate() (ChatCompletionResponse, error) {
+	acc := NewChatCompletionAccumulator()
+	for s.Next() {
+		acc.Add(s.Current())
+	}
+	return acc.Response(), s.Err()
+}
@@ -0,0 +1,72 @@
+package openai_test
+
+import (
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+func TestChatCompletionAccumulator(t *testing.T) {
+	usage := &openai.Usage{PromptTokens: 3, CompletionTokens: 4, TotalTokens: 7}
+	chunks := []openai.ChatCompletionStreamResponse{
+		{ID: "c1", Model: "gpt-4o", Created: 10, Choices: []openai.ChatCompletionStreamChoice{
+			{Index: 1, Delta: openai.ChatCompletionStreamChoiceDelta{Role: "assistant", Content: "Bon"}},
+		}},
+		{ID: "c1", Choices: []openai.ChatCompletionStreamChoice{
+			{Index: 0, Delta: openai.ChatCompletionStreamChoiceDelta{Role: "assistant", Content: "Hel"},
+				Logprobs: &openai.ChatCompletionStreamChoiceLogprobs{
+					Content: []openai.ChatCompletionTokenLogprob{{Token: "Hel", Logprob: -0.1, Bytes: []int64{72, 101, 108}}},
+				}},
+		}},
+		{ID: "c1", Choices: []openai.ChatCompletionStreamChoice{
+			{Index: 0, Delta: openai.ChatCompletionStreamChoiceDelta{Content: "lo"}, FinishReason: openai.FinishReasonStop},
+			{Index: 1, Delta: openai.ChatCompletionStreamChoiceDelta{Content: "jour"}, FinishReason: openai.FinishReasonLength},
+		}},
+		{ID: "c1", Usage: usage},
+	}
+
+	acc := openai.NewChatCompletionAccumulator()
+	for _, chunk := range chunks {
+		acc.Add(chunk)
+	}
+	resp := acc.Response()
+	if resp.ID != "c1" || resp.Model != "gpt-4o" || resp.Usage.TotalTokens != 7 {
+		t.Fatalf("unexpected response metadata: %+v", resp)
+	}
+	if len(resp.Choices) != 2 {
+		t.Fatalf("expected 2 choices, got %d", len(resp.Choices))
+	}
+	if resp.Choices[0].Message.Content != "Hello" || resp.Choices[0].FinishReason != openai.FinishReasonStop {
+		t.Fatalf("unexpected first choice: %+v", resp.Choices[0])
+	}
+	if resp.Choices[1].Message.Content != "Bonjour" || resp.Choices[1].FinishReason != openai.FinishReasonLength {
+		t.Fatalf("unexpected second choice: %+v", resp.Choices[1])
+	}
+	if resp.Choices[0].LogProbs == nil || string(resp.Choices[0].LogProbs.Content[0].Bytes) != "Hel" {
+		t.Fatalf("unexpected logprobs: %+v", resp.Choices[0].LogProbs)
+	}
+}
+
+func TestChatCompletionChoiceAccumulatorToolCalls(t *testing.T) {
+	acc := openai.NewChatCompletionChoiceAccumulator(0)
+	acc.Add(openai.ChatCompletionStreamChoice{Delta: openai.ChatCompletionStreamChoiceDelta{
+		ToolCalls: []openai.ToolCall{{Index: intPtr(0), ID: "call", Function: openai.FunctionCall{Name: "f", Arguments: "{"}}},
+	}})
+	acc.Add(openai.ChatCompletionStreamChoice{
+		Delta: openai.ChatCompletionStreamChoiceDelta{
+			ToolCalls: []openai.ToolCall{{Index: intPtr(0), Function: openai.FunctionCall{Arguments: "}"}}},
+		},
+		FinishReason: openai.FinishReasonToolCalls,
+	})
+
+	choice := acc.Choice()
+	if choice.Message.Role != openai.ChatMessageRoleAssistant || len(choice.Message.ToolCalls) != 1 {
+		t.Fatalf("unexpected choice: %+v", choice)
+	}
+	if call := choice.Message.ToolCalls[0]; call.Index != nil || call.Function.Arguments != "{}" {
+		t.Fatalf("unexpected tool call: %+v", call)
+	}
+	if acc.FinishReason() != openai.FinishReasonToolCalls {
+		t.Fatalf("unexpected finish reason: %s", acc.FinishReason())
+	}
+}
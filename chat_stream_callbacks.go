@@ -0,0 +1,51 @@
+package openai
+
+// ChatCompletionStreamCallbacks receive the parts of the chunks of a chat
+// completion stream as they are read, a middle ground between handling raw
+// chunks and accumulating the whole response. Nil callbacks are skipped.
+type ChatCompletionStreamCallbacks struct {
+	// OnContentDelta receives the content deltas of each choice.
+	OnContentDelta func(index int, content string)
+	// OnToolCallDelta receives the tool call fragments of each choice. The
+	// arguments of a call arrive split over several fragments sharing its
+	// Index; only the first one carries its ID and name.
+	OnToolCallDelta func(index int, toolCall ToolCall)
+	// OnFinish is called when a choice finishes.
+	OnFinish func(index int, reason FinishReason)
+	// OnUsage receives the usage of the request, sent in the last chunk when
+	// StreamOptions.IncludeUsage is set.
+	OnUsage func(usage Usage)
+}
+
+// AddCallbacks registers callbacks invoked for every chunk read afterwards,
+// whether through Recv, Next, Collect, Accumulate or Drain:
+//
+//	stream.AddCallbacks(openai.ChatCompletionStreamCallbacks{
+//		OnContentDelta: func(_ int, content string) { fmt.Print(content) },
+//	})
+//	err := stream.Drain()
+func (s *ChatCompletionStream) AddCallbacks(callbacks ChatCompletionStreamCallbacks) {
+	s.AddTransform(func(chunk *ChatCompletionStreamResponse) error {
+		callbacks.dispatch(chunk)
+		return nil
+	})
+}
+
+func (c ChatCompletionStreamCallbacks) dispatch(chunk *ChatCompletionStreamResponse) {
+	for _, choice := range chunk.Choices {
+		if c.OnContentDelta != nil && choice.Delta.Content != "" {
+			c.OnContentDelta(choice.Index, choice.Delta.Content)
+		}
+		if c.OnToolCallDelta != nil {
+			for _, toolCall := range choice.Delta.ToolCalls {
+				c.OnToolCallDelta(choice.Index, toolCall)
+			}
+		}
+		if c.OnFinish != nil && choice.FinishReason.IsFinished() {
+			c.OnFinish(choice.Index, choice.FinishReason)
+		}
+	}
+	if c.OnUsage != nil && chunk.Usage != nil {
+		c.OnUsage(*chunk.Usage)
+	}
+}
@@ -0,0 +1,84 @@
+package openai_test
+
+import (
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestChatCompletionStreamCallbacks(t *testing.T) {
+	stream := openai.NewChatCompletionStream(&mockStreamReader{responses: []openai.ChatCompletionStreamResponse{
+		{Choices: []openai.ChatCompletionStreamChoice{
+			{Index: 0, Delta: openai.ChatCompletionStreamChoiceDelta{Role: "assistant", Content: "Hel"}},
+			{Index: 1, Delta: openai.ChatCompletionStreamChoiceDelta{ToolCalls: []openai.ToolCall{
+				{ID: "call_1", Type: openai.ToolTypeFunction, Function: openai.FunctionCall{Name: "get_weather"}},
+			}}},
+		}},
+		{Choices: []openai.ChatCompletionStreamChoice{
+			{Index: 0, Delta: openai.ChatCompletionStreamChoiceDelta{Content: "lo"}, FinishReason: openai.FinishReasonStop},
+			{Index: 1, Delta: openai.ChatCompletionStreamChoiceDelta{ToolCalls: []openai.ToolCall{
+				{Function: openai.FunctionCall{Arguments: `{"city":"Paris"}`}},
+			}}, FinishReason: openai.FinishReasonToolCalls},
+		}},
+		{Usage: &openai.Usage{PromptTokens: 4, CompletionTokens: 6, TotalTokens: 10}},
+	}})
+
+	var (
+		content   string
+		arguments string
+		toolName  string
+		finished  = map[int]openai.FinishReason{}
+		usage     openai.Usage
+	)
+	stream.AddCallbacks(openai.ChatCompletionStreamCallbacks{
+		OnContentDelta: func(index int, delta string) {
+			if index != 0 {
+				t.Errorf("content delta for choice %d", index)
+			}
+			content += delta
+		},
+		OnToolCallDelta: func(_ int, toolCall openai.ToolCall) {
+			if toolCall.Function.Name != "" {
+				toolName = toolCall.Function.Name
+			}
+			arguments += toolCall.Function.Arguments
+		},
+		OnFinish: func(index int, reason openai.FinishReason) {
+			finished[index] = reason
+		},
+		OnUsage: func(u openai.Usage) {
+			usage = u
+		},
+	})
+	checks.NoError(t, stream.Drain())
+
+	if content != "Hello" {
+		t.Errorf("content = %q, want Hello", content)
+	}
+	if toolName != "get_weather" || arguments != `{"city":"Paris"}` {
+		t.Errorf("tool call = %s(%s)", toolName, arguments)
+	}
+	if finished[0] != openai.FinishReasonStop || finished[1] != openai.FinishReasonToolCalls {
+		t.Errorf("unexpected finish reasons %v", finished)
+	}
+	if usage.TotalTokens != 10 {
+		t.Errorf("usage = %+v, want 10 total tokens", usage)
+	}
+}
+
+func TestChatCompletionStreamCallbacksWithCollect(t *testing.T) {
+	stream := openai.NewChatCompletionStream(&mockStreamReader{responses: []openai.ChatCompletionStreamResponse{
+		{Choices: []openai.ChatCompletionStreamChoice{{Delta: openai.ChatCompletionStreamChoiceDelta{Content: "a"}}}},
+		{Choices: []openai.ChatCompletionStreamChoice{{Delta: openai.ChatCompletionStreamChoiceDelta{Content: "b"}}}},
+	}})
+	deltas := 0
+	stream.AddCallbacks(openai.ChatCompletionStreamCallbacks{
+		OnContentDelta: func(int, string) { deltas++ },
+	})
+	chunks, err := stream.Collect()
+	checks.NoError(t, err)
+	if len(chunks) != 2 || deltas != 2 {
+		t.Errorf("expected 2 chunks and 2 deltas, got %d and %d", len(chunks), deltas)
+	}
+}
@@ -0,0 +1,181 @@
+package openai
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// ChatCompletionStreamDemuxer splits a chat completion stream requested with
+// n > 1, whose chunks interleave the choices, into one stream per choice.
+//
+// Each per-choice stream yields the original chunks restricted to that
+// choice (its index is preserved); chunks without choices, such as the final
+// usage chunk, are delivered to every choice. Reading one choice buffers the
+// chunks of the others until they are read, so choices can be consumed
+// sequentially or from separate goroutines. The Stats of every choice are
+// those of the underlying stream, while Event is that of the last chunk of
+// the choice.
+//
+//	demuxer := openai.NewChatCompletionStreamDemuxer(stream, 3)
+//	defer demuxer.Close()
+//	for i := 0; i < 3; i++ {
+//		go render(demuxer.Choice(i))
+//	}
+type ChatCompletionStreamDemuxer struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	stream *ChatCompletionStream
+	queues []demuxQueue
+	err    error
+	// reading is set while a choice reads from stream, without holding mu,
+	// so that the other choices can be read from their buffers or closed
+	// meanwhile. Choices with empty buffers wait on cond for the next chunk.
+	reading bool
+}
+
+type demuxQueue struct {
+	chunks []demuxChunk
+	closed bool
+}
+
+// demuxChunk is a chunk buffered for a choice, with the server-sent event
+// that carried it.
+type demuxChunk struct {
+	chunk ChatCompletionStreamResponse
+	event StreamEvent
+}
+
+// NewChatCompletionStreamDemuxer creates a demuxer for a stream of n choices.
+func NewChatCompletionStreamDemuxer(stream *ChatCompletionStream, n int) *ChatCompletionStreamDemuxer {
+	if n < 1 {
+		n = 1
+	}
+	d := &ChatCompletionStreamDemuxer{
+		stream: stream,
+		queues: make([]demuxQueue, n),
+	}
+	d.cond = sync.NewCond(&d.mu)
+	return d
+}
+
+// Choice returns the stream of the choice with the given index. Closing it
+// only discards the choice's buffered chunks; close the demuxer to release
+// the underlying connection.
+func (d *ChatCompletionStreamDemuxer) Choice(index int) *ChatCompletionStream {
+	return NewChatCompletionStream(&demuxChoiceReader{demuxer: d, index: index})
+}
+
+// Close closes the underlying stream.
+func (d *ChatCompletionStreamDemuxer) Close() error {
+	return d.stream.Close()
+}
+
+func (d *ChatCompletionStreamDemuxer) recv(index int) (demuxChunk, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if index < 0 || index >= len(d.queues) {
+		return demuxChunk{}, fmt.Errorf("choice index %d out of range, stream has %d choices",
+			index, len(d.queues))
+	}
+
+	for {
+		queue := &d.queues[index]
+		if queue.closed {
+			return demuxChunk{}, io.EOF
+		}
+		if len(queue.chunks) > 0 {
+			chunk := queue.chunks[0]
+			queue.chunks = queue.chunks[1:]
+			return chunk, nil
+		}
+		if d.err != nil {
+			return demuxChunk{}, d.err
+		}
+		if d.reading {
+			d.cond.Wait()
+			continue
+		}
+
+		d.reading = true
+		d.mu.Unlock()
+		chunk, err := d.stream.Recv()
+		event := d.stream.Event()
+		d.mu.Lock()
+		d.reading = false
+		d.cond.Broadcast()
+		if err != nil {
+			d.err = err
+			continue
+		}
+		d.dispatch(demuxChunk{chunk: chunk, event: event})
+	}
+}
+
+func (d *ChatCompletionStreamDemuxer) dispatch(chunk demuxChunk) {
+	if len(chunk.chunk.Choices) == 0 {
+		for i := range d.queues {
+			d.enqueue(i, chunk)
+		}
+		return
+	}
+	for _, choice := range chunk.chunk.Choices {
+		choiceChunk := chunk
+		choiceChunk.chunk.Choices = []ChatCompletionStreamChoice{choice}
+		d.enqueue(choice.Index, choiceChunk)
+	}
+}
+
+func (d *ChatCompletionStreamDemuxer) enqueue(index int, chunk demuxChunk) {
+	if index < 0 || index >= len(d.queues) || d.queues[index].closed {
+		return
+	}
+	d.queues[index].chunks = append(d.queues[index].chunks, chunk)
+}
+
+func (d *ChatCompletionStreamDemuxer) closeChoice(index int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if index >= 0 && index < len(d.queues) {
+		d.queues[index] = demuxQueue{closed: true}
+		d.cond.Broadcast()
+	}
+}
+
+type demuxChoiceReader struct {
+	demuxer *ChatCompletionStreamDemuxer
+	index   int
+	event   StreamEvent
+}
+
+func (r *demuxChoiceReader) Recv() (ChatCompletionStreamResponse, error) {
+	chunk, err := r.demuxer.recv(r.index)
+	if err != nil {
+		return ChatCompletionStreamResponse{}, err
+	}
+	r.event = chunk.event
+	return chunk.chunk, nil
+}
+
+func (r *demuxChoiceReader) Close() error {
+	r.demuxer.closeChoice(r.index)
+	return nil
+}
+
+func (r *demuxChoiceReader) Header() http.Header {
+	return r.demuxer.stream.Header()
+}
+
+// Stats returns the statistics of the underlying stream, shared by all the
+// choices.
+func (r *demuxChoiceReader) Stats() StreamStats {
+	return r.demuxer.stream.Stats()
+}
+
+// Event returns the server-sent event that carried the last chunk of the
+// choice.
+func (r *demuxChoiceReader) Event() StreamEvent {
+	return r.event
+}
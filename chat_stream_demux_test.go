@@ -0,0 +1,168 @@
+package openai_test
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func interleavedChunks() []openai.ChatCompletionStreamResponse {
+	choice := func(index int, content string) openai.ChatCompletionStreamResponse {
+		return openai.ChatCompletionStreamResponse{Choices: []openai.ChatCompletionStreamChoice{
+			{Index: index, Delta: openai.ChatCompletionStreamChoiceDelta{Content: content}},
+		}}
+	}
+	return []openai.ChatCompletionStreamResponse{
+		choice(0, "a"), choice(1, "x"), choice(1, "y"), choice(0, "b"), choice(2, "!"),
+		{Usage: &openai.Usage{TotalTokens: 5}},
+	}
+}
+
+func readContent(t *testing.T, stream *openai.ChatCompletionStream) (content string, sawUsage bool) {
+	t.Helper()
+	content, sawUsage, err := streamContent(stream)
+	checks.NoError(t, err, "unexpected stream error")
+	return content, sawUsage
+}
+
+// streamContent reads the stream to the end without a *testing.T, so that it
+// can run in goroutines spawned by a test.
+func streamContent(stream *openai.ChatCompletionStream) (content string, sawUsage bool, err error) {
+	for stream.Next() {
+		chunk := stream.Current()
+		if chunk.Usage != nil {
+			sawUsage = true
+		}
+		for _, c := range chunk.Choices {
+			content += c.Delta.Content
+		}
+	}
+	return content, sawUsage, stream.Err()
+}
+
+func TestChatCompletionStreamDemuxerSequential(t *testing.T) {
+	stream := openai.NewChatCompletionStream(&mockStreamReader{responses: interleavedChunks()})
+	demuxer := openai.NewChatCompletionStreamDemuxer(stream, 3)
+	defer demuxer.Close()
+
+	want := []string{"ab", "xy", "!"}
+	for i := 2; i >= 0; i-- {
+		content, sawUsage := readContent(t, demuxer.Choice(i))
+		if content != want[i] || !sawUsage {
+			t.Fatalf("choice %d: got %q (usage %v), want %q", i, content, sawUsage, want[i])
+		}
+	}
+}
+
+func TestChatCompletionStreamDemuxerConcurrent(t *testing.T) {
+	stream := openai.NewChatCompletionStream(&mockStreamReader{responses: interleavedChunks()})
+	demuxer := openai.NewChatCompletionStreamDemuxer(stream, 2)
+
+	results := make([]string, 2)
+	errs := make(chan error, 2)
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var err error
+			results[i], _, err = streamContent(demuxer.Choice(i))
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		checks.NoError(t, err, "unexpected stream error")
+	}
+	if results[0] != "ab" || results[1] != "xy" {
+		t.Fatalf("unexpected results: %v", results)
+	}
+}
+
+func TestChatCompletionStreamDemuxerErrors(t *testing.T) {
+	errBroken := errors.New("broken")
+	stream := openai.NewChatCompletionStream(&errorAfterStreamReader{err: errBroken})
+	demuxer := openai.NewChatCompletionStreamDemuxer(stream, 1)
+
+	_, err := demuxer.Choice(0).Recv()
+	checks.ErrorIs(t, err, errBroken, "reader error should be propagated")
+	_, err = demuxer.Choice(3).Recv()
+	checks.HasError(t, err, "out of range choice should fail")
+
+	closed := demuxer.Choice(0)
+	checks.NoError(t, closed.Close())
+	_, err = closed.Recv()
+	checks.ErrorIs(t, err, io.EOF, "closed choice should return EOF")
+}
+
+// stalledStreamReader returns its chunks, then blocks until it is closed,
+// like a stream whose server stopped sending.
+type stalledStreamReader struct {
+	mockStreamReader
+	stalled chan struct{}
+	closed  chan struct{}
+}
+
+func (r *stalledStreamReader) Recv() (openai.ChatCompletionStreamResponse, error) {
+	chunk, err := r.mockStreamReader.Recv()
+	if !errors.Is(err, io.EOF) {
+		return chunk, err
+	}
+	close(r.stalled)
+	<-r.closed
+	return chunk, errors.New("read on closed body")
+}
+
+func (r *stalledStreamReader) Close() error {
+	close(r.closed)
+	return nil
+}
+
+func TestChatCompletionStreamDemuxerBufferedDuringRecv(t *testing.T) {
+	reader := &stalledStreamReader{
+		mockStreamReader: mockStreamReader{responses: interleavedChunks()[:2]},
+		stalled:          make(chan struct{}),
+		closed:           make(chan struct{}),
+	}
+	demuxer := openai.NewChatCompletionStreamDemuxer(openai.NewChatCompletionStream(reader), 2)
+
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := streamContent(demuxer.Choice(0))
+		done <- err
+	}()
+	<-reader.stalled
+
+	// The chunk of choice 1 was buffered before the read of choice 0 stalled.
+	received := make(chan string, 1)
+	go func() {
+		choice := demuxer.Choice(1)
+		choice.Stats()
+		if chunk, err := choice.Recv(); err == nil {
+			received <- chunk.Choices[0].Delta.Content
+		}
+		choice.Close()
+	}()
+	select {
+	case content := <-received:
+		if content != "x" {
+			t.Errorf("choice 1 received %q, want x", content)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("buffered chunk blocked behind the pending read")
+	}
+
+	checks.NoError(t, demuxer.Close())
+	select {
+	case err := <-done:
+		checks.HasError(t, err, "expected the pending read to fail once closed")
+	case <-time.After(5 * time.Second):
+		t.Fatal("pending read not unblocked by closing the demuxer")
+	}
+}
@@ -0,0 +1,230 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+const defaultResilientStreamRetries = 2
+
+// ErrStreamInterrupted is matched by the StreamInterruptedError returned by a
+// ResilientStream that failed after content was received.
+var ErrStreamInterrupted = errors.New("stream interrupted")
+
+// ResilientStreamOptions configures CreateResilientChatCompletionStream.
+type ResilientStreamOptions struct {
+	// MaxRetries is the number of times the request is sent again when the
+	// stream fails before any content was received. It defaults to 2; use a
+	// negative value to disable retries.
+	MaxRetries int
+	// RetryBackoff is the wait before the first retry, doubled on every
+	// further retry. It defaults to 500ms.
+	RetryBackoff time.Duration
+}
+
+// StreamInterruptedError is returned by a ResilientStream when the connection
+// fails after content was received, which cannot be retried without
+// repeating it. Partial holds the response received so far.
+type StreamInterruptedError struct {
+	Partial ChatCompletionResponse
+	Err     error
+
+	request ChatCompletionRequest
+}
+
+func (e *StreamInterruptedError) Error() string {
+	return fmt.Sprintf("stream interrupted after partial content: %v", e.Err)
+}
+
+func (e *StreamInterruptedError) Unwrap() error {
+	return e.Err
+}
+
+func (e *StreamInterruptedError) Is(target error) bool {
+	return target == ErrStreamInterrupted
+}
+
+// ContinuationRequest returns the original request extended with the partial
+// output and a user message asking the model to continue it, so the caller
+// can stream the rest. The prompt defaults to asking for a continuation
+// without repetition.
+func (e *StreamInterruptedError) ContinuationRequest(prompt string) ChatCompletionRequest {
+	if prompt == "" {
+		prompt = defaultContinuationPrompt
+	}
+	request := e.request
+	request.Messages = append([]ChatCompletionMessage{}, request.Messages...)
+	var content string
+	if len(e.Partial.Choices) > 0 {
+		content = e.Partial.Choices[0].Message.Content
+	}
+	request.Messages = append(request.Messages, AssistantMessage(content), UserMessage(prompt))
+	return request
+}
+
+// ResilientStream is a chat completion stream that recovers from connection
+// failures. A stream failing before any content was received is transparently
+// replaced by a new request; chunks without content, such as the role delta,
+// may then be received again. A stream failing afterwards returns a
+// StreamInterruptedError holding the partial response. Header, Stats and
+// Event are those of the last request.
+type ResilientStream struct {
+	*ChatCompletionStream
+
+	reader *resilientStreamReader
+}
+
+// CreateResilientChatCompletionStream creates a chat completion stream that
+// is retried on connection failures until content is received. Failures to
+// open the stream are retried on the same conditions as
+// CreateChatCompletions.
+func (c *Client) CreateResilientChatCompletionStream(
+	ctx context.Context,
+	request ChatCompletionRequest,
+	opts ResilientStreamOptions,
+) (stream *ResilientStream, err error) {
+	if opts.MaxRetries == 0 {
+		opts.MaxRetries = defaultResilientStreamRetries
+	}
+	if opts.RetryBackoff <= 0 {
+		opts.RetryBackoff = defaultParallelRetryBackoff
+	}
+	reader := &resilientStreamReader{
+		client:  c,
+		ctx:     ctx,
+		request: request,
+		opts:    opts,
+		acc:     NewChatCompletionAccumulator(),
+	}
+	if err = reader.open(nil); err != nil {
+		return
+	}
+	stream = &ResilientStream{
+		ChatCompletionStream: NewChatCompletionStream(reader),
+		reader:               reader,
+	}
+	return
+}
+
+// Partial returns the response assembled from the chunks received so far.
+func (s *ResilientStream) Partial() ChatCompletionResponse {
+	return s.reader.acc.Response()
+}
+
+// Retries returns the number of times the request was sent again.
+func (s *ResilientStream) Retries() int {
+	return s.reader.retries
+}
+
+type resilientStreamReader struct {
+	client  *Client
+	ctx     context.Context
+	request ChatCompletionRequest
+	opts    ResilientStreamOptions
+
+	current    *ChatCompletionStream
+	acc        *ChatCompletionAccumulator
+	retries    int
+	hasContent bool
+}
+
+// open creates the underlying stream, retrying while it fails with err or a
+// retryable error.
+func (r *resilientStreamReader) open(err error) error {
+	for {
+		if err != nil {
+			if r.retries >= r.opts.MaxRetries {
+				return err
+			}
+			timer := time.NewTimer(r.opts.RetryBackoff << r.retries)
+			select {
+			case <-r.ctx.Done():
+				timer.Stop()
+				return r.ctx.Err()
+			case <-timer.C:
+			}
+			r.retries++
+		}
+
+		var stream *ChatCompletionStream
+		stream, err = r.client.CreateChatCompletionStream(r.ctx, r.request)
+		if err == nil {
+			r.current = stream
+			return nil
+		}
+		if !isRetryableError(err) {
+			return err
+		}
+	}
+}
+
+func (r *resilientStreamReader) Recv() (chunk ChatCompletionStreamResponse, err error) {
+	for {
+		chunk, err = r.current.Recv()
+		if err == nil {
+			r.acc.Add(chunk)
+			r.hasContent = r.hasContent || hasStreamContent(chunk)
+			return
+		}
+		if !isStreamInterruption(r.ctx, err) {
+			return
+		}
+		if r.hasContent {
+			return chunk, &StreamInterruptedError{Partial: r.acc.Response(), Err: err, request: r.request}
+		}
+
+		r.current.Close()
+		r.acc = NewChatCompletionAccumulator()
+		if openErr := r.open(err); openErr != nil {
+			return chunk, openErr
+		}
+	}
+}
+
+func (r *resilientStreamReader) Close() error {
+	return r.current.Close()
+}
+
+func (r *resilientStreamReader) Header() http.Header {
+	return r.current.Header()
+}
+
+func (r *resilientStreamReader) Stats() StreamStats {
+	return r.current.Stats()
+}
+
+func (r *resilientStreamReader) Event() StreamEvent {
+	return r.current.Event()
+}
+
+// hasStreamContent reports whether a chunk carries output that would be
+// repeated by retrying the request.
+func hasStreamContent(chunk ChatCompletionStreamResponse) bool {
+	for _, choice := range chunk.Choices {
+		delta := choice.Delta
+		if delta.Content != "" || delta.Refusal != "" || delta.ReasoningContent != "" ||
+			delta.FunctionCall != nil || len(delta.ToolCalls) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// isStreamInterruption reports whether err is a connection failure while
+// reading a stream, as opposed to its end, an error sent by the server or the
+// cancellation of ctx.
+func isStreamInterruption(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, io.EOF) {
+		return false
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,137 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func resilientChunk(content string) string {
+	return fmt.Sprintf(`data: {"id":"1","choices":[{"index":0,"delta":{"content":%q}}]}`+"\n\n", content)
+}
+
+// setupResilientStreamServer serves the given bodies, one per request. Bodies
+// ending with "!" are cut off by aborting the connection.
+func setupResilientStreamServer(t *testing.T, bodies ...string) (*openai.Client, *int) {
+	t.Helper()
+	requests := 0
+	client, server := setupOpenAITestServerWithConfig(t, nil)
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		body := bodies[requests]
+		if requests < len(bodies)-1 {
+			requests++
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, strings.TrimSuffix(body, "!"))
+		w.(http.Flusher).Flush()
+		if strings.HasSuffix(body, "!") {
+			panic(http.ErrAbortHandler)
+		}
+	})
+	return client, &requests
+}
+
+var resilientRequest = openai.ChatCompletionRequest{
+	Model:    openai.GPT4oMini,
+	Messages: []openai.ChatCompletionMessage{openai.UserMessage("Hi")},
+}
+
+func TestResilientStreamRetriesBeforeContent(t *testing.T) {
+	client, _ := setupResilientStreamServer(t,
+		`data: {"id":"1","choices":[{"index":0,"delta":{"role":"assistant"}}]}`+"\n\n!",
+		resilientChunk("Hel")+resilientChunk("lo")+"data: [DONE]\n\n",
+	)
+	stream, err := client.CreateResilientChatCompletionStream(context.Background(), resilientRequest,
+		openai.ResilientStreamOptions{RetryBackoff: time.Millisecond})
+	checks.NoError(t, err, "CreateResilientChatCompletionStream error")
+	defer stream.Close()
+
+	response, err := stream.Accumulate()
+	checks.NoError(t, err, "Accumulate error")
+	if content := response.Choices[0].Message.Content; content != "Hello" {
+		t.Errorf("content = %q, want Hello", content)
+	}
+	if stream.Retries() != 1 {
+		t.Errorf("Retries() = %d, want 1", stream.Retries())
+	}
+	// Stats are those of the retried request.
+	if stats := stream.Stats(); stats.Events != 2 || stats.Duration == 0 {
+		t.Errorf("Stats() = %+v, want the 2 events of the retried request", stats)
+	}
+}
+
+func TestResilientStreamInterruptedAfterContent(t *testing.T) {
+	client, requests := setupResilientStreamServer(t,
+		resilientChunk("Hel")+"!",
+		resilientChunk("lo")+"data: [DONE]\n\n",
+	)
+	stream, err := client.CreateResilientChatCompletionStream(context.Background(), resilientRequest,
+		openai.ResilientStreamOptions{RetryBackoff: time.Millisecond})
+	checks.NoError(t, err, "CreateResilientChatCompletionStream error")
+	defer stream.Close()
+
+	_, err = stream.Accumulate()
+	checks.ErrorIs(t, err, openai.ErrStreamInterrupted, "Accumulate should return an interruption")
+	var interrupted *openai.StreamInterruptedError
+	if !errors.As(err, &interrupted) {
+		t.Fatalf("error = %v, want a StreamInterruptedError", err)
+	}
+	if content := interrupted.Partial.Choices[0].Message.Content; content != "Hel" {
+		t.Errorf("partial content = %q, want Hel", content)
+	}
+	if *requests != 1 {
+		t.Errorf("requests = %d, want no retry", *requests)
+	}
+
+	continuation := interrupted.ContinuationRequest("")
+	if len(continuation.Messages) != 3 || continuation.Messages[1].Content != "Hel" {
+		t.Fatalf("continuation messages = %+v", continuation.Messages)
+	}
+	if len(resilientRequest.Messages) != 1 {
+		t.Errorf("original request was modified: %+v", resilientRequest.Messages)
+	}
+}
+
+func TestResilientStreamRetriesExhausted(t *testing.T) {
+	client, _ := setupResilientStreamServer(t, "!")
+	stream, err := client.CreateResilientChatCompletionStream(context.Background(), resilientRequest,
+		openai.ResilientStreamOptions{MaxRetries: 1, RetryBackoff: time.Millisecond})
+	checks.NoError(t, err, "CreateResilientChatCompletionStream error")
+	defer stream.Close()
+
+	_, err = stream.Recv()
+	checks.HasError(t, err, "Recv should fail once retries are exhausted")
+	if errors.Is(err, openai.ErrStreamInterrupted) {
+		t.Errorf("error without content should not be an interruption: %v", err)
+	}
+	if stream.Retries() != 1 {
+		t.Errorf("Retries() = %d, want 1", stream.Retries())
+	}
+}
+
+func TestResilientStreamServerErrorNotRetried(t *testing.T) {
+	client, requests := setupResilientStreamServer(t,
+		`data: {"error":{"message":"bad request","type":"invalid_request_error"}}`+"\n\n",
+		"data: [DONE]\n\n",
+	)
+	stream, err := client.CreateResilientChatCompletionStream(context.Background(), resilientRequest,
+		openai.ResilientStreamOptions{RetryBackoff: time.Millisecond})
+	checks.NoError(t, err, "CreateResilientChatCompletionStream error")
+	defer stream.Close()
+
+	_, err = stream.Recv()
+	var apiErr *openai.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Recv error = %v, want an APIError", err)
+	}
+	if *requests != 1 {
+		t.Errorf("requests = %d, want no retry", *requests)
+	}
+}
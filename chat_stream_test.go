@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
 	"github.com/sashabaranov/go-openai/internal/test/checks"
 )
 
@@ -1049,3 +1050,29 @@ func compareChatStreamResponseChoices(c1, c2 openai.ChatCompletionStreamChoice)
 	}
 	return true
 }
+
+func TestCreateChatCompletionStreamDisconnect(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, err := io.WriteString(w, `data: {"id":"1","choices":[{"index":0,"delta":{"content":"Hel"}}]}`+"\n\n"+
+			`data: {"id":"1","choices":[{"index":0,"delta":{"content":"lo"}}]}`+"\n\n"+
+			"data: [DONE]\n\n")
+		checks.NoError(t, err, "Write error")
+	})
+	server.InjectFaults("/v1/chat/completions", test.DisconnectAfterEvents(1))
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), openai.ChatCompletionRequest{
+		Model:    openai.GPT4oMini,
+		Messages: []openai.ChatCompletionMessage{openai.UserMessage("Hello!")},
+	})
+	checks.NoError(t, err, "CreateChatCompletionStream returned error")
+	defer stream.Close()
+
+	chunks, err := stream.Collect()
+	if len(chunks) != 1 || chunks[0].Choices[0].Delta.Content != "Hel" {
+		t.Errorf("expected the chunk sent before the disconnect, got %+v", chunks)
+	}
+	checks.HasError(t, err, "a dropped connection should not look like the end of the stream")
+}
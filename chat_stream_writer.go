@@ -0,0 +1,76 @@
+package openai
+
+import (
+	"io"
+	"net/http"
+)
+
+// ChatStreamWriteOptions configures WriteChatStream.
+type ChatStreamWriteOptions struct {
+	// Choice is the index of the choice whose content is written, for
+	// requests with n > 1. All choices are accumulated regardless.
+	Choice int
+	// Flush flushes the writer after each delta when it has a Flush method,
+	// such as a *bufio.Writer or an http.ResponseWriter.
+	Flush bool
+	// FinalNewline terminates the written content with a newline, unless it
+	// is empty or already ends with one.
+	FinalNewline bool
+}
+
+// WriteChatStream reads the remaining chunks of the stream, writing the
+// content deltas to w as they arrive, and returns the accumulated response,
+// as Accumulate would:
+//
+//	stream, err := client.CreateChatCompletionStream(ctx, req)
+//	...
+//	defer stream.Close()
+//	response, err := openai.WriteChatStream(os.Stdout, stream, openai.ChatStreamWriteOptions{FinalNewline: true})
+//
+// If writing fails, the stream is closed and the write error returned. The
+// response assembled so far is returned along with any error.
+func WriteChatStream(
+	w io.Writer,
+	stream *ChatCompletionStream,
+	opts ChatStreamWriteOptions,
+) (ChatCompletionResponse, error) {
+	acc := NewChatCompletionAccumulator()
+	var last byte
+	for stream.Next() {
+		chunk := stream.Current()
+		acc.Add(chunk)
+		for _, choice := range chunk.Choices {
+			content := choice.Delta.Content
+			if choice.Index != opts.Choice || content == "" {
+				continue
+			}
+			if err := writeChatDelta(w, content, opts.Flush); err != nil {
+				stream.Close()
+				return acc.Response(), err
+			}
+			last = content[len(content)-1]
+		}
+	}
+	if opts.FinalNewline && last != 0 && last != '\n' {
+		if err := writeChatDelta(w, "\n", opts.Flush); err != nil {
+			return acc.Response(), err
+		}
+	}
+	return acc.Response(), stream.Err()
+}
+
+func writeChatDelta(w io.Writer, content string, flush bool) error {
+	if _, err := io.WriteString(w, content); err != nil {
+		return err
+	}
+	if !flush {
+		return nil
+	}
+	switch flusher := w.(type) {
+	case interface{ Flush() error }:
+		return flusher.Flush()
+	case http.Flusher:
+		flusher.Flush()
+	}
+	return nil
+}
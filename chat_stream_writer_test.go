@@ -0,0 +1,69 @@
+package openai_test
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+type failingWriter struct {
+	err error
+}
+
+func (w failingWriter) Write([]byte) (int, error) {
+	return 0, w.err
+}
+
+func TestWriteChatStream(t *testing.T) {
+	var out bytes.Buffer
+	stream := openai.NewChatCompletionStream(&mockStreamReader{responses: interleavedChunks()})
+	response, err := openai.WriteChatStream(&out, stream, openai.ChatStreamWriteOptions{FinalNewline: true})
+	checks.NoError(t, err, "WriteChatStream error")
+
+	if out.String() != "ab\n" {
+		t.Errorf("written content = %q, want %q", out.String(), "ab\n")
+	}
+	if len(response.Choices) != 3 || response.Choices[1].Message.Content != "xy" {
+		t.Errorf("response choices = %+v", response.Choices)
+	}
+	if response.Usage.TotalTokens != 5 {
+		t.Errorf("response usage = %+v", response.Usage)
+	}
+}
+
+func TestWriteChatStreamFlushes(t *testing.T) {
+	var out bytes.Buffer
+	writer := bufio.NewWriterSize(&out, 4096)
+	stream := openai.NewChatCompletionStream(&mockStreamReader{responses: interleavedChunks()})
+	_, err := openai.WriteChatStream(writer, stream, openai.ChatStreamWriteOptions{Choice: 1, Flush: true})
+	checks.NoError(t, err, "WriteChatStream error")
+	if out.String() != "xy" {
+		t.Errorf("flushed content = %q, want %q", out.String(), "xy")
+	}
+}
+
+func TestWriteChatStreamErrors(t *testing.T) {
+	errWrite := errors.New("disk full")
+	stream := openai.NewChatCompletionStream(&mockStreamReader{responses: interleavedChunks()})
+	response, err := openai.WriteChatStream(failingWriter{errWrite}, stream, openai.ChatStreamWriteOptions{})
+	checks.ErrorIs(t, err, errWrite, "write error not returned")
+	if response.Choices[0].Message.Content != "a" {
+		t.Errorf("partial response = %+v", response.Choices)
+	}
+
+	errBroken := errors.New("connection reset")
+	var out bytes.Buffer
+	stream = openai.NewChatCompletionStream(&errorAfterStreamReader{
+		mockStreamReader: mockStreamReader{responses: interleavedChunks()[:1]},
+		err:              errBroken,
+	})
+	_, err = openai.WriteChatStream(&out, stream, openai.ChatStreamWriteOptions{FinalNewline: true})
+	checks.ErrorIs(t, err, errBroken, "stream error not returned")
+	if out.String() != "a\n" {
+		t.Errorf("written content = %q, want %q", out.String(), "a\n")
+	}
+}
@@ -478,6 +478,23 @@ func TestChatCompletionsWithStream(t *testing.T) {
 	checks.ErrorIs(t, err, openai.ErrChatCompletionStreamNotSupported, "unexpected error")
 }
 
+func TestChatCompletionsWithFileSearchTool(t *testing.T) {
+	config := openai.DefaultConfig("whatever")
+	config.BaseURL = "http://localhost/v1"
+	client := openai.NewClientWithConfig(config)
+	ctx := context.Background()
+
+	req := openai.ChatCompletionRequest{
+		Model:    openai.GPT4o,
+		Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "Hello!"}},
+		Tools:    []openai.Tool{{Type: openai.ToolTypeFileSearch}},
+	}
+	_, err := client.CreateChatCompletion(ctx, req)
+	checks.ErrorIs(t, err, openai.ErrChatCompletionToolNotSupported, "unexpected error")
+	_, err = client.CreateChatCompletionStream(ctx, req)
+	checks.ErrorIs(t, err, openai.ErrChatCompletionToolNotSupported, "unexpected stream error")
+}
+
 // TestCompletions Tests the completions endpoint of the API using the mocked server.
 func TestChatCompletions(t *testing.T) {
 	client, server, teardown := setupOpenAITestServer()
@@ -1315,3 +1332,71 @@ func TestChatCompletionRequest_UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestFinishReasonUnmarshal(t *testing.T) {
+	tests := []struct {
+		data string
+		want openai.FinishReason
+	}{
+		{`"stop"`, openai.FinishReasonStop},
+		{`"Stop"`, openai.FinishReasonStop},
+		{`"LENGTH"`, openai.FinishReasonLength},
+		{`"toolCalls"`, openai.FinishReasonToolCalls},
+		{`"ContentFilter"`, openai.FinishReasonContentFilter},
+		{`"function_call"`, openai.FinishReasonFunctionCall},
+		{`"null"`, ""},
+		{`null`, ""},
+	}
+	for _, tt := range tests {
+		var r openai.FinishReason
+		checks.NoError(t, json.Unmarshal([]byte(tt.data), &r))
+		if r != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.data, r, tt.want)
+		}
+	}
+
+	var r openai.FinishReason
+	checks.HasError(t, json.Unmarshal([]byte(`1`), &r))
+}
+
+func TestFinishReasonHelpers(t *testing.T) {
+	if openai.FinishReason("").IsFinished() || openai.FinishReasonNull.IsFinished() {
+		t.Error("empty and null reasons should not be finished")
+	}
+	if !openai.FinishReasonStop.IsFinished() {
+		t.Error("stop should be finished")
+	}
+	if !openai.FinishReasonLength.IsTruncated() || openai.FinishReasonStop.IsTruncated() {
+		t.Error("only length should be truncated")
+	}
+	if !openai.FinishReasonToolCalls.IsToolCall() || !openai.FinishReasonFunctionCall.IsToolCall() {
+		t.Error("tool_calls and function_call should be tool calls")
+	}
+	if !openai.FinishReasonContentFilter.IsContentFiltered() {
+		t.Error("content_filter should be content filtered")
+	}
+}
+
+func TestChatCompletionStreamFinishReasonCasing(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, `data: {"id":"1","choices":[{"index":0,"delta":{"content":"Hi"},"finish_reason":null}]}`+"\n\n")
+		fmt.Fprint(w, `data: {"id":"1","choices":[{"index":0,"delta":{},"finish_reason":"ToolCalls"}]}`+"\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	})
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), openai.ChatCompletionRequest{
+		Model:    openai.GPT4o,
+		Messages: []openai.ChatCompletionMessage{openai.UserMessage("Hello")},
+	})
+	checks.NoError(t, err)
+	defer stream.Close()
+	chunks, err := stream.Collect()
+	checks.NoError(t, err)
+	if len(chunks) != 2 || chunks[0].Choices[0].FinishReason.IsFinished() ||
+		chunks[1].Choices[0].FinishReason != openai.FinishReasonToolCalls {
+		t.Errorf("unexpected chunks %+v", chunks)
+	}
+}
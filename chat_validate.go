@@ -0,0 +1,161 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/sashabaranov/go-openai/jsonschema"
+)
+
+const defaultCorrectionPrompt = "Your previous answer is invalid: %v. Answer again, fixing this."
+
+var (
+	ErrResponseValidation = errors.New("response failed validation")
+	ErrResponseNoChoices  = errors.New("the response has no choices")
+	ErrOutputFlagged      = errors.New("output flagged by moderation")
+)
+
+// ResponseValidator checks a chat completion response. The error it returns
+// is shown to the model in the corrective message of the next attempt, so it
+// should say what is wrong.
+type ResponseValidator func(ctx context.Context, response ChatCompletionResponse) error
+
+// ValidateContent returns a ResponseValidator checking the content of the
+// first choice with check.
+func ValidateContent(check func(content string) error) ResponseValidator {
+	return func(_ context.Context, response ChatCompletionResponse) error {
+		if len(response.Choices) == 0 {
+			return ErrResponseNoChoices
+		}
+		return check(response.Choices[0].Message.Content)
+	}
+}
+
+// ValidateJSONSchema returns a ResponseValidator checking that the content
+// of the first choice is JSON matching schema, for models or providers that
+// do not enforce structured outputs.
+func ValidateJSONSchema(schema jsonschema.Definition) ResponseValidator {
+	return ValidateContent(func(content string) error {
+		var data any
+		if err := json.Unmarshal([]byte(content), &data); err != nil {
+			return fmt.Errorf("the answer is not valid JSON: %w", err)
+		}
+		if !jsonschema.Validate(schema, data) {
+			return errors.New("the answer does not match the JSON schema")
+		}
+		return nil
+	})
+}
+
+// ModerationValidator returns a ResponseValidator failing with an
+// *OutputFlaggedError when the moderation model flags the content of a
+// choice. Errors of the moderation request fail the attempt as well.
+func (c *Client) ModerationValidator(model string) ResponseValidator {
+	return func(ctx context.Context, response ChatCompletionResponse) error {
+		if len(response.Choices) == 0 {
+			return ErrResponseNoChoices
+		}
+		return c.ModerateOutput(ctx, response, model)
+	}
+}
+
+// ValidationOptions configures CreateChatCompletionWithValidation.
+type ValidationOptions struct {
+	// Validators are run in order on each response; the first failing one
+	// fails the attempt.
+	Validators []ResponseValidator
+	// MaxRetries is the number of follow-up requests sent after failed
+	// attempts.
+	MaxRetries int
+	// CorrectionPrompt is the format of the user message sent after a failed
+	// attempt, given the validation error. It defaults to asking the model
+	// to fix the error.
+	CorrectionPrompt string
+}
+
+// ValidationAttempt is a response obtained by
+// CreateChatCompletionWithValidation and the error of its validation.
+type ValidationAttempt struct {
+	Response ChatCompletionResponse
+	Err      error
+}
+
+// ResponseValidationError is returned when every attempt failed
+// validation. It wraps ErrResponseValidation and the error of the last
+// attempt.
+type ResponseValidationError struct {
+	Attempts []ValidationAttempt
+}
+
+func (e *ResponseValidationError) Error() string {
+	return fmt.Sprintf("%s after %d attempts: %v", ErrResponseValidation, len(e.Attempts), e.last())
+}
+
+func (e *ResponseValidationError) Unwrap() error {
+	return e.last()
+}
+
+func (e *ResponseValidationError) Is(target error) bool {
+	return target == ErrResponseValidation
+}
+
+func (e *ResponseValidationError) last() error {
+	if len(e.Attempts) == 0 {
+		return nil
+	}
+	return e.Attempts[len(e.Attempts)-1].Err
+}
+
+// CreateChatCompletionWithValidation creates a chat completion and checks
+// it with opts.Validators. While validation fails, it appends the answer and
+// a corrective message giving the error to the conversation and asks again,
+// up to opts.MaxRetries times. It returns the last response along with the
+// history of attempts; when none passed, the error is a
+// *ResponseValidationError.
+func (c *Client) CreateChatCompletionWithValidation(
+	ctx context.Context,
+	request ChatCompletionRequest,
+	opts ValidationOptions,
+) (response ChatCompletionResponse, attempts []ValidationAttempt, err error) {
+	prompt := opts.CorrectionPrompt
+	if prompt == "" {
+		prompt = defaultCorrectionPrompt
+	}
+
+	// Copy the messages rather than appending to the caller's slice.
+	messages := append([]ChatCompletionMessage{}, request.Messages...)
+	for attempt := 0; ; attempt++ {
+		request.Messages = messages
+		response, err = c.CreateChatCompletion(ctx, request)
+		if err != nil {
+			return
+		}
+
+		validationErr := validateResponse(ctx, response, opts.Validators)
+		attempts = append(attempts, ValidationAttempt{Response: response, Err: validationErr})
+		if validationErr == nil {
+			return
+		}
+		if attempt >= opts.MaxRetries {
+			err = &ResponseValidationError{Attempts: attempts}
+			return
+		}
+
+		var content string
+		if len(response.Choices) > 0 {
+			content = response.Choices[0].Message.Content
+		}
+		messages = append(messages, AssistantMessage(content), UserMessage(fmt.Sprintf(prompt, validationErr)))
+	}
+}
+
+func validateResponse(ctx context.Context, response ChatCompletionResponse, validators []ResponseValidator) error {
+	for _, validator := range validators {
+		if err := validator(ctx, response); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+	"github.com/sashabaranov/go-openai/jsonschema"
+)
+
+func TestCreateChatCompletionWithValidation(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	answers := []string{`{"city": 42}`, `not json`, `{"city": "Paris"}`}
+	var requests []openai.ChatCompletionRequest
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		var req openai.ChatCompletionRequest
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		requests = append(requests, req)
+		fmt.Fprintf(w, `{"id":"chatcmpl-%d","choices":[{"index":0,"message":{"role":"assistant","content":%q}}]}`,
+			len(requests), answers[len(requests)-1])
+	})
+
+	schema := jsonschema.Definition{
+		Type:       jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{"city": {Type: jsonschema.String}},
+		Required:   []string{"city"},
+	}
+	messages := []openai.ChatCompletionMessage{openai.UserMessage("Where is the Louvre? Answer in JSON.")}
+	response, attempts, err := client.CreateChatCompletionWithValidation(context.Background(),
+		openai.ChatCompletionRequest{Model: openai.GPT4o, Messages: messages},
+		openai.ValidationOptions{Validators: []openai.ResponseValidator{openai.ValidateJSONSchema(schema)}, MaxRetries: 2})
+	checks.NoError(t, err, "CreateChatCompletionWithValidation error")
+
+	if got := response.Choices[0].Message.Content; got != `{"city": "Paris"}` {
+		t.Errorf("content = %q", got)
+	}
+	if len(attempts) != 3 || attempts[0].Err == nil || attempts[1].Err == nil || attempts[2].Err != nil {
+		t.Fatalf("attempts = %+v", attempts)
+	}
+	if len(requests[2].Messages) != 5 {
+		t.Fatalf("third request has %d messages, want 5", len(requests[2].Messages))
+	}
+	correction := requests[1].Messages[2]
+	if requests[1].Messages[1].Content != `{"city": 42}` || correction.Role != openai.ChatMessageRoleUser ||
+		!strings.Contains(correction.Content, "does not match the JSON schema") {
+		t.Errorf("corrective messages = %v", requests[1].Messages[1:])
+	}
+	if len(messages) != 1 {
+		t.Errorf("caller messages modified: %v", messages)
+	}
+}
+
+func TestCreateChatCompletionWithValidationExhausted(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	requests := 0
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"id":"chatcmpl-1","choices":[{"index":0,"message":{"role":"assistant","content":"maybe"}}]}`)
+	})
+
+	errNotYesNo := errors.New("answer yes or no")
+	validator := openai.ValidateContent(func(content string) error {
+		if content != "yes" && content != "no" {
+			return errNotYesNo
+		}
+		return nil
+	})
+	_, attempts, err := client.CreateChatCompletionWithValidation(context.Background(), openai.ChatCompletionRequest{
+		Model:    openai.GPT4o,
+		Messages: []openai.ChatCompletionMessage{openai.UserMessage("Is it raining?")},
+	}, openai.ValidationOptions{Validators: []openai.ResponseValidator{validator}, MaxRetries: 1})
+
+	if !errors.Is(err, openai.ErrResponseValidation) || !errors.Is(err, errNotYesNo) {
+		t.Fatalf("expected ErrResponseValidation wrapping errNotYesNo, got %v", err)
+	}
+	var validationErr *openai.ResponseValidationError
+	if !errors.As(err, &validationErr) || len(validationErr.Attempts) != 2 || len(attempts) != 2 || requests != 2 {
+		t.Errorf("attempts = %d, requests = %d", len(attempts), requests)
+	}
+}
+
+func TestModerationValidator(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/moderations", handleFlagWordModeration(t, "attack"))
+
+	validator := client.ModerationValidator(openai.ModerationOmniLatest)
+	response := func(content string) openai.ChatCompletionResponse {
+		return openai.ChatCompletionResponse{Choices: []openai.ChatCompletionChoice{
+			{Message: openai.AssistantMessage(content)},
+		}}
+	}
+	checks.NoError(t, validator(context.Background(), response("Hello!")), "unflagged output")
+	if err := validator(context.Background(), response("How to attack")); !errors.Is(err, openai.ErrOutputFlagged) {
+		t.Errorf("expected ErrOutputFlagged, got %v", err)
+	}
+	err := validator(context.Background(), openai.ChatCompletionResponse{})
+	if !errors.Is(err, openai.ErrResponseNoChoices) {
+		t.Errorf("expected ErrResponseNoChoices, got %v", err)
+	}
+}
+
+// handleFlagWordModeration serves moderation requests with string array
+// inputs, flagging the inputs containing word.
+func handleFlagWordModeration(t *testing.T, word string) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req openai.ModerationStrArrayRequest
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		results := make([]openai.Result, len(req.Input))
+		for i, input := range req.Input {
+			results[i].Flagged = strings.Contains(input, word)
+		}
+		checks.NoError(t, json.NewEncoder(w).Encode(openai.ModerationResponse{ID: "modr-1", Results: results}))
+	}
+}
@@ -2,13 +2,18 @@ package openai
 
 import (
 	"bufio"
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	utils "github.com/sashabaranov/go-openai/internal"
 )
@@ -17,10 +22,30 @@ import (
 type Client struct {
 	config ClientConfig
 
+	fingerprints *fingerprintTracker
+	flights      *flightGroup
+	latencies    *LatencyTracker
+	lastRequest  *requestRecorder
+	models       *modelCache
+	shadows      *shadowMirror
+	authToken    atomic.Value
+
 	requestBuilder    utils.RequestBuilder
 	createFormBuilder func(io.Writer) utils.FormBuilder
 }
 
+// CompletionClient is the subset of the Client API that generates text and
+// embeddings. Code depending on it rather than on *Client can be exercised
+// with test doubles such as openaitest.DeterministicClient.
+type CompletionClient interface {
+	CreateChatCompletion(ctx context.Context, request ChatCompletionRequest) (ChatCompletionResponse, error)
+	CreateChatCompletionStream(ctx context.Context, request ChatCompletionRequest) (*ChatCompletionStream, error)
+	CreateCompletion(ctx context.Context, request CompletionRequest) (CompletionResponse, error)
+	CreateEmbeddings(ctx context.Context, conv EmbeddingRequestConverter) (EmbeddingResponse, error)
+}
+
+var _ CompletionClient = (*Client)(nil)
+
 type Response interface {
 	SetHeader(http.Header)
 }
@@ -31,6 +56,8 @@ func (h *httpHeader) SetHeader(header http.Header) {
 	*h = httpHeader(header)
 }
 
+// Header returns the headers of the response as received, without the
+// ClientConfig.HeaderScrubber applied.
 func (h *httpHeader) Header() http.Header {
 	return http.Header(*h)
 }
@@ -53,13 +80,45 @@ func NewClient(authToken string) *Client {
 
 // NewClientWithConfig creates new OpenAI API client for specified config.
 func NewClientWithConfig(config ClientConfig) *Client {
-	return &Client{
+	if config.ProxyURL != nil {
+		transport := TransportConfig{}
+		if config.Transport != nil {
+			transport = *config.Transport
+		}
+		if transport.ProxyURL == nil {
+			transport.ProxyURL = config.ProxyURL
+		}
+		config.Transport = &transport
+	}
+	if config.Transport != nil {
+		config.HTTPClient = config.Transport.httpClient(config.HTTPClient)
+	}
+	if config.Scheduler != nil {
+		config.HTTPClient = config.Scheduler.httpClient(config.HTTPClient)
+	}
+	client := &Client{
 		config:         config,
-		requestBuilder: utils.NewRequestBuilder(),
+		fingerprints:   newFingerprintTracker(config.OnSystemFingerprintChange),
+		shadows:        newShadowMirror(config.Shadow),
+		requestBuilder: utils.NewRequestBuilderWithMarshaller(config.JSONCodec),
 		createFormBuilder: func(body io.Writer) utils.FormBuilder {
 			return utils.NewFormBuilder(body)
 		},
 	}
+	client.authToken.Store(config.authToken)
+	if config.CoalesceRequests {
+		client.flights = newFlightGroup()
+	}
+	if config.CaptureLastRequest {
+		client.lastRequest = &requestRecorder{}
+	}
+	if config.ModelCacheTTL > 0 {
+		client.models = newModelCache(config.ModelCacheTTL)
+	}
+	if client.latencies = config.LatencyTracker; client.latencies == nil {
+		client.latencies = NewLatencyTracker(0)
+	}
+	return client
 }
 
 // NewOrgClient creates new OpenAI API client for specified Organization ID.
@@ -115,12 +174,6 @@ func withContentType(contentType string) requestOption {
 	}
 }
 
-func withBetaAssistantVersion(version string) requestOption {
-	return func(args *requestOptions) {
-		args.header.Set("OpenAI-Beta", fmt.Sprintf("assistants=%s", version))
-	}
-}
-
 func (c *Client) newRequest(ctx context.Context, method, url string, setters ...requestOption) (*http.Request, error) {
 	// Default Options
 	args := &requestOptions{
@@ -130,14 +183,115 @@ func (c *Client) newRequest(ctx context.Context, method, url string, setters ...
 	for _, setter := range setters {
 		setter(args)
 	}
+	ctx = c.withRequestInfo(ctx, url, args.body)
+	creds, err := c.credentials(ctx)
+	if err != nil {
+		return nil, err
+	}
 	req, err := c.requestBuilder.Build(ctx, method, url, args.body, args.header)
 	if err != nil {
 		return nil, err
 	}
-	c.setCommonHeaders(req)
+	c.setCommonHeaders(req, creds)
+	c.setBetaHeader(req)
+	setContextHeaders(req)
+	if err = c.compressRequestBody(req); err != nil {
+		return nil, err
+	}
 	return req, nil
 }
 
+// do sends the request, asking for a gzip-encoded response. Setting
+// Accept-Encoding explicitly turns off the transparent decompression of
+// http.Transport, so responses are decompressed here, which also covers custom
+// transports that disable compression.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+	if c.lastRequest != nil {
+		c.lastRequest.record(req, c.scrubHeader(req.Header))
+	}
+	start := time.Now()
+	resp, err := c.config.HTTPClient.Do(req)
+	if err != nil {
+		if c.config.Metrics != nil {
+			c.recordRequestError(req, start, err)
+		}
+		return nil, err
+	}
+	if c.config.Metrics != nil {
+		c.instrumentResponse(req, resp, start)
+	}
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return resp, nil
+	}
+	// The gzip reader is created on the first read, as bodies of responses
+	// without content, such as 204 responses, are empty rather than gzip.
+	resp.Body = &gzipReadCloser{body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return resp, nil
+}
+
+type gzipReadCloser struct {
+	reader *gzip.Reader
+	body   io.ReadCloser
+}
+
+func (r *gzipReadCloser) Read(p []byte) (int, error) {
+	if r.reader == nil {
+		reader, err := gzip.NewReader(r.body)
+		if errors.Is(err, io.EOF) {
+			return 0, io.EOF
+		}
+		if err != nil {
+			return 0, fmt.Errorf("error, decompressing response body: %w", err)
+		}
+		r.reader = reader
+	}
+	return r.reader.Read(p)
+}
+
+func (r *gzipReadCloser) Close() error {
+	if r.reader != nil {
+		r.reader.Close()
+	}
+	return r.body.Close()
+}
+
+// compressRequestBody gzips request bodies larger than
+// ClientConfig.RequestCompressionThreshold sent to the endpoints accepting
+// them.
+func (c *Client) compressRequestBody(req *http.Request) error {
+	threshold := c.config.RequestCompressionThreshold
+	if threshold <= 0 || req.Body == nil || req.ContentLength <= int64(threshold) ||
+		req.Header.Get("Content-Encoding") != "" || !c.acceptsCompressedRequests(req.URL) {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := io.Copy(zw, req.Body); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	req.Body.Close()
+
+	data := buf.Bytes()
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	req.ContentLength = int64(len(data))
+	req.Header.Set("Content-Encoding", "gzip")
+	return nil
+}
+
 func (c *Client) sendRequest(req *http.Request, v Response) error {
 	req.Header.Set("Accept", "application/json")
 
@@ -148,7 +302,7 @@ func (c *Client) sendRequest(req *http.Request, v Response) error {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	res, err := c.config.HTTPClient.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
@@ -163,16 +317,21 @@ func (c *Client) sendRequest(req *http.Request, v Response) error {
 		return c.handleErrorResp(res)
 	}
 
-	return decodeResponse(res.Body, v)
+	body := io.Reader(res.Body)
+	if limit := c.config.MaxResponseBodySize; limit > 0 {
+		body = &maxBytesReader{r: body, remaining: limit, limit: limit}
+	}
+	return decodeResponse(c.jsonCodec(), body, v)
 }
 
 func (c *Client) sendRequestRaw(req *http.Request) (response RawResponse, err error) {
-	resp, err := c.config.HTTPClient.Do(req) //nolint:bodyclose // body should be closed by outer function
+	resp, err := c.do(req) //nolint:bodyclose // body should be closed by outer function
 	if err != nil {
 		return
 	}
 
 	if isFailureStatusCode(resp) {
+		defer resp.Body.Close()
 		err = c.handleErrorResp(resp)
 		return
 	}
@@ -188,42 +347,59 @@ func sendRequestStream[T streamable](client *Client, req *http.Request) (*stream
 	req.Header.Set("Cache-Control", "no-cache")
 	req.Header.Set("Connection", "keep-alive")
 
-	resp, err := client.config.HTTPClient.Do(req) //nolint:bodyclose // body is closed in stream.Close()
+	start := time.Now()
+	resp, err := client.do(req) //nolint:bodyclose // body is closed in stream.Close()
 	if err != nil {
 		return new(streamReader[T]), err
 	}
 	if isFailureStatusCode(resp) {
+		defer resp.Body.Close()
 		return new(streamReader[T]), client.handleErrorResp(resp)
 	}
-	return &streamReader[T]{
+	format := client.config.StreamFormat
+	if format == StreamFormatAuto {
+		format = streamFormatOf(resp.Header.Get("Content-Type"))
+	}
+	stream := &streamReader[T]{
 		emptyMessagesLimit: client.config.EmptyMessagesLimit,
+		format:             format,
 		reader:             bufio.NewReader(resp.Body),
 		response:           resp,
 		errAccumulator:     utils.NewErrorAccumulator(),
-		unmarshaler:        &utils.JSONUnmarshaler{},
+		unmarshaler:        client.jsonCodec(),
 		httpHeader:         httpHeader(resp.Header),
-	}, nil
+		streamTimer:        streamTimer{start: start},
+	}
+	if info, ok := requestInfoFrom(req.Context()); ok {
+		stream.onFirstEvent = func() {
+			client.config.Metrics.RecordTimeToFirstToken(info.endpoint, info.model, time.Since(start))
+		}
+	}
+	return stream, nil
 }
 
-func (c *Client) setCommonHeaders(req *http.Request) {
+func (c *Client) setCommonHeaders(req *http.Request, creds requestCredentials) {
 	// https://learn.microsoft.com/en-us/azure/cognitive-services/openai/reference#authentication
 	switch c.config.APIType {
 	case APITypeAzure, APITypeCloudflareAzure:
 		// Azure API Key authentication
-		req.Header.Set(AzureAPIKeyHeader, c.config.authToken)
+		req.Header.Set(AzureAPIKeyHeader, creds.apiKey)
 	case APITypeAnthropic:
 		// https://docs.anthropic.com/en/api/versioning
 		req.Header.Set("anthropic-version", c.config.APIVersion)
 	case APITypeOpenAI, APITypeAzureAD:
 		fallthrough
 	default:
-		if c.config.authToken != "" {
-			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.config.authToken))
+		if creds.apiKey != "" {
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", creds.apiKey))
 		}
 	}
 
-	if c.config.OrgID != "" {
-		req.Header.Set("OpenAI-Organization", c.config.OrgID)
+	if creds.orgID != "" {
+		req.Header.Set("OpenAI-Organization", creds.orgID)
+	}
+	if creds.project != "" {
+		req.Header.Set("OpenAI-Project", creds.project)
 	}
 }
 
@@ -231,7 +407,29 @@ func isFailureStatusCode(resp *http.Response) bool {
 	return resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest
 }
 
-func decodeResponse(body io.Reader, v any) error {
+// maxBytesReader fails with a *ResponseTooLargeError once more than limit
+// bytes have been read.
+type maxBytesReader struct {
+	r         io.Reader
+	remaining int64
+	limit     int64
+}
+
+func (m *maxBytesReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > m.remaining+1 {
+		p = p[:m.remaining+1]
+	}
+	n, err := m.r.Read(p)
+	if int64(n) <= m.remaining {
+		m.remaining -= int64(n)
+		return n, err
+	}
+	n = int(m.remaining)
+	m.remaining = 0
+	return n, &ResponseTooLargeError{Limit: m.limit}
+}
+
+func decodeResponse(codec JSONCodec, body io.Reader, v any) error {
 	if v == nil {
 		return nil
 	}
@@ -242,7 +440,7 @@ func decodeResponse(body io.Reader, v any) error {
 	case *audioTextResponse:
 		return decodeString(body, &o.Text)
 	default:
-		return json.NewDecoder(body).Decode(v)
+		return codec.NewDecoder(body).Decode(v)
 	}
 }
 
@@ -295,6 +493,38 @@ func (c *Client) fullURL(suffix string, setters ...fullURLOption) string {
 	return fmt.Sprintf("%s%s", baseURL, suffix)
 }
 
+// endpointPath returns the path of the endpoint a request URL targets, such
+// as "/chat/completions", without the path of BaseURL and, for Azure, the
+// deployment prefix.
+func (c *Client) endpointPath(u *url.URL) string {
+	path := u.Path
+	if base, err := url.Parse(c.config.BaseURL); err == nil {
+		path = strings.TrimPrefix(path, strings.TrimRight(base.Path, "/"))
+	}
+	if c.config.APIType == APITypeAzure || c.config.APIType == APITypeAzureAD {
+		path = strings.TrimPrefix(path, "/"+azureAPIPrefix)
+		if deployment := strings.TrimPrefix(path, "/"+azureDeploymentsPrefix+"/"); deployment != path {
+			path = ""
+			if i := strings.Index(deployment, "/"); i >= 0 {
+				path = deployment[i:]
+			}
+		}
+	}
+	return path
+}
+
+// acceptsCompressedRequests reports whether the endpoint of u is one of the
+// RequestCompressionEndpoints.
+func (c *Client) acceptsCompressedRequests(u *url.URL) bool {
+	path := c.endpointPath(u)
+	for _, endpoint := range c.config.RequestCompressionEndpoints {
+		if path == endpoint || strings.HasPrefix(path, endpoint+"/") {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Client) suffixWithAPIVersion(suffix string) string {
 	parsedSuffix, err := url.Parse(suffix)
 	if err != nil {
@@ -318,7 +548,13 @@ func (c *Client) baseURLWithAzureDeployment(baseURL, suffix, model string) (newB
 }
 
 func (c *Client) handleErrorResp(resp *http.Response) error {
-	body, err := io.ReadAll(resp.Body)
+	// Error bodies are truncated rather than rejected, so the status code of an
+	// oversized error page is still reported.
+	reader := io.Reader(resp.Body)
+	if limit := c.config.MaxResponseBodySize; limit > 0 {
+		reader = io.LimitReader(reader, limit)
+	}
+	body, err := io.ReadAll(reader)
 	if err != nil {
 		return fmt.Errorf("error, reading response body: %w", err)
 	}
@@ -330,15 +566,21 @@ func (c *Client) handleErrorResp(resp *http.Response) error {
 			HTTPStatusCode: resp.StatusCode,
 			Err:            err,
 			Body:           body,
+			Header:         c.scrubHeader(resp.Header),
+			kind:           c.mapError(resp.StatusCode, nil, ""),
 		}
 		if errRes.Error != nil {
 			reqErr.Err = errRes.Error
+		} else if err != nil && !json.Valid(body) {
+			reqErr.Err = newNonJSONErrorResponse(resp.Status, resp.StatusCode, resp.Header.Get("Content-Type"), body)
 		}
 		return reqErr
 	}
 
 	errRes.Error.HTTPStatus = resp.Status
 	errRes.Error.HTTPStatusCode = resp.StatusCode
+	errRes.Error.Header = c.scrubHeader(resp.Header)
+	errRes.Error.kind = c.mapError(resp.StatusCode, errRes.Error.Code, errRes.Error.Type)
 	return errRes.Error
 }
 
@@ -2,12 +2,16 @@ package openai //nolint:testpackage // testing private field
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/sashabaranov/go-openai/internal/test"
@@ -22,6 +26,22 @@ func (*failingRequestBuilder) Build(_ context.Context, _, _ string, _ any, _ htt
 	return nil, errTestRequestBuilderFailed
 }
 
+// setupOpenAITestServerWithConfig is the twin, for the internal tests, of the
+// helper of the same name in openai_test.go.
+func setupOpenAITestServerWithConfig(t *testing.T, configure func(*ClientConfig)) (*Client, *test.ServerTest) {
+	t.Helper()
+	server := test.NewTestServer()
+	ts := server.OpenAITestServer()
+	ts.Start()
+	t.Cleanup(ts.Close)
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	if configure != nil {
+		configure(&config)
+	}
+	return NewClientWithConfig(config), server
+}
+
 func TestClient(t *testing.T) {
 	const mockToken = "mock token"
 	client := NewClient(mockToken)
@@ -76,7 +96,7 @@ func TestSetCommonHeadersAnthropic(t *testing.T) {
 		t.Fatalf("Failed to create request: %v", err)
 	}
 
-	client.setCommonHeaders(req)
+	client.setCommonHeaders(req, requestCredentials{apiKey: "mock-token"})
 
 	if got := req.Header.Get("anthropic-version"); got != AnthropicAPIVersion {
 		t.Errorf("Expected anthropic-version header to be %q, got %q", AnthropicAPIVersion, got)
@@ -142,7 +162,7 @@ func TestDecodeResponse(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			err := decodeResponse(tc.body, tc.value)
+			err := decodeResponse(StandardJSONCodec, tc.body, tc.value)
 			if tc.hasError {
 				checks.HasError(t, err, "Unexpected nil error")
 				return
@@ -255,14 +275,17 @@ func TestHandleErrorResp(t *testing.T) {
 	<hr><center>nginx</center>
 	</body>
 	</html>`)),
-			expected: `error, status code: 413, status: , message: invalid character '<' looking for beginning of value, body: 
-	<html>
-	<head><title>413 Request Entity Too Large</title></head>
-	<body>
-	<center><h1>413 Request Entity Too Large</h1></center>
-	<hr><center>nginx</center>
-	</body>
-	</html>`,
+			expected: "error, status code: 413, status: , message: unexpected non-JSON error response (text/html): " +
+				"<html> <head><title>413 Request Entity Too Large</title></head> <body> " +
+				"<center><h1>413 Request Entity Too Large</h1></center> <hr><center>nginx</center> </body> </html>",
+		},
+		{
+			name:        "502 plain text",
+			httpCode:    http.StatusBadGateway,
+			contentType: "text/plain",
+			body:        strings.NewReader(strings.Repeat("upstream connect error ", 20)),
+			expected: "error, status code: 502, status: , message: unexpected non-JSON error response (text/plain): " +
+				strings.TrimSpace(strings.Repeat("upstream connect error ", 11)) + " ups...",
 		},
 		{
 			name:        "errorReader",
@@ -292,6 +315,29 @@ func TestHandleErrorResp(t *testing.T) {
 	}
 }
 
+func TestHandleErrorRespNonJSON(t *testing.T) {
+	client := NewClient("mock token")
+	err := client.handleErrorResp(&http.Response{
+		Status:     "503 Service Unavailable",
+		StatusCode: http.StatusServiceUnavailable,
+		Header:     http.Header{"Content-Type": {"text/html"}},
+		Body:       io.NopCloser(strings.NewReader("<h1>Service Unavailable</h1>")),
+	})
+
+	var nonJSON *NonJSONErrorResponse
+	if !errors.As(err, &nonJSON) {
+		t.Fatalf("expected NonJSONErrorResponse, got %T", err)
+	}
+	if nonJSON.HTTPStatusCode != http.StatusServiceUnavailable || nonJSON.ContentType != "text/html" ||
+		nonJSON.Snippet != "<h1>Service Unavailable</h1>" {
+		t.Errorf("unexpected error details: %+v", nonJSON)
+	}
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) || string(reqErr.Body) != "<h1>Service Unavailable</h1>" {
+		t.Errorf("expected RequestError with the full body, got %v", err)
+	}
+}
+
 func TestClientReturnsRequestBuilderErrors(t *testing.T) {
 	config := DefaultConfig(test.GetTestToken())
 	client := NewClientWithConfig(config)
@@ -615,3 +661,168 @@ func TestClient_baseURLWithAzureDeployment(t *testing.T) {
 		})
 	}
 }
+
+func TestClientDecompressesGzipResponses(t *testing.T) {
+	client, server := setupOpenAITestServerWithConfig(t, func(config *ClientConfig) {
+		config.HTTPClient = &http.Client{Transport: &http.Transport{DisableCompression: true}}
+	})
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			t.Errorf("expected Accept-Encoding gzip, got %q", r.Header.Get("Accept-Encoding"))
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		fmt.Fprint(zw, `{"object":"list","data":[{"id":"gpt-4o"}]}`)
+		checks.NoError(t, zw.Close())
+	})
+
+	models, err := client.ListModels(context.Background())
+	checks.NoError(t, err)
+	if len(models.Models) != 1 || models.Models[0].ID != "gpt-4o" {
+		t.Errorf("unexpected models: %+v", models.Models)
+	}
+}
+
+func TestClientCompressesLargeRequestBodies(t *testing.T) {
+	var encodings []string
+	client, server := setupOpenAITestServerWithConfig(t, func(config *ClientConfig) {
+		config.RequestCompressionThreshold = 512
+		config.RequestCompressionEndpoints = []string{"/embeddings"}
+	})
+	server.RegisterHandler("/v1/embeddings", func(w http.ResponseWriter, r *http.Request) {
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+		body := io.Reader(r.Body)
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			zr, err := gzip.NewReader(r.Body)
+			checks.NoError(t, err)
+			body = zr
+		}
+		var req EmbeddingRequest
+		checks.NoError(t, json.NewDecoder(body).Decode(&req))
+		fmt.Fprint(w, `{"object":"list","data":[{"embedding":[1]}]}`)
+	})
+
+	for _, input := range []string{"short", strings.Repeat("long input ", 100)} {
+		_, err := client.CreateEmbeddings(context.Background(), EmbeddingRequest{
+			Input: []string{input},
+			Model: SmallEmbedding3,
+		})
+		checks.NoError(t, err)
+	}
+	if !reflect.DeepEqual(encodings, []string{"", "gzip"}) {
+		t.Errorf("expected only the large body to be compressed, got %q", encodings)
+	}
+
+	encodings = nil
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+		fmt.Fprint(w, `{"id":"chatcmpl-1","choices":[]}`)
+	})
+	_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{
+		Model:    GPT4oMini,
+		Messages: []ChatCompletionMessage{UserMessage(strings.Repeat("long input ", 100))},
+	})
+	checks.NoError(t, err)
+	if !reflect.DeepEqual(encodings, []string{""}) {
+		t.Errorf("expected chat completion bodies not to be compressed, got %q", encodings)
+	}
+
+	// Without endpoints, nothing is compressed.
+	encodings = nil
+	client.config.RequestCompressionEndpoints = nil
+	_, err = client.CreateEmbeddings(context.Background(), EmbeddingRequest{
+		Input: []string{strings.Repeat("long input ", 100)},
+		Model: SmallEmbedding3,
+	})
+	checks.NoError(t, err)
+	if !reflect.DeepEqual(encodings, []string{""}) {
+		t.Errorf("expected no compression without endpoints, got %q", encodings)
+	}
+}
+
+func TestClientEndpointPath(t *testing.T) {
+	testCases := []struct {
+		config ClientConfig
+		url    string
+		want   string
+	}{
+		{DefaultConfig(""), "https://api.openai.com/v1/embeddings", "/embeddings"},
+		{DefaultConfig(""), "https://api.openai.com/v1/uploads/upload_1/parts", "/uploads/upload_1/parts"},
+		{
+			ClientConfig{BaseURL: "https://proxy.internal/assistants/v1"},
+			"https://proxy.internal/assistants/v1/chat/completions",
+			"/chat/completions",
+		},
+		{
+			DefaultAzureConfig("", "https://example.openai.azure.com/"),
+			"https://example.openai.azure.com/openai/deployments/assistants/chat/completions",
+			"/chat/completions",
+		},
+		{
+			DefaultAzureConfig("", "https://example.openai.azure.com/"),
+			"https://example.openai.azure.com/openai/assistants/asst_1",
+			"/assistants/asst_1",
+		},
+	}
+	for _, tc := range testCases {
+		client := NewClientWithConfig(tc.config)
+		u, err := url.Parse(tc.url)
+		checks.NoError(t, err)
+		if got := client.endpointPath(u); got != tc.want {
+			t.Errorf("endpointPath(%s) = %q, want %q", tc.url, got, tc.want)
+		}
+	}
+}
+
+func TestClientEmptyGzipResponse(t *testing.T) {
+	client, server := setupOpenAITestServerWithConfig(t, func(config *ClientConfig) {
+		config.HTTPClient = &http.Client{Transport: &http.Transport{DisableCompression: true}}
+	})
+	server.RegisterHandler("/v1/files/file-1", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req, err := client.newRequest(context.Background(), http.MethodDelete, client.fullURL("/files/file-1"))
+	checks.NoError(t, err)
+	checks.NoError(t, client.sendRequest(req, nil), "empty gzip-encoded body")
+}
+
+func TestClientMaxResponseBodySize(t *testing.T) {
+	client, server := setupOpenAITestServerWithConfig(t, func(config *ClientConfig) {
+		config.MaxResponseBodySize = 100
+	})
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `{"object":"list","data":[{"id":"%s"}]}`, strings.Repeat("x", 1000))
+	})
+	server.RegisterHandler("/v1/models/broken", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, "<html>"+strings.Repeat("x", 10000)+"</html>")
+	})
+
+	_, err := client.ListModels(context.Background())
+	checks.ErrorIs(t, err, ErrResponseTooLarge)
+	var tooLarge *ResponseTooLargeError
+	if !errors.As(err, &tooLarge) || tooLarge.Limit != 100 {
+		t.Errorf("expected ResponseTooLargeError with limit 100, got %v", err)
+	}
+
+	_, err = client.GetModel(context.Background(), "broken")
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) || reqErr.HTTPStatusCode != http.StatusBadGateway || len(reqErr.Body) != 100 {
+		t.Errorf("expected truncated 502 RequestError, got %v", err)
+	}
+
+	client.config.MaxResponseBodySize = 2000
+	_, err = client.ListModels(context.Background())
+	checks.NoError(t, err)
+}
+
+func TestMaxBytesReaderExactLimit(t *testing.T) {
+	r := &maxBytesReader{r: strings.NewReader("hello"), remaining: 5, limit: 5}
+	data, err := io.ReadAll(r)
+	checks.NoError(t, err)
+	if string(data) != "hello" {
+		t.Errorf("expected body at the limit to be read, got %q", data)
+	}
+}
@@ -0,0 +1,133 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// flightGroup coalesces concurrent identical requests: the first one is sent
+// upstream and the others wait for its response.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done chan struct{}
+	// dups is the number of requests waiting for this one.
+	dups int
+
+	header http.Header
+	body   []byte
+	err    error
+}
+
+func newFlightGroup() *flightGroup {
+	return &flightGroup{calls: make(map[string]*flightCall)}
+}
+
+// do runs fn once for all the concurrent calls with the same key. Waiting
+// callers give up when ctx is done, without affecting the running call.
+func (g *flightGroup) do(
+	ctx context.Context,
+	key string,
+	fn func() (http.Header, []byte, error),
+) (*flightCall, error) {
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		call.dups++
+		g.mu.Unlock()
+		select {
+		case <-call.done:
+			return call, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	call := &flightCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.header, call.body, call.err = fn()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(call.done)
+	return call, nil
+}
+
+// rawResponseBody captures the undecoded body of a JSON response, so that it
+// can be decoded by each coalesced caller.
+type rawResponseBody struct {
+	httpHeader
+	data []byte
+}
+
+func (r *rawResponseBody) UnmarshalJSON(data []byte) error {
+	r.data = append([]byte(nil), data...)
+	return nil
+}
+
+// sendRequestCoalesced sends req like sendRequest. When
+// ClientConfig.CoalesceRequests is set, concurrent requests with the same
+// method, URL, key and tenant credentials share one upstream request; an
+// empty key is derived from the request body.
+func (c *Client) sendRequestCoalesced(req *http.Request, key string, v Response) error {
+	if c.flights == nil {
+		return c.sendRequest(req, v)
+	}
+	if key == "" {
+		var ok bool
+		if key, ok = requestBodyKey(req); !ok {
+			return c.sendRequest(req, v)
+		}
+	}
+
+	tenant, err := c.tenantKey(req.Context())
+	if err != nil {
+		return err
+	}
+
+	call, err := c.flights.do(req.Context(), req.Method+" "+req.URL.String()+" "+key+" "+tenant,
+		func() (http.Header, []byte, error) {
+			var raw rawResponseBody
+			err := c.sendRequest(req, &raw)
+			return raw.Header(), raw.data, err
+		})
+	if err != nil {
+		return err
+	}
+	if v != nil {
+		v.SetHeader(call.header.Clone())
+	}
+	if call.err != nil {
+		return call.err
+	}
+	return decodeResponse(c.jsonCodec(), bytes.NewReader(call.body), v)
+}
+
+// requestBodyKey returns a hash of the request body, reporting false when the
+// body cannot be read without consuming it.
+func requestBodyKey(req *http.Request) (string, bool) {
+	h := sha256.New()
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return "", false
+		}
+		body, err := req.GetBody()
+		if err != nil {
+			return "", false
+		}
+		defer body.Close()
+		if _, err = io.Copy(h, body); err != nil {
+			return "", false
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), true
+}
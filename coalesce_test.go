@@ -0,0 +1,185 @@
+package openai //nolint:testpackage // testing private field
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+// waitForDups waits until n requests are waiting on the in-flight call.
+func waitForDups(t *testing.T, g *flightGroup, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		g.mu.Lock()
+		dups := 0
+		for _, call := range g.calls {
+			dups += call.dups
+		}
+		g.mu.Unlock()
+		if dups == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d coalesced requests", n)
+}
+
+func TestCoalesceRequests(t *testing.T) {
+	var hits int32
+	release := make(chan struct{})
+	client, server := setupOpenAITestServerWithConfig(t, func(config *ClientConfig) {
+		config.CoalesceRequests = true
+	})
+	server.RegisterHandler("/v1/embeddings", func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		<-release
+		w.Header().Set("X-Request-Id", "req-1")
+		fmt.Fprint(w, `{"object":"list","data":[{"object":"embedding","embedding":[0.5],"index":0}]}`)
+	})
+
+	const callers = 5
+	request := EmbeddingRequestStrings{Input: []string{"hello"}, Model: SmallEmbedding3}
+	var wg sync.WaitGroup
+	results := make([]EmbeddingResponse, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = client.CreateEmbeddings(context.Background(), request)
+		}(i)
+	}
+	waitForDups(t, client.flights, callers-1)
+	close(release)
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&hits); n != 1 {
+		t.Errorf("expected 1 upstream request, got %d", n)
+	}
+	for i := 0; i < callers; i++ {
+		checks.NoError(t, errs[i])
+		if len(results[i].Data) != 1 || results[i].Data[0].Embedding[0] != 0.5 {
+			t.Errorf("caller %d: unexpected response %+v", i, results[i])
+		}
+		if got := results[i].Header().Get("X-Request-Id"); got != "req-1" {
+			t.Errorf("caller %d: X-Request-Id = %q, want req-1", i, got)
+		}
+	}
+	// Each caller decodes its own copy of the response.
+	results[0].Data[0].Embedding[0] = 1
+	if results[1].Data[0].Embedding[0] != 0.5 {
+		t.Error("coalesced callers should not share response data")
+	}
+
+	// Requests are no longer coalesced once the shared one has completed.
+	_, err := client.CreateEmbeddings(context.Background(), request)
+	checks.NoError(t, err)
+	if n := atomic.LoadInt32(&hits); n != 2 {
+		t.Errorf("expected 2 upstream requests, got %d", n)
+	}
+}
+
+func TestCoalesceRequestsDifferentBodies(t *testing.T) {
+	var hits int32
+	client, server := setupOpenAITestServerWithConfig(t, func(config *ClientConfig) {
+		config.CoalesceRequests = true
+	})
+	server.RegisterHandler("/v1/moderations", func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		fmt.Fprint(w, `{"id":"modr-1","results":[]}`)
+	})
+
+	for _, input := range []string{"a", "b"} {
+		_, err := client.Moderations(context.Background(), ModerationRequest{Input: input})
+		checks.NoError(t, err)
+	}
+	if n := atomic.LoadInt32(&hits); n != 2 {
+		t.Errorf("expected 2 upstream requests, got %d", n)
+	}
+}
+
+func TestFlightGroupWaiterContext(t *testing.T) {
+	g := newFlightGroup()
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = g.do(context.Background(), "k", func() (http.Header, []byte, error) {
+			<-release
+			return nil, []byte("{}"), nil
+		})
+	}()
+	for {
+		g.mu.Lock()
+		_, running := g.calls["k"]
+		g.mu.Unlock()
+		if running {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := g.do(ctx, "k", func() (http.Header, []byte, error) {
+		t.Error("waiting call should not run")
+		return nil, nil, nil
+	})
+	checks.ErrorIs(t, err, context.Canceled)
+	close(release)
+	<-done
+}
+
+func TestCoalesceRequestsTenants(t *testing.T) {
+	type tenantKey struct{}
+	var hits int32
+	release := make(chan struct{})
+	client, server := setupOpenAITestServerWithConfig(t, func(config *ClientConfig) {
+		config.CoalesceRequests = true
+		config.CredentialResolver = func(ctx context.Context) (string, string, string, error) {
+			org, _ := ctx.Value(tenantKey{}).(string)
+			return "", org, "", nil
+		}
+	})
+	server.RegisterHandler("/v1/embeddings", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		<-release
+		fmt.Fprintf(w, `{"object":"list","model":%q,"data":[]}`, r.Header.Get("OpenAI-Organization"))
+	})
+
+	tenants := []string{"org-acme", "org-globex"}
+	var wg sync.WaitGroup
+	results := make([]EmbeddingResponse, len(tenants))
+	errs := make([]error, len(tenants))
+	for i, org := range tenants {
+		wg.Add(1)
+		go func(i int, org string) {
+			defer wg.Done()
+			ctx := context.WithValue(context.Background(), tenantKey{}, org)
+			results[i], errs[i] = client.CreateEmbeddings(ctx, EmbeddingRequestStrings{Input: []string{"hello"}})
+		}(i, org)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt32(&hits) < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&hits); n != 2 {
+		t.Errorf("expected 1 upstream request per tenant, got %d", n)
+	}
+	for i, org := range tenants {
+		checks.NoError(t, errs[i])
+		if results[i].Model != EmbeddingModel(org) {
+			t.Errorf("tenant %s got the response of %q", org, results[i].Model)
+		}
+	}
+}
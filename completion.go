@@ -2,7 +2,10 @@ package openai
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
+	"time"
 )
 
 // GPT3 Defines the models provided by OpenAI to use when generating
@@ -196,11 +199,26 @@ func checkPromptType(prompt any) bool {
 	return true // all items in the slice are string, so it is []string
 }
 
+var (
+	ErrCompletionBestOfLessThanN          = errors.New("best_of must be greater than or equal to n")
+	ErrCompletionBestOfStreamNotSupported = errors.New("best_of cannot be used when streaming")
+	ErrCompletionEchoWithSuffix           = errors.New("echo cannot be used together with suffix")
+	ErrCompletionLogProbsOutOfRange       = errors.New("logprobs must be between 0 and 5")
+)
+
+const completionMaxLogProbs = 5
+
 // CompletionRequest represents a request structure for completion API.
 type CompletionRequest struct {
-	Model            string  `json:"model"`
-	Prompt           any     `json:"prompt,omitempty"`
-	BestOf           int     `json:"best_of,omitempty"`
+	Model  string `json:"model"`
+	Prompt any    `json:"prompt,omitempty"`
+	// BestOf generates best_of completions server-side and returns the n best,
+	// ranked by log probability per token. It must be greater than or equal to
+	// N and cannot be used when streaming.
+	BestOf int `json:"best_of,omitempty"`
+	// Echo returns the prompt in addition to the completion. The prompt
+	// tokens are included in LogProbs, with a null log probability for the
+	// first token. It cannot be combined with Suffix.
 	Echo             bool    `json:"echo,omitempty"`
 	FrequencyPenalty float32 `json:"frequency_penalty,omitempty"`
 	// LogitBias is must be a token id string (specified by their token ID in the tokenizer), not a word string.
@@ -211,20 +229,47 @@ type CompletionRequest struct {
 	// https://platform.openai.com/docs/api-reference/chat/create#chat-create-store
 	Store bool `json:"store,omitempty"`
 	// Metadata to store with the completion.
-	Metadata        map[string]string `json:"metadata,omitempty"`
-	LogProbs        int               `json:"logprobs,omitempty"`
-	MaxTokens       int               `json:"max_tokens,omitempty"`
-	N               int               `json:"n,omitempty"`
-	PresencePenalty float32           `json:"presence_penalty,omitempty"`
-	Seed            *int              `json:"seed,omitempty"`
-	Stop            []string          `json:"stop,omitempty"`
-	Stream          bool              `json:"stream,omitempty"`
-	Suffix          string            `json:"suffix,omitempty"`
-	Temperature     float32           `json:"temperature,omitempty"`
-	TopP            float32           `json:"top_p,omitempty"`
-	User            string            `json:"user,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// LogProbs includes the log probabilities of the LogProbs most likely
+	// tokens at each position, up to 5, in CompletionChoice.LogProbs.
+	LogProbs        int      `json:"logprobs,omitempty"`
+	MaxTokens       int      `json:"max_tokens,omitempty"`
+	N               int      `json:"n,omitempty"`
+	PresencePenalty float32  `json:"presence_penalty,omitempty"`
+	Seed            *int     `json:"seed,omitempty"`
+	Stop            []string `json:"stop,omitempty"`
+	Stream          bool     `json:"stream,omitempty"`
+	// Suffix is the text that comes after the completion, for insertion
+	// (fill-in-the-middle) use cases.
+	Suffix      string  `json:"suffix,omitempty"`
+	Temperature float32 `json:"temperature,omitempty"`
+	TopP        float32 `json:"top_p,omitempty"`
+	User        string  `json:"user,omitempty"`
 	// Options for streaming response. Only set this when you set stream: true.
 	StreamOptions *StreamOptions `json:"stream_options,omitempty"`
+
+	// explicit holds the fields set with SetTemperature and the like, which
+	// are sent even when zero.
+	explicit explicitFields
+}
+
+func validateCompletionRequest(request CompletionRequest) error {
+	if !checkPromptType(request.Prompt) {
+		return ErrCompletionRequestPromptTypeNotSupported
+	}
+	if request.BestOf > 0 && request.N > request.BestOf {
+		return ErrCompletionBestOfLessThanN
+	}
+	if request.BestOf > 0 && request.Stream {
+		return ErrCompletionBestOfStreamNotSupported
+	}
+	if request.Echo && request.Suffix != "" {
+		return ErrCompletionEchoWithSuffix
+	}
+	if request.LogProbs < 0 || request.LogProbs > completionMaxLogProbs {
+		return ErrCompletionLogProbsOutOfRange
+	}
+	return nil
 }
 
 // CompletionChoice represents one of possible completions.
@@ -235,12 +280,40 @@ type CompletionChoice struct {
 	LogProbs     LogprobResult `json:"logprobs"`
 }
 
-// LogprobResult represents logprob result of Choice.
+// LogprobResult represents logprob result of Choice. The slices are
+// parallel, one entry per token; with Echo the first prompt token has no
+// log probability, so its TokenLogprobs entry is 0 and FirstTokenLogprobNull
+// is set.
 type LogprobResult struct {
 	Tokens        []string             `json:"tokens"`
 	TokenLogprobs []float32            `json:"token_logprobs"`
 	TopLogprobs   []map[string]float32 `json:"top_logprobs"`
 	TextOffset    []int                `json:"text_offset"`
+	// FirstTokenLogprobNull reports that the first token was returned without
+	// a log probability, rather than with a log probability of 0.
+	FirstTokenLogprobNull bool `json:"-"`
+}
+
+func (r *LogprobResult) UnmarshalJSON(data []byte) error {
+	type logprobResult LogprobResult
+	var result struct {
+		logprobResult
+		TokenLogprobs []*float32 `json:"token_logprobs"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+	*r = LogprobResult(result.logprobResult)
+	if result.TokenLogprobs != nil {
+		r.TokenLogprobs = make([]float32, len(result.TokenLogprobs))
+		for i, logprob := range result.TokenLogprobs {
+			if logprob != nil {
+				r.TokenLogprobs[i] = *logprob
+			}
+		}
+	}
+	r.FirstTokenLogprobNull = len(result.TokenLogprobs) > 0 && result.TokenLogprobs[0] == nil
+	return nil
 }
 
 // CompletionResponse represents a response structure for completion API.
@@ -275,8 +348,12 @@ func (c *Client) CreateCompletion(
 		return
 	}
 
-	if !checkPromptType(request.Prompt) {
-		err = ErrCompletionRequestPromptTypeNotSupported
+	if err = validateCompletionRequest(request); err != nil {
+		return
+	}
+
+	request.User = c.resolveUser(ctx, request.User)
+	if err = c.admit(ctx, request.Model); err != nil {
 		return
 	}
 
@@ -290,6 +367,11 @@ func (c *Client) CreateCompletion(
 		return
 	}
 
+	start := time.Now()
 	err = c.sendRequest(req, &response)
+	c.observeLatency(request.Model, start, err)
+	if err == nil && response.Usage != nil {
+		c.recordTokens(req, response.Usage.PromptTokens, response.Usage.CompletionTokens)
+	}
 	return
 }
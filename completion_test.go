@@ -329,3 +329,46 @@ func TestCompletionWithGPT5Models(t *testing.T) {
 		})
 	}
 }
+
+func TestCompletionRequestValidation(t *testing.T) {
+	client := openai.NewClient("whatever")
+	ctx := context.Background()
+	cases := []struct {
+		request openai.CompletionRequest
+		err     error
+	}{
+		{openai.CompletionRequest{Prompt: "a", BestOf: 1, N: 2}, openai.ErrCompletionBestOfLessThanN},
+		{openai.CompletionRequest{Prompt: "a", Echo: true, Suffix: "b"}, openai.ErrCompletionEchoWithSuffix},
+		{openai.CompletionRequest{Prompt: "a", LogProbs: 6}, openai.ErrCompletionLogProbsOutOfRange},
+	}
+	for _, tc := range cases {
+		tc.request.Model = openai.GPT3Dot5TurboInstruct
+		_, err := client.CreateCompletion(ctx, tc.request)
+		checks.ErrorIs(t, err, tc.err, "CreateCompletion should validate the request")
+		_, err = client.CreateCompletionStream(ctx, tc.request)
+		checks.ErrorIs(t, err, tc.err, "CreateCompletionStream should validate the request")
+	}
+
+	_, err := client.CreateCompletionStream(ctx, openai.CompletionRequest{
+		Model: openai.GPT3Dot5TurboInstruct, Prompt: "a", BestOf: 2,
+	})
+	checks.ErrorIs(t, err, openai.ErrCompletionBestOfStreamNotSupported, "best_of is not supported when streaming")
+}
+
+func TestCompletionEchoNullLogprob(t *testing.T) {
+	var choice openai.CompletionChoice
+	err := json.Unmarshal([]byte(`{"text":"Hello world","logprobs":{"tokens":["Hello"," world"],`+
+		`"token_logprobs":[null,-0.5],"top_logprobs":[null,{" world":-0.5}],"text_offset":[0,5]}}`), &choice)
+	checks.NoError(t, err, "Unmarshal error")
+
+	logprobs := choice.LogProbs
+	if len(logprobs.TokenLogprobs) != 2 || logprobs.TokenLogprobs[1] != -0.5 || !logprobs.FirstTokenLogprobNull {
+		t.Errorf("logprobs = %+v, want [0 -0.5] with a null first token", logprobs)
+	}
+
+	err = json.Unmarshal([]byte(`{"logprobs":{"tokens":["Hi"],"token_logprobs":[0]}}`), &choice)
+	checks.NoError(t, err, "Unmarshal error")
+	if choice.LogProbs.FirstTokenLogprobNull {
+		t.Error("a first token log probability of 0 reported as null")
+	}
+}
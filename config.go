@@ -2,8 +2,10 @@ package openai
 
 import (
 	"net/http"
+	"net/url"
 	"regexp"
 	"strings"
+	"time"
 )
 
 const (
@@ -47,6 +49,191 @@ type ClientConfig struct {
 	HTTPClient           HTTPDoer
 
 	EmptyMessagesLimit uint
+
+	// ChatCompletionStreamTransforms are applied to every chunk of the chat
+	// completion streams created by the client. See Stream.AddTransform.
+	ChatCompletionStreamTransforms []StreamTransform[ChatCompletionStreamResponse]
+
+	// Tokenizer enables the context-length preflight: chat completion requests
+	// whose prompt plus requested max tokens exceed the model's context window
+	// fail with ErrContextLengthExceeded instead of being sent.
+	Tokenizer Tokenizer
+	// ClampMaxTokens makes the preflight lower MaxTokens/MaxCompletionTokens to
+	// the remaining context budget instead of failing. The request still fails
+	// when less than MinClampedMaxTokens tokens would remain.
+	ClampMaxTokens      bool
+	MinClampedMaxTokens int
+
+	// ResponseCache, when set, serves repeated non-streaming chat completion
+	// requests from its store. See WithCacheBypass.
+	ResponseCache *ResponseCache
+
+	// RequestCompressionThreshold, when positive, gzips the request bodies
+	// larger than this many bytes sent to RequestCompressionEndpoints, such
+	// as batch JSONL uploads or large embedding inputs.
+	RequestCompressionThreshold int
+	// RequestCompressionEndpoints lists the endpoints of the server accepting
+	// gzip-encoded requests, as paths such as "/embeddings" that also cover
+	// the paths below them. It is empty by default, as the OpenAI API does
+	// not document support for compressed requests, so nothing is compressed
+	// until the endpoints are listed.
+	RequestCompressionEndpoints []string
+
+	// Transport, when set, makes the client use a transport built with these
+	// settings instead of http.DefaultTransport.
+	Transport *TransportConfig
+
+	// MaxResponseBodySize, when positive, caps the size in bytes of decoded
+	// non-streaming response bodies; larger responses fail with a
+	// *ResponseTooLargeError. Error bodies are truncated to this size.
+	MaxResponseBodySize int64
+
+	// OnSystemFingerprintChange, when set, is called whenever a chat
+	// completion reports a different system_fingerprint than the previous one
+	// for the same model. A change means the backend configuration changed,
+	// so seeded requests may no longer reproduce earlier outputs.
+	OnSystemFingerprintChange SystemFingerprintChangeFunc
+
+	// DefaultUser, when set, is sent as the end-user identifier of chat,
+	// completion, embedding, image generation and response requests that do
+	// not set one themselves, so abuse is attributed consistently without
+	// setting it on every request. WithUser overrides it per context. Chat
+	// completion and response requests setting neither their user nor their
+	// safety identifier also send it as their safety identifier.
+	DefaultUser string
+
+	// DefaultModel, when set, is the model of chat completion and response
+	// requests that do not set one.
+	DefaultModel string
+
+	// DefaultEmbeddingModel, when set, is the model of embedding requests
+	// that do not set one.
+	DefaultEmbeddingModel EmbeddingModel
+
+	// DefaultTemperature, when set, is the temperature of chat completion and
+	// response requests that leave it at zero, except for reasoning models,
+	// which only accept their default. Use SetTemperature to send a zero
+	// temperature regardless.
+	DefaultTemperature *float32
+
+	// DefaultMetadata is added to the metadata of stored chat completion
+	// and response requests, as metadata is only kept with stored outputs:
+	// chat completion requests setting Store and response requests not
+	// setting it to false. Keys set by the request take precedence.
+	DefaultMetadata map[string]string
+
+	// PromptExperiment, when set, replaces the system prompt of chat
+	// completion requests and the instructions of response requests that
+	// have a user or an experiment unit with the variant assigned to it, and
+	// tags their metadata with it. See PromptExperiment.
+	PromptExperiment *PromptExperiment
+
+	// Shadow, when set, mirrors a sample of the non-streaming chat
+	// completion requests of the client to a second model in the background
+	// and reports both responses. See ShadowConfig.
+	Shadow *ShadowConfig
+
+	// RequestMutator, ResponseRequestMutator and EmbeddingRequestMutator, when
+	// set, modify the chat completion, response and embedding requests of the
+	// client before they are sent. See RequestMutator.
+	RequestMutator          RequestMutator
+	ResponseRequestMutator  ResponseRequestMutator
+	EmbeddingRequestMutator EmbeddingRequestMutator
+
+	// Policy, when set, is checked by chat completion and response requests
+	// after the request mutators, and fails those breaking it without
+	// sending them.
+	Policy *RequestPolicy
+
+	// CoalesceRequests, when set, makes concurrent identical non-streaming
+	// chat completion, embedding and moderation requests share a single
+	// upstream request. Chat completions are matched on
+	// HashChatCompletionRequest, the others on their body. The shared request
+	// runs with the context of the first caller, so canceling it fails all of
+	// them.
+	CoalesceRequests bool
+
+	// Scheduler, when set, queues the requests of the client and dispatches
+	// them by priority within its budgets. See WithPriority.
+	Scheduler *Scheduler
+
+	// LatencyTracker records the latency of non-streaming chat completion,
+	// completion, embedding and response requests. The client creates its own
+	// when it is nil; set it to share one between clients. See
+	// Client.LatencyTracker.
+	LatencyTracker *LatencyTracker
+	// DeadlineAdmission, when set, fails requests with ErrDeadlineTooShort
+	// without sending them when their context deadline is shorter than the
+	// median latency observed for their model.
+	DeadlineAdmission bool
+
+	// Metrics, when set, receives request, token and stream latency metrics.
+	Metrics MetricsRecorder
+
+	// CaptureLastRequest, when set, keeps a copy of the last request sent,
+	// with credentials redacted, for Client.DumpLastRequest. It is meant for
+	// debugging, as request bodies may hold sensitive content.
+	CaptureLastRequest bool
+
+	// StreamFormat is the format of streamed responses. By default it is
+	// detected, so that OpenAI-compatible servers streaming newline-delimited
+	// JSON instead of server-sent events work as well.
+	StreamFormat StreamFormat
+
+	// APIKeySource, when set, provides the API key of every request instead of
+	// the key the config was created with, so that keys rotated in a secret
+	// store are picked up without recreating the client. See also
+	// Client.SetAPIKey.
+	APIKeySource APIKeySource
+
+	// CredentialResolver, when set, resolves the API key, organization and
+	// project of each request from its context, so that a shared client can
+	// authenticate every tenant with its own credentials. It takes precedence
+	// over APIKeySource. The response cache and request coalescing are scoped
+	// to the resolved credentials, so tenants never share responses.
+	CredentialResolver CredentialResolver
+
+	// HeaderScrubber scrubs the headers carried by errors and request dumps,
+	// so that they can be logged safely. It defaults to DefaultHeaderAllowList.
+	// Credentials and cookies are redacted whatever the scrubber. The Header
+	// method of responses is left as received; see Client.ScrubHeader.
+	HeaderScrubber HeaderScrubber
+
+	// ProxyURL, when set, routes the requests of the client, streams included,
+	// through an HTTP, HTTPS or SOCKS5 proxy such as
+	// "socks5://egress.internal:1080", except those to the hosts listed in the
+	// NO_PROXY environment variable. It sets Transport.ProxyURL, so it is
+	// likewise ignored when HTTPClient has its own transport.
+	ProxyURL *url.URL
+
+	// ModelCacheTTL, when positive, caches the results of ListModels and
+	// GetModel for this long, so that checking the availability of several
+	// models with ModelExists takes a single request.
+	ModelCacheTTL time.Duration
+
+	// EmbeddingVectorPool, when set, supplies the vectors of embedding
+	// responses, which EmbeddingResponse.Release returns to it, so that
+	// high-throughput embedding workloads reuse them instead of allocating.
+	EmbeddingVectorPool EmbeddingVectorPool
+
+	// JSONCodec encodes requests and decodes responses and stream events. It
+	// defaults to StandardJSONCodec.
+	JSONCodec JSONCodec
+
+	// ErrorMapping holds rules mapping error responses to sentinel errors,
+	// such as the codes of a proxy, which take precedence over
+	// DefaultErrorMapping.
+	ErrorMapping ErrorMapping
+
+	// BetaVersions overrides the versions sent in the OpenAI-Beta header of
+	// beta endpoints, keyed by feature such as BetaAssistants. An empty
+	// version omits the header. AssistantVersion is used for the assistants
+	// feature when it has no entry.
+	BetaVersions map[string]string
+
+	// AudioPreprocessor, when set, transforms the audio of transcriptions
+	// and translations before upload, for example to trim silence.
+	AudioPreprocessor AudioPreprocessor
 }
 
 func DefaultConfig(authToken string) ClientConfig {
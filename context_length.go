@@ -0,0 +1,137 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var ErrContextLengthExceeded = errors.New("request exceeds the model's context window")
+
+// ContextLengthError is returned by the context-length preflight. It wraps
+// ErrContextLengthExceeded.
+type ContextLengthError struct {
+	Model         string
+	PromptTokens  int
+	MaxTokens     int
+	ContextWindow int
+}
+
+func (e *ContextLengthError) Error() string {
+	return fmt.Sprintf("%s: %d prompt tokens + %d max tokens > %d tokens for model %s",
+		ErrContextLengthExceeded, e.PromptTokens, e.MaxTokens, e.ContextWindow, e.Model)
+}
+
+func (e *ContextLengthError) Unwrap() error {
+	return ErrContextLengthExceeded
+}
+
+var (
+	modelContextWindowsMu sync.RWMutex
+	modelContextWindows   = map[string]int{
+		O1Mini:                128000,
+		O1Preview:             128000,
+		O1:                    200000,
+		O3:                    200000,
+		O3Mini:                200000,
+		O4Mini:                200000,
+		GPT4:                  8192,
+		GPT432K:               32768,
+		GPT4Turbo:             128000,
+		GPT4Turbo0125:         128000,
+		GPT4Turbo1106:         128000,
+		GPT4TurboPreview:      128000,
+		GPT4VisionPreview:     128000,
+		GPT4o:                 128000,
+		GPT4oLatest:           128000,
+		GPT4oMini:             128000,
+		GPT4Dot1:              1047576,
+		GPT4Dot1Mini:          1047576,
+		GPT4Dot1Nano:          1047576,
+		GPT4Dot5Preview:       128000,
+		GPT5:                  400000,
+		GPT5Mini:              400000,
+		GPT5Nano:              400000,
+		GPT5ChatLatest:        128000,
+		GPT3Dot5Turbo:         16385,
+		GPT3Dot5Turbo0613:     4096,
+		GPT3Dot5Turbo0301:     4096,
+		GPT3Dot5Turbo16K:      16385,
+		GPT3Dot5TurboInstruct: 4096,
+		GPT3Davinci002:        16384,
+		GPT3Babbage002:        16384,
+	}
+)
+
+// ModelContextWindow returns the context window, in tokens, of the model.
+// Dated snapshots without an entry of their own resolve to the longest
+// registered model name they start with, e.g. gpt-4o-2024-08-06 to gpt-4o.
+func ModelContextWindow(model string) (int, bool) {
+	modelContextWindowsMu.RLock()
+	defer modelContextWindowsMu.RUnlock()
+
+	if window, ok := modelContextWindows[model]; ok {
+		return window, true
+	}
+	var match string
+	for name := range modelContextWindows {
+		if strings.HasPrefix(model, name+"-") && len(name) > len(match) {
+			match = name
+		}
+	}
+	if match == "" {
+		return 0, false
+	}
+	return modelContextWindows[match], true
+}
+
+// RegisterModelContextWindow sets the context window of a model, for models
+// this package does not know about such as fine-tunes or other providers.
+func RegisterModelContextWindow(model string, tokens int) {
+	modelContextWindowsMu.Lock()
+	defer modelContextWindowsMu.Unlock()
+	modelContextWindows[model] = tokens
+}
+
+// checkContextLength runs the context-length preflight configured with
+// ClientConfig.Tokenizer, clamping the requested max tokens when
+// ClientConfig.ClampMaxTokens is set. Requests for models with an unknown
+// context window are let through.
+func (c *Client) checkContextLength(request *ChatCompletionRequest) error {
+	if c.config.Tokenizer == nil {
+		return nil
+	}
+	window, ok := ModelContextWindow(request.Model)
+	if !ok {
+		return nil
+	}
+
+	promptTokens, err := CountMessageTokens(c.config.Tokenizer, request.Messages)
+	if err != nil {
+		return err
+	}
+	maxTokens := &request.MaxCompletionTokens
+	if *maxTokens == 0 {
+		maxTokens = &request.MaxTokens
+	}
+	if promptTokens+*maxTokens <= window {
+		return nil
+	}
+
+	remaining := window - promptTokens
+	floor := c.config.MinClampedMaxTokens
+	if floor < 1 {
+		floor = 1
+	}
+	if c.config.ClampMaxTokens && remaining >= floor {
+		*maxTokens = remaining
+		return nil
+	}
+	return &ContextLengthError{
+		Model:         request.Model,
+		PromptTokens:  promptTokens,
+		MaxTokens:     *maxTokens,
+		ContextWindow: window,
+	}
+}
@@ -0,0 +1,118 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestModelContextWindow(t *testing.T) {
+	openai.RegisterModelContextWindow("ft:gpt-4o-mini:acme", 64000)
+
+	tests := []struct {
+		model  string
+		window int
+		ok     bool
+	}{
+		{openai.GPT4o, 128000, true},
+		{openai.GPT4o20240806, 128000, true},
+		{openai.GPT4, 8192, true},
+		{openai.GPT40613, 8192, true},
+		{openai.GPT432K0613, 32768, true},
+		{openai.GPT4Turbo20240409, 128000, true},
+		{openai.GPT3Dot5Turbo0613, 4096, true},
+		{"ft:gpt-4o-mini:acme", 64000, true},
+		{"unknown-model", 0, false},
+	}
+	for _, tt := range tests {
+		window, ok := openai.ModelContextWindow(tt.model)
+		if window != tt.window || ok != tt.ok {
+			t.Errorf("ModelContextWindow(%q) = %d, %v; want %d, %v", tt.model, window, ok, tt.window, tt.ok)
+		}
+	}
+}
+
+func TestCountMessageTokens(t *testing.T) {
+	count, err := openai.CountMessageTokens(fakeTokenizer, []openai.ChatCompletionMessage{
+		openai.SystemMessage("Be brief."),
+		{Role: openai.ChatMessageRoleUser, Content: "What is the weather in Paris?", Name: "bob"},
+	})
+	checks.NoError(t, err)
+	// priming 3, system 3 + role 1 + content 2, user 3 + role 1 + content 6 + name overhead 1 + name 1.
+	if count != 21 {
+		t.Errorf("expected 21 tokens, got %d", count)
+	}
+
+	_, err = openai.CountMessageTokens(fakeTokenizer, []openai.ChatCompletionMessage{
+		openai.UserMessage("\x00"),
+	})
+	checks.HasError(t, err)
+}
+
+func TestChatCompletionsContextLengthPreflight(t *testing.T) {
+	client, server := setupOpenAITestServerWithConfig(t, func(config *openai.ClientConfig) {
+		config.Tokenizer = fakeTokenizer
+	})
+	server.RegisterHandler("/v1/chat/completions", handleChatCompletionEndpoint)
+
+	openai.RegisterModelContextWindow("tiny-model", 20)
+	request := openai.ChatCompletionRequest{
+		Model:     "tiny-model",
+		MaxTokens: 10,
+		Messages:  []openai.ChatCompletionMessage{openai.UserMessage("Hello there")},
+	}
+	_, err := client.CreateChatCompletion(context.Background(), request)
+	checks.NoError(t, err, "request within the context window should be sent")
+
+	request.MaxTokens = 20
+	_, err = client.CreateChatCompletion(context.Background(), request)
+	checks.ErrorIs(t, err, openai.ErrContextLengthExceeded)
+	var lengthErr *openai.ContextLengthError
+	if !errors.As(err, &lengthErr) {
+		t.Fatalf("expected ContextLengthError, got %T", err)
+	}
+	if lengthErr.PromptTokens != 9 || lengthErr.MaxTokens != 20 || lengthErr.ContextWindow != 20 {
+		t.Errorf("unexpected error details: %+v", lengthErr)
+	}
+
+	_, err = client.CreateChatCompletionStream(context.Background(), request)
+	checks.ErrorIs(t, err, openai.ErrContextLengthExceeded)
+}
+
+func TestChatCompletionsClampMaxTokens(t *testing.T) {
+	var sent openai.ChatCompletionRequest
+	client, server := setupOpenAITestServerWithConfig(t, func(config *openai.ClientConfig) {
+		config.Tokenizer = fakeTokenizer
+		config.ClampMaxTokens = true
+		config.MinClampedMaxTokens = 5
+	})
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `{"object":"chat.completion","choices":[]}`)
+	})
+
+	openai.RegisterModelContextWindow("tiny-model", 20)
+	request := openai.ChatCompletionRequest{
+		Model:               "tiny-model",
+		MaxCompletionTokens: 100,
+		Messages:            []openai.ChatCompletionMessage{openai.UserMessage("Hello there")},
+	}
+	_, err := client.CreateChatCompletion(context.Background(), request)
+	checks.NoError(t, err)
+	if sent.MaxCompletionTokens != 11 {
+		t.Errorf("expected max_completion_tokens to be clamped to 11, got %d", sent.MaxCompletionTokens)
+	}
+
+	request.Messages = []openai.ChatCompletionMessage{openai.UserMessage("one two three four five six seven eight nine ten")}
+	_, err = client.CreateChatCompletion(context.Background(), request)
+	checks.ErrorIs(t, err, openai.ErrContextLengthExceeded, "budget below the floor should fail")
+}
@@ -0,0 +1,69 @@
+package openai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// CredentialResolver returns the credentials of a request made with ctx, so
+// that a client shared between tenants authenticates each request as its
+// tenant, identified by a value of the context. Empty values fall back to the
+// credentials of the client: return an error to refuse requests without a
+// tenant instead.
+//
+//	config.CredentialResolver = func(ctx context.Context) (string, string, string, error) {
+//		tenant, ok := ctx.Value(tenantKey{}).(*Tenant)
+//		if !ok {
+//			return "", "", "", errors.New("no tenant")
+//		}
+//		return tenant.APIKey, tenant.OrgID, tenant.Project, nil
+//	}
+type CredentialResolver func(ctx context.Context) (apiKey, org, project string, err error)
+
+// requestCredentials authenticate a request.
+type requestCredentials struct {
+	apiKey  string
+	orgID   string
+	project string
+}
+
+// credentials returns the credentials of a request made with ctx.
+func (c *Client) credentials(ctx context.Context) (creds requestCredentials, err error) {
+	if c.config.CredentialResolver != nil {
+		creds.apiKey, creds.orgID, creds.project, err = c.config.CredentialResolver(ctx)
+		if err != nil {
+			return creds, fmt.Errorf("error, resolving credentials: %w", err)
+		}
+	}
+	if creds.apiKey == "" {
+		if creds.apiKey, err = c.apiKey(ctx); err != nil {
+			return creds, err
+		}
+	}
+	if creds.orgID == "" {
+		creds.orgID = c.config.OrgID
+	}
+	return creds, nil
+}
+
+// tenantKey returns a fingerprint of the credentials resolved for ctx by
+// ClientConfig.CredentialResolver, added to the response cache and request
+// coalescing keys so that tenants never share responses. It is empty without
+// a resolver.
+func (c *Client) tenantKey(ctx context.Context) (string, error) {
+	if c.config.CredentialResolver == nil {
+		return "", nil
+	}
+	creds, err := c.credentials(ctx)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	for _, value := range []string{creds.apiKey, creds.orgID, creds.project} {
+		h.Write([]byte(value))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16]), nil
+}
@@ -0,0 +1,93 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+type tenantKey struct{}
+
+type tenant struct {
+	apiKey, org, project string
+}
+
+var errNoTenant = errors.New("no tenant")
+
+func resolveTenant(ctx context.Context) (string, string, string, error) {
+	t, ok := ctx.Value(tenantKey{}).(tenant)
+	if !ok {
+		return "", "", "", errNoTenant
+	}
+	return t.apiKey, t.org, t.project, nil
+}
+
+func TestCredentialResolver(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		headers []http.Header
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		headers = append(headers, r.Header.Clone())
+		mu.Unlock()
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	}))
+	defer ts.Close()
+
+	config := openai.DefaultConfig("shared-key")
+	config.BaseURL = ts.URL + "/v1"
+	config.OrgID = "org-default"
+	config.CredentialResolver = resolveTenant
+	client := openai.NewClientWithConfig(config)
+
+	acme := context.WithValue(context.Background(), tenantKey{}, tenant{"acme-key", "org-acme", "proj-acme"})
+	_, err := client.ListModels(acme)
+	checks.NoError(t, err)
+	// Empty values fall back to the client's credentials.
+	globex := context.WithValue(context.Background(), tenantKey{}, tenant{apiKey: "globex-key"})
+	_, err = client.ListModels(globex)
+	checks.NoError(t, err)
+	_, err = client.ListModels(context.Background())
+	checks.ErrorIs(t, err, errNoTenant)
+
+	if len(headers) != 2 {
+		t.Fatalf("got %d requests, want 2", len(headers))
+	}
+	want := []struct{ auth, org, project string }{
+		{"Bearer acme-key", "org-acme", "proj-acme"},
+		{"Bearer globex-key", "org-default", ""},
+	}
+	for i, w := range want {
+		h := headers[i]
+		if h.Get("Authorization") != w.auth || h.Get("OpenAI-Organization") != w.org || h.Get("OpenAI-Project") != w.project {
+			t.Errorf("request %d: headers = %v", i, h)
+		}
+	}
+}
+
+func TestRealtimeHeaderContext(t *testing.T) {
+	config := openai.DefaultConfig("shared-key")
+	config.CredentialResolver = resolveTenant
+	client := openai.NewClientWithConfig(config)
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, tenant{apiKey: "acme-key"})
+	header, err := client.RealtimeHeaderContext(ctx)
+	checks.NoError(t, err)
+	if header.Get("Authorization") != "Bearer acme-key" || header.Get("OpenAI-Beta") != "realtime=v1" {
+		t.Errorf("header = %v", header)
+	}
+
+	_, err = client.RealtimeHeaderContext(context.Background())
+	checks.ErrorIs(t, err, errNoTenant)
+	if header = client.RealtimeHeader(); header.Get("Authorization") != "" {
+		t.Errorf("Authorization = %q, want none", header.Get("Authorization"))
+	}
+}
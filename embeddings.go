@@ -8,6 +8,7 @@ import (
 	"errors"
 	"math"
 	"net/http"
+	"time"
 )
 
 var ErrVectorLengthMismatch = errors.New("vector length mismatch")
@@ -77,6 +78,8 @@ type EmbeddingResponse struct {
 	Usage  Usage          `json:"usage"`
 
 	httpHeader
+
+	pool EmbeddingVectorPool
 }
 
 type base64String string
@@ -115,11 +118,28 @@ type EmbeddingResponseBase64 struct {
 
 // ToEmbeddingResponse converts an embeddingResponseBase64 to an EmbeddingResponse.
 func (r *EmbeddingResponseBase64) ToEmbeddingResponse() (EmbeddingResponse, error) {
+	return r.toEmbeddingResponse(nil)
+}
+
+// toEmbeddingResponse converts the response, decoding the vectors into ones
+// from pool when it is not nil.
+func (r *EmbeddingResponseBase64) toEmbeddingResponse(pool EmbeddingVectorPool) (EmbeddingResponse, error) {
 	data := make([]Embedding, len(r.Data))
 
 	for i, base64Embedding := range r.Data {
-		embedding, err := base64Embedding.Embedding.Decode()
+		var embedding []float32
+		var err error
+		if pool != nil {
+			embedding, err = base64Embedding.Embedding.decodeInto(pool)
+		} else {
+			embedding, err = base64Embedding.Embedding.Decode()
+		}
 		if err != nil {
+			if pool != nil {
+				for _, e := range data[:i] {
+					pool.Put(e.Embedding)
+				}
+			}
 			return EmbeddingResponse{}, err
 		}
 
@@ -135,6 +155,7 @@ func (r *EmbeddingResponseBase64) ToEmbeddingResponse() (EmbeddingResponse, erro
 		Model:  r.Model,
 		Data:   data,
 		Usage:  r.Usage,
+		pool:   pool,
 	}, nil
 }
 
@@ -253,6 +274,17 @@ func (c *Client) CreateEmbeddings(
 	conv EmbeddingRequestConverter,
 ) (res EmbeddingResponse, err error) {
 	baseReq := conv.Convert()
+	baseReq.User = c.resolveUser(ctx, baseReq.User)
+	if baseReq.Model == "" {
+		baseReq.Model = c.config.DefaultEmbeddingModel
+	}
+	if err = c.mutateEmbeddingRequest(ctx, &baseReq); err != nil {
+		return
+	}
+	model := string(baseReq.Model)
+	if err = c.admit(ctx, model); err != nil {
+		return
+	}
 
 	// The body map is used to dynamically construct the request payload for the embedding API.
 	// Instead of relying on a fixed struct, the body map allows for flexible inclusion of fields
@@ -273,7 +305,7 @@ func (c *Client) CreateEmbeddings(
 	req, err := c.newRequest(
 		ctx,
 		http.MethodPost,
-		c.fullURL("/embeddings", withModel(string(baseReq.Model))),
+		c.fullURL("/embeddings", withModel(model)),
 		withBody(body),           // Main request body.
 		withExtraBody(extraBody), // Merge ExtraBody fields.
 	)
@@ -281,17 +313,31 @@ func (c *Client) CreateEmbeddings(
 		return
 	}
 
+	start := time.Now()
+	pool := c.config.EmbeddingVectorPool
 	if baseReq.EncodingFormat != EmbeddingEncodingFormatBase64 {
-		err = c.sendRequest(req, &res)
+		if pool != nil {
+			res.prepare(pool, embeddingInputCount(baseReq.Input), baseReq.Dimensions)
+		}
+		err = c.sendRequestCoalesced(req, "", &res)
+		res.reclaim(err != nil)
+		c.observeLatency(model, start, err)
+		if err == nil {
+			c.recordTokens(req, res.Usage.PromptTokens, res.Usage.CompletionTokens)
+		}
 		return
 	}
 
 	base64Response := &EmbeddingResponseBase64{}
-	err = c.sendRequest(req, base64Response)
+	err = c.sendRequestCoalesced(req, "", base64Response)
+	c.observeLatency(model, start, err)
 	if err != nil {
 		return
 	}
 
-	res, err = base64Response.ToEmbeddingResponse()
+	res, err = base64Response.toEmbeddingResponse(pool)
+	if err == nil {
+		c.recordTokens(req, res.Usage.PromptTokens, res.Usage.CompletionTokens)
+	}
 	return
 }
@@ -0,0 +1,114 @@
+package openai
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"math"
+	"sync"
+)
+
+// EmbeddingVectorPool supplies the vectors embedding responses are decoded
+// into and takes them back once they are released. It must be safe for
+// concurrent use.
+type EmbeddingVectorPool interface {
+	// Get returns an empty vector, preferably with a capacity of at least
+	// size. The size is zero when the dimensions are not known in advance.
+	Get(size int) []float32
+	// Put returns a vector that is no longer used to the pool.
+	Put(vector []float32)
+}
+
+// NewEmbeddingVectorPool returns an EmbeddingVectorPool backed by a
+// sync.Pool.
+func NewEmbeddingVectorPool() EmbeddingVectorPool {
+	return &syncVectorPool{}
+}
+
+type syncVectorPool struct {
+	pool sync.Pool
+}
+
+func (p *syncVectorPool) Get(size int) []float32 {
+	if v, ok := p.pool.Get().(*[]float32); ok && cap(*v) >= size {
+		return (*v)[:0]
+	}
+	return make([]float32, 0, size)
+}
+
+func (p *syncVectorPool) Put(vector []float32) {
+	if cap(vector) == 0 {
+		return
+	}
+	vector = vector[:0]
+	p.pool.Put(&vector)
+}
+
+// Release returns the vectors of the response to the EmbeddingVectorPool of
+// the client that created it and clears them. The vectors must not be used
+// afterwards. It does nothing for clients without a pool.
+func (r *EmbeddingResponse) Release() {
+	if r.pool == nil {
+		return
+	}
+	for i := range r.Data {
+		r.pool.Put(r.Data[i].Embedding)
+		r.Data[i].Embedding = nil
+	}
+	r.pool = nil
+}
+
+// prepare fills the response with count embeddings whose vectors come from
+// pool, which the JSON decoder appends to rather than allocating new ones.
+func (r *EmbeddingResponse) prepare(pool EmbeddingVectorPool, count, dimensions int) {
+	r.pool = pool
+	r.Data = make([]Embedding, count)
+	for i := range r.Data {
+		r.Data[i].Embedding = pool.Get(dimensions)
+	}
+}
+
+// reclaim returns the vectors prepared for embeddings the response did not
+// contain, and those of a failed request, to the pool.
+func (r *EmbeddingResponse) reclaim(failed bool) {
+	if r.pool == nil {
+		return
+	}
+	if failed {
+		r.Release()
+		r.Data = nil
+		return
+	}
+	unused := r.Data[len(r.Data):cap(r.Data)]
+	for i := range unused {
+		if unused[i].Embedding != nil {
+			r.pool.Put(unused[i].Embedding)
+			unused[i].Embedding = nil
+		}
+	}
+}
+
+// decodeInto decodes the embedding into a vector from pool.
+func (b base64String) decodeInto(pool EmbeddingVectorPool) ([]float32, error) {
+	decodedData, err := base64.StdEncoding.DecodeString(string(b))
+	if err != nil {
+		return nil, err
+	}
+
+	const sizeOfFloat32 = 4
+	floats := pool.Get(len(decodedData) / sizeOfFloat32)[:0]
+	for i := 0; i+sizeOfFloat32 <= len(decodedData); i += sizeOfFloat32 {
+		floats = append(floats, math.Float32frombits(binary.LittleEndian.Uint32(decodedData[i:])))
+	}
+	return floats, nil
+}
+
+// embeddingInputCount returns the number of embeddings requested by input.
+func embeddingInputCount(input any) int {
+	switch v := input.(type) {
+	case []string:
+		return len(v)
+	case [][]int:
+		return len(v)
+	}
+	return 1
+}
@@ -0,0 +1,155 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+// recordingVectorPool hands out vectors it remembers, so tests can check that
+// responses are decoded into them.
+type recordingVectorPool struct {
+	mu   sync.Mutex
+	got  map[*float32]bool
+	puts int
+}
+
+func (p *recordingVectorPool) Get(size int) []float32 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	v := make([]float32, 0, size+8)
+	if p.got == nil {
+		p.got = make(map[*float32]bool)
+	}
+	p.got[&v[:1][0]] = true
+	return v
+}
+
+func (p *recordingVectorPool) Put([]float32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.puts++
+}
+
+func (p *recordingVectorPool) owns(v []float32) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(v) > 0 && p.got[&v[0]]
+}
+
+func setupEmbeddingPoolClient(t *testing.T, pool openai.EmbeddingVectorPool, results int) *openai.Client {
+	t.Helper()
+	client, server := setupOpenAITestServerWithConfig(t, func(config *openai.ClientConfig) {
+		config.EmbeddingVectorPool = pool
+	})
+	server.RegisterHandler("/v1/embeddings", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			EncodingFormat openai.EmbeddingEncodingFormat `json:"encoding_format"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		var resBytes []byte
+		if req.EncodingFormat == openai.EmbeddingEncodingFormatBase64 {
+			data := make([]openai.Base64Embedding, results)
+			for i := range data {
+				data[i] = openai.Base64Embedding{Embedding: "pHCdP4XrkUDhevxA", Index: i}
+			}
+			resBytes, _ = json.Marshal(openai.EmbeddingResponseBase64{Data: data})
+		} else {
+			data := make([]openai.Embedding, results)
+			for i := range data {
+				data[i] = openai.Embedding{Embedding: []float32{1.23, 4.56, 7.89}, Index: i}
+			}
+			resBytes, _ = json.Marshal(openai.EmbeddingResponse{Data: data})
+		}
+		fmt.Fprintln(w, string(resBytes))
+	})
+	return client
+}
+
+func TestEmbeddingVectorPool(t *testing.T) {
+	for _, format := range []openai.EmbeddingEncodingFormat{
+		openai.EmbeddingEncodingFormatFloat,
+		openai.EmbeddingEncodingFormatBase64,
+	} {
+		t.Run(string(format), func(t *testing.T) {
+			pool := &recordingVectorPool{}
+			client := setupEmbeddingPoolClient(t, pool, 2)
+
+			res, err := client.CreateEmbeddings(context.Background(), openai.EmbeddingRequestStrings{
+				Input:          []string{"a", "b"},
+				Model:          openai.SmallEmbedding3,
+				EncodingFormat: format,
+				Dimensions:     3,
+			})
+			checks.NoError(t, err, "CreateEmbeddings error")
+			if len(res.Data) != 2 {
+				t.Fatalf("got %d embeddings, want 2", len(res.Data))
+			}
+			for i, e := range res.Data {
+				if len(e.Embedding) != 3 {
+					t.Fatalf("embedding %d = %v", i, e.Embedding)
+				}
+				if !pool.owns(e.Embedding) {
+					t.Errorf("embedding %d was not decoded into a pooled vector", i)
+				}
+			}
+
+			res.Release()
+			if pool.puts != 2 {
+				t.Errorf("Put called %d times, want 2", pool.puts)
+			}
+			if res.Data[0].Embedding != nil {
+				t.Error("Release did not clear the vectors")
+			}
+			res.Release()
+			if pool.puts != 2 {
+				t.Errorf("second Release put vectors again: %d", pool.puts)
+			}
+		})
+	}
+}
+
+func TestEmbeddingVectorPoolReclaimsUnused(t *testing.T) {
+	pool := &recordingVectorPool{}
+	client := setupEmbeddingPoolClient(t, pool, 1)
+
+	res, err := client.CreateEmbeddings(context.Background(), openai.EmbeddingRequestStrings{
+		Input: []string{"a", "b", "c"},
+		Model: openai.SmallEmbedding3,
+	})
+	checks.NoError(t, err, "CreateEmbeddings error")
+	if len(res.Data) != 1 {
+		t.Fatalf("got %d embeddings, want 1", len(res.Data))
+	}
+	if pool.puts != 2 {
+		t.Errorf("Put called %d times for unused vectors, want 2", pool.puts)
+	}
+}
+
+func TestEmbeddingResponseReleaseWithoutPool(t *testing.T) {
+	res := openai.EmbeddingResponse{Data: []openai.Embedding{{Embedding: []float32{1}}}}
+	res.Release()
+	if res.Data[0].Embedding == nil {
+		t.Error("Release cleared vectors that do not belong to a pool")
+	}
+}
+
+func TestNewEmbeddingVectorPool(t *testing.T) {
+	pool := openai.NewEmbeddingVectorPool()
+	v := pool.Get(16)
+	if len(v) != 0 || cap(v) < 16 {
+		t.Fatalf("Get(16) returned len %d cap %d", len(v), cap(v))
+	}
+	pool.Put(append(v, 1, 2, 3))
+	v = pool.Get(4)
+	if len(v) != 0 || cap(v) < 4 {
+		t.Fatalf("Get(4) returned len %d cap %d", len(v), cap(v))
+	}
+}
@@ -2,10 +2,15 @@ package openai
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
+	"unicode/utf8"
 )
 
+var ErrResponseTooLarge = errors.New("response body exceeds the configured maximum size")
+
 // APIError provides error information returned by the OpenAI API.
 // InnerError struct is only valid for Azure OpenAI Service.
 type APIError struct {
@@ -16,6 +21,15 @@ type APIError struct {
 	HTTPStatus     string      `json:"-"`
 	HTTPStatusCode int         `json:"-"`
 	InnerError     *InnerError `json:"innererror,omitempty"`
+	// Header holds the response headers, scrubbed by
+	// ClientConfig.HeaderScrubber.
+	Header http.Header `json:"-"`
+
+	// kind is the sentinel error the response maps to.
+	kind error
+	// revisedPrompt is the prompt rewritten by image models, which some
+	// content policy rejections include.
+	revisedPrompt string
 }
 
 // InnerError Azure Content filtering. Only valid for Azure OpenAI Service.
@@ -30,6 +44,30 @@ type RequestError struct {
 	HTTPStatusCode int
 	Err            error
 	Body           []byte
+	// Header holds the response headers, scrubbed by
+	// ClientConfig.HeaderScrubber.
+	Header http.Header
+
+	kind error
+}
+
+// NonJSONErrorResponse describes an error response whose body is not JSON,
+// such as the HTML or plain text pages returned by gateways and proxies on
+// 502 and 503. It is the Err of the RequestError returned for them.
+type NonJSONErrorResponse struct {
+	HTTPStatus     string
+	HTTPStatusCode int
+	ContentType    string
+	// Snippet is the start of the body with whitespace collapsed.
+	Snippet string
+}
+
+const nonJSONErrorSnippetSize = 256
+
+// ResponseTooLargeError is returned when a response body exceeds
+// ClientConfig.MaxResponseBodySize. It matches ErrResponseTooLarge.
+type ResponseTooLargeError struct {
+	Limit int64
 }
 
 type ErrorResponse struct {
@@ -79,6 +117,13 @@ func (e *APIError) UnmarshalJSON(data []byte) (err error) {
 		}
 	}
 
+	if _, ok := rawMap["revised_prompt"]; ok {
+		err = json.Unmarshal(rawMap["revised_prompt"], &e.revisedPrompt)
+		if err != nil {
+			return
+		}
+	}
+
 	// optional fields
 	if _, ok := rawMap["param"]; ok {
 		err = json.Unmarshal(rawMap["param"], &e.Param)
@@ -104,6 +149,12 @@ func (e *APIError) UnmarshalJSON(data []byte) (err error) {
 }
 
 func (e *RequestError) Error() string {
+	var nonJSON *NonJSONErrorResponse
+	if errors.As(e.Err, &nonJSON) {
+		// The snippet already shows the relevant part of the body.
+		return fmt.Sprintf("error, status code: %d, status: %s, message: %s",
+			e.HTTPStatusCode, e.HTTPStatus, e.Err)
+	}
 	return fmt.Sprintf(
 		"error, status code: %d, status: %s, message: %s, body: %s",
 		e.HTTPStatusCode, e.HTTPStatus, e.Err, e.Body,
@@ -113,3 +164,36 @@ func (e *RequestError) Error() string {
 func (e *RequestError) Unwrap() error {
 	return e.Err
 }
+
+func newNonJSONErrorResponse(status string, statusCode int, contentType string, body []byte) *NonJSONErrorResponse {
+	snippet := strings.Join(strings.Fields(string(body)), " ")
+	if len(snippet) > nonJSONErrorSnippetSize {
+		cut := nonJSONErrorSnippetSize
+		for cut > 0 && !utf8.RuneStart(snippet[cut]) {
+			cut--
+		}
+		snippet = snippet[:cut] + "..."
+	}
+	return &NonJSONErrorResponse{
+		HTTPStatus:     status,
+		HTTPStatusCode: statusCode,
+		ContentType:    contentType,
+		Snippet:        snippet,
+	}
+}
+
+func (e *NonJSONErrorResponse) Error() string {
+	contentType := e.ContentType
+	if contentType == "" {
+		contentType = "unknown content type"
+	}
+	return fmt.Sprintf("unexpected non-JSON error response (%s): %s", contentType, e.Snippet)
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("%s: limit is %d bytes", ErrResponseTooLarge, e.Limit)
+}
+
+func (e *ResponseTooLargeError) Unwrap() error {
+	return ErrResponseTooLarge
+}
@@ -0,0 +1,146 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors matched, through errors.Is, by the APIError and
+// RequestError returned for failed requests, according to the ErrorMapping
+// of the client.
+var (
+	ErrInvalidRequest   = errors.New("invalid request")
+	ErrAuthentication   = errors.New("authentication failed")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrNotFound         = errors.New("not found")
+	ErrRateLimited      = errors.New("rate limited")
+	ErrQuotaExceeded    = errors.New("quota exceeded")
+	ErrServerError      = errors.New("server error")
+	ErrServerOverloaded = errors.New("server overloaded")
+
+	// ErrImageContentPolicy matches image requests rejected by the content
+	// policy. It also matches ErrInvalidRequest.
+	ErrImageContentPolicy = fmt.Errorf("image content policy violation: %w", ErrInvalidRequest)
+)
+
+// statusOverloaded is the status some OpenAI-compatible providers return when
+// they are overloaded.
+const statusOverloaded = 529
+
+// ErrorRule maps the error responses matching all its non-zero fields to Err.
+type ErrorRule struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+	// Code is the code of the API error, such as "insufficient_quota".
+	// Numeric codes are compared in their decimal form.
+	Code string
+	// Type is the type of the API error, such as "invalid_request_error".
+	Type string
+	// Err is the error the response matches.
+	Err error
+}
+
+// ErrorMapping is a table of ErrorRules, the first matching rule of which
+// applies.
+type ErrorMapping []ErrorRule
+
+// DefaultErrorMapping is the mapping applied after the rules of
+// ClientConfig.ErrorMapping:
+//
+//	Status   Code or type                   Error
+//	429      insufficient_quota             ErrQuotaExceeded
+//	429      any other                      ErrRateLimited
+//	any      rate_limit_exceeded            ErrRateLimited
+//	any      content_policy_violation,      ErrImageContentPolicy
+//	         moderation_blocked,
+//	         image_generation_user_error
+//	400, 422                                ErrInvalidRequest
+//	any      invalid_request_error          ErrInvalidRequest
+//	401                                     ErrAuthentication
+//	403                                     ErrPermissionDenied
+//	404                                     ErrNotFound
+//	503, 529                                ErrServerOverloaded
+//	any      server_overloaded, overloaded  ErrServerOverloaded
+//	500, 502, 504                           ErrServerError
+//	any      server_error                   ErrServerError
+//
+// Statuses take precedence over types, so a 401 with the type
+// invalid_request_error is an ErrAuthentication.
+var DefaultErrorMapping = ErrorMapping{
+	{StatusCode: http.StatusTooManyRequests, Code: "insufficient_quota", Err: ErrQuotaExceeded},
+	{StatusCode: http.StatusTooManyRequests, Type: "insufficient_quota", Err: ErrQuotaExceeded},
+	{Code: "insufficient_quota", Err: ErrQuotaExceeded},
+	{StatusCode: http.StatusTooManyRequests, Err: ErrRateLimited},
+	{Code: "rate_limit_exceeded", Err: ErrRateLimited},
+	{Code: "content_policy_violation", Err: ErrImageContentPolicy},
+	{Code: "moderation_blocked", Err: ErrImageContentPolicy},
+	{Type: "image_generation_user_error", Err: ErrImageContentPolicy},
+	{StatusCode: http.StatusBadRequest, Err: ErrInvalidRequest},
+	{StatusCode: http.StatusUnprocessableEntity, Err: ErrInvalidRequest},
+	{StatusCode: http.StatusUnauthorized, Err: ErrAuthentication},
+	{StatusCode: http.StatusForbidden, Err: ErrPermissionDenied},
+	{StatusCode: http.StatusNotFound, Err: ErrNotFound},
+	{StatusCode: http.StatusServiceUnavailable, Err: ErrServerOverloaded},
+	{StatusCode: statusOverloaded, Err: ErrServerOverloaded},
+	{StatusCode: http.StatusInternalServerError, Err: ErrServerError},
+	{StatusCode: http.StatusBadGateway, Err: ErrServerError},
+	{StatusCode: http.StatusGatewayTimeout, Err: ErrServerError},
+	{Type: "invalid_request_error", Err: ErrInvalidRequest},
+	{Code: "server_overloaded", Err: ErrServerOverloaded},
+	{Type: "overloaded_error", Err: ErrServerOverloaded},
+	{Type: "server_error", Err: ErrServerError},
+}
+
+// lookup returns the error of the first rule matching the response, or nil.
+func (m ErrorMapping) lookup(statusCode int, code any, errType string) error {
+	codeString := ""
+	if code != nil {
+		codeString = fmt.Sprint(code)
+	}
+	for _, rule := range m {
+		if rule.StatusCode != 0 && rule.StatusCode != statusCode {
+			continue
+		}
+		if rule.Code != "" && rule.Code != codeString {
+			continue
+		}
+		if rule.Type != "" && rule.Type != errType {
+			continue
+		}
+		if rule.StatusCode == 0 && rule.Code == "" && rule.Type == "" {
+			continue
+		}
+		return rule.Err
+	}
+	return nil
+}
+
+// mapError returns the sentinel error of a response according to the rules
+// of the client, then the default ones.
+func (c *Client) mapError(statusCode int, code any, errType string) error {
+	if err := c.config.ErrorMapping.lookup(statusCode, code, errType); err != nil {
+		return err
+	}
+	return DefaultErrorMapping.lookup(statusCode, code, errType)
+}
+
+// Is reports whether the error maps to target, such as ErrRateLimited. Errors
+// not returned by a client, such as those built by hand or sent within a
+// stream, are mapped with DefaultErrorMapping.
+func (e *APIError) Is(target error) bool {
+	kind := e.kind
+	if kind == nil {
+		kind = DefaultErrorMapping.lookup(e.HTTPStatusCode, e.Code, e.Type)
+	}
+	return kind != nil && errors.Is(kind, target)
+}
+
+// Is reports whether the error maps to target, such as ErrServerError.
+func (e *RequestError) Is(target error) bool {
+	kind := e.kind
+	if kind == nil {
+		kind = DefaultErrorMapping.lookup(e.HTTPStatusCode, nil, "")
+	}
+	return kind != nil && errors.Is(kind, target)
+}
@@ -0,0 +1,97 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+func errorMappingClient(t *testing.T, mapping openai.ErrorMapping, status int, body string) error {
+	t.Helper()
+	client, server := setupOpenAITestServerWithConfig(t, func(config *openai.ClientConfig) {
+		config.ErrorMapping = mapping
+	})
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	})
+	_, err := client.ListModels(context.Background())
+	return err
+}
+
+func apiErrorBody(code, errType string) string {
+	return fmt.Sprintf(`{"error":{"message":"failed","code":%q,"type":%q}}`, code, errType)
+}
+
+func TestDefaultErrorMapping(t *testing.T) {
+	sentinels := []error{
+		openai.ErrInvalidRequest, openai.ErrAuthentication, openai.ErrPermissionDenied, openai.ErrNotFound,
+		openai.ErrRateLimited, openai.ErrQuotaExceeded, openai.ErrServerError, openai.ErrServerOverloaded,
+	}
+	cases := []struct {
+		status int
+		body   string
+		want   error
+	}{
+		{http.StatusTooManyRequests, apiErrorBody("insufficient_quota", "insufficient_quota"), openai.ErrQuotaExceeded},
+		{http.StatusTooManyRequests, apiErrorBody("rate_limit_exceeded", "requests"), openai.ErrRateLimited},
+		{http.StatusBadRequest, apiErrorBody("context_length_exceeded", "invalid_request_error"), openai.ErrInvalidRequest},
+		{http.StatusUnauthorized, apiErrorBody("invalid_api_key", "invalid_request_error"), openai.ErrAuthentication},
+		{http.StatusForbidden, apiErrorBody("", "invalid_request_error"), openai.ErrPermissionDenied},
+		{http.StatusNotFound, apiErrorBody("model_not_found", "invalid_request_error"), openai.ErrNotFound},
+		{http.StatusInternalServerError, apiErrorBody("", "server_error"), openai.ErrServerError},
+		{http.StatusServiceUnavailable, apiErrorBody("", "server_error"), openai.ErrServerOverloaded},
+		{529, apiErrorBody("", "overloaded_error"), openai.ErrServerOverloaded},
+		{http.StatusBadGateway, "<html>Bad Gateway</html>", openai.ErrServerError},
+		{http.StatusTeapot, apiErrorBody("", "teapot"), nil},
+	}
+	for _, tc := range cases {
+		t.Run(fmt.Sprint(tc.status), func(t *testing.T) {
+			err := errorMappingClient(t, nil, tc.status, tc.body)
+			for _, sentinel := range sentinels {
+				if got := errors.Is(err, sentinel); got != (sentinel == tc.want) {
+					t.Errorf("errors.Is(%v, %v) = %v", err, sentinel, got)
+				}
+			}
+		})
+	}
+}
+
+func TestCustomErrorMapping(t *testing.T) {
+	errProxyBlocked := fmt.Errorf("blocked by proxy: %w", openai.ErrPermissionDenied)
+	mapping := openai.ErrorMapping{
+		{StatusCode: 599, Err: openai.ErrServerOverloaded},
+		{Code: "proxy_blocked", Err: errProxyBlocked},
+	}
+
+	err := errorMappingClient(t, mapping, 599, apiErrorBody("", "proxy_error"))
+	if !errors.Is(err, openai.ErrServerOverloaded) {
+		t.Errorf("599 error %v does not match ErrServerOverloaded", err)
+	}
+
+	err = errorMappingClient(t, mapping, http.StatusBadRequest, apiErrorBody("proxy_blocked", "invalid_request_error"))
+	if !errors.Is(err, errProxyBlocked) || !errors.Is(err, openai.ErrPermissionDenied) {
+		t.Errorf("proxy error %v does not match the custom rule", err)
+	}
+	if errors.Is(err, openai.ErrInvalidRequest) {
+		t.Errorf("custom rule should take precedence over the default mapping")
+	}
+}
+
+func TestAPIErrorIsWithoutClient(t *testing.T) {
+	err := &openai.APIError{Code: "rate_limit_exceeded", Message: "slow down"}
+	if !errors.Is(err, openai.ErrRateLimited) {
+		t.Error("stream error with a rate limit code does not match ErrRateLimited")
+	}
+	err = &openai.APIError{HTTPStatusCode: http.StatusUnauthorized}
+	if !errors.Is(err, openai.ErrAuthentication) {
+		t.Error("hand-built 401 does not match ErrAuthentication")
+	}
+	if errors.Is(&openai.APIError{Message: "unknown"}, openai.ErrServerError) {
+		t.Error("unmapped error matches ErrServerError")
+	}
+}
@@ -2,8 +2,10 @@ package openai_test
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/sashabaranov/go-openai"
@@ -277,3 +279,14 @@ func TestRequestError(t *testing.T) {
 		t.Fatalf("Empty request error occurred")
 	}
 }
+
+func TestRequestErrorWrappedNonJSONResponse(t *testing.T) {
+	err := &openai.RequestError{
+		HTTPStatusCode: http.StatusBadGateway,
+		Err:            fmt.Errorf("gateway: %w", &openai.NonJSONErrorResponse{Snippet: "<html>Bad Gateway</html>"}),
+		Body:           []byte("<html>Bad Gateway</html>"),
+	}
+	if msg := err.Error(); strings.Contains(msg, "body:") {
+		t.Errorf("expected the body of a wrapped non-JSON response to be left out, got %q", msg)
+	}
+}
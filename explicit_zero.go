@@ -0,0 +1,163 @@
+package openai
+
+import "encoding/json"
+
+// explicitFields records which sampling parameters of a request were set
+// through a setter, so that a zero value is sent instead of being dropped by
+// omitempty. Plain field assignments keep the previous behavior.
+type explicitFields uint8
+
+const (
+	explicitTemperature explicitFields = 1 << iota
+	explicitTopP
+	explicitN
+	explicitPresencePenalty
+	explicitFrequencyPenalty
+)
+
+// explicitValue returns the value to marshal for a field: nil, omitting it,
+// when it is zero and was not set explicitly.
+func explicitValue[T float32 | int](set, field explicitFields, v T) *T {
+	if v == 0 && set&field == 0 {
+		return nil
+	}
+	return &v
+}
+
+// SetTemperature sets Temperature and sends it even when it is zero.
+func (r *ChatCompletionRequest) SetTemperature(v float32) {
+	r.Temperature = v
+	r.explicit |= explicitTemperature
+}
+
+// SetTopP sets TopP and sends it even when it is zero.
+func (r *ChatCompletionRequest) SetTopP(v float32) {
+	r.TopP = v
+	r.explicit |= explicitTopP
+}
+
+// SetN sets N and sends it even when it is zero.
+func (r *ChatCompletionRequest) SetN(v int) {
+	r.N = v
+	r.explicit |= explicitN
+}
+
+// SetPresencePenalty sets PresencePenalty and sends it even when it is zero.
+func (r *ChatCompletionRequest) SetPresencePenalty(v float32) {
+	r.PresencePenalty = v
+	r.explicit |= explicitPresencePenalty
+}
+
+// SetFrequencyPenalty sets FrequencyPenalty and sends it even when it is zero.
+func (r *ChatCompletionRequest) SetFrequencyPenalty(v float32) {
+	r.FrequencyPenalty = v
+	r.explicit |= explicitFrequencyPenalty
+}
+
+// MarshalJSON includes the zero-valued fields set through the setters of the
+// request, such as SetTemperature.
+func (r ChatCompletionRequest) MarshalJSON() ([]byte, error) {
+	type alias ChatCompletionRequest
+	if r.explicit == 0 {
+		return json.Marshal(alias(r))
+	}
+	return json.Marshal(struct {
+		alias
+		Temperature      *float32 `json:"temperature,omitempty"`
+		TopP             *float32 `json:"top_p,omitempty"`
+		N                *int     `json:"n,omitempty"`
+		PresencePenalty  *float32 `json:"presence_penalty,omitempty"`
+		FrequencyPenalty *float32 `json:"frequency_penalty,omitempty"`
+	}{
+		alias:            alias(r),
+		Temperature:      explicitValue(r.explicit, explicitTemperature, r.Temperature),
+		TopP:             explicitValue(r.explicit, explicitTopP, r.TopP),
+		N:                explicitValue(r.explicit, explicitN, r.N),
+		PresencePenalty:  explicitValue(r.explicit, explicitPresencePenalty, r.PresencePenalty),
+		FrequencyPenalty: explicitValue(r.explicit, explicitFrequencyPenalty, r.FrequencyPenalty),
+	})
+}
+
+// SetTemperature sets Temperature and sends it even when it is zero.
+func (r *CompletionRequest) SetTemperature(v float32) {
+	r.Temperature = v
+	r.explicit |= explicitTemperature
+}
+
+// SetTopP sets TopP and sends it even when it is zero.
+func (r *CompletionRequest) SetTopP(v float32) {
+	r.TopP = v
+	r.explicit |= explicitTopP
+}
+
+// SetN sets N and sends it even when it is zero.
+func (r *CompletionRequest) SetN(v int) {
+	r.N = v
+	r.explicit |= explicitN
+}
+
+// SetPresencePenalty sets PresencePenalty and sends it even when it is zero.
+func (r *CompletionRequest) SetPresencePenalty(v float32) {
+	r.PresencePenalty = v
+	r.explicit |= explicitPresencePenalty
+}
+
+// SetFrequencyPenalty sets FrequencyPenalty and sends it even when it is zero.
+func (r *CompletionRequest) SetFrequencyPenalty(v float32) {
+	r.FrequencyPenalty = v
+	r.explicit |= explicitFrequencyPenalty
+}
+
+// MarshalJSON includes the zero-valued fields set through the setters of the
+// request, such as SetTemperature.
+func (r CompletionRequest) MarshalJSON() ([]byte, error) {
+	type alias CompletionRequest
+	if r.explicit == 0 {
+		return json.Marshal(alias(r))
+	}
+	return json.Marshal(struct {
+		alias
+		Temperature      *float32 `json:"temperature,omitempty"`
+		TopP             *float32 `json:"top_p,omitempty"`
+		N                *int     `json:"n,omitempty"`
+		PresencePenalty  *float32 `json:"presence_penalty,omitempty"`
+		FrequencyPenalty *float32 `json:"frequency_penalty,omitempty"`
+	}{
+		alias:            alias(r),
+		Temperature:      explicitValue(r.explicit, explicitTemperature, r.Temperature),
+		TopP:             explicitValue(r.explicit, explicitTopP, r.TopP),
+		N:                explicitValue(r.explicit, explicitN, r.N),
+		PresencePenalty:  explicitValue(r.explicit, explicitPresencePenalty, r.PresencePenalty),
+		FrequencyPenalty: explicitValue(r.explicit, explicitFrequencyPenalty, r.FrequencyPenalty),
+	})
+}
+
+// SetTemperature sets Temperature and sends it even when it is zero.
+func (r *ResponseRequest) SetTemperature(v float32) {
+	r.Temperature = v
+	r.explicit |= explicitTemperature
+}
+
+// SetTopP sets TopP and sends it even when it is zero.
+func (r *ResponseRequest) SetTopP(v float32) {
+	r.TopP = v
+	r.explicit |= explicitTopP
+}
+
+// MarshalJSON includes the zero-valued fields set through the setters of the
+// request, such as SetTemperature.
+func (r ResponseRequest) MarshalJSON() ([]byte, error) {
+	type alias ResponseRequest
+	if r.explicit == 0 {
+		return json.Marshal(alias(r))
+	}
+	return json.Marshal(struct {
+		alias
+		Temperature *float32 `json:"temperature,omitempty"`
+		TopP        *float32 `json:"top_p,omitempty"`
+	}{
+		alias:       alias(r),
+		Temperature: explicitValue(r.explicit, explicitTemperature, r.Temperature),
+		TopP:        explicitValue(r.explicit, explicitTopP, r.TopP),
+	})
+}
@@ -0,0 +1,86 @@
+package openai_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func marshalToMap(t *testing.T, v any) map[string]any {
+	t.Helper()
+	data, err := json.Marshal(v)
+	checks.NoError(t, err)
+	var m map[string]any
+	checks.NoError(t, json.Unmarshal(data, &m))
+	return m
+}
+
+func TestChatCompletionRequestExplicitZero(t *testing.T) {
+	req := openai.ChatCompletionRequest{Model: openai.GPT4o, Temperature: 0, N: 0}
+	m := marshalToMap(t, req)
+	for _, key := range []string{"temperature", "top_p", "n", "presence_penalty", "frequency_penalty"} {
+		if _, ok := m[key]; ok {
+			t.Errorf("%s sent without being set", key)
+		}
+	}
+
+	req.SetTemperature(0)
+	req.SetN(0)
+	req.SetFrequencyPenalty(0)
+	req.PresencePenalty = 0.5
+	m = marshalToMap(t, req)
+	for key, want := range map[string]float64{
+		"temperature":       0,
+		"n":                 0,
+		"frequency_penalty": 0,
+		"presence_penalty":  0.5,
+	} {
+		got, ok := m[key]
+		if !ok || got != want {
+			t.Errorf("%s = %v, want %v", key, got, want)
+		}
+	}
+	if _, ok := m["top_p"]; ok {
+		t.Error("top_p sent without being set")
+	}
+	if m["model"] != openai.GPT4o {
+		t.Errorf("model = %v, want %v", m["model"], openai.GPT4o)
+	}
+
+	// A field assigned after its setter keeps being sent.
+	req.Temperature = 0.7
+	m = marshalToMap(t, req)
+	if got := m["temperature"].(float64); float32(got) != 0.7 {
+		t.Errorf("temperature = %v, want 0.7", got)
+	}
+}
+
+func TestCompletionRequestExplicitZero(t *testing.T) {
+	req := openai.CompletionRequest{Model: "gpt-3.5-turbo-instruct", Prompt: "Hello"}
+	req.SetTopP(0)
+	req.SetPresencePenalty(0)
+	m := marshalToMap(t, req)
+	if got, ok := m["top_p"]; !ok || got != float64(0) {
+		t.Errorf("top_p = %v, want 0", got)
+	}
+	if got, ok := m["presence_penalty"]; !ok || got != float64(0) {
+		t.Errorf("presence_penalty = %v, want 0", got)
+	}
+	if _, ok := m["temperature"]; ok {
+		t.Error("temperature sent without being set")
+	}
+}
+
+func TestResponseRequestExplicitZero(t *testing.T) {
+	req := openai.ResponseRequest{Model: openai.GPT4o, Input: "Hello"}
+	req.SetTemperature(0)
+	m := marshalToMap(t, req)
+	if got, ok := m["temperature"]; !ok || got != float64(0) {
+		t.Errorf("temperature = %v, want 0", got)
+	}
+	if m["input"] != "Hello" {
+		t.Errorf("input = %v, want Hello", m["input"])
+	}
+}
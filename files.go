@@ -51,7 +51,10 @@ type File struct {
 
 // FilesList is a list of files that belong to the user or organization.
 type FilesList struct {
-	Files []File `json:"data"`
+	Files   []File `json:"data"`
+	FirstID string `json:"first_id"`
+	LastID  string `json:"last_id"`
+	HasMore bool   `json:"has_more"`
 
 	httpHeader
 }
@@ -138,7 +141,13 @@ func (c *Client) DeleteFile(ctx context.Context, fileID string) (err error) {
 // ListFiles Lists the currently available files,
 // and provides basic information about each file such as the file name and purpose.
 func (c *Client) ListFiles(ctx context.Context) (files FilesList, err error) {
-	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL("/files"))
+	return c.ListFilesWithOptions(ctx, ListOptions{})
+}
+
+// ListFilesWithOptions lists the currently available files, one page at a
+// time.
+func (c *Client) ListFilesWithOptions(ctx context.Context, opts ListOptions) (files FilesList, err error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(withQuery("/files", opts.values())))
 	if err != nil {
 		return
 	}
@@ -0,0 +1,119 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrModelNotFound = errors.New("model not found")
+	ErrModelNotOwned = errors.New("model is not owned by the organization")
+	ErrModelInUse    = errors.New("model is in use")
+)
+
+// ModelDeleteError is returned by DeleteModel when the API refuses to delete
+// a model. It matches its Reason with errors.Is and unwraps to the API error.
+type ModelDeleteError struct {
+	ModelID string
+	// Reason is ErrModelNotFound, ErrModelNotOwned or ErrModelInUse.
+	Reason error
+	Err    error
+}
+
+func (e *ModelDeleteError) Error() string {
+	return fmt.Sprintf("deleting model %s: %v: %v", e.ModelID, e.Reason, e.Err)
+}
+
+func (e *ModelDeleteError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ModelDeleteError) Is(target error) bool {
+	return target == e.Reason
+}
+
+// newModelDeleteError classifies the error of a model deletion, returning err
+// unchanged when the API gave no known reason.
+func newModelDeleteError(modelID string, err error) error {
+	var (
+		statusCode int
+		message    string
+	)
+	var apiErr *APIError
+	var reqErr *RequestError
+	switch {
+	case errors.As(err, &apiErr):
+		statusCode, message = apiErr.HTTPStatusCode, apiErr.Message
+	case errors.As(err, &reqErr):
+		statusCode = reqErr.HTTPStatusCode
+	default:
+		return err
+	}
+
+	var reason error
+	message = strings.ToLower(message)
+	switch {
+	case statusCode == http.StatusConflict,
+		strings.Contains(message, "in use"), strings.Contains(message, "being used"):
+		reason = ErrModelInUse
+	case statusCode == http.StatusNotFound:
+		reason = ErrModelNotFound
+	case statusCode == http.StatusForbidden:
+		reason = ErrModelNotOwned
+	default:
+		return err
+	}
+	return &ModelDeleteError{ModelID: modelID, Reason: reason, Err: err}
+}
+
+// FineTunedModelID is a parsed fine-tuned model ID, of the form
+// "ft:<base>:<organization>:<suffix>:<id>".
+type FineTunedModelID struct {
+	Base         string
+	Organization string
+	Suffix       string
+	ID           string
+}
+
+// ParseFineTunedModelID parses a fine-tuned model ID, reporting false for
+// other model IDs.
+func ParseFineTunedModelID(modelID string) (FineTunedModelID, bool) {
+	parts := strings.Split(modelID, ":")
+	if len(parts) != 5 || parts[0] != "ft" || parts[1] == "" {
+		return FineTunedModelID{}, false
+	}
+	return FineTunedModelID{Base: parts[1], Organization: parts[2], Suffix: parts[3], ID: parts[4]}, true
+}
+
+var modelSnapshotPattern = regexp.MustCompile(`^-\d{4}-\d{2}-\d{2}$`)
+
+// IsBasedOn reports whether the model was fine-tuned from base, either the
+// exact model or one of its dated snapshots: "gpt-4o-mini" matches
+// "gpt-4o-mini-2024-07-18", but not "gpt-4o-mini-audio".
+func (id FineTunedModelID) IsBasedOn(base string) bool {
+	if id.Base == base {
+		return true
+	}
+	return strings.HasPrefix(id.Base, base) && modelSnapshotPattern.MatchString(id.Base[len(base):])
+}
+
+// ListFineTunedModels lists the fine-tuned models based on baseModel, or all
+// of them when it is empty, such as for jobs deleting stale models.
+func (c *Client) ListFineTunedModels(ctx context.Context, baseModel string) ([]Model, error) {
+	models, err := c.ListModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var fineTuned []Model
+	for _, model := range models.Models {
+		id, ok := ParseFineTunedModelID(model.ID)
+		if ok && (baseModel == "" || id.IsBasedOn(baseModel)) {
+			fineTuned = append(fineTuned, model)
+		}
+	}
+	return fineTuned, nil
+}
@@ -0,0 +1,123 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestDeleteModelFineTuned(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	const id = "ft:gpt-4o-mini-2024-07-18:acme::abc123"
+	server.RegisterHandler("/v1/models/"+id, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		fmt.Fprintf(w, `{"id":%q,"object":"model","deleted":true}`, id)
+	})
+	response, err := client.DeleteModel(context.Background(), id)
+	checks.NoError(t, err)
+	if !response.Deleted || response.ID != id {
+		t.Errorf("response = %+v", response)
+	}
+}
+
+func TestDeleteModelErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		message string
+		want    error
+	}{
+		{"not found", http.StatusNotFound, "The model does not exist", openai.ErrModelNotFound},
+		{"not owned", http.StatusForbidden, "You have insufficient permissions", openai.ErrModelNotOwned},
+		{"conflict", http.StatusConflict, "Conflict", openai.ErrModelInUse},
+		{"in use", http.StatusBadRequest, "The model is currently in use by a fine-tuning job", openai.ErrModelInUse},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server, teardown := setupOpenAITestServer()
+			defer teardown()
+			server.RegisterHandler("/v1/models/ft:gpt-4o:acme::abc", func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				fmt.Fprintf(w, `{"error":{"message":%q,"type":"invalid_request_error"}}`, tt.message)
+			})
+			_, err := client.DeleteModel(context.Background(), "ft:gpt-4o:acme::abc")
+			checks.ErrorIs(t, err, tt.want)
+			var deleteErr *openai.ModelDeleteError
+			var apiErr *openai.APIError
+			if !errors.As(err, &deleteErr) || !errors.As(err, &apiErr) || apiErr.HTTPStatusCode != tt.status {
+				t.Errorf("err = %v", err)
+			}
+		})
+	}
+}
+
+func TestDeleteModelUnclassifiedError(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/models/ft:gpt-4o:acme::abc", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"error":{"message":"boom"}}`)
+	})
+	_, err := client.DeleteModel(context.Background(), "ft:gpt-4o:acme::abc")
+	var deleteErr *openai.ModelDeleteError
+	if err == nil || errors.As(err, &deleteErr) {
+		t.Errorf("err = %v, want an unclassified API error", err)
+	}
+}
+
+func TestParseFineTunedModelID(t *testing.T) {
+	id, ok := openai.ParseFineTunedModelID("ft:gpt-4o-mini-2024-07-18:acme:support:abc123")
+	if !ok || id.Base != "gpt-4o-mini-2024-07-18" || id.Organization != "acme" || id.Suffix != "support" ||
+		id.ID != "abc123" {
+		t.Errorf("id = %+v, %v", id, ok)
+	}
+	for _, modelID := range []string{"gpt-4o", "ft:gpt-4o", "curie:ft-acme-2023-01-01"} {
+		if _, ok = openai.ParseFineTunedModelID(modelID); ok {
+			t.Errorf("%q parsed as a fine-tuned model ID", modelID)
+		}
+	}
+
+	for base, want := range map[string]bool{
+		"gpt-4o-mini-2024-07-18": true,
+		"gpt-4o-mini":            true,
+		"gpt-4o":                 false,
+		"gpt-4o-mini-2024":       false,
+	} {
+		if got := id.IsBasedOn(base); got != want {
+			t.Errorf("IsBasedOn(%q) = %v, want %v", base, got, want)
+		}
+	}
+}
+
+func TestListFineTunedModels(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"object":"list","data":[`+
+			`{"id":"gpt-4o-mini","owned_by":"system"},`+
+			`{"id":"ft:gpt-4o-mini-2024-07-18:acme::a","owned_by":"acme"},`+
+			`{"id":"ft:gpt-4o-2024-08-06:acme::b","owned_by":"acme"},`+
+			`{"id":"ft:gpt-4o-mini-2024-07-18:acme:v2:c","owned_by":"acme"}]}`)
+	})
+
+	models, err := client.ListFineTunedModels(context.Background(), openai.GPT4oMini)
+	checks.NoError(t, err)
+	if len(models) != 2 || models[0].ID != "ft:gpt-4o-mini-2024-07-18:acme::a" {
+		t.Errorf("models = %+v", models)
+	}
+	models, err = client.ListFineTunedModels(context.Background(), "")
+	checks.NoError(t, err)
+	if len(models) != 3 {
+		t.Errorf("got %d fine-tuned models, want 3", len(models))
+	}
+}
@@ -0,0 +1,301 @@
+package openai
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	ErrDatasetNoMessages           = errors.New("example has no messages")
+	ErrDatasetNoAssistantMessage   = errors.New("example has no assistant message to train on")
+	ErrDatasetInvalidRole          = errors.New("role must be system, developer, user, assistant or tool")
+	ErrDatasetInvalidWeight        = errors.New("weight must be 0 or 1, on assistant messages only")
+	ErrDatasetInvalidPreference    = errors.New("preference outputs must each be a single assistant message")
+	ErrDatasetExampleTooManyTokens = errors.New("example exceeds the token limit")
+)
+
+// FineTuningMessage is a message of a chat fine-tuning example. Weight, on
+// assistant messages, sets whether the model is trained on the message: 1,
+// the default, or 0.
+type FineTuningMessage struct {
+	ChatCompletionMessage
+	Weight *int
+}
+
+func (m FineTuningMessage) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(m.ChatCompletionMessage)
+	if err != nil || m.Weight == nil {
+		return data, err
+	}
+	// Splice the weight into the message object.
+	return append(data[:len(data)-1], fmt.Sprintf(`,"weight":%d}`, *m.Weight)...), nil
+}
+
+func (m *FineTuningMessage) UnmarshalJSON(data []byte) error {
+	var weight struct {
+		Weight *int `json:"weight"`
+	}
+	if err := json.Unmarshal(data, &weight); err != nil {
+		return err
+	}
+	m.Weight = weight.Weight
+	return json.Unmarshal(data, &m.ChatCompletionMessage)
+}
+
+// FineTuningChatExample is a line of a supervised fine-tuning dataset in the
+// chat format.
+type FineTuningChatExample struct {
+	Messages          []FineTuningMessage `json:"messages"`
+	Tools             []Tool              `json:"tools,omitempty"`
+	ParallelToolCalls *bool               `json:"parallel_tool_calls,omitempty"`
+}
+
+// FineTuningPreferenceInput is the conversation leading to the outputs of a
+// preference example.
+type FineTuningPreferenceInput struct {
+	Messages          []ChatCompletionMessage `json:"messages"`
+	Tools             []Tool                  `json:"tools,omitempty"`
+	ParallelToolCalls *bool                   `json:"parallel_tool_calls,omitempty"`
+}
+
+// FineTuningPreferenceExample is a line of a DPO (direct preference
+// optimization) dataset: a conversation and a preferred and a non-preferred
+// assistant reply to it.
+type FineTuningPreferenceExample struct {
+	Input              FineTuningPreferenceInput `json:"input"`
+	PreferredOutput    []ChatCompletionMessage   `json:"preferred_output"`
+	NonPreferredOutput []ChatCompletionMessage   `json:"non_preferred_output"`
+}
+
+// FineTuningRecord is the type of the lines of a fine-tuning dataset.
+type FineTuningRecord interface {
+	FineTuningChatExample | FineTuningPreferenceExample
+}
+
+// DatasetOptions configures the validation of fine-tuning examples.
+type DatasetOptions struct {
+	// Tokenizer and MaxTokens, when both set, reject the examples longer
+	// than MaxTokens tokens, which the API would truncate.
+	Tokenizer Tokenizer
+	MaxTokens int
+}
+
+// DatasetError reports an invalid line of a dataset, numbered from 1.
+type DatasetError struct {
+	Line int
+	Err  error
+}
+
+func (e *DatasetError) Error() string {
+	return fmt.Sprintf("invalid dataset line %d: %v", e.Line, e.Err)
+}
+
+func (e *DatasetError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateFineTuningRecord checks a fine-tuning example for errors the API
+// would reject the dataset for. Invalid messages are reported through a
+// *MessageValidationError.
+func ValidateFineTuningRecord[T FineTuningRecord](record T, opts DatasetOptions) error {
+	switch r := any(record).(type) {
+	case FineTuningChatExample:
+		if err := validateChatExample(r); err != nil {
+			return err
+		}
+		messages := make([]ChatCompletionMessage, len(r.Messages))
+		for i, message := range r.Messages {
+			messages[i] = message.ChatCompletionMessage
+		}
+		return checkExampleTokens(messages, r.Tools, opts)
+	case FineTuningPreferenceExample:
+		if err := validatePreferenceExample(r); err != nil {
+			return err
+		}
+		// Each output is trained on with the input.
+		for _, output := range []ChatCompletionMessage{r.PreferredOutput[0], r.NonPreferredOutput[0]} {
+			messages := make([]ChatCompletionMessage, 0, len(r.Input.Messages)+1)
+			messages = append(append(messages, r.Input.Messages...), output)
+			if err := checkExampleTokens(messages, r.Input.Tools, opts); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateChatExample(example FineTuningChatExample) error {
+	if len(example.Messages) == 0 {
+		return ErrDatasetNoMessages
+	}
+	trained := false
+	for i, message := range example.Messages {
+		if err := validateDatasetMessage(message.ChatCompletionMessage); err != nil {
+			return &MessageValidationError{Index: i, Role: message.Role, Err: err}
+		}
+		if message.Weight != nil &&
+			(message.Role != ChatMessageRoleAssistant || (*message.Weight != 0 && *message.Weight != 1)) {
+			return &MessageValidationError{Index: i, Role: message.Role, Err: ErrDatasetInvalidWeight}
+		}
+		if message.Role == ChatMessageRoleAssistant && (message.Weight == nil || *message.Weight == 1) {
+			trained = true
+		}
+	}
+	if !trained {
+		return ErrDatasetNoAssistantMessage
+	}
+	return nil
+}
+
+func validatePreferenceExample(example FineTuningPreferenceExample) error {
+	if len(example.Input.Messages) == 0 {
+		return ErrDatasetNoMessages
+	}
+	for i, message := range example.Input.Messages {
+		if err := validateDatasetMessage(message); err != nil {
+			return &MessageValidationError{Index: i, Role: message.Role, Err: err}
+		}
+	}
+	for _, output := range [][]ChatCompletionMessage{example.PreferredOutput, example.NonPreferredOutput} {
+		if len(output) != 1 || output[0].Role != ChatMessageRoleAssistant {
+			return ErrDatasetInvalidPreference
+		}
+	}
+	return nil
+}
+
+func validateDatasetMessage(message ChatCompletionMessage) error {
+	switch message.Role {
+	case "":
+		return ErrMessageMissingRole
+	case ChatMessageRoleSystem, ChatMessageRoleDeveloper, ChatMessageRoleUser,
+		ChatMessageRoleAssistant, ChatMessageRoleTool:
+		return nil
+	default:
+		return ErrDatasetInvalidRole
+	}
+}
+
+// checkExampleTokens checks the length of an example against the token limit
+// of opts.
+func checkExampleTokens(messages []ChatCompletionMessage, tools []Tool, opts DatasetOptions) error {
+	if opts.Tokenizer == nil || opts.MaxTokens <= 0 {
+		return nil
+	}
+	total, err := countExampleTokens(opts.Tokenizer, messages, tools)
+	if err != nil {
+		return err
+	}
+	if total > opts.MaxTokens {
+		return fmt.Errorf("%w: %d tokens, limit is %d", ErrDatasetExampleTooManyTokens, total, opts.MaxTokens)
+	}
+	return nil
+}
+
+// countExampleTokens estimates the number of tokens of an example, counting
+// its tools as their JSON definitions.
+func countExampleTokens(tokenizer Tokenizer, messages []ChatCompletionMessage, tools []Tool) (int, error) {
+	total, err := CountMessageTokens(tokenizer, messages)
+	if err != nil {
+		return 0, err
+	}
+	if len(tools) > 0 {
+		data, marshalErr := json.Marshal(tools)
+		if marshalErr != nil {
+			return 0, marshalErr
+		}
+		tokens, encodeErr := tokenizer.Encode(string(data))
+		if encodeErr != nil {
+			return 0, encodeErr
+		}
+		total += len(tokens)
+	}
+	return total, nil
+}
+
+// DatasetWriter writes a validated fine-tuning dataset as JSONL.
+type DatasetWriter[T FineTuningRecord] struct {
+	w    io.Writer
+	opts DatasetOptions
+	line int
+}
+
+// NewDatasetWriter returns a writer of the examples of a dataset to w.
+func NewDatasetWriter[T FineTuningRecord](w io.Writer, opts DatasetOptions) *DatasetWriter[T] {
+	return &DatasetWriter[T]{w: w, opts: opts}
+}
+
+// Write validates the example and writes it as a line. Invalid examples are
+// not written and are reported through a *DatasetError.
+func (w *DatasetWriter[T]) Write(record T) error {
+	line := w.line + 1
+	if err := ValidateFineTuningRecord(record, w.opts); err != nil {
+		return &DatasetError{Line: line, Err: err}
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return &DatasetError{Line: line, Err: err}
+	}
+	if _, err = w.w.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	w.line = line
+	return nil
+}
+
+// DatasetReader reads and validates a JSONL fine-tuning dataset.
+type DatasetReader[T FineTuningRecord] struct {
+	r    *bufio.Reader
+	opts DatasetOptions
+	line int
+}
+
+// NewDatasetReader returns a reader of the examples of the dataset in r.
+func NewDatasetReader[T FineTuningRecord](r io.Reader, opts DatasetOptions) *DatasetReader[T] {
+	return &DatasetReader[T]{r: bufio.NewReader(r), opts: opts}
+}
+
+// Read returns the next example of the dataset, or io.EOF at its end. Blank
+// lines are skipped. Lines that cannot be decoded or hold an invalid example
+// are reported through a *DatasetError, after which reading can go on.
+func (r *DatasetReader[T]) Read() (record T, err error) {
+	for {
+		data, readErr := r.r.ReadBytes('\n')
+		if len(data) == 0 && readErr != nil {
+			return record, readErr
+		}
+		r.line++
+		data = bytes.TrimSpace(data)
+		if len(data) == 0 {
+			continue
+		}
+		if err = json.Unmarshal(data, &record); err != nil {
+			return record, &DatasetError{Line: r.line, Err: err}
+		}
+		if err = ValidateFineTuningRecord(record, r.opts); err != nil {
+			return record, &DatasetError{Line: r.line, Err: err}
+		}
+		return record, nil
+	}
+}
+
+// ReadDataset reads and validates all the examples of the dataset in r,
+// stopping at the first invalid line.
+func ReadDataset[T FineTuningRecord](r io.Reader, opts DatasetOptions) ([]T, error) {
+	reader := NewDatasetReader[T](r, opts)
+	var records []T
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return records, err
+		}
+		records = append(records, record)
+	}
+}
@@ -0,0 +1,208 @@
+package openai
+
+import (
+	"errors"
+	"io"
+	"sort"
+)
+
+// Defaults of the fine-tuning dataset analysis, from the OpenAI cookbook.
+const (
+	defaultDatasetMaxTokens = 16385
+	datasetTargetEpochs     = 3
+	datasetMinTargetLines   = 100
+	datasetMaxTargetLines   = 25000
+	datasetMaxDefaultEpochs = 25
+)
+
+// DatasetAnalysisOptions configures AnalyzeDataset.
+type DatasetAnalysisOptions struct {
+	// Tokenizer counts the tokens of the examples. Without it, the token
+	// statistics and the cost estimate are left zero.
+	Tokenizer Tokenizer
+	// MaxTokens is the length past which training truncates examples, 16385
+	// by default.
+	MaxTokens int
+	// Epochs is the number of training epochs. By default, it is estimated
+	// like the API does: 3, raised for small datasets and lowered for large
+	// ones.
+	Epochs int
+	// PricePerMillionTokens is the training price of the model, used for
+	// DatasetStats.EstimatedCost.
+	PricePerMillionTokens float64
+}
+
+// Distribution summarizes a set of counts.
+type Distribution struct {
+	Min    int
+	Max    int
+	Mean   float64
+	Median int
+	P5     int
+	P95    int
+}
+
+func newDistribution(values []int) Distribution {
+	if len(values) == 0 {
+		return Distribution{}
+	}
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+	sum := 0
+	for _, v := range sorted {
+		sum += v
+	}
+	at := func(p float64) int { return sorted[int(p*float64(len(sorted)-1))] }
+	return Distribution{
+		Min:    sorted[0],
+		Max:    sorted[len(sorted)-1],
+		Mean:   float64(sum) / float64(len(sorted)),
+		Median: at(0.5),
+		P5:     at(0.05),
+		P95:    at(0.95),
+	}
+}
+
+// DatasetStats reports on a chat fine-tuning dataset.
+type DatasetStats struct {
+	// Examples is the number of valid examples, which the statistics cover.
+	Examples int
+	// Invalid lists the lines that failed to decode or validate.
+	Invalid []*DatasetError
+
+	// MissingSystemMessage and MissingUserMessage count the examples without
+	// a system or user message.
+	MissingSystemMessage int
+	MissingUserMessage   int
+	// RoleCounts is the number of messages of each role.
+	RoleCounts map[string]int
+
+	MessagesPerExample Distribution
+	// TokensPerExample and AssistantTokensPerExample are left zero without a
+	// tokenizer.
+	TokensPerExample          Distribution
+	AssistantTokensPerExample Distribution
+	// OverLimit lists the lines of the examples longer than MaxTokens, which
+	// will be truncated.
+	OverLimit []int
+
+	// Epochs is the number of training epochs of the estimate.
+	Epochs int
+	// BillingTokens is the number of tokens training is billed for: the
+	// tokens of every example, truncated to MaxTokens, times Epochs.
+	BillingTokens int
+	// EstimatedCost is BillingTokens at DatasetAnalysisOptions.PricePerMillionTokens.
+	EstimatedCost float64
+}
+
+// AnalyzeDataset reads a chat fine-tuning dataset in JSONL and reports its
+// format errors, token distribution and estimated training cost, like the
+// data preparation checks of the OpenAI cookbook. Invalid lines are reported
+// in DatasetStats.Invalid; the returned error is for failures to read r.
+func AnalyzeDataset(r io.Reader, opts DatasetAnalysisOptions) (*DatasetStats, error) {
+	if opts.MaxTokens <= 0 {
+		opts.MaxTokens = defaultDatasetMaxTokens
+	}
+	stats := &DatasetStats{RoleCounts: make(map[string]int)}
+	var messageCounts, tokenCounts, assistantCounts []int
+	billed := 0
+
+	reader := NewDatasetReader[FineTuningChatExample](r, DatasetOptions{})
+	for {
+		example, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var datasetErr *DatasetError
+		if errors.As(err, &datasetErr) {
+			stats.Invalid = append(stats.Invalid, datasetErr)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		stats.Examples++
+		messageCounts = append(messageCounts, len(example.Messages))
+		messages := make([]ChatCompletionMessage, len(example.Messages))
+		var assistant []ChatCompletionMessage
+		for i, message := range example.Messages {
+			messages[i] = message.ChatCompletionMessage
+			stats.RoleCounts[message.Role]++
+			if message.Role == ChatMessageRoleAssistant {
+				assistant = append(assistant, message.ChatCompletionMessage)
+			}
+		}
+		if !hasRole(messages, ChatMessageRoleSystem) {
+			stats.MissingSystemMessage++
+		}
+		if !hasRole(messages, ChatMessageRoleUser) {
+			stats.MissingUserMessage++
+		}
+
+		if opts.Tokenizer == nil {
+			continue
+		}
+		tokens, err := countExampleTokens(opts.Tokenizer, messages, example.Tools)
+		if err != nil {
+			return nil, err
+		}
+		assistantTokens := 0
+		for _, message := range assistant {
+			content, encodeErr := opts.Tokenizer.Encode(message.Content)
+			if encodeErr != nil {
+				return nil, encodeErr
+			}
+			assistantTokens += len(content)
+		}
+		tokenCounts = append(tokenCounts, tokens)
+		assistantCounts = append(assistantCounts, assistantTokens)
+		if tokens > opts.MaxTokens {
+			stats.OverLimit = append(stats.OverLimit, reader.line)
+			tokens = opts.MaxTokens
+		}
+		billed += tokens
+	}
+
+	stats.MessagesPerExample = newDistribution(messageCounts)
+	stats.TokensPerExample = newDistribution(tokenCounts)
+	stats.AssistantTokensPerExample = newDistribution(assistantCounts)
+	stats.Epochs = opts.Epochs
+	if stats.Epochs <= 0 {
+		stats.Epochs = defaultDatasetEpochs(stats.Examples)
+	}
+	stats.BillingTokens = billed * stats.Epochs
+	stats.EstimatedCost = float64(stats.BillingTokens) / 1e6 * opts.PricePerMillionTokens
+	return stats, nil
+}
+
+func hasRole(messages []ChatCompletionMessage, role string) bool {
+	for _, message := range messages {
+		if message.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultDatasetEpochs estimates the number of epochs the API trains on a
+// dataset of n examples for, rounding down as the OpenAI cookbook does.
+func defaultDatasetEpochs(n int) int {
+	if n == 0 {
+		return datasetTargetEpochs
+	}
+	switch {
+	case n*datasetTargetEpochs < datasetMinTargetLines:
+		epochs := datasetMinTargetLines / n
+		if epochs > datasetMaxDefaultEpochs {
+			epochs = datasetMaxDefaultEpochs
+		}
+		return epochs
+	case n*datasetTargetEpochs > datasetMaxTargetLines:
+		if epochs := datasetMaxTargetLines / n; epochs > 1 {
+			return epochs
+		}
+		return 1
+	}
+	return datasetTargetEpochs
+}
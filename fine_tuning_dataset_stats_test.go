@@ -0,0 +1,86 @@
+package openai_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestAnalyzeDataset(t *testing.T) {
+	dataset := strings.Join([]string{
+		`{"messages":[{"role":"system","content":"Be terse."},{"role":"user","content":"Hi"},` +
+			`{"role":"assistant","content":"Hello there"}]}`,
+		`{"messages":[{"content":"x"}]}`,
+		`{"messages":[{"role":"user","content":"one two three four five six seven eight nine ten"},` +
+			`{"role":"assistant","content":"ok"}]}`,
+	}, "\n")
+
+	stats, err := openai.AnalyzeDataset(strings.NewReader(dataset), openai.DatasetAnalysisOptions{
+		Tokenizer:             wordTokenizer,
+		MaxTokens:             21,
+		PricePerMillionTokens: 2,
+	})
+	checks.NoError(t, err)
+
+	if stats.Examples != 2 || len(stats.Invalid) != 1 || stats.Invalid[0].Line != 2 {
+		t.Errorf("examples = %d, invalid = %v", stats.Examples, stats.Invalid)
+	}
+	if stats.MissingSystemMessage != 1 || stats.MissingUserMessage != 0 {
+		t.Errorf("missing system = %d, user = %d", stats.MissingSystemMessage, stats.MissingUserMessage)
+	}
+	if stats.RoleCounts[openai.ChatMessageRoleAssistant] != 2 || stats.RoleCounts[openai.ChatMessageRoleSystem] != 1 {
+		t.Errorf("role counts = %v", stats.RoleCounts)
+	}
+	if stats.MessagesPerExample.Min != 2 || stats.MessagesPerExample.Max != 3 {
+		t.Errorf("messages per example = %+v", stats.MessagesPerExample)
+	}
+	if stats.TokensPerExample.Min != 20 || stats.TokensPerExample.Max != 22 {
+		t.Errorf("tokens per example = %+v", stats.TokensPerExample)
+	}
+	if stats.AssistantTokensPerExample.Mean != 1.5 {
+		t.Errorf("assistant tokens per example = %+v", stats.AssistantTokensPerExample)
+	}
+	if len(stats.OverLimit) != 1 || stats.OverLimit[0] != 3 {
+		t.Errorf("over limit = %v", stats.OverLimit)
+	}
+	// Two examples are trained on for the maximum default of 25 epochs, the
+	// second truncated to 21 tokens.
+	if stats.Epochs != 25 || stats.BillingTokens != (20+21)*25 {
+		t.Errorf("epochs = %d, billing tokens = %d", stats.Epochs, stats.BillingTokens)
+	}
+	if math.Abs(stats.EstimatedCost-0.00205) > 1e-9 {
+		t.Errorf("estimated cost = %v", stats.EstimatedCost)
+	}
+}
+
+func TestAnalyzeDatasetEpochs(t *testing.T) {
+	line := `{"messages":[{"role":"user","content":"Hi"},{"role":"assistant","content":"Hello"}]}` + "\n"
+	tests := []struct {
+		examples int
+		epochs   int
+	}{
+		{20, 5},
+		// 100/30 rounds down to 3 epochs, not up to 4.
+		{30, 3},
+		{33, 3},
+		{34, 3},
+		{7, 14},
+		{3, 25},
+		{100, 3},
+		{10000, 2},
+	}
+	for _, tt := range tests {
+		stats, err := openai.AnalyzeDataset(strings.NewReader(strings.Repeat(line, tt.examples)),
+			openai.DatasetAnalysisOptions{})
+		checks.NoError(t, err)
+		if stats.Epochs != tt.epochs {
+			t.Errorf("%d examples: epochs = %d, want %d", tt.examples, stats.Epochs, tt.epochs)
+		}
+		if stats.BillingTokens != 0 {
+			t.Errorf("billing tokens without a tokenizer = %d", stats.BillingTokens)
+		}
+	}
+}
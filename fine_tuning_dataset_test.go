@@ -0,0 +1,137 @@
+package openai_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+// wordTokenizer counts one token per word.
+var wordTokenizer = openai.TokenizerFunc(func(text string) ([]int, error) {
+	return make([]int, len(strings.Fields(text))), nil
+})
+
+func chatExample(messages ...openai.ChatCompletionMessage) openai.FineTuningChatExample {
+	var example openai.FineTuningChatExample
+	for _, message := range messages {
+		example.Messages = append(example.Messages, openai.FineTuningMessage{ChatCompletionMessage: message})
+	}
+	return example
+}
+
+func TestDatasetWriterReaderRoundTrip(t *testing.T) {
+	zero := 0
+	example := chatExample(
+		openai.SystemMessage("Be terse."),
+		openai.UserMessage("Hi"),
+		openai.AssistantMessage("Hello there, how can I help?"),
+		openai.UserMessage("Nothing"),
+		openai.AssistantMessage("Bye"),
+	)
+	example.Messages[2].Weight = &zero
+
+	var buf bytes.Buffer
+	writer := openai.NewDatasetWriter[openai.FineTuningChatExample](&buf, openai.DatasetOptions{})
+	checks.NoError(t, writer.Write(example))
+	checks.NoError(t, writer.Write(chatExample(openai.UserMessage("Ping"), openai.AssistantMessage("Pong"))))
+	if !strings.Contains(buf.String(), `"content":"Hello there, how can I help?","weight":0}`) {
+		t.Errorf("dataset = %s", buf.String())
+	}
+
+	records, err := openai.ReadDataset[openai.FineTuningChatExample](&buf, openai.DatasetOptions{})
+	checks.NoError(t, err)
+	if len(records) != 2 || records[0].Messages[2].Weight == nil || *records[0].Messages[2].Weight != 0 ||
+		records[0].Messages[4].Weight != nil || records[1].Messages[1].Content != "Pong" {
+		t.Errorf("records = %+v", records)
+	}
+}
+
+func TestDatasetWriterRejectsInvalidExamples(t *testing.T) {
+	zero, two := 0, 2
+	weighted := func(weight *int, role string) openai.FineTuningChatExample {
+		example := chatExample(openai.UserMessage("Hi"), openai.AssistantMessage("Hello"))
+		for i := range example.Messages {
+			if example.Messages[i].Role == role {
+				example.Messages[i].Weight = weight
+			}
+		}
+		return example
+	}
+	tests := []struct {
+		name    string
+		example openai.FineTuningChatExample
+		wantErr error
+	}{
+		{"no messages", openai.FineTuningChatExample{}, openai.ErrDatasetNoMessages},
+		{"missing role", chatExample(openai.ChatCompletionMessage{Content: "Hi"}), openai.ErrMessageMissingRole},
+		{"unknown role", chatExample(openai.ChatCompletionMessage{Role: "bot"}), openai.ErrDatasetInvalidRole},
+		{"no assistant", chatExample(openai.UserMessage("Hi")), openai.ErrDatasetNoAssistantMessage},
+		{"all weights zero", weighted(&zero, openai.ChatMessageRoleAssistant), openai.ErrDatasetNoAssistantMessage},
+		{"weight not 0 or 1", weighted(&two, openai.ChatMessageRoleAssistant), openai.ErrDatasetInvalidWeight},
+		{"weight on user", weighted(&zero, openai.ChatMessageRoleUser), openai.ErrDatasetInvalidWeight},
+		{"too long", chatExample(
+			openai.UserMessage("one two three four five"), openai.AssistantMessage("six"),
+		), openai.ErrDatasetExampleTooManyTokens},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writer := openai.NewDatasetWriter[openai.FineTuningChatExample](&buf, openai.DatasetOptions{
+				Tokenizer: wordTokenizer,
+				MaxTokens: 15,
+			})
+			err := writer.Write(tt.example)
+			checks.ErrorIs(t, err, tt.wantErr)
+			var datasetErr *openai.DatasetError
+			if !errors.As(err, &datasetErr) || datasetErr.Line != 1 {
+				t.Errorf("err = %v, want a *DatasetError on line 1", err)
+			}
+			if buf.Len() != 0 {
+				t.Errorf("invalid example was written: %s", buf.String())
+			}
+		})
+	}
+}
+
+func TestDatasetReaderReportsLine(t *testing.T) {
+	dataset := `{"messages":[{"role":"user","content":"Hi"},{"role":"assistant","content":"Hello"}]}
+
+{"messages":[{"content":"Hi"},{"role":"assistant","content":"Hello"}]}
+`
+	reader := openai.NewDatasetReader[openai.FineTuningChatExample](strings.NewReader(dataset), openai.DatasetOptions{})
+	_, err := reader.Read()
+	checks.NoError(t, err)
+	_, err = reader.Read()
+	var datasetErr *openai.DatasetError
+	var messageErr *openai.MessageValidationError
+	if !errors.As(err, &datasetErr) || datasetErr.Line != 3 || !errors.As(err, &messageErr) || messageErr.Index != 0 {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestPreferenceDataset(t *testing.T) {
+	valid := `{"input":{"messages":[{"role":"user","content":"Hi"}]},` +
+		`"preferred_output":[{"role":"assistant","content":"Hello!"}],` +
+		`"non_preferred_output":[{"role":"assistant","content":"What do you want"}]}`
+	invalid := `{"input":{"messages":[{"role":"user","content":"Hi"}]},` +
+		`"preferred_output":[{"role":"user","content":"Hello!"}],` +
+		`"non_preferred_output":[{"role":"assistant","content":"What"}]}`
+
+	records, err := openai.ReadDataset[openai.FineTuningPreferenceExample](
+		strings.NewReader(valid+"\n"+invalid), openai.DatasetOptions{})
+	checks.ErrorIs(t, err, openai.ErrDatasetInvalidPreference)
+	if len(records) != 1 || records[0].NonPreferredOutput[0].Content != "What do you want" {
+		t.Errorf("records = %+v", records)
+	}
+
+	// The longer output exceeds the limit.
+	_, err = openai.ReadDataset[openai.FineTuningPreferenceExample](strings.NewReader(valid), openai.DatasetOptions{
+		Tokenizer: wordTokenizer,
+		MaxTokens: 14,
+	})
+	checks.ErrorIs(t, err, openai.ErrDatasetExampleTooManyTokens)
+}
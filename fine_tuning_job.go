@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"net/url"
 )
 
 type FineTuningJob struct {
@@ -39,6 +38,15 @@ type FineTuningJobRequest struct {
 	Suffix          string           `json:"suffix,omitempty"`
 }
 
+// FineTuningJobList is a page of fine-tuning jobs.
+type FineTuningJobList struct {
+	Object  string          `json:"object"`
+	Data    []FineTuningJob `json:"data"`
+	HasMore bool            `json:"has_more"`
+
+	httpHeader
+}
+
 type FineTuningJobEventList struct {
 	Object  string          `json:"object"`
 	Data    []FineTuneEvent `json:"data"`
@@ -98,6 +106,32 @@ func (c *Client) RetrieveFineTuningJob(
 	return
 }
 
+// ListFineTuningJobsOptions holds the pagination parameters of
+// ListFineTuningJobs. Unlike the other list endpoints, it only supports
+// forward pagination, from the most recent job.
+type ListFineTuningJobsOptions struct {
+	// Limit is the number of jobs to return, 20 by default.
+	Limit *int
+	// After is the ID of the job after which to start listing.
+	After *string
+}
+
+// ListFineTuningJobs lists the fine-tuning jobs of the organization, one page
+// at a time.
+func (c *Client) ListFineTuningJobs(
+	ctx context.Context,
+	opts ListFineTuningJobsOptions,
+) (response FineTuningJobList, err error) {
+	values := ListOptions{Limit: opts.Limit, After: opts.After}.values()
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(withQuery("/fine_tuning/jobs", values)))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
 type listFineTuningJobEventsParameters struct {
 	after *string
 	limit *int
@@ -132,23 +166,11 @@ func (c *Client) ListFineTuningJobEvents(
 		setter(parameters)
 	}
 
-	urlValues := url.Values{}
-	if parameters.after != nil {
-		urlValues.Add("after", *parameters.after)
-	}
-	if parameters.limit != nil {
-		urlValues.Add("limit", fmt.Sprintf("%d", *parameters.limit))
-	}
-
-	encodedValues := ""
-	if len(urlValues) > 0 {
-		encodedValues = "?" + urlValues.Encode()
-	}
-
+	opts := ListOptions{After: parameters.after, Limit: parameters.limit}
 	req, err := c.newRequest(
 		ctx,
 		http.MethodGet,
-		c.fullURL("/fine_tuning/jobs/"+fineTuningJobID+"/events"+encodedValues),
+		c.fullURL(withQuery("/fine_tuning/jobs/"+fineTuningJobID+"/events", opts.values())),
 	)
 	if err != nil {
 		return
@@ -0,0 +1,50 @@
+package openai
+
+import "sync"
+
+// SystemFingerprintChangeFunc is called when the system fingerprint reported
+// for a model differs from the one seen in a previous response.
+type SystemFingerprintChangeFunc func(model, previous, current string)
+
+// fingerprintTracker remembers the last system fingerprint seen per model.
+type fingerprintTracker struct {
+	mu           sync.Mutex
+	fingerprints map[string]string
+	onChange     SystemFingerprintChangeFunc
+}
+
+func newFingerprintTracker(onChange SystemFingerprintChangeFunc) *fingerprintTracker {
+	if onChange == nil {
+		return nil
+	}
+	return &fingerprintTracker{
+		fingerprints: make(map[string]string),
+		onChange:     onChange,
+	}
+}
+
+func (t *fingerprintTracker) record(model, fingerprint string) {
+	if t == nil || model == "" || fingerprint == "" {
+		return
+	}
+	t.mu.Lock()
+	previous, seen := t.fingerprints[model]
+	t.fingerprints[model] = fingerprint
+	t.mu.Unlock()
+
+	if seen && previous != fingerprint {
+		t.onChange(model, previous, fingerprint)
+	}
+}
+
+// streamTransform records the fingerprint of the first chunk carrying one.
+func (t *fingerprintTracker) streamTransform() StreamTransform[ChatCompletionStreamResponse] {
+	recorded := false
+	return func(chunk *ChatCompletionStreamResponse) error {
+		if !recorded && chunk.SystemFingerprint != "" {
+			recorded = true
+			t.record(chunk.Model, chunk.SystemFingerprint)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,54 @@
+package openai_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestSystemFingerprintChange(t *testing.T) {
+	fingerprints := []string{"fp_1", "fp_1", "fp_2", "fp_3"}
+	calls := 0
+	var changes []string
+
+	client, server := setupOpenAITestServerWithConfig(t, func(config *openai.ClientConfig) {
+		config.OnSystemFingerprintChange = func(model, previous, current string) {
+			changes = append(changes, fmt.Sprintf("%s:%s->%s", model, previous, current))
+		}
+	})
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		fingerprint := fingerprints[calls]
+		calls++
+		if r.Header.Get("Accept") == "text/event-stream" {
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprintf(w, "data: {\"model\":\"gpt-4o\",\"system_fingerprint\":%q,\"choices\":[]}\n\n", fingerprint)
+			fmt.Fprint(w, "data: [DONE]\n\n")
+			return
+		}
+		fmt.Fprintf(w, `{"model":"gpt-4o","system_fingerprint":%q,"choices":[]}`, fingerprint)
+	})
+
+	seed := 42
+	request := openai.ChatCompletionRequest{
+		Model:    openai.GPT4o,
+		Seed:     &seed,
+		Messages: []openai.ChatCompletionMessage{openai.UserMessage("Hello")},
+	}
+	for i := 0; i < 3; i++ {
+		_, err := client.CreateChatCompletion(context.Background(), request)
+		checks.NoError(t, err)
+	}
+	stream, err := client.CreateChatCompletionStream(context.Background(), request)
+	checks.NoError(t, err)
+	_, err = stream.Collect()
+	checks.NoError(t, err)
+	stream.Close()
+
+	if len(changes) != 2 || changes[0] != "gpt-4o:fp_1->fp_2" || changes[1] != "gpt-4o:fp_2->fp_3" {
+		t.Errorf("unexpected fingerprint changes: %q", changes)
+	}
+}
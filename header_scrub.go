@@ -0,0 +1,111 @@
+package openai
+
+import (
+	"net/http"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// credentialHeaders carry credentials and are redacted whatever the
+// HeaderScrubber.
+var credentialHeaders = []string{"Authorization", "Api-Key", "X-Api-Key", "Cookie", "Set-Cookie"}
+
+// HeaderScrubber removes sensitive values from the headers the library
+// surfaces for logging: those of errors and of request dumps. Scrub receives
+// a copy it may modify.
+//
+// The Header method of responses and streams is not scrubbed: it returns
+// the response headers as received, for programmatic use. Scrub them with
+// Client.ScrubHeader before logging them.
+type HeaderScrubber interface {
+	Scrub(header http.Header) http.Header
+}
+
+// HeaderScrubberFunc adapts a function to the HeaderScrubber interface.
+type HeaderScrubberFunc func(header http.Header) http.Header
+
+func (f HeaderScrubberFunc) Scrub(header http.Header) http.Header {
+	return f(header)
+}
+
+// HeaderAllowList is a HeaderScrubber keeping the values of the listed
+// headers and redacting the others. Entries ending with "*" match the
+// headers starting with them.
+type HeaderAllowList []string
+
+// DefaultHeaderAllowList is the HeaderScrubber used when
+// ClientConfig.HeaderScrubber is nil. It keeps the content, tracing and rate
+// limit headers.
+var DefaultHeaderAllowList = HeaderAllowList{
+	"Accept",
+	"Accept-Encoding",
+	"Cf-Ray",
+	"Content-Encoding",
+	"Content-Length",
+	"Content-Type",
+	"Date",
+	"Openai-Beta",
+	"Openai-Model",
+	"Openai-Processing-Ms",
+	"Openai-Version",
+	"Retry-After",
+	"Traceparent",
+	"Tracestate",
+	"User-Agent",
+	"X-Ratelimit-*",
+	"X-Request-Id",
+}
+
+func (l HeaderAllowList) Scrub(header http.Header) http.Header {
+	for key, values := range header {
+		if l.allows(key) {
+			continue
+		}
+		for i := range values {
+			values[i] = redacted
+		}
+	}
+	return header
+}
+
+func (l HeaderAllowList) allows(key string) bool {
+	key = http.CanonicalHeaderKey(key)
+	for _, entry := range l {
+		entry = http.CanonicalHeaderKey(entry)
+		if prefix := strings.TrimSuffix(entry, "*"); prefix != entry {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if key == entry {
+			return true
+		}
+	}
+	return false
+}
+
+// ScrubHeader returns a copy of header, such as the Header of a response,
+// scrubbed as the client scrubs the headers of errors and request dumps.
+func (c *Client) ScrubHeader(header http.Header) http.Header {
+	return c.scrubHeader(header)
+}
+
+// scrubHeader returns a copy of header scrubbed by the configured
+// HeaderScrubber, with credentials always redacted.
+func (c *Client) scrubHeader(header http.Header) http.Header {
+	if header == nil {
+		return nil
+	}
+	var scrubber HeaderScrubber = DefaultHeaderAllowList
+	if c.config.HeaderScrubber != nil {
+		scrubber = c.config.HeaderScrubber
+	}
+	scrubbed := scrubber.Scrub(header.Clone())
+	for _, key := range credentialHeaders {
+		values := scrubbed.Values(key)
+		for i := range values {
+			values[i] = redacted
+		}
+	}
+	return scrubbed
+}
@@ -0,0 +1,122 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+func TestHeaderAllowListScrub(t *testing.T) {
+	header := http.Header{
+		"Content-Type":                   {"application/json"},
+		"X-Ratelimit-Remaining-Requests": {"99"},
+		"X-Internal-Route":               {"pod-7", "pod-8"},
+	}
+	scrubbed := openai.HeaderAllowList{"content-type", "x-ratelimit-*"}.Scrub(header)
+	if scrubbed.Get("Content-Type") != "application/json" || scrubbed.Get("X-Ratelimit-Remaining-Requests") != "99" {
+		t.Errorf("allowed headers were scrubbed: %v", scrubbed)
+	}
+	if got := scrubbed.Values("X-Internal-Route"); len(got) != 2 || got[0] != "[REDACTED]" || got[1] != "[REDACTED]" {
+		t.Errorf("X-Internal-Route = %v", got)
+	}
+}
+
+func setupScrubbedErrorServer(t *testing.T, scrubber openai.HeaderScrubber) *openai.Client {
+	t.Helper()
+	client, server := setupOpenAITestServerWithConfig(t, func(config *openai.ClientConfig) {
+		config.HeaderScrubber = scrubber
+		config.CaptureLastRequest = true
+	})
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.Header().Set("Set-Cookie", "__cf_bm=secret")
+		w.Header().Set("X-Internal-Route", "pod-7")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":{"message":"bad request","type":"invalid_request_error"}}`)
+	})
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Internal-Route", "pod-7")
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, `<html>Bad Gateway</html>`)
+	})
+	return client
+}
+
+func TestErrorHeadersScrubbed(t *testing.T) {
+	client := setupScrubbedErrorServer(t, nil)
+	ctx := openai.WithRequestHeaders(context.Background(), http.Header{"X-Tenant": {"acme"}})
+	_, err := client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+		Model:    openai.GPT4oMini,
+		Messages: []openai.ChatCompletionMessage{openai.UserMessage("Hi")},
+	})
+	var apiErr *openai.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v", err)
+	}
+	if apiErr.Header.Get("X-Request-Id") != "req-123" {
+		t.Errorf("X-Request-Id = %q", apiErr.Header.Get("X-Request-Id"))
+	}
+	if apiErr.Header.Get("Set-Cookie") != "[REDACTED]" || apiErr.Header.Get("X-Internal-Route") != "[REDACTED]" {
+		t.Errorf("headers not scrubbed: %v", apiErr.Header)
+	}
+
+	dump := client.DumpLastRequest()
+	if dump.Header.Get("Authorization") != "[REDACTED]" || dump.Header.Get("X-Tenant") != "[REDACTED]" ||
+		dump.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("dump headers = %v", dump.Header)
+	}
+
+	_, err = client.ListModels(context.Background())
+	var reqErr *openai.RequestError
+	if !errors.As(err, &reqErr) || reqErr.Header.Get("X-Internal-Route") != "[REDACTED]" {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestCustomHeaderScrubberKeepsCredentialsRedacted(t *testing.T) {
+	keepAll := openai.HeaderScrubberFunc(func(header http.Header) http.Header { return header })
+	client := setupScrubbedErrorServer(t, keepAll)
+	_, err := client.CreateChatCompletion(context.Background(), openai.ChatCompletionRequest{
+		Model:    openai.GPT4oMini,
+		Messages: []openai.ChatCompletionMessage{openai.UserMessage("Hi")},
+	})
+	var apiErr *openai.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v", err)
+	}
+	if apiErr.Header.Get("X-Internal-Route") != "pod-7" || apiErr.Header.Get("Set-Cookie") != "[REDACTED]" {
+		t.Errorf("headers = %v", apiErr.Header)
+	}
+	if got := client.DumpLastRequest().Header.Get("Authorization"); got != "[REDACTED]" {
+		t.Errorf("Authorization = %q", got)
+	}
+}
+
+func TestResponseHeaderNotScrubbed(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Internal-Route", "pod-7")
+		w.Header().Set("Set-Cookie", "__cf_bm=secret")
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	})
+	models, err := client.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels error: %v", err)
+	}
+	if models.Header().Get("X-Internal-Route") != "pod-7" {
+		t.Errorf("response header was scrubbed: %v", models.Header())
+	}
+
+	scrubbed := client.ScrubHeader(models.Header())
+	if scrubbed.Get("X-Internal-Route") != "[REDACTED]" || scrubbed.Get("Set-Cookie") != "[REDACTED]" {
+		t.Errorf("ScrubHeader() = %v", scrubbed)
+	}
+	if models.Header().Get("Set-Cookie") != "__cf_bm=secret" {
+		t.Error("ScrubHeader modified the response header")
+	}
+}
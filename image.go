@@ -1,8 +1,11 @@
 package openai
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"strconv"
@@ -91,6 +94,13 @@ type ImageResponse struct {
 	Data    []ImageResponseDataInner `json:"data,omitempty"`
 	Usage   ImageResponseUsage       `json:"usage,omitempty"`
 
+	// The settings the images were generated with, returned by gpt-image-1
+	// models, which resolve "auto" requests to actual values.
+	Background   string `json:"background,omitempty"`
+	OutputFormat string `json:"output_format,omitempty"`
+	Quality      string `json:"quality,omitempty"`
+	Size         string `json:"size,omitempty"`
+
 	httpHeader
 }
 
@@ -110,14 +120,30 @@ type ImageResponseUsage struct {
 
 // ImageResponseDataInner represents a response data structure for image API.
 type ImageResponseDataInner struct {
-	URL           string `json:"url,omitempty"`
-	B64JSON       string `json:"b64_json,omitempty"`
+	URL     string `json:"url,omitempty"`
+	B64JSON string `json:"b64_json,omitempty"`
+	// RevisedPrompt is the prompt dall-e-3 actually used after rewriting the
+	// requested one.
 	RevisedPrompt string `json:"revised_prompt,omitempty"`
+	// Seed is the seed the image was sampled with, returned by some
+	// OpenAI-compatible providers.
+	Seed *int64 `json:"seed,omitempty"`
+}
+
+// RevisedPrompts returns the revised prompt of each image, empty for images
+// whose prompt was not rewritten.
+func (r ImageResponse) RevisedPrompts() []string {
+	prompts := make([]string, len(r.Data))
+	for i, image := range r.Data {
+		prompts[i] = image.RevisedPrompt
+	}
+	return prompts
 }
 
 // CreateImage - API call to create an image. This is the main endpoint of the DALL-E API.
 func (c *Client) CreateImage(ctx context.Context, request ImageRequest) (response ImageResponse, err error) {
 	urlSuffix := "/images/generations"
+	request.User = c.resolveUser(ctx, request.User)
 	req, err := c.newRequest(
 		ctx,
 		http.MethodPost,
@@ -128,10 +154,37 @@ func (c *Client) CreateImage(ctx context.Context, request ImageRequest) (respons
 		return
 	}
 
-	err = c.sendRequest(req, &response)
+	err = imageError(c.sendRequest(req, &response))
 	return
 }
 
+// ImageContentPolicyError is returned by the image endpoints when the prompt
+// or the image is rejected by the content policy, which retrying does not
+// fix. It matches ErrImageContentPolicy.
+type ImageContentPolicyError struct {
+	// RevisedPrompt is the prompt as rewritten by the model, when the
+	// response includes it.
+	RevisedPrompt string
+	Err           *APIError
+}
+
+func (e *ImageContentPolicyError) Error() string {
+	return fmt.Sprintf("error, image rejected by the content policy: %v", e.Err)
+}
+
+func (e *ImageContentPolicyError) Unwrap() error {
+	return e.Err
+}
+
+// imageError turns content policy rejections into an ImageContentPolicyError.
+func imageError(err error) error {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.Is(ErrImageContentPolicy) {
+		return &ImageContentPolicyError{RevisedPrompt: apiErr.revisedPrompt, Err: apiErr}
+	}
+	return err
+}
+
 // WrapReader wraps an io.Reader with filename and Content-type.
 func WrapReader(rdr io.Reader, filename string, contentType string) io.Reader {
 	return file{rdr, filename, contentType}
@@ -156,8 +209,45 @@ func (f file) ContentType() string {
 	return f.contentType
 }
 
+// sniffLen is the number of bytes http.DetectContentType considers.
+const sniffLen = 512
+
+var imageExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/webp": ".webp",
+	"image/gif":  ".gif",
+}
+
+// imageUpload prepares an image read from memory or object storage, which
+// has no file name, for upload: the API tells formats apart by file name, so
+// the image is named after field and the format sniffed from its first
+// bytes, such as "image.png". Readers with a name, such as an *os.File or
+// one wrapped with WrapReader, are uploaded as is.
+func imageUpload(r io.Reader, field string) (io.Reader, error) {
+	if named, ok := r.(interface{ Name() string }); ok && named.Name() != "" {
+		return r, nil
+	}
+	buffered := bufio.NewReaderSize(r, sniffLen)
+	head, err := buffered.Peek(sniffLen)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("error, reading %s: %w", field, err)
+	}
+	contentType := ""
+	if typed, ok := r.(interface{ ContentType() string }); ok {
+		contentType = typed.ContentType()
+	}
+	if contentType == "" {
+		contentType = http.DetectContentType(head)
+	}
+	return WrapReader(buffered, field+imageExtensions[contentType], contentType), nil
+}
+
 // ImageEditRequest represents the request structure for the image API.
-// Use WrapReader to wrap an io.Reader with filename and Content-type.
+// Image and Mask can be any io.Reader, such as a *bytes.Reader holding an
+// image generated in memory or the body of an object storage download. Use
+// WrapReader to set their filename and Content-type; readers without a
+// filename are named after the image format detected from their content.
 type ImageEditRequest struct {
 	Image          io.Reader `json:"image,omitempty"`
 	Mask           io.Reader `json:"mask,omitempty"`
@@ -175,16 +265,23 @@ func (c *Client) CreateEditImage(ctx context.Context, request ImageEditRequest)
 	body := &bytes.Buffer{}
 	builder := c.createFormBuilder(body)
 
-	// image, filename verification can be postponed
-	err = builder.CreateFormFileReader("image", request.Image, "")
+	image, err := imageUpload(request.Image, "image")
+	if err != nil {
+		return
+	}
+	err = builder.CreateFormFileReader("image", image, "")
 	if err != nil {
 		return
 	}
 
 	// mask, it is optional
 	if request.Mask != nil {
-		// filename verification can be postponed
-		err = builder.CreateFormFileReader("mask", request.Mask, "")
+		var mask io.Reader
+		mask, err = imageUpload(request.Mask, "mask")
+		if err != nil {
+			return
+		}
+		err = builder.CreateFormFileReader("mask", mask, "")
 		if err != nil {
 			return
 		}
@@ -226,12 +323,12 @@ func (c *Client) CreateEditImage(ctx context.Context, request ImageEditRequest)
 		return
 	}
 
-	err = c.sendRequest(req, &response)
+	err = imageError(c.sendRequest(req, &response))
 	return
 }
 
 // ImageVariRequest represents the request structure for the image API.
-// Use WrapReader to wrap an io.Reader with filename and Content-type.
+// Image can be any io.Reader; see ImageEditRequest.
 type ImageVariRequest struct {
 	Image          io.Reader `json:"image,omitempty"`
 	Model          string    `json:"model,omitempty"`
@@ -247,8 +344,11 @@ func (c *Client) CreateVariImage(ctx context.Context, request ImageVariRequest)
 	body := &bytes.Buffer{}
 	builder := c.createFormBuilder(body)
 
-	// image, filename verification can be postponed
-	err = builder.CreateFormFileReader("image", request.Image, "")
+	image, err := imageUpload(request.Image, "image")
+	if err != nil {
+		return
+	}
+	err = builder.CreateFormFileReader("image", image, "")
 	if err != nil {
 		return
 	}
@@ -284,6 +384,6 @@ func (c *Client) CreateVariImage(ctx context.Context, request ImageVariRequest)
 		return
 	}
 
-	err = c.sendRequest(req, &response)
+	err = imageError(c.sendRequest(req, &response))
 	return
 }
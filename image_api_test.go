@@ -1,13 +1,16 @@
 package openai_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -131,6 +134,101 @@ func TestImageEditWithoutMask(t *testing.T) {
 	checks.NoError(t, err, "CreateImage error")
 }
 
+func TestImageEditFromReaders(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	type upload struct{ filename, contentType, content string }
+	uploads := map[string]upload{}
+	server.RegisterHandler("/v1/images/edits", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for field, headers := range r.MultipartForm.File {
+			f, _ := headers[0].Open()
+			content, _ := io.ReadAll(f)
+			f.Close()
+			uploads[field] = upload{headers[0].Filename, headers[0].Header.Get("Content-Type"), string(content)}
+		}
+		handleEditImageEndpoint(w, r)
+	})
+
+	png := "\x89PNG\r\n\x1a\n" + strings.Repeat("p", 1024)
+	_, err := client.CreateEditImage(context.Background(), openai.ImageEditRequest{
+		Image:  strings.NewReader(png),
+		Mask:   openai.WrapReader(bytes.NewReader([]byte("mask")), "alpha.png", "image/png"),
+		Prompt: "There is a turtle in the pool",
+	})
+	checks.NoError(t, err, "CreateEditImage error")
+
+	if got, want := uploads["image"], (upload{"image.png", "image/png", png}); got != want {
+		t.Errorf("image uploaded as %q (%s, %d bytes), want %q (%s, %d bytes)",
+			got.filename, got.contentType, len(got.content), want.filename, want.contentType, len(want.content))
+	}
+	if got := uploads["mask"]; got.filename != "alpha.png" || got.content != "mask" {
+		t.Errorf("mask uploaded as %+v", got)
+	}
+}
+
+func TestImageContentPolicyError(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	body := `{"error":{"message":"blocked","code":"content_policy_violation",` +
+		`"type":"image_generation_user_error","revised_prompt":"a calm turtle"}}`
+	server.RegisterHandler("/v1/images/generations", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, body)
+	})
+
+	_, err := client.CreateImage(context.Background(), openai.ImageRequest{Prompt: "an angry turtle"})
+	var policyErr *openai.ImageContentPolicyError
+	if !errors.As(err, &policyErr) {
+		t.Fatalf("CreateImage error = %v, want an ImageContentPolicyError", err)
+	}
+	if policyErr.RevisedPrompt != "a calm turtle" || policyErr.Err.Message != "blocked" {
+		t.Errorf("ImageContentPolicyError = %+v", policyErr)
+	}
+	checks.ErrorIs(t, err, openai.ErrImageContentPolicy, "error does not match ErrImageContentPolicy")
+	checks.ErrorIs(t, err, openai.ErrInvalidRequest, "error does not match ErrInvalidRequest")
+
+	server.RegisterHandler("/v1/images/generations", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":{"message":"bad size","type":"invalid_request_error"}}`)
+	})
+	_, err = client.CreateImage(context.Background(), openai.ImageRequest{Prompt: "a turtle"})
+	if errors.As(err, &policyErr) || errors.Is(err, openai.ErrImageContentPolicy) {
+		t.Errorf("invalid size error %v reported as a content policy rejection", err)
+	}
+}
+
+func TestImageResponseMetadata(t *testing.T) {
+	var dallE3 openai.ImageResponse
+	err := json.Unmarshal([]byte(`{"created":1,"data":[`+
+		`{"url":"https://example.com/a.png","revised_prompt":"A turtle swimming in a pool"},`+
+		`{"url":"https://example.com/b.png"}]}`), &dallE3)
+	checks.NoError(t, err, "Unmarshal error")
+	if prompts := dallE3.RevisedPrompts(); len(prompts) != 2 ||
+		prompts[0] != "A turtle swimming in a pool" || prompts[1] != "" {
+		t.Errorf("RevisedPrompts() = %q", prompts)
+	}
+
+	var gptImage openai.ImageResponse
+	err = json.Unmarshal([]byte(`{"created":1,"background":"opaque","output_format":"webp",`+
+		`"quality":"medium","size":"1024x1536","data":[{"b64_json":"aW1n","seed":42}],`+
+		`"usage":{"total_tokens":10,"input_tokens":4,"output_tokens":6}}`), &gptImage)
+	checks.NoError(t, err, "Unmarshal error")
+	if gptImage.Background != openai.CreateImageBackgroundOpaque ||
+		gptImage.OutputFormat != openai.CreateImageOutputFormatWEBP ||
+		gptImage.Quality != openai.CreateImageQualityMedium ||
+		gptImage.Size != openai.CreateImageSize1024x1536 {
+		t.Errorf("image settings not decoded: %+v", gptImage)
+	}
+	if seed := gptImage.Data[0].Seed; seed == nil || *seed != 42 {
+		t.Errorf("Seed = %v, want 42", seed)
+	}
+}
+
 // handleEditImageEndpoint Handles the images endpoint by the test server.
 func handleEditImageEndpoint(w http.ResponseWriter, r *http.Request) {
 	var resBytes []byte
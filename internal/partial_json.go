@@ -0,0 +1,350 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+var ErrInvalidPartialJSON = errors.New("invalid partial JSON")
+
+// ParsePartialJSON parses a JSON document that may be truncated at any point,
+// as produced while streaming tool call arguments or JSON mode content. It
+// returns the value parsed so far using the same types as encoding/json
+// (map[string]any, []any, string, float64, bool and nil) and whether the
+// document was complete.
+//
+// Truncated strings are kept as-is, while object keys without a value and
+// incomplete numbers or literals are dropped. Input that can never become
+// valid JSON returns ErrInvalidPartialJSON.
+func ParsePartialJSON(data []byte) (value any, complete bool, err error) {
+	p := &partialJSONParser{data: data}
+	p.skipSpace()
+	if p.eof() {
+		return nil, false, nil
+	}
+	value, complete, err = p.parseValue()
+	if err != nil {
+		return nil, false, err
+	}
+	if complete {
+		p.skipSpace()
+		if !p.eof() {
+			return nil, false, p.errorf("unexpected trailing data")
+		}
+	}
+	return value, complete, nil
+}
+
+// ParsePartialJSONObject is like ParsePartialJSON for documents whose root is
+// an object. In addition to the fields parsed so far it returns the keys of
+// the top-level fields whose values are fully received, in document order.
+func ParsePartialJSONObject(data []byte) (fields map[string]any, completedKeys []string, complete bool, err error) {
+	p := &partialJSONParser{data: data, trackKeys: true}
+	p.skipSpace()
+	if p.eof() {
+		return map[string]any{}, nil, false, nil
+	}
+	if p.data[p.pos] != '{' {
+		return nil, nil, false, p.errorf("expected object")
+	}
+	value, complete, err := p.parseObject()
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if complete {
+		p.skipSpace()
+		if !p.eof() {
+			return nil, nil, false, p.errorf("unexpected trailing data")
+		}
+	}
+	fields, _ = value.(map[string]any)
+	return fields, p.completedKeys, complete, nil
+}
+
+type partialJSONParser struct {
+	data []byte
+	pos  int
+	// depth is the number of objects and arrays the parser is in.
+	depth int
+
+	trackKeys     bool
+	completedKeys []string
+}
+
+func (p *partialJSONParser) eof() bool {
+	return p.pos >= len(p.data)
+}
+
+func (p *partialJSONParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s at offset %d", ErrInvalidPartialJSON, fmt.Sprintf(format, args...), p.pos)
+}
+
+func (p *partialJSONParser) skipSpace() {
+	for !p.eof() {
+		switch p.data[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+// parseValue parses the value at the current position. A nil value with
+// complete == false means nothing usable could be parsed before the input ran out.
+func (p *partialJSONParser) parseValue() (any, bool, error) {
+	switch c := p.data[p.pos]; {
+	case c == '{':
+		return p.parseObject()
+	case c == '[':
+		return p.parseArray()
+	case c == '"':
+		s, complete, err := p.parseString()
+		if err != nil {
+			return nil, false, err
+		}
+		return s, complete, nil
+	case c == '-' || (c >= '0' && c <= '9'):
+		return p.parseNumber()
+	case c == 't':
+		return p.parseLiteral("true", true)
+	case c == 'f':
+		return p.parseLiteral("false", false)
+	case c == 'n':
+		return p.parseLiteral("null", nil)
+	default:
+		return nil, false, p.errorf("unexpected character %q", c)
+	}
+}
+
+func (p *partialJSONParser) parseObject() (any, bool, error) {
+	p.pos++ // {
+	p.depth++
+	defer func() { p.depth-- }()
+	obj := map[string]any{}
+	for first := true; ; first = false {
+		p.skipSpace()
+		if p.eof() {
+			return obj, false, nil
+		}
+		if p.data[p.pos] == '}' {
+			p.pos++
+			return obj, true, nil
+		}
+		if !first {
+			if p.data[p.pos] != ',' {
+				return nil, false, p.errorf("expected ',' in object")
+			}
+			p.pos++
+			p.skipSpace()
+			if p.eof() {
+				return obj, false, nil
+			}
+		}
+		if p.data[p.pos] != '"' {
+			return nil, false, p.errorf("expected object key")
+		}
+		key, keyComplete, err := p.parseString()
+		if err != nil || !keyComplete {
+			return obj, false, err
+		}
+		p.skipSpace()
+		if p.eof() {
+			return obj, false, nil
+		}
+		if p.data[p.pos] != ':' {
+			return nil, false, p.errorf("expected ':' after object key")
+		}
+		p.pos++
+		p.skipSpace()
+		if p.eof() {
+			return obj, false, nil
+		}
+		value, complete, err := p.parseValue()
+		if err != nil {
+			return nil, false, err
+		}
+		if !complete {
+			if value != nil {
+				obj[key] = value
+			}
+			return obj, false, nil
+		}
+		obj[key] = value
+		if p.trackKeys && p.depth == 1 {
+			p.completedKeys = append(p.completedKeys, key)
+		}
+	}
+}
+
+func (p *partialJSONParser) parseArray() (any, bool, error) {
+	p.pos++ // [
+	p.depth++
+	defer func() { p.depth-- }()
+	arr := []any{}
+	for {
+		p.skipSpace()
+		if p.eof() {
+			return arr, false, nil
+		}
+		if p.data[p.pos] == ']' {
+			p.pos++
+			return arr, true, nil
+		}
+		if len(arr) > 0 {
+			if p.data[p.pos] != ',' {
+				return nil, false, p.errorf("expected ',' in array")
+			}
+			p.pos++
+			p.skipSpace()
+			if p.eof() {
+				return arr, false, nil
+			}
+		}
+		value, complete, err := p.parseValue()
+		if err != nil {
+			return nil, false, err
+		}
+		if !complete {
+			if value != nil {
+				arr = append(arr, value)
+			}
+			return arr, false, nil
+		}
+		arr = append(arr, value)
+	}
+}
+
+func (p *partialJSONParser) parseString() (string, bool, error) {
+	p.pos++ // "
+	var buf []byte
+	for !p.eof() {
+		c := p.data[p.pos]
+		switch {
+		case c == '"':
+			p.pos++
+			return string(buf), true, nil
+		case c == '\\':
+			if p.pos+1 >= len(p.data) {
+				p.pos = len(p.data)
+				return string(buf), false, nil
+			}
+			r, n, ok, err := p.parseEscape()
+			if err != nil {
+				return "", false, err
+			}
+			if !ok {
+				p.pos = len(p.data)
+				return string(buf), false, nil
+			}
+			buf = utf8.AppendRune(buf, r)
+			p.pos += n
+		case c < ' ':
+			return "", false, p.errorf("control character in string")
+		default:
+			buf = append(buf, c)
+			p.pos++
+		}
+	}
+	return string(buf), false, nil
+}
+
+// parseEscape decodes the escape sequence at the current position. ok is
+// false when the sequence is truncated.
+func (p *partialJSONParser) parseEscape() (r rune, n int, ok bool, err error) {
+	switch p.data[p.pos+1] {
+	case '"':
+		return '"', 2, true, nil
+	case '\\':
+		return '\\', 2, true, nil
+	case '/':
+		return '/', 2, true, nil
+	case 'b':
+		return '\b', 2, true, nil
+	case 'f':
+		return '\f', 2, true, nil
+	case 'n':
+		return '\n', 2, true, nil
+	case 'r':
+		return '\r', 2, true, nil
+	case 't':
+		return '\t', 2, true, nil
+	case 'u':
+		r, ok, err = p.parseHex(p.pos + 2)
+		if !ok || err != nil {
+			return 0, 0, ok, err
+		}
+		if !utf16.IsSurrogate(r) {
+			return r, 6, true, nil
+		}
+		// Surrogate pairs are encoded as two consecutive \u escapes.
+		if p.pos+8 > len(p.data) {
+			return 0, 0, false, nil
+		}
+		if p.data[p.pos+6] != '\\' || p.data[p.pos+7] != 'u' {
+			return utf8.RuneError, 6, true, nil
+		}
+		low, lowOK, lowErr := p.parseHex(p.pos + 8)
+		if !lowOK || lowErr != nil {
+			return 0, 0, lowOK, lowErr
+		}
+		return utf16.DecodeRune(r, low), 12, true, nil
+	default:
+		return 0, 0, false, p.errorf("invalid escape sequence")
+	}
+}
+
+func (p *partialJSONParser) parseHex(start int) (rune, bool, error) {
+	const hexLen = 4
+	if start+hexLen > len(p.data) {
+		return 0, false, nil
+	}
+	v, err := strconv.ParseUint(string(p.data[start:start+hexLen]), 16, 32)
+	if err != nil {
+		return 0, false, p.errorf("invalid unicode escape")
+	}
+	return rune(v), true, nil
+}
+
+// parseNumber parses the number at the current position. A number running to
+// the end of the input is dropped, as it may continue in the next chunk: a
+// truncated 12 may be 1234. At the root, the document ends with the number,
+// which is then complete.
+func (p *partialJSONParser) parseNumber() (any, bool, error) {
+	start := p.pos
+	for !p.eof() {
+		c := p.data[p.pos]
+		if (c >= '0' && c <= '9') || c == '-' || c == '+' || c == '.' || c == 'e' || c == 'E' {
+			p.pos++
+			continue
+		}
+		break
+	}
+	v, err := strconv.ParseFloat(string(p.data[start:p.pos]), 64)
+	if p.eof() && (p.depth > 0 || err != nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, p.errorf("invalid number")
+	}
+	return v, true, nil
+}
+
+func (p *partialJSONParser) parseLiteral(literal string, value any) (any, bool, error) {
+	rest := p.data[p.pos:]
+	if len(rest) < len(literal) {
+		if string(rest) == literal[:len(rest)] {
+			p.pos = len(p.data)
+			return nil, false, nil
+		}
+		return nil, false, p.errorf("invalid literal")
+	}
+	if string(rest[:len(literal)]) != literal {
+		return nil, false, p.errorf("invalid literal")
+	}
+	p.pos += len(literal)
+	return value, true, nil
+}
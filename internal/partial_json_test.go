@@ -0,0 +1,72 @@
+package openai_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	openai "github.com/sashabaranov/go-openai/internal"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestParsePartialJSON(t *testing.T) {
+	cases := []struct {
+		input    string
+		want     string
+		complete bool
+	}{
+		{``, `null`, false},
+		{`{`, `{}`, false},
+		{`{"pa`, `{}`, false},
+		{`{"path"`, `{}`, false},
+		{`{"path": `, `{}`, false},
+		{`{"path": "/usr/lo`, `{"path":"/usr/lo"}`, false},
+		{`{"path": "/usr/local", "mode": tr`, `{"path":"/usr/local"}`, false},
+		{`{"path": "/usr/local", "n": 12`, `{"path":"/usr/local"}`, false},
+		{`{"path": "/usr/local", "n": 12,`, `{"n":12,"path":"/usr/local"}`, false},
+		{`[1, 23`, `[1]`, false},
+		{`{"path": "/usr/local", "n": -`, `{"path":"/usr/local"}`, false},
+		{`{"items": [1, 2, {"a": "b\n`, `{"items":[1,2,{"a":"b\n"}]}`, false},
+		{`{"s": "café \u`, `{"s":"café "}`, false},
+		{`{"s": "😀"}`, `{"s":"😀"}`, true},
+		{`{"a": [true, false, null]}`, `{"a":[true,false,null]}`, true},
+		{` [1, "x"] `, `[1,"x"]`, true},
+		{`42`, `42`, true},
+		{`-`, `null`, false},
+		{`"abc"`, `"abc"`, true},
+		{`true`, `true`, true},
+	}
+	for _, tc := range cases {
+		value, complete, err := openai.ParsePartialJSON([]byte(tc.input))
+		checks.NoError(t, err, tc.input)
+		got, err := json.Marshal(value)
+		checks.NoError(t, err)
+		if string(got) != tc.want {
+			t.Errorf("ParsePartialJSON(%q) = %s, want %s", tc.input, got, tc.want)
+		}
+		if complete != tc.complete {
+			t.Errorf("ParsePartialJSON(%q) complete = %v, want %v", tc.input, complete, tc.complete)
+		}
+	}
+}
+
+func TestParsePartialJSONInvalid(t *testing.T) {
+	for _, input := range []string{`{"a" 1}`, `{a}`, `[1 2]`, `{"a": tx}`, `{"a": 1} x`, `{"a": "\q"}`} {
+		_, _, err := openai.ParsePartialJSON([]byte(input))
+		checks.ErrorIs(t, err, openai.ErrInvalidPartialJSON, input)
+	}
+}
+
+func TestParsePartialJSONObject(t *testing.T) {
+	fields, keys, complete, err := openai.ParsePartialJSONObject([]byte(`{"a": {"b": 1}, "c": "x", "d": 4`))
+	checks.NoError(t, err)
+	// The trailing 4 may be truncated, so "d" is left out.
+	if complete || len(fields) != 2 || fields["d"] != nil {
+		t.Fatalf("unexpected result: %v complete=%v", fields, complete)
+	}
+	if len(keys) != 2 || keys[0] != "a" || keys[1] != "c" {
+		t.Fatalf("unexpected completed keys: %v", keys)
+	}
+
+	_, _, _, err = openai.ParsePartialJSONObject([]byte(`[1]`))
+	checks.ErrorIs(t, err, openai.ErrInvalidPartialJSON, "root must be an object")
+}
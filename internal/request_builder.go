@@ -21,6 +21,15 @@ func NewRequestBuilder() *HTTPRequestBuilder {
 	}
 }
 
+// NewRequestBuilderWithMarshaller returns a request builder encoding bodies
+// with marshaller, or with encoding/json when it is nil.
+func NewRequestBuilderWithMarshaller(marshaller Marshaller) *HTTPRequestBuilder {
+	if marshaller == nil {
+		return NewRequestBuilder()
+	}
+	return &HTTPRequestBuilder{marshaller: marshaller}
+}
+
 func (b *HTTPRequestBuilder) Build(
 	ctx context.Context,
 	method string,
@@ -94,3 +94,16 @@ func TestRequestBuilderInvalidURL(t *testing.T) {
 		t.Fatal("expected error for invalid URL")
 	}
 }
+
+func TestNewRequestBuilderWithMarshaller(t *testing.T) {
+	b := NewRequestBuilderWithMarshaller(&failingMarshaller{})
+	_, err := b.Build(context.Background(), http.MethodPost, "/foo", struct{}{}, nil)
+	if !errors.Is(err, errTestMarshallerFailed) {
+		t.Fatalf("Did not use the given marshaller: %v", err)
+	}
+
+	b = NewRequestBuilderWithMarshaller(nil)
+	if _, ok := b.marshaller.(*JSONMarshaller); !ok {
+		t.Fatalf("Did not default to JSONMarshaller: %T", b.marshaller)
+	}
+}
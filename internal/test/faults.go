@@ -0,0 +1,145 @@
+package test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Fault alters how the test server answers a request. It either writes a
+// response itself or calls next, possibly with a wrapped ResponseWriter.
+type Fault func(w http.ResponseWriter, r *http.Request, next handler)
+
+// InjectFaults queues faults for the handler registered at path. Each request
+// to the path consumes the next fault; once the queue is empty requests reach
+// the handler unaltered. A nil fault lets a single request through.
+func (ts *ServerTest) InjectFaults(path string, faults ...Fault) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	path = normalizePath(path)
+	ts.faults[path] = append(ts.faults[path], faults...)
+}
+
+func (ts *ServerTest) nextFault(route string) Fault {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	queue := ts.faults[route]
+	if len(queue) == 0 {
+		return nil
+	}
+	ts.faults[route] = queue[1:]
+	return queue[0]
+}
+
+// Repeat returns n copies of fault, e.g. Repeat(3, ServerError()) for a burst
+// of errors.
+func Repeat(n int, fault Fault) []Fault {
+	faults := make([]Fault, n)
+	for i := range faults {
+		faults[i] = fault
+	}
+	return faults
+}
+
+// RateLimited answers with a 429 carrying a Retry-After header.
+func RateLimited(retryAfter time.Duration) Fault {
+	return func(w http.ResponseWriter, _ *http.Request, _ handler) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+		writeErrorResponse(w, http.StatusTooManyRequests, "rate_limit_exceeded", "Rate limit reached")
+	}
+}
+
+// ServerError answers with a 500.
+func ServerError() Fault {
+	return func(w http.ResponseWriter, _ *http.Request, _ handler) {
+		writeErrorResponse(w, http.StatusInternalServerError, "server_error", "The server had an error")
+	}
+}
+
+// DisconnectAfterEvents lets the handler stream n server-sent events and
+// then drops the connection.
+func DisconnectAfterEvents(n int) Fault {
+	return func(w http.ResponseWriter, r *http.Request, next handler) {
+		ew := &eventWriter{ResponseWriter: w, onEvent: func(ew *eventWriter, _ []byte) {
+			if ew.events == n {
+				ew.flush()
+				panic(http.ErrAbortHandler)
+			}
+		}}
+		next(ew, r)
+	}
+}
+
+// MalformedEventAfter inserts an undecodable event after the first n events
+// written by the handler.
+func MalformedEventAfter(n int) Fault {
+	return func(w http.ResponseWriter, r *http.Request, next handler) {
+		ew := &eventWriter{ResponseWriter: w, onEvent: func(ew *eventWriter, _ []byte) {
+			if ew.events == n {
+				_, _ = ew.ResponseWriter.Write([]byte("data: {\"id\": \"truncated\n\n"))
+			}
+		}}
+		next(ew, r)
+	}
+}
+
+// SlowEvents delays every server-sent event written by the handler.
+func SlowEvents(delay time.Duration) Fault {
+	return func(w http.ResponseWriter, r *http.Request, next handler) {
+		ew := &eventWriter{ResponseWriter: w, onEvent: func(ew *eventWriter, _ []byte) {
+			ew.flush()
+			time.Sleep(delay)
+		}}
+		next(ew, r)
+	}
+}
+
+func writeErrorResponse(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `{"error":{"message":%q,"type":%q,"code":%q}}`, message, code, code)
+}
+
+// eventWriter splits the handler output into server-sent events and calls
+// onEvent before writing each of them. events counts the events written so
+// far.
+type eventWriter struct {
+	http.ResponseWriter
+	onEvent func(ew *eventWriter, event []byte)
+
+	pending []byte
+	events  int
+}
+
+func (ew *eventWriter) Write(p []byte) (int, error) {
+	ew.pending = append(ew.pending, p...)
+	for {
+		end := bytes.Index(ew.pending, []byte("\n\n"))
+		if end < 0 {
+			return len(p), nil
+		}
+		event := ew.pending[:end+2]
+		ew.pending = ew.pending[end+2:]
+		ew.onEvent(ew, event)
+		if _, err := ew.ResponseWriter.Write(event); err != nil {
+			return 0, err
+		}
+		ew.events++
+	}
+}
+
+func (ew *eventWriter) Flush() {
+	if len(ew.pending) > 0 {
+		_, _ = ew.ResponseWriter.Write(ew.pending)
+		ew.pending = nil
+	}
+	ew.flush()
+}
+
+func (ew *eventWriter) flush() {
+	if f, ok := ew.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
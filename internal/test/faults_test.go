@@ -0,0 +1,90 @@
+package test_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	internaltest "github.com/sashabaranov/go-openai/internal/test"
+)
+
+const faultTestEvents = "data: 1\n\ndata: 2\n\ndata: [DONE]\n\n"
+
+func faultTestServer(t *testing.T, faults ...internaltest.Fault) (get func() (*http.Response, string, error), done func()) {
+	t.Helper()
+	ts := internaltest.NewTestServer()
+	ts.RegisterHandler("/v1/stream", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, faultTestEvents)
+	})
+	ts.InjectFaults("/v1/stream", faults...)
+	srv := ts.OpenAITestServer()
+	srv.Start()
+
+	client := &http.Client{Transport: &internaltest.TokenRoundTripper{
+		Token:    internaltest.GetTestToken(),
+		Fallback: srv.Client().Transport,
+	}}
+	get = func() (*http.Response, string, error) {
+		resp, err := client.Get(srv.URL + "/v1/stream")
+		if err != nil {
+			return nil, "", err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		return resp, string(body), err
+	}
+	return get, srv.Close
+}
+
+func TestInjectFaultsErrors(t *testing.T) {
+	faults := append([]internaltest.Fault{internaltest.RateLimited(2 * time.Second)},
+		internaltest.Repeat(2, internaltest.ServerError())...)
+	get, done := faultTestServer(t, faults...)
+	defer done()
+
+	resp, _, err := get()
+	if err != nil {
+		t.Fatalf("request error: %v", err)
+	}
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") != "2" {
+		t.Fatalf("expected 429 with Retry-After, got %d %q", resp.StatusCode, resp.Header.Get("Retry-After"))
+	}
+	for i := 0; i < 2; i++ {
+		if resp, _, err = get(); err != nil || resp.StatusCode != http.StatusInternalServerError {
+			t.Fatalf("expected 500 burst, got %v %v", resp, err)
+		}
+	}
+	if resp, body, err := get(); err != nil || resp.StatusCode != http.StatusOK || body != faultTestEvents {
+		t.Fatalf("expected faults to be exhausted, got %v %q %v", resp, body, err)
+	}
+}
+
+func TestInjectFaultsStreams(t *testing.T) {
+	get, done := faultTestServer(t,
+		internaltest.DisconnectAfterEvents(1),
+		internaltest.MalformedEventAfter(1),
+		internaltest.SlowEvents(10*time.Millisecond),
+	)
+	defer done()
+
+	_, body, err := get()
+	if err == nil || body != "data: 1\n\n" {
+		t.Fatalf("expected disconnect after the first event, got %q %v", body, err)
+	}
+
+	_, body, err = get()
+	if err != nil || body != "data: 1\n\ndata: {\"id\": \"truncated\n\ndata: 2\n\ndata: [DONE]\n\n" {
+		t.Fatalf("expected malformed event after the first one, got %q %v", body, err)
+	}
+
+	start := time.Now()
+	_, body, err = get()
+	if err != nil || body != faultTestEvents {
+		t.Fatalf("expected unaltered events, got %q %v", body, err)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Fatalf("expected events to be delayed, took %v", elapsed)
+	}
+}
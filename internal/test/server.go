@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"regexp"
 	"strings"
+	"sync"
 )
 
 const testAPI = "this-is-my-secure-token-do-not-steal!!"
@@ -16,11 +17,17 @@ func GetTestToken() string {
 
 type ServerTest struct {
 	handlers map[string]handler
+
+	mu     sync.Mutex
+	faults map[string][]Fault
 }
 type handler func(w http.ResponseWriter, r *http.Request)
 
 func NewTestServer() *ServerTest {
-	return &ServerTest{handlers: make(map[string]handler)}
+	return &ServerTest{
+		handlers: make(map[string]handler),
+		faults:   make(map[string][]Fault),
+	}
 }
 
 // HandlerCount returns the number of registered handlers.
@@ -30,16 +37,19 @@ func (ts *ServerTest) HandlerCount() int {
 
 // HasHandler checks if a handler was registered for the given path.
 func (ts *ServerTest) HasHandler(path string) bool {
-	path = strings.ReplaceAll(path, "*", ".*")
+	path = normalizePath(path)
 	_, ok := ts.handlers[path]
 	return ok
 }
 
 func (ts *ServerTest) RegisterHandler(path string, handler handler) {
-	// to make the registered paths friendlier to a regex match in the route handler
-	// in OpenAITestServer
-	path = strings.ReplaceAll(path, "*", ".*")
-	ts.handlers[path] = handler
+	ts.handlers[normalizePath(path)] = handler
+}
+
+// normalizePath makes the registered paths friendlier to a regex match in the
+// route handler in OpenAITestServer.
+func normalizePath(path string) string {
+	return strings.ReplaceAll(path, "*", ".*")
 }
 
 // OpenAITestServer Creates a mocked OpenAI server which can pretend to handle requests during testing.
@@ -59,6 +69,10 @@ func (ts *ServerTest) OpenAITestServer() *httptest.Server {
 			// Adding ^ and $ to make path matching deterministic since go map iteration isn't ordered
 			pattern, _ := regexp.Compile("^" + route + "$")
 			if pattern.MatchString(r.URL.Path) {
+				if fault := ts.nextFault(route); fault != nil {
+					fault(w, r, handler)
+					return
+				}
 				handler(w, r)
 				return
 			}
@@ -0,0 +1,47 @@
+package openai
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// JSONCodec encodes request bodies and decodes response bodies and stream
+// events. It defaults to encoding/json; set ClientConfig.JSONCodec to use a
+// faster implementation, such as sonic or json-iterator, for large embedding
+// and logprob payloads. Implementations must honor the json struct tags and
+// the MarshalJSON and UnmarshalJSON methods of the types in this package.
+type JSONCodec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+	NewDecoder(r io.Reader) JSONDecoder
+}
+
+// JSONDecoder reads successive JSON values from a stream.
+type JSONDecoder interface {
+	Decode(v any) error
+}
+
+// StandardJSONCodec is the JSONCodec backed by encoding/json.
+var StandardJSONCodec JSONCodec = standardJSONCodec{}
+
+type standardJSONCodec struct{}
+
+func (standardJSONCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (standardJSONCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+func (standardJSONCodec) NewDecoder(r io.Reader) JSONDecoder {
+	return json.NewDecoder(r)
+}
+
+// jsonCodec returns the codec configured for the client.
+func (c *Client) jsonCodec() JSONCodec {
+	if c.config.JSONCodec != nil {
+		return c.config.JSONCodec
+	}
+	return StandardJSONCodec
+}
@@ -0,0 +1,83 @@
+package openai_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+// countingCodec counts the calls made to the standard codec.
+type countingCodec struct {
+	mu                           sync.Mutex
+	marshals, unmarshals, decode int
+}
+
+func (c *countingCodec) Marshal(v any) ([]byte, error) {
+	c.mu.Lock()
+	c.marshals++
+	c.mu.Unlock()
+	return openai.StandardJSONCodec.Marshal(v)
+}
+
+func (c *countingCodec) Unmarshal(data []byte, v any) error {
+	c.mu.Lock()
+	c.unmarshals++
+	c.mu.Unlock()
+	return openai.StandardJSONCodec.Unmarshal(data, v)
+}
+
+func (c *countingCodec) NewDecoder(r io.Reader) openai.JSONDecoder {
+	c.mu.Lock()
+	c.decode++
+	c.mu.Unlock()
+	return openai.StandardJSONCodec.NewDecoder(r)
+}
+
+func TestClientJSONCodec(t *testing.T) {
+	codec := &countingCodec{}
+
+	client, server := setupOpenAITestServerWithConfig(t, func(config *openai.ClientConfig) {
+		config.JSONCodec = codec
+	})
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") == "text/event-stream" {
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, `data: {"id":"1","choices":[{"index":0,"delta":{"content":"Hi"}}]}`+"\n\n")
+			fmt.Fprint(w, `data: {"id":"1","choices":[{"index":0,"delta":{"content":"!"}}]}`+"\n\n")
+			fmt.Fprint(w, "data: [DONE]\n\n")
+			return
+		}
+		fmt.Fprint(w, `{"id":"1","choices":[{"index":0,"message":{"role":"assistant","content":"Hi!"}}]}`)
+	})
+	request := openai.ChatCompletionRequest{
+		Model:    openai.GPT4oMini,
+		Messages: []openai.ChatCompletionMessage{openai.UserMessage("Hi")},
+	}
+
+	resp, err := client.CreateChatCompletion(context.Background(), request)
+	checks.NoError(t, err, "CreateChatCompletion error")
+	if resp.Choices[0].Message.Content != "Hi!" {
+		t.Fatalf("content = %q", resp.Choices[0].Message.Content)
+	}
+	if codec.marshals != 1 || codec.decode != 1 {
+		t.Errorf("codec calls = %d marshals, %d decoders; want 1 each", codec.marshals, codec.decode)
+	}
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), request)
+	checks.NoError(t, err, "CreateChatCompletionStream error")
+	defer stream.Close()
+	chunks, err := stream.Collect()
+	checks.NoError(t, err, "stream error")
+	if len(chunks) != 2 {
+		t.Fatalf("got %d chunks, want 2", len(chunks))
+	}
+	if codec.marshals != 2 || codec.unmarshals != 2 {
+		t.Errorf("codec calls = %d marshals, %d unmarshals; want 2 each", codec.marshals, codec.unmarshals)
+	}
+}
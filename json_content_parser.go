@@ -0,0 +1,100 @@
+package openai
+
+import (
+	"encoding/json"
+	"strings"
+
+	utils "github.com/sashabaranov/go-openai/internal"
+)
+
+// JSONFieldEvent reports a top-level field of a streamed JSON object whose
+// value has been fully received.
+type JSONFieldEvent struct {
+	Key   string
+	Value any
+}
+
+// JSONContentParser incrementally parses the content of a streamed chat
+// completion produced with a json_object or json_schema response format.
+// Each call to Write or Add returns the top-level fields that were completed
+// by the new content, and Snapshot/Unmarshal expose the partially received
+// object at any point, enabling progressive rendering of structured answers.
+//
+// The accumulated content is re-parsed on every update, which is cheap for
+// the response sizes JSON mode is typically used for.
+type JSONContentParser struct {
+	content  strings.Builder
+	fields   map[string]any
+	emitted  map[string]bool
+	complete bool
+}
+
+// NewJSONContentParser creates an empty JSONContentParser.
+func NewJSONContentParser() *JSONContentParser {
+	return &JSONContentParser{
+		fields:  map[string]any{},
+		emitted: map[string]bool{},
+	}
+}
+
+// Add consumes the content delta of the first choice of a stream chunk.
+func (p *JSONContentParser) Add(chunk ChatCompletionStreamResponse) ([]JSONFieldEvent, error) {
+	for _, choice := range chunk.Choices {
+		if choice.Index == 0 {
+			return p.Write(choice.Delta.Content)
+		}
+	}
+	return nil, nil
+}
+
+// Write appends a content delta and returns the fields completed by it.
+func (p *JSONContentParser) Write(delta string) ([]JSONFieldEvent, error) {
+	if delta == "" {
+		return nil, nil
+	}
+	p.content.WriteString(delta)
+
+	fields, completedKeys, complete, err := utils.ParsePartialJSONObject([]byte(p.content.String()))
+	if err != nil {
+		return nil, err
+	}
+	p.fields = fields
+	p.complete = complete
+
+	var events []JSONFieldEvent
+	for _, key := range completedKeys {
+		if p.emitted[key] {
+			continue
+		}
+		p.emitted[key] = true
+		events = append(events, JSONFieldEvent{Key: key, Value: fields[key]})
+	}
+	return events, nil
+}
+
+// Content returns the raw content received so far.
+func (p *JSONContentParser) Content() string {
+	return p.content.String()
+}
+
+// Complete reports whether the whole JSON object has been received.
+func (p *JSONContentParser) Complete() bool {
+	return p.complete
+}
+
+// Snapshot returns the fields parsed so far, including partially received
+// string values.
+func (p *JSONContentParser) Snapshot() map[string]any {
+	return p.fields
+}
+
+// Unmarshal decodes the partially received object into v. Fields that have
+// not been received yet are left untouched, so calling it after every update
+// yields a progressively filled struct.
+func (p *JSONContentParser) Unmarshal(v any) error {
+	data, err := json.Marshal(p.fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,58 @@
+package openai_test
+
+import (
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func contentChunk(content string) openai.ChatCompletionStreamResponse {
+	return openai.ChatCompletionStreamResponse{
+		Choices: []openai.ChatCompletionStreamChoice{
+			{Delta: openai.ChatCompletionStreamChoiceDelta{Content: content}},
+		},
+	}
+}
+
+func TestJSONContentParser(t *testing.T) {
+	type answer struct {
+		Title string   `json:"title"`
+		Tags  []string `json:"tags"`
+		Score float64  `json:"score"`
+	}
+
+	parser := openai.NewJSONContentParser()
+	var completed []string
+	var snapshot answer
+	for _, delta := range []string{`{"title": "Go `, `concurrency", "ta`, `gs": ["go", "ch`, `an"], "score": 0.`, `9}`} {
+		events, err := parser.Add(contentChunk(delta))
+		checks.NoError(t, err, "Add returned error")
+		for _, e := range events {
+			completed = append(completed, e.Key)
+		}
+		checks.NoError(t, parser.Unmarshal(&snapshot))
+		if delta == `{"title": "Go ` && snapshot.Title != "Go " {
+			t.Fatalf("expected partial title, got %q", snapshot.Title)
+		}
+	}
+
+	if !parser.Complete() {
+		t.Fatal("expected parser to be complete")
+	}
+	if len(completed) != 3 || completed[0] != "title" || completed[1] != "tags" || completed[2] != "score" {
+		t.Fatalf("unexpected completed fields: %v", completed)
+	}
+	if snapshot.Title != "Go concurrency" || len(snapshot.Tags) != 2 || snapshot.Score != 0.9 {
+		t.Fatalf("unexpected snapshot: %+v", snapshot)
+	}
+	if parser.Content() != `{"title": "Go concurrency", "tags": ["go", "chan"], "score": 0.9}` {
+		t.Fatalf("unexpected content: %s", parser.Content())
+	}
+}
+
+func TestJSONContentParserInvalid(t *testing.T) {
+	parser := openai.NewJSONContentParser()
+	_, err := parser.Write(`Sure! Here is your JSON`)
+	checks.HasError(t, err, "Write should fail on non JSON content")
+}
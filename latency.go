@@ -0,0 +1,165 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	defaultLatencyWindow = 100
+	// minLatencySamples is the number of samples a model needs before its
+	// latency is used for admission.
+	minLatencySamples = 10
+)
+
+// ErrDeadlineTooShort is returned, when ClientConfig.DeadlineAdmission is set,
+// for requests whose context deadline is shorter than the median latency
+// observed for their model.
+var ErrDeadlineTooShort = errors.New("context deadline is shorter than the observed latency")
+
+// LatencyTracker records the latency of successful non-streaming requests
+// per model over a sliding window of recent requests. It is safe for
+// concurrent use.
+type LatencyTracker struct {
+	window int
+
+	mu     sync.Mutex
+	models map[string]*latencyWindow
+}
+
+type latencyWindow struct {
+	samples []time.Duration
+	next    int
+	count   int64
+}
+
+// LatencyStats summarizes the latencies observed for a model.
+type LatencyStats struct {
+	// Count is the total number of observed requests, including those that
+	// left the window.
+	Count int64
+	P50   time.Duration
+	P90   time.Duration
+	P99   time.Duration
+}
+
+// NewLatencyTracker returns a tracker keeping the last window samples of each
+// model, 100 by default.
+func NewLatencyTracker(window int) *LatencyTracker {
+	if window <= 0 {
+		window = defaultLatencyWindow
+	}
+	return &LatencyTracker{window: window, models: make(map[string]*latencyWindow)}
+}
+
+// Observe records the latency of a request to model.
+func (t *LatencyTracker) Observe(model string, latency time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	w, ok := t.models[model]
+	if !ok {
+		w = &latencyWindow{}
+		t.models[model] = w
+	}
+	if len(w.samples) < t.window {
+		w.samples = append(w.samples, latency)
+	} else {
+		w.samples[w.next] = latency
+		w.next = (w.next + 1) % t.window
+	}
+	w.count++
+}
+
+// Percentile returns the p-th percentile, between 0 and 1, of the latencies
+// in the window of model, reporting false when none were observed.
+func (t *LatencyTracker) Percentile(model string, p float64) (time.Duration, bool) {
+	sorted, _ := t.sorted(model)
+	if len(sorted) == 0 {
+		return 0, false
+	}
+	return percentile(sorted, p), true
+}
+
+// Stats returns the latency statistics of every observed model.
+func (t *LatencyTracker) Stats() map[string]LatencyStats {
+	t.mu.Lock()
+	models := make([]string, 0, len(t.models))
+	for model := range t.models {
+		models = append(models, model)
+	}
+	t.mu.Unlock()
+
+	stats := make(map[string]LatencyStats, len(models))
+	for _, model := range models {
+		sorted, count := t.sorted(model)
+		stats[model] = LatencyStats{
+			Count: count,
+			P50:   percentile(sorted, 0.5),
+			P90:   percentile(sorted, 0.9),
+			P99:   percentile(sorted, 0.99),
+		}
+	}
+	return stats
+}
+
+// sorted returns a sorted copy of the window of model and its total count.
+func (t *LatencyTracker) sorted(model string) ([]time.Duration, int64) {
+	t.mu.Lock()
+	w, ok := t.models[model]
+	if !ok {
+		t.mu.Unlock()
+		return nil, 0
+	}
+	sorted := append([]time.Duration(nil), w.samples...)
+	count := w.count
+	t.mu.Unlock()
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted, count
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	return sorted[int(p*float64(len(sorted)-1))]
+}
+
+// LatencyTracker returns the tracker of the latencies observed by the client.
+func (c *Client) LatencyTracker() *LatencyTracker {
+	return c.latencies
+}
+
+// admit fails with ErrDeadlineTooShort when ClientConfig.DeadlineAdmission is
+// set and the deadline of ctx leaves less time than the median latency of
+// model.
+func (c *Client) admit(ctx context.Context, model string) error {
+	if !c.config.DeadlineAdmission {
+		return nil
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return nil
+	}
+	sorted, _ := c.latencies.sorted(model)
+	if len(sorted) < minLatencySamples {
+		return nil
+	}
+	p50 := percentile(sorted, 0.5)
+	if remaining := time.Until(deadline); remaining < p50 {
+		return fmt.Errorf("%w: %v left, p50 latency of %s is %v", ErrDeadlineTooShort, remaining, model, p50)
+	}
+	return nil
+}
+
+// observeLatency records the latency of a successful request started at
+// start.
+func (c *Client) observeLatency(model string, start time.Time, err error) {
+	if err == nil {
+		c.latencies.Observe(model, time.Since(start))
+	}
+}
@@ -0,0 +1,77 @@
+package openai_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestLatencyTracker(t *testing.T) {
+	tracker := openai.NewLatencyTracker(10)
+	if _, ok := tracker.Percentile(openai.GPT4o, 0.5); ok {
+		t.Error("expected no percentile without samples")
+	}
+
+	for i := 1; i <= 20; i++ {
+		tracker.Observe(openai.GPT4o, time.Duration(i)*time.Millisecond)
+	}
+	// Only the last 10 samples, 11ms to 20ms, are kept.
+	p50, ok := tracker.Percentile(openai.GPT4o, 0.5)
+	if !ok || p50 != 15*time.Millisecond {
+		t.Errorf("p50 = %v, want 15ms", p50)
+	}
+
+	stats := tracker.Stats()[openai.GPT4o]
+	if stats.Count != 20 || stats.P90 != 19*time.Millisecond || stats.P99 != 19*time.Millisecond {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestDeadlineAdmission(t *testing.T) {
+	hits := 0
+	tracker := openai.NewLatencyTracker(0)
+
+	client, server := setupOpenAITestServerWithConfig(t, func(config *openai.ClientConfig) {
+		config.LatencyTracker = tracker
+		config.DeadlineAdmission = true
+	})
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		hits++
+		fmt.Fprint(w, `{"id":"chatcmpl-1","object":"chat.completion","choices":[]}`)
+	})
+	if client.LatencyTracker() != tracker {
+		t.Fatal("expected the client to use the configured tracker")
+	}
+
+	request := openai.ChatCompletionRequest{
+		Model:    openai.GPT4o,
+		Messages: []openai.ChatCompletionMessage{openai.UserMessage("Hello")},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// Requests are admitted until enough latencies have been observed.
+	_, err := client.CreateChatCompletion(ctx, request)
+	checks.NoError(t, err)
+	if stats := tracker.Stats()[openai.GPT4o]; stats.Count != 1 {
+		t.Errorf("expected the request latency to be recorded, got %+v", stats)
+	}
+
+	for i := 0; i < 10; i++ {
+		tracker.Observe(openai.GPT4o, time.Second)
+	}
+	_, err = client.CreateChatCompletion(ctx, request)
+	checks.ErrorIs(t, err, openai.ErrDeadlineTooShort)
+	if hits != 1 {
+		t.Errorf("expected the doomed request not to be sent, got %d requests", hits)
+	}
+
+	// Requests without a deadline are always admitted.
+	_, err = client.CreateChatCompletion(context.Background(), request)
+	checks.NoError(t, err)
+}
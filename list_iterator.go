@@ -0,0 +1,250 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ListIterator iterates over the objects of a list endpoint, requesting the
+// following pages as needed. Each page is decoded incrementally, so objects
+// are delivered as they are parsed rather than after the whole page has been
+// read, which keeps memory flat for lists of thousands of files or models.
+//
+//	it := client.IterateFiles(ctx, openai.ListOptions{})
+//	defer it.Close()
+//	for it.Next() {
+//		file := it.Current()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type ListIterator[T any] struct {
+	client *Client
+	ctx    context.Context
+	path   string
+	opts   ListOptions
+	idOf   func(T) string
+
+	body    io.ReadCloser
+	decoder *json.Decoder
+	inData  bool
+	header  http.Header
+
+	started    bool
+	hasMore    bool
+	pageLastID string
+	lastID     string
+	pageItems  int
+	pages      int
+
+	current T
+	err     error
+}
+
+func newListIterator[T any](
+	ctx context.Context,
+	c *Client,
+	path string,
+	opts ListOptions,
+	idOf func(T) string,
+) *ListIterator[T] {
+	return &ListIterator[T]{client: c, ctx: ctx, path: path, opts: opts, idOf: idOf}
+}
+
+// IterateFiles iterates over the files of the organization, following the
+// After cursor from page to page. opts.Limit sets the page size.
+func (c *Client) IterateFiles(ctx context.Context, opts ListOptions) *ListIterator[File] {
+	return newListIterator(ctx, c, "/files", opts, func(f File) string { return f.ID })
+}
+
+// IterateModels iterates over the available models. Unlike ListModels, it
+// is not cached.
+func (c *Client) IterateModels(ctx context.Context) *ListIterator[Model] {
+	return newListIterator(ctx, c, "/models", ListOptions{}, func(m Model) string { return m.ID })
+}
+
+// IterateBatches iterates over the batches of the organization, following
+// the After cursor from page to page. opts.Limit sets the page size.
+func (c *Client) IterateBatches(ctx context.Context, opts ListOptions) *ListIterator[Batch] {
+	return newListIterator(ctx, c, "/batches", opts, func(b Batch) string { return b.ID })
+}
+
+// Next advances to the next object, which is then available through
+// Current. It returns false when the list is exhausted or an error occurred;
+// use Err to tell the two apart.
+func (it *ListIterator[T]) Next() bool {
+	for it.err == nil {
+		if it.decoder == nil {
+			if it.started && !it.nextPage() {
+				return false
+			}
+			it.started = true
+			it.err = it.openPage()
+			continue
+		}
+
+		if it.inData {
+			if it.decoder.More() {
+				var item T
+				if it.err = it.decodeItem(&item); it.err != nil {
+					break
+				}
+				it.current = item
+				it.lastID = it.idOf(item)
+				it.pageItems++
+				return true
+			}
+			it.err = it.expectDelim(']')
+			it.inData = false
+			continue
+		}
+
+		if !it.decoder.More() {
+			it.err = it.expectDelim('}')
+			it.closeBody()
+			continue
+		}
+		it.err = it.readField()
+	}
+	it.closeBody()
+	return false
+}
+
+// Current returns the object read by the last call to Next.
+func (it *ListIterator[T]) Current() T {
+	return it.current
+}
+
+// Err returns the error that stopped iteration, if any.
+func (it *ListIterator[T]) Err() error {
+	return it.err
+}
+
+// Pages returns the number of pages requested so far.
+func (it *ListIterator[T]) Pages() int {
+	return it.pages
+}
+
+// Header returns the HTTP response headers of the last page requested.
+func (it *ListIterator[T]) Header() http.Header {
+	return it.header
+}
+
+// Close releases the page being read. It only needs to be called when
+// iteration stops before the list is exhausted.
+func (it *ListIterator[T]) Close() error {
+	return it.closeBody()
+}
+
+// nextPage sets up the cursor of the next page, reporting false when there
+// is none.
+func (it *ListIterator[T]) nextPage() bool {
+	cursor := it.pageLastID
+	if cursor == "" {
+		cursor = it.lastID
+	}
+	// Stop on empty pages and cursors that do not advance rather than
+	// requesting the same page forever.
+	if !it.hasMore || it.pageItems == 0 || cursor == "" || (it.opts.After != nil && *it.opts.After == cursor) {
+		return false
+	}
+	it.opts.After = &cursor
+	return true
+}
+
+func (it *ListIterator[T]) openPage() error {
+	req, err := it.client.newRequest(it.ctx, http.MethodGet, it.client.fullURL(withQuery(it.path, it.opts.values())))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := it.client.sendRequestRaw(req)
+	if err != nil {
+		return err
+	}
+	it.pages++
+	it.body = resp.ReadCloser
+	it.header = resp.Header()
+	it.decoder = json.NewDecoder(resp)
+	it.hasMore, it.pageLastID, it.pageItems = false, "", 0
+	return it.expectDelim('{')
+}
+
+// readField reads a field of the list object, entering its data array.
+func (it *ListIterator[T]) readField() error {
+	token, err := it.decoder.Token()
+	if err != nil {
+		return it.decodeError(err)
+	}
+	switch token {
+	case "data":
+		if err = it.expectDelim('['); err == nil {
+			it.inData = true
+		}
+		return err
+	case "has_more":
+		err = it.decoder.Decode(&it.hasMore)
+	case "last_id":
+		var lastID *string
+		if err = it.decoder.Decode(&lastID); err == nil && lastID != nil {
+			it.pageLastID = *lastID
+		}
+	default:
+		var skipped json.RawMessage
+		err = it.decoder.Decode(&skipped)
+	}
+	if err != nil {
+		return it.decodeError(err)
+	}
+	return nil
+}
+
+// decodeItem decodes the next object of the data array, with the client's
+// JSONCodec when one is configured.
+func (it *ListIterator[T]) decodeItem(item *T) error {
+	codec := it.client.jsonCodec()
+	if codec == StandardJSONCodec {
+		if err := it.decoder.Decode(item); err != nil {
+			return it.decodeError(err)
+		}
+		return nil
+	}
+	var raw json.RawMessage
+	if err := it.decoder.Decode(&raw); err != nil {
+		return it.decodeError(err)
+	}
+	return codec.Unmarshal(raw, item)
+}
+
+func (it *ListIterator[T]) expectDelim(delim json.Delim) error {
+	token, err := it.decoder.Token()
+	if err != nil {
+		return it.decodeError(err)
+	}
+	if token != delim {
+		return fmt.Errorf("error, decoding list page %d: expected %v, got %v", it.pages, delim, token)
+	}
+	return nil
+}
+
+func (it *ListIterator[T]) decodeError(err error) error {
+	if errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+	return fmt.Errorf("error, decoding list page %d: %w", it.pages, err)
+}
+
+func (it *ListIterator[T]) closeBody() error {
+	it.decoder = nil
+	it.inData = false
+	if it.body == nil {
+		return nil
+	}
+	err := it.body.Close()
+	it.body = nil
+	return err
+}
@@ -0,0 +1,115 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestIterateFiles(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	var afters []string
+	server.RegisterHandler("/v1/files", func(w http.ResponseWriter, r *http.Request) {
+		after := r.URL.Query().Get("after")
+		afters = append(afters, after)
+		if r.URL.Query().Get("limit") != "2" {
+			t.Errorf("limit = %q, want 2", r.URL.Query().Get("limit"))
+		}
+		switch after {
+		case "":
+			fmt.Fprint(w, `{"object":"list","data":[{"id":"file-1"},{"id":"file-2"}],"has_more":true,"extra":{"a":[1]}}`)
+		case "file-2":
+			fmt.Fprint(w, `{"object":"list","has_more":false,"data":[{"id":"file-3"}],"last_id":"file-3"}`)
+		default:
+			t.Errorf("unexpected cursor %q", after)
+		}
+	})
+
+	it := client.IterateFiles(context.Background(), openai.ListOptions{Limit: intPtr(2)})
+	defer it.Close()
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Current().ID)
+	}
+	checks.NoError(t, it.Err(), "iteration error")
+	if fmt.Sprint(ids) != "[file-1 file-2 file-3]" {
+		t.Errorf("ids = %v", ids)
+	}
+	if it.Pages() != 2 || fmt.Sprint(afters) != "[ file-2]" {
+		t.Errorf("pages = %d, cursors = %q", it.Pages(), afters)
+	}
+}
+
+func TestIterateModelsDeliversItemsAsTheyParse(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	received := make(chan struct{})
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"object":"list","data":[{"id":"gpt-4o"},`)
+		w.(http.Flusher).Flush()
+		select {
+		case <-received:
+		case <-time.After(5 * time.Second):
+			t.Error("first model was not delivered before the page was complete")
+		}
+		fmt.Fprint(w, `{"id":"gpt-4o-mini"}]}`)
+	})
+
+	it := client.IterateModels(context.Background())
+	defer it.Close()
+	if !it.Next() || it.Current().ID != "gpt-4o" {
+		t.Fatalf("first model = %+v, err = %v", it.Current(), it.Err())
+	}
+	close(received)
+	if !it.Next() || it.Current().ID != "gpt-4o-mini" {
+		t.Fatalf("second model = %+v, err = %v", it.Current(), it.Err())
+	}
+	if it.Next() {
+		t.Fatalf("unexpected model %+v", it.Current())
+	}
+	checks.NoError(t, it.Err(), "iteration error")
+}
+
+func TestListIteratorErrors(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/batches", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("after") == "" {
+			fmt.Fprint(w, `{"object":"list","data":[{"id":"batch-1"}],"has_more":true}`)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"error":{"message":"boom","type":"server_error"}}`)
+	})
+	server.RegisterHandler("/v1/files", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"object":"list","data":[{"id":"file-1"},{"id":`)
+	})
+
+	it := client.IterateBatches(context.Background(), openai.ListOptions{})
+	if !it.Next() || it.Current().ID != "batch-1" {
+		t.Fatalf("first batch = %+v, err = %v", it.Current(), it.Err())
+	}
+	if it.Next() {
+		t.Fatal("Next should fail on the second page")
+	}
+	var apiErr *openai.APIError
+	if !errors.As(it.Err(), &apiErr) || apiErr.HTTPStatusCode != http.StatusInternalServerError {
+		t.Errorf("Err() = %v, want a 500 APIError", it.Err())
+	}
+
+	files := client.IterateFiles(context.Background(), openai.ListOptions{})
+	if !files.Next() {
+		t.Fatalf("first file was not delivered: %v", files.Err())
+	}
+	if files.Next() {
+		t.Fatal("Next should fail on a truncated page")
+	}
+	checks.HasError(t, files.Err(), "truncated page should fail")
+}
@@ -0,0 +1,47 @@
+package openai
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// ListOptions holds the cursor-based pagination parameters shared by the list
+// endpoints. Nil fields are omitted from the query string.
+type ListOptions struct {
+	// Limit is the number of objects to return, between 1 and 100.
+	Limit *int
+	// Order sorts by created_at, either "asc" or "desc".
+	Order *string
+	// After is the ID of the object after which to start listing.
+	After *string
+	// Before is the ID of the object before which to start listing.
+	Before *string
+}
+
+// Pagination is the former name of ListOptions.
+type Pagination = ListOptions
+
+func (o ListOptions) values() url.Values {
+	values := url.Values{}
+	if o.Limit != nil {
+		values.Add("limit", fmt.Sprintf("%d", *o.Limit))
+	}
+	if o.Order != nil {
+		values.Add("order", *o.Order)
+	}
+	if o.After != nil {
+		values.Add("after", *o.After)
+	}
+	if o.Before != nil {
+		values.Add("before", *o.Before)
+	}
+	return values
+}
+
+// withQuery appends the encoded values to path, if there are any.
+func withQuery(path string, values url.Values) string {
+	if len(values) == 0 {
+		return path
+	}
+	return path + "?" + values.Encode()
+}
@@ -0,0 +1,82 @@
+package openai_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestListOptionsQuery(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	queries := map[string]string{}
+	record := func(w http.ResponseWriter, r *http.Request) {
+		queries[r.URL.Path] = r.URL.RawQuery
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	}
+	server.RegisterHandler("/v1/fine_tuning/jobs", record)
+	paths := []string{
+		"/v1/files",
+		"/v1/batches",
+		"/v1/assistants",
+		"/v1/assistants/asst_1/files",
+		"/v1/threads/thread_1/messages",
+		"/v1/threads/thread_1/runs",
+		"/v1/threads/thread_1/runs/run_1/steps",
+		"/v1/vector_stores",
+		"/v1/vector_stores/vs_1/files",
+		"/v1/vector_stores/vs_1/file_batches/batch_1/files",
+	}
+	for _, path := range paths {
+		server.RegisterHandler(path, record)
+	}
+
+	limit, order, after, before := 10, "desc", "obj_a", "obj_b"
+	opts := openai.ListOptions{Limit: &limit, Order: &order, After: &after, Before: &before}
+	ctx := context.Background()
+	var err error
+	_, err = client.ListFilesWithOptions(ctx, opts)
+	checks.NoError(t, err)
+	_, err = client.ListBatchWithOptions(ctx, opts)
+	checks.NoError(t, err)
+	_, err = client.ListAssistantsWithOptions(ctx, opts)
+	checks.NoError(t, err)
+	_, err = client.ListAssistantFilesWithOptions(ctx, "asst_1", opts)
+	checks.NoError(t, err)
+	_, err = client.ListMessagesWithOptions(ctx, "thread_1", opts)
+	checks.NoError(t, err)
+	_, err = client.ListRuns(ctx, "thread_1", opts)
+	checks.NoError(t, err)
+	_, err = client.ListRunSteps(ctx, "thread_1", "run_1", opts)
+	checks.NoError(t, err)
+	_, err = client.ListVectorStores(ctx, opts)
+	checks.NoError(t, err)
+	_, err = client.ListVectorStoreFiles(ctx, "vs_1", opts)
+	checks.NoError(t, err)
+	_, err = client.ListVectorStoreFilesInBatch(ctx, "vs_1", "batch_1", opts)
+	checks.NoError(t, err)
+
+	const want = "after=obj_a&before=obj_b&limit=10&order=desc"
+	for _, path := range paths {
+		if got, ok := queries[path]; !ok || got != want {
+			t.Errorf("%s: expected query %q, got %q", path, want, got)
+		}
+	}
+
+	_, err = client.ListFineTuningJobs(ctx, openai.ListFineTuningJobsOptions{Limit: &limit, After: &after})
+	checks.NoError(t, err)
+	if got := queries["/v1/fine_tuning/jobs"]; got != "after=obj_a&limit=10" {
+		t.Errorf("/v1/fine_tuning/jobs: expected query %q, got %q", "after=obj_a&limit=10", got)
+	}
+
+	_, err = client.ListFilesWithOptions(ctx, openai.ListOptions{})
+	checks.NoError(t, err)
+	if got := queries["/v1/files"]; got != "" {
+		t.Errorf("expected no query for empty options, got %q", got)
+	}
+}
@@ -0,0 +1,110 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+const (
+	LogitBiasMin = -100
+	LogitBiasMax = 100
+)
+
+var (
+	ErrLogitBiasOutOfRange = errors.New("logit bias must be between -100 and 100")
+	ErrLogitBiasEmptyText  = errors.New("logit bias text produced no tokens")
+)
+
+// LogitBiasBuilder builds logit_bias maps from plain strings by tokenizing
+// them with the tokenizer of the target model, since the API only accepts
+// token IDs as keys.
+//
+// The API biases tokens, not words: a word split into several tokens, such
+// as "Parisian" into "Par", "isian", has every one of them biased, and so is
+// every other word sharing one of them. Banning a multi-token word thus bans
+// the words starting or ending like it, and boosting it boosts its pieces
+// wherever they appear. Check how the tokenizer splits a word before biasing
+// it, and prefer words that are a single token.
+//
+//	bias, err := openai.NewLogitBiasBuilder(tokenizer).
+//		Ban("Sorry").
+//		Word("Paris", 10).
+//		Build()
+type LogitBiasBuilder struct {
+	tokenizer Tokenizer
+	bias      map[int]int
+	err       error
+}
+
+// NewLogitBiasBuilder creates a builder that tokenizes text with tokenizer.
+func NewLogitBiasBuilder(tokenizer Tokenizer) *LogitBiasBuilder {
+	return &LogitBiasBuilder{
+		tokenizer: tokenizer,
+		bias:      make(map[int]int),
+	}
+}
+
+// Text applies bias to every token of text, exactly as written. When text
+// is several tokens long, each of them is biased on its own, wherever it
+// appears in the output.
+func (b *LogitBiasBuilder) Text(text string, bias int) *LogitBiasBuilder {
+	if b.err != nil {
+		return b
+	}
+	if bias < LogitBiasMin || bias > LogitBiasMax {
+		b.err = fmt.Errorf("%w: %q has bias %d", ErrLogitBiasOutOfRange, text, bias)
+		return b
+	}
+	tokens, err := b.tokenizer.Encode(text)
+	if err != nil {
+		b.err = fmt.Errorf("tokenizing %q: %w", text, err)
+		return b
+	}
+	if len(tokens) == 0 {
+		b.err = fmt.Errorf("%w: %q", ErrLogitBiasEmptyText, text)
+		return b
+	}
+	for _, token := range tokens {
+		b.bias[token] = bias
+	}
+	return b
+}
+
+// Word applies bias to word both at the start of a text and after a space,
+// since BPE tokenizers encode the two forms as different tokens. Like Text,
+// it biases every token of a multi-token word.
+func (b *LogitBiasBuilder) Word(word string, bias int) *LogitBiasBuilder {
+	return b.Text(word, bias).Text(" "+word, bias)
+}
+
+// Ban prevents the model from producing word. For a multi-token word, it
+// bans each of its tokens, and so any other word using one of them.
+func (b *LogitBiasBuilder) Ban(word string) *LogitBiasBuilder {
+	return b.Word(word, LogitBiasMin)
+}
+
+// Token applies bias to a token ID directly.
+func (b *LogitBiasBuilder) Token(token, bias int) *LogitBiasBuilder {
+	if b.err != nil {
+		return b
+	}
+	if bias < LogitBiasMin || bias > LogitBiasMax {
+		b.err = fmt.Errorf("%w: token %d has bias %d", ErrLogitBiasOutOfRange, token, bias)
+		return b
+	}
+	b.bias[token] = bias
+	return b
+}
+
+// Build returns the logit_bias map, or the first error encountered.
+func (b *LogitBiasBuilder) Build() (map[string]int, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	result := make(map[string]int, len(b.bias))
+	for token, bias := range b.bias {
+		result[strconv.Itoa(token)] = bias
+	}
+	return result, nil
+}
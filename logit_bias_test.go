@@ -0,0 +1,98 @@
+package openai_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+// fakeTokenizer assigns one token per whitespace separated word, with a
+// different ID for words preceded by a space.
+var fakeTokenizer = openai.TokenizerFunc(func(text string) ([]int, error) {
+	if strings.Contains(text, "\x00") {
+		return nil, errors.New("invalid text")
+	}
+	var tokens []int
+	for i, word := range strings.Fields(text) {
+		id := len(word) * 100
+		if i > 0 || strings.HasPrefix(text, " ") {
+			id++
+		}
+		tokens = append(tokens, id)
+	}
+	return tokens, nil
+})
+
+// splittingTokenizer is fakeTokenizer, except that words longer than 6
+// letters are split after the sixth, into a second token whose ID ends with 2.
+var splittingTokenizer = openai.TokenizerFunc(func(text string) ([]int, error) {
+	var tokens []int
+	for i, word := range strings.Fields(text) {
+		var rest string
+		if len(word) > 6 {
+			word, rest = word[:6], word[6:]
+		}
+		id := len(word) * 100
+		if i > 0 || strings.HasPrefix(text, " ") {
+			id++
+		}
+		tokens = append(tokens, id)
+		if rest != "" {
+			tokens = append(tokens, len(rest)*100+2)
+		}
+	}
+	return tokens, nil
+})
+
+func TestLogitBiasBuilder(t *testing.T) {
+	bias, err := openai.NewLogitBiasBuilder(fakeTokenizer).
+		Ban("Sorry").
+		Text("Paris", 10).
+		Token(42, 5).
+		Build()
+	checks.NoError(t, err)
+
+	want := map[string]int{"500": 10, "501": -100, "42": 5}
+	if len(bias) != len(want) {
+		t.Fatalf("unexpected bias map: %v", bias)
+	}
+	for k, v := range want {
+		if bias[k] != v {
+			t.Fatalf("bias[%s] = %d, want %d (%v)", k, bias[k], v, bias)
+		}
+	}
+}
+
+func TestLogitBiasBuilderMultiTokenWord(t *testing.T) {
+	// "Sorrowful" is "Sorrow" + "ful": both tokens are banned, in both
+	// forms of the word.
+	bias, err := openai.NewLogitBiasBuilder(splittingTokenizer).Ban("Sorrowful").Build()
+	checks.NoError(t, err)
+
+	want := map[string]int{"600": -100, "601": -100, "302": -100}
+	if len(bias) != len(want) {
+		t.Fatalf("unexpected bias map: %v", bias)
+	}
+	for k, v := range want {
+		if bias[k] != v {
+			t.Fatalf("bias[%s] = %d, want %d (%v)", k, bias[k], v, bias)
+		}
+	}
+}
+
+func TestLogitBiasBuilderErrors(t *testing.T) {
+	_, err := openai.NewLogitBiasBuilder(fakeTokenizer).Text("x", 101).Build()
+	checks.ErrorIs(t, err, openai.ErrLogitBiasOutOfRange, "bias above 100 should fail")
+
+	_, err = openai.NewLogitBiasBuilder(fakeTokenizer).Token(1, -101).Build()
+	checks.ErrorIs(t, err, openai.ErrLogitBiasOutOfRange, "bias below -100 should fail")
+
+	_, err = openai.NewLogitBiasBuilder(fakeTokenizer).Text("  ", 1).Build()
+	checks.ErrorIs(t, err, openai.ErrLogitBiasEmptyText, "empty text should fail")
+
+	_, err = openai.NewLogitBiasBuilder(fakeTokenizer).Text("\x00", 1).Build()
+	checks.HasError(t, err, "tokenizer errors should be returned")
+}
@@ -0,0 +1,179 @@
+package openai
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// TokenLogprobs is a sequence of token log probabilities, as returned in
+// ChatCompletionStreamChoiceLogprobs or converted from LogProbs, with helpers
+// for the usual evaluation math.
+type TokenLogprobs []ChatCompletionTokenLogprob
+
+// TokenAlternative is a candidate token at a position of the output.
+type TokenAlternative struct {
+	Token       string
+	Logprob     float64
+	Probability float64
+}
+
+// LabelConfidence is the outcome of a classification-style prompt, where the
+// answer is one of a known set of labels.
+type LabelConfidence struct {
+	// Label is the most probable label, or empty if no label appeared among
+	// the top alternatives.
+	Label string
+	// Confidence is the probability of Label normalized over the matched labels.
+	Confidence float64
+	// Probabilities holds the raw probability mass found for each label.
+	Probabilities map[string]float64
+}
+
+// TokenLogprobs converts the non-streaming log probabilities of a choice.
+func (l *LogProbs) TokenLogprobs() TokenLogprobs {
+	if l == nil {
+		return nil
+	}
+	result := make(TokenLogprobs, len(l.Content))
+	for i, token := range l.Content {
+		result[i] = ChatCompletionTokenLogprob{
+			Token:   token.Token,
+			Logprob: token.LogProb,
+			Bytes:   bytesToInt64s(token.Bytes),
+		}
+		for _, top := range token.TopLogProbs {
+			result[i].TopLogprobs = append(result[i].TopLogprobs, ChatCompletionTokenLogprobTopLogprob{
+				Token:   top.Token,
+				Logprob: top.LogProb,
+				Bytes:   bytesToInt64s(top.Bytes),
+			})
+		}
+	}
+	return result
+}
+
+func bytesToInt64s(b []byte) []int64 {
+	if b == nil {
+		return nil
+	}
+	result := make([]int64, len(b))
+	for i, v := range b {
+		result[i] = int64(v)
+	}
+	return result
+}
+
+// Text returns the concatenated tokens.
+func (t TokenLogprobs) Text() string {
+	var sb strings.Builder
+	for _, token := range t {
+		sb.WriteString(token.Token)
+	}
+	return sb.String()
+}
+
+// Total returns the log probability of the whole sequence.
+func (t TokenLogprobs) Total() float64 {
+	var total float64
+	for _, token := range t {
+		total += token.Logprob
+	}
+	return total
+}
+
+// Mean returns the average log probability per token, or 0 for an empty sequence.
+func (t TokenLogprobs) Mean() float64 {
+	if len(t) == 0 {
+		return 0
+	}
+	return t.Total() / float64(len(t))
+}
+
+// Probability returns the joint probability of the sequence.
+func (t TokenLogprobs) Probability() float64 {
+	return math.Exp(t.Total())
+}
+
+// Perplexity returns the perplexity of the sequence, exp(-mean logprob).
+// Lower values mean the model was more certain of its output.
+func (t TokenLogprobs) Perplexity() float64 {
+	return math.Exp(-t.Mean())
+}
+
+// TokenPerplexities returns the perplexity of each individual token, which
+// highlights the positions where the model hesitated.
+func (t TokenLogprobs) TokenPerplexities() []float64 {
+	result := make([]float64, len(t))
+	for i, token := range t {
+		result[i] = math.Exp(-token.Logprob)
+	}
+	return result
+}
+
+// TopAlternatives returns the candidates at position i other than the
+// sampled token, most probable first. It returns nil if i is out of range or
+// top_logprobs were not requested.
+func (t TokenLogprobs) TopAlternatives(i int) []TokenAlternative {
+	if i < 0 || i >= len(t) {
+		return nil
+	}
+	var alternatives []TokenAlternative
+	for _, top := range t[i].TopLogprobs {
+		if top.Token == t[i].Token {
+			continue
+		}
+		alternatives = append(alternatives, TokenAlternative{
+			Token:       top.Token,
+			Logprob:     top.Logprob,
+			Probability: math.Exp(top.Logprob),
+		})
+	}
+	sort.SliceStable(alternatives, func(a, b int) bool {
+		return alternatives[a].Logprob > alternatives[b].Logprob
+	})
+	return alternatives
+}
+
+// ClassifyLabels scores a classification-style answer whose first token
+// selects one of labels. The top alternatives of the first token are matched
+// against the labels case-insensitively, ignoring surrounding whitespace; a
+// token also matches a label it is a prefix of, since labels may span
+// several tokens. Request top_logprobs to get a meaningful distribution.
+func (t TokenLogprobs) ClassifyLabels(labels ...string) LabelConfidence {
+	result := LabelConfidence{Probabilities: make(map[string]float64, len(labels))}
+	if len(t) == 0 {
+		return result
+	}
+
+	candidates := t[0].TopLogprobs
+	if len(candidates) == 0 {
+		candidates = []ChatCompletionTokenLogprobTopLogprob{{Token: t[0].Token, Logprob: t[0].Logprob}}
+	}
+
+	var total float64
+	for _, candidate := range candidates {
+		token := strings.ToLower(strings.TrimSpace(candidate.Token))
+		if token == "" {
+			continue
+		}
+		for _, label := range labels {
+			if strings.HasPrefix(strings.ToLower(label), token) {
+				p := math.Exp(candidate.Logprob)
+				result.Probabilities[label] += p
+				total += p
+				break
+			}
+		}
+	}
+
+	for _, label := range labels {
+		if p := result.Probabilities[label]; p > 0 && p > result.Probabilities[result.Label] {
+			result.Label = label
+		}
+	}
+	if total > 0 {
+		result.Confidence = result.Probabilities[result.Label] / total
+	}
+	return result
+}
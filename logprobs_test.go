@@ -0,0 +1,85 @@
+package openai_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestTokenLogprobsMath(t *testing.T) {
+	tokens := openai.TokenLogprobs{
+		{Token: "Hello", Logprob: math.Log(0.5)},
+		{Token: " world", Logprob: math.Log(0.25)},
+	}
+	if tokens.Text() != "Hello world" {
+		t.Fatalf("unexpected text %q", tokens.Text())
+	}
+	if !almostEqual(tokens.Probability(), 0.125) {
+		t.Fatalf("unexpected probability %f", tokens.Probability())
+	}
+	if !almostEqual(tokens.Total(), math.Log(0.125)) {
+		t.Fatalf("unexpected total %f", tokens.Total())
+	}
+	if !almostEqual(tokens.Perplexity(), math.Sqrt(8)) {
+		t.Fatalf("unexpected perplexity %f", tokens.Perplexity())
+	}
+	perTokens := tokens.TokenPerplexities()
+	if !almostEqual(perTokens[0], 2) || !almostEqual(perTokens[1], 4) {
+		t.Fatalf("unexpected token perplexities %v", perTokens)
+	}
+	if (openai.TokenLogprobs{}).Perplexity() != 1 {
+		t.Fatal("empty sequence should have perplexity 1")
+	}
+}
+
+func TestTokenLogprobsTopAlternatives(t *testing.T) {
+	tokens := openai.TokenLogprobs{{
+		Token:   "cat",
+		Logprob: math.Log(0.6),
+		TopLogprobs: []openai.ChatCompletionTokenLogprobTopLogprob{
+			{Token: "cat", Logprob: math.Log(0.6)},
+			{Token: "bird", Logprob: math.Log(0.1)},
+			{Token: "dog", Logprob: math.Log(0.3)},
+		},
+	}}
+	alternatives := tokens.TopAlternatives(0)
+	if len(alternatives) != 2 || alternatives[0].Token != "dog" || !almostEqual(alternatives[0].Probability, 0.3) {
+		t.Fatalf("unexpected alternatives: %+v", alternatives)
+	}
+	if tokens.TopAlternatives(1) != nil {
+		t.Fatal("out of range position should return nil")
+	}
+}
+
+func TestTokenLogprobsClassifyLabels(t *testing.T) {
+	logprobs := &openai.LogProbs{Content: []openai.LogProb{{
+		Token:   "Positive",
+		LogProb: math.Log(0.6),
+		TopLogProbs: []openai.TopLogProbs{
+			{Token: "Positive", LogProb: math.Log(0.6)},
+			{Token: " neg", LogProb: math.Log(0.2)},
+			{Token: "Maybe", LogProb: math.Log(0.15)},
+		},
+	}}}
+
+	result := logprobs.TokenLogprobs().ClassifyLabels("positive", "negative")
+	if result.Label != "positive" {
+		t.Fatalf("unexpected label %q", result.Label)
+	}
+	if !almostEqual(result.Confidence, 0.75) || !almostEqual(result.Probabilities["negative"], 0.2) {
+		t.Fatalf("unexpected confidence: %+v", result)
+	}
+
+	none := logprobs.TokenLogprobs().ClassifyLabels("unrelated")
+	if none.Label != "" || none.Confidence != 0 {
+		t.Fatalf("expected no match, got %+v", none)
+	}
+	if (*openai.LogProbs)(nil).TokenLogprobs() != nil {
+		t.Fatal("nil logprobs should convert to nil")
+	}
+}
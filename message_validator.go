@@ -0,0 +1,133 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+var (
+	ErrMessageMissingRole              = errors.New("message role is required")
+	ErrToolMessageMissingToolCallID    = errors.New("tool messages require ToolCallID")
+	ErrToolMessageWithoutToolCall      = errors.New("tool message does not answer a tool call of a preceding assistant message") //nolint:lll
+	ErrToolCallFieldOutsideAssistant   = errors.New("only assistant messages can contain ToolCalls")
+	ErrToolCallMissingID               = errors.New("assistant tool calls require an ID")
+	ErrToolCallMissingFunctionName     = errors.New("assistant tool calls require a function name")
+	ErrMessageNameInvalid              = errors.New("message name must be 1 to 64 characters without whitespace or <|\\/>") //nolint:lll
+	ErrToolCallIDOnNonToolMessage      = errors.New("ToolCallID can only be set on tool messages")
+	ErrMessageMultiContentEmptyPart    = errors.New("content parts require a type")
+	ErrMessageMultiContentMismatchPart = errors.New("content part payload does not match its type")
+)
+
+// messageNamePattern matches the names the API accepts, which only exclude
+// whitespace and the characters <|\/>.
+var messageNamePattern = regexp.MustCompile(`^[^\s<|\\/>]{1,64}$`)
+
+// MessageValidationError reports which message of a request is invalid.
+type MessageValidationError struct {
+	Index int
+	Role  string
+	Err   error
+}
+
+func (e *MessageValidationError) Error() string {
+	return fmt.Sprintf("invalid message at index %d (role %q): %v", e.Index, e.Role, e.Err)
+}
+
+func (e *MessageValidationError) Unwrap() error {
+	return e.Err
+}
+
+// MessageValidator checks chat messages for shapes the API is known to
+// reject, so that mistakes surface as descriptive local errors instead of
+// opaque 400 responses.
+type MessageValidator struct{}
+
+// NewMessageValidator creates a new validator for chat messages.
+func NewMessageValidator() *MessageValidator {
+	return &MessageValidator{}
+}
+
+// Validate checks every message of the request and returns a
+// *MessageValidationError for the first invalid one.
+func (v *MessageValidator) Validate(request ChatCompletionRequest) error {
+	pendingToolCalls := map[string]bool{}
+	for i, message := range request.Messages {
+		err := v.validateMessage(message, pendingToolCalls)
+		if err != nil {
+			return &MessageValidationError{Index: i, Role: message.Role, Err: err}
+		}
+	}
+	return nil
+}
+
+func (v *MessageValidator) validateMessage(message ChatCompletionMessage, pendingToolCalls map[string]bool) error {
+	if message.Role == "" {
+		return ErrMessageMissingRole
+	}
+	if message.Content != "" && message.MultiContent != nil {
+		return ErrContentFieldsMisused
+	}
+	if message.Name != "" && !messageNamePattern.MatchString(message.Name) {
+		return ErrMessageNameInvalid
+	}
+	if err := v.validateParts(message.MultiContent); err != nil {
+		return err
+	}
+
+	switch message.Role {
+	case ChatMessageRoleTool:
+		if message.ToolCallID == "" {
+			return ErrToolMessageMissingToolCallID
+		}
+		if !pendingToolCalls[message.ToolCallID] {
+			return fmt.Errorf("%w: %s", ErrToolMessageWithoutToolCall, message.ToolCallID)
+		}
+	case ChatMessageRoleAssistant:
+		for id := range pendingToolCalls {
+			delete(pendingToolCalls, id)
+		}
+		for _, call := range message.ToolCalls {
+			if call.ID == "" {
+				return ErrToolCallMissingID
+			}
+			if call.Function.Name == "" {
+				return ErrToolCallMissingFunctionName
+			}
+			pendingToolCalls[call.ID] = true
+		}
+	default:
+		if len(message.ToolCalls) > 0 {
+			return ErrToolCallFieldOutsideAssistant
+		}
+		if message.ToolCallID != "" {
+			return ErrToolCallIDOnNonToolMessage
+		}
+	}
+	return nil
+}
+
+func (v *MessageValidator) validateParts(parts []ChatMessagePart) error {
+	for _, part := range parts {
+		var ok bool
+		switch part.Type {
+		case "":
+			return ErrMessageMultiContentEmptyPart
+		case ChatMessagePartTypeText:
+			ok = part.ImageURL == nil && part.File == nil && part.InputAudio == nil
+		case ChatMessagePartTypeImageURL:
+			ok = part.ImageURL != nil
+		case ChatMessagePartTypeFile:
+			ok = part.File != nil
+		case ChatMessagePartTypeInputAudio:
+			ok = part.InputAudio != nil
+		default:
+			// Unknown part types may be supported by compatible providers.
+			ok = true
+		}
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrMessageMultiContentMismatchPart, part.Type)
+		}
+	}
+	return nil
+}
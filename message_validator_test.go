@@ -0,0 +1,179 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestMessageValidator(t *testing.T) {
+	toolCall := openai.ToolCall{
+		ID:       "call_1",
+		Type:     openai.ToolTypeFunction,
+		Function: openai.FunctionCall{Name: "get_weather", Arguments: "{}"},
+	}
+
+	tests := []struct {
+		name          string
+		messages      []openai.ChatCompletionMessage
+		expectedError error
+		expectedIndex int
+	}{
+		{
+			name: "valid_conversation",
+			messages: []openai.ChatCompletionMessage{
+				openai.SystemMessage("Be brief."),
+				{Role: openai.ChatMessageRoleUser, Content: "Weather?", Name: "John_Doe"},
+				openai.AssistantToolCallsMessage(toolCall),
+				openai.ToolMessage("call_1", "sunny"),
+				openai.AssistantMessage("It is sunny."),
+			},
+		},
+		{
+			name: "missing_role",
+			messages: []openai.ChatCompletionMessage{
+				{Content: "Hello"},
+			},
+			expectedError: openai.ErrMessageMissingRole,
+		},
+		{
+			name: "content_and_multi_content",
+			messages: []openai.ChatCompletionMessage{
+				{
+					Role:         openai.ChatMessageRoleUser,
+					Content:      "Hello",
+					MultiContent: []openai.ChatMessagePart{openai.TextPart("Hello")},
+				},
+			},
+			expectedError: openai.ErrContentFieldsMisused,
+		},
+		{
+			name: "dotted_and_non_ascii_names",
+			messages: []openai.ChatCompletionMessage{
+				{Role: openai.ChatMessageRoleUser, Content: "Hello", Name: "john.doe"},
+				{Role: openai.ChatMessageRoleUser, Content: "Hello", Name: "José"},
+			},
+		},
+		{
+			name: "invalid_name",
+			messages: []openai.ChatCompletionMessage{
+				{Role: openai.ChatMessageRoleUser, Content: "Hello", Name: "John Doe"},
+			},
+			expectedError: openai.ErrMessageNameInvalid,
+		},
+		{
+			name: "name_with_slash",
+			messages: []openai.ChatCompletionMessage{
+				{Role: openai.ChatMessageRoleUser, Content: "Hello", Name: "john/doe"},
+			},
+			expectedError: openai.ErrMessageNameInvalid,
+		},
+		{
+			name: "tool_message_without_tool_call_id",
+			messages: []openai.ChatCompletionMessage{
+				openai.UserMessage("Weather?"),
+				openai.AssistantToolCallsMessage(toolCall),
+				{Role: openai.ChatMessageRoleTool, Content: "sunny"},
+			},
+			expectedError: openai.ErrToolMessageMissingToolCallID,
+			expectedIndex: 2,
+		},
+		{
+			name: "tool_message_without_tool_call",
+			messages: []openai.ChatCompletionMessage{
+				openai.UserMessage("Weather?"),
+				openai.ToolMessage("call_1", "sunny"),
+			},
+			expectedError: openai.ErrToolMessageWithoutToolCall,
+			expectedIndex: 1,
+		},
+		{
+			name: "tool_call_missing_id",
+			messages: []openai.ChatCompletionMessage{
+				openai.AssistantToolCallsMessage(openai.ToolCall{
+					Type:     openai.ToolTypeFunction,
+					Function: openai.FunctionCall{Name: "get_weather"},
+				}),
+			},
+			expectedError: openai.ErrToolCallMissingID,
+		},
+		{
+			name: "tool_call_missing_function_name",
+			messages: []openai.ChatCompletionMessage{
+				openai.AssistantToolCallsMessage(openai.ToolCall{ID: "call_1", Type: openai.ToolTypeFunction}),
+			},
+			expectedError: openai.ErrToolCallMissingFunctionName,
+		},
+		{
+			name: "tool_calls_on_user_message",
+			messages: []openai.ChatCompletionMessage{
+				{Role: openai.ChatMessageRoleUser, Content: "Hello", ToolCalls: []openai.ToolCall{toolCall}},
+			},
+			expectedError: openai.ErrToolCallFieldOutsideAssistant,
+		},
+		{
+			name: "tool_call_id_on_user_message",
+			messages: []openai.ChatCompletionMessage{
+				{Role: openai.ChatMessageRoleUser, Content: "Hello", ToolCallID: "call_1"},
+			},
+			expectedError: openai.ErrToolCallIDOnNonToolMessage,
+		},
+		{
+			name: "part_without_type",
+			messages: []openai.ChatCompletionMessage{
+				openai.UserMessageParts(openai.ChatMessagePart{Text: "Hello"}),
+			},
+			expectedError: openai.ErrMessageMultiContentEmptyPart,
+		},
+		{
+			name: "image_part_without_image",
+			messages: []openai.ChatCompletionMessage{
+				openai.UserMessageParts(openai.ChatMessagePart{Type: openai.ChatMessagePartTypeImageURL}),
+			},
+			expectedError: openai.ErrMessageMultiContentMismatchPart,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := openai.NewMessageValidator().Validate(openai.ChatCompletionRequest{
+				Model:    openai.GPT4oMini,
+				Messages: tt.messages,
+			})
+			if tt.expectedError == nil {
+				checks.NoError(t, err)
+				return
+			}
+			checks.ErrorIs(t, err, tt.expectedError)
+
+			var validationErr *openai.MessageValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected MessageValidationError, got %T", err)
+			}
+			if validationErr.Index != tt.expectedIndex {
+				t.Errorf("expected index %d, got %d", tt.expectedIndex, validationErr.Index)
+			}
+		})
+	}
+}
+
+func TestChatCompletionsInvalidMessage(t *testing.T) {
+	config := openai.DefaultConfig("whatever")
+	config.BaseURL = "http://localhost/v1"
+	client := openai.NewClientWithConfig(config)
+
+	req := openai.ChatCompletionRequest{
+		Model: openai.GPT4oMini,
+		Messages: []openai.ChatCompletionMessage{
+			openai.ToolMessage("", "sunny"),
+		},
+	}
+	_, err := client.CreateChatCompletion(context.Background(), req)
+	checks.ErrorIs(t, err, openai.ErrToolMessageMissingToolCallID)
+
+	_, err = client.CreateChatCompletionStream(context.Background(), req)
+	checks.ErrorIs(t, err, openai.ErrToolMessageMissingToolCallID)
+}
@@ -12,16 +12,17 @@ const (
 )
 
 type Message struct {
-	ID          string           `json:"id"`
-	Object      string           `json:"object"`
-	CreatedAt   int              `json:"created_at"`
-	ThreadID    string           `json:"thread_id"`
-	Role        string           `json:"role"`
-	Content     []MessageContent `json:"content"`
-	FileIds     []string         `json:"file_ids"` //nolint:revive //backwards-compatibility
-	AssistantID *string          `json:"assistant_id,omitempty"`
-	RunID       *string          `json:"run_id,omitempty"`
-	Metadata    map[string]any   `json:"metadata"`
+	ID          string             `json:"id"`
+	Object      string             `json:"object"`
+	CreatedAt   int                `json:"created_at"`
+	ThreadID    string             `json:"thread_id"`
+	Role        string             `json:"role"`
+	Content     []MessageContent   `json:"content"`
+	FileIds     []string           `json:"file_ids"` //nolint:revive //backwards-compatibility
+	AssistantID *string            `json:"assistant_id,omitempty"`
+	RunID       *string            `json:"run_id,omitempty"`
+	Attachments []ThreadAttachment `json:"attachments,omitempty"`
+	Metadata    map[string]any     `json:"metadata"`
 
 	httpHeader
 }
@@ -91,8 +92,7 @@ type MessageDeletionStatus struct {
 // CreateMessage creates a new message.
 func (c *Client) CreateMessage(ctx context.Context, threadID string, request MessageRequest) (msg Message, err error) {
 	urlSuffix := fmt.Sprintf("/threads/%s/%s", threadID, messagesSuffix)
-	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix), withBody(request),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix), withBody(request))
 	if err != nil {
 		return
 	}
@@ -109,31 +109,26 @@ func (c *Client) ListMessage(ctx context.Context, threadID string,
 	before *string,
 	runID *string,
 ) (messages MessagesList, err error) {
-	urlValues := url.Values{}
-	if limit != nil {
-		urlValues.Add("limit", fmt.Sprintf("%d", *limit))
-	}
-	if order != nil {
-		urlValues.Add("order", *order)
-	}
-	if after != nil {
-		urlValues.Add("after", *after)
-	}
-	if before != nil {
-		urlValues.Add("before", *before)
-	}
+	urlValues := ListOptions{Limit: limit, Order: order, After: after, Before: before}.values()
 	if runID != nil {
 		urlValues.Add("run_id", *runID)
 	}
+	return c.listMessages(ctx, threadID, urlValues)
+}
 
-	encodedValues := ""
-	if len(urlValues) > 0 {
-		encodedValues = "?" + urlValues.Encode()
-	}
+// ListMessagesWithOptions fetches the messages in the thread, one page at a
+// time.
+func (c *Client) ListMessagesWithOptions(
+	ctx context.Context,
+	threadID string,
+	opts ListOptions,
+) (messages MessagesList, err error) {
+	return c.listMessages(ctx, threadID, opts.values())
+}
 
-	urlSuffix := fmt.Sprintf("/threads/%s/%s%s", threadID, messagesSuffix, encodedValues)
-	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+func (c *Client) listMessages(ctx context.Context, threadID string, urlValues url.Values) (messages MessagesList, err error) {
+	urlSuffix := withQuery(fmt.Sprintf("/threads/%s/%s", threadID, messagesSuffix), urlValues)
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
 	if err != nil {
 		return
 	}
@@ -148,8 +143,7 @@ func (c *Client) RetrieveMessage(
 	threadID, messageID string,
 ) (msg Message, err error) {
 	urlSuffix := fmt.Sprintf("/threads/%s/%s/%s", threadID, messagesSuffix, messageID)
-	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
 	if err != nil {
 		return
 	}
@@ -166,7 +160,7 @@ func (c *Client) ModifyMessage(
 ) (msg Message, err error) {
 	urlSuffix := fmt.Sprintf("/threads/%s/%s/%s", threadID, messagesSuffix, messageID)
 	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix),
-		withBody(map[string]any{"metadata": metadata}), withBetaAssistantVersion(c.config.AssistantVersion))
+		withBody(map[string]any{"metadata": metadata}))
 	if err != nil {
 		return
 	}
@@ -181,8 +175,7 @@ func (c *Client) RetrieveMessageFile(
 	threadID, messageID, fileID string,
 ) (file MessageFile, err error) {
 	urlSuffix := fmt.Sprintf("/threads/%s/%s/%s/files/%s", threadID, messagesSuffix, messageID, fileID)
-	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
 	if err != nil {
 		return
 	}
@@ -197,8 +190,7 @@ func (c *Client) ListMessageFiles(
 	threadID, messageID string,
 ) (files MessageFilesList, err error) {
 	urlSuffix := fmt.Sprintf("/threads/%s/%s/%s/files", threadID, messagesSuffix, messageID)
-	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
 	if err != nil {
 		return
 	}
@@ -213,8 +205,7 @@ func (c *Client) DeleteMessage(
 	threadID, messageID string,
 ) (status MessageDeletionStatus, err error) {
 	urlSuffix := fmt.Sprintf("/threads/%s/%s/%s", threadID, messagesSuffix, messageID)
-	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL(urlSuffix))
 	if err != nil {
 		return
 	}
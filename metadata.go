@@ -0,0 +1,62 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// Limits the API places on metadata attached to objects and requests.
+const (
+	MaxMetadataPairs       = 16
+	MaxMetadataKeyLength   = 64
+	MaxMetadataValueLength = 512
+)
+
+var (
+	ErrMetadataTooManyPairs   = fmt.Errorf("metadata has more than %d key-value pairs", MaxMetadataPairs)
+	ErrMetadataKeyTooLong     = fmt.Errorf("metadata key is longer than %d characters", MaxMetadataKeyLength)
+	ErrMetadataValueTooLong   = fmt.Errorf("metadata value is longer than %d characters", MaxMetadataValueLength)
+	ErrMetadataValueNotString = errors.New("metadata value is not a string")
+)
+
+// MetadataError reports the metadata key that failed validation.
+type MetadataError struct {
+	Key string
+	Err error
+}
+
+func (e *MetadataError) Error() string {
+	return fmt.Sprintf("metadata key %q: %s", e.Key, e.Err)
+}
+
+func (e *MetadataError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateMetadata checks metadata against the limits of the API, so that
+// requests tagged with overly long identifiers fail before being sent.
+func ValidateMetadata(metadata map[string]string) error {
+	return validateMetadata(metadata)
+}
+
+// validateMetadata also accepts the map[string]any metadata of the
+// assistants and batch requests, whose values must still be strings.
+func validateMetadata[V any](metadata map[string]V) error {
+	if len(metadata) > MaxMetadataPairs {
+		return ErrMetadataTooManyPairs
+	}
+	for key, value := range metadata {
+		if utf8.RuneCountInString(key) > MaxMetadataKeyLength {
+			return &MetadataError{Key: key, Err: ErrMetadataKeyTooLong}
+		}
+		s, ok := any(value).(string)
+		if !ok {
+			return &MetadataError{Key: key, Err: ErrMetadataValueNotString}
+		}
+		if utf8.RuneCountInString(s) > MaxMetadataValueLength {
+			return &MetadataError{Key: key, Err: ErrMetadataValueTooLong}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestValidateMetadata(t *testing.T) {
+	checks.NoError(t, openai.ValidateMetadata(nil))
+	checks.NoError(t, openai.ValidateMetadata(map[string]string{
+		"tenant":  "acme",
+		"feature": strings.Repeat("é", openai.MaxMetadataValueLength),
+	}))
+
+	tooMany := map[string]string{}
+	for i := 0; i <= openai.MaxMetadataPairs; i++ {
+		tooMany[fmt.Sprint(i)] = "v"
+	}
+	checks.ErrorIs(t, openai.ValidateMetadata(tooMany), openai.ErrMetadataTooManyPairs)
+
+	longKey := strings.Repeat("k", openai.MaxMetadataKeyLength+1)
+	err := openai.ValidateMetadata(map[string]string{longKey: "v"})
+	checks.ErrorIs(t, err, openai.ErrMetadataKeyTooLong)
+	var metadataErr *openai.MetadataError
+	if !errors.As(err, &metadataErr) || metadataErr.Key != longKey {
+		t.Errorf("expected the offending key to be reported, got %v", err)
+	}
+
+	err = openai.ValidateMetadata(map[string]string{"k": strings.Repeat("v", openai.MaxMetadataValueLength+1)})
+	checks.ErrorIs(t, err, openai.ErrMetadataValueTooLong)
+}
+
+func TestRequestsValidateMetadata(t *testing.T) {
+	client := openai.NewClient("token")
+	ctx := context.Background()
+	longValue := strings.Repeat("v", openai.MaxMetadataValueLength+1)
+
+	_, err := client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+		Model:    openai.GPT4o,
+		Messages: []openai.ChatCompletionMessage{openai.UserMessage("hi")},
+		Metadata: map[string]string{"tenant": longValue},
+	})
+	checks.ErrorIs(t, err, openai.ErrMetadataValueTooLong)
+
+	_, err = client.CreateResponse(ctx, openai.ResponseRequest{Metadata: map[string]string{"tenant": longValue}})
+	checks.ErrorIs(t, err, openai.ErrMetadataValueTooLong)
+
+	_, err = client.CreateThread(ctx, openai.ThreadRequest{Metadata: map[string]any{"tenant": 42}})
+	checks.ErrorIs(t, err, openai.ErrMetadataValueNotString)
+
+	_, err = client.CreateThreadAndRun(ctx, openai.CreateThreadAndRunRequest{
+		Thread: openai.ThreadRequest{Metadata: map[string]any{"tenant": longValue}},
+	})
+	checks.ErrorIs(t, err, openai.ErrMetadataValueTooLong)
+
+	_, err = client.CreateBatch(ctx, openai.CreateBatchRequest{Metadata: map[string]any{"tenant": true}})
+	checks.ErrorIs(t, err, openai.ErrMetadataValueNotString)
+}
@@ -0,0 +1,202 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MetricsRecorder receives metrics about the requests made by a client, set
+// with ClientConfig.Metrics. Its methods are called synchronously from the
+// request path, so they must be fast and safe for concurrent use. Labels have
+// a bounded cardinality, which makes them suitable for Prometheus.
+type MetricsRecorder interface {
+	// RecordRequest is called once per HTTP request, when its response body
+	// has been read or closed, or when it failed without a response.
+	RecordRequest(metrics RequestMetrics)
+	// RecordTokens is called for responses reporting their token usage,
+	// including streams requested with usage.
+	RecordTokens(metrics TokenMetrics)
+	// RecordTimeToFirstToken is called when the first event of a stream is
+	// received.
+	RecordTimeToFirstToken(endpoint, model string, ttft time.Duration)
+}
+
+// RequestMetrics describes a completed HTTP request.
+type RequestMetrics struct {
+	Method string
+	// Endpoint is the path of the request relative to the base URL, with the
+	// segments containing digits, such as IDs, replaced by "{id}".
+	Endpoint string
+	// Model is the model of the request, empty for endpoints without one.
+	Model  string
+	Stream bool
+	// StatusCode is zero when no response was received, in which case Err is
+	// set.
+	StatusCode int
+	// Duration runs from sending the request to the end of its response body.
+	Duration time.Duration
+	Err      error
+}
+
+// TokenMetrics is the token usage of a response.
+type TokenMetrics struct {
+	Endpoint         string
+	Model            string
+	PromptTokens     int
+	CompletionTokens int
+}
+
+type requestInfoKey struct{}
+
+// requestInfo labels the metrics of a request. It is carried by the request
+// context.
+type requestInfo struct {
+	endpoint string
+	model    string
+}
+
+// withRequestInfo returns ctx carrying the metric labels of a request to
+// rawURL with the given body.
+func (c *Client) withRequestInfo(ctx context.Context, rawURL string, body any) context.Context {
+	if c.config.Metrics == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, requestInfoKey{}, requestInfo{
+		endpoint: c.metricsEndpoint(rawURL),
+		model:    requestModel(body),
+	})
+}
+
+func requestInfoFrom(ctx context.Context) (requestInfo, bool) {
+	info, ok := ctx.Value(requestInfoKey{}).(requestInfo)
+	return info, ok
+}
+
+// metricsEndpoint returns the path of rawURL relative to the base URL, with
+// the segments containing digits replaced.
+func (c *Client) metricsEndpoint(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	path := u.Path
+	if base, baseErr := url.Parse(c.config.BaseURL); baseErr == nil {
+		path = strings.TrimPrefix(path, strings.TrimRight(base.Path, "/"))
+	}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.ContainsAny(segment, "0123456789") {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// requestModel returns the model of a request body: the Model field of a
+// struct or the "model" key of a map.
+func requestModel(body any) string {
+	if m, ok := body.(map[string]any); ok {
+		model, _ := m["model"].(string)
+		return model
+	}
+	v := reflect.ValueOf(body)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+	if field := v.FieldByName("Model"); field.IsValid() && field.Kind() == reflect.String {
+		return field.String()
+	}
+	return ""
+}
+
+// recordRequestError reports a request that failed without a response.
+func (c *Client) recordRequestError(req *http.Request, start time.Time, err error) {
+	info, ok := requestInfoFrom(req.Context())
+	if !ok {
+		return
+	}
+	c.config.Metrics.RecordRequest(RequestMetrics{
+		Method:   req.Method,
+		Endpoint: info.endpoint,
+		Model:    info.model,
+		Stream:   isStreamRequest(req),
+		Duration: time.Since(start),
+		Err:      err,
+	})
+}
+
+// instrumentResponse makes resp report its request once its body has been
+// read or closed.
+func (c *Client) instrumentResponse(req *http.Request, resp *http.Response, start time.Time) {
+	info, ok := requestInfoFrom(req.Context())
+	if !ok {
+		return
+	}
+	metrics := RequestMetrics{
+		Method:     req.Method,
+		Endpoint:   info.endpoint,
+		Model:      info.model,
+		Stream:     isStreamRequest(req),
+		StatusCode: resp.StatusCode,
+	}
+	resp.Body = &metricsBody{ReadCloser: resp.Body, record: func(err error) {
+		metrics.Duration = time.Since(start)
+		metrics.Err = err
+		c.config.Metrics.RecordRequest(metrics)
+	}}
+}
+
+func isStreamRequest(req *http.Request) bool {
+	return req.Header.Get("Accept") == "text/event-stream"
+}
+
+type metricsBody struct {
+	io.ReadCloser
+	once   sync.Once
+	record func(err error)
+}
+
+func (b *metricsBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil {
+		var recorded error
+		if !errors.Is(err, io.EOF) {
+			recorded = err
+		}
+		b.once.Do(func() { b.record(recorded) })
+	}
+	return n, err
+}
+
+func (b *metricsBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() { b.record(nil) })
+	return err
+}
+
+// recordTokens reports the token usage of the response to req.
+func (c *Client) recordTokens(req *http.Request, promptTokens, completionTokens int) {
+	info, ok := requestInfoFrom(req.Context())
+	if !ok {
+		return
+	}
+	c.config.Metrics.RecordTokens(TokenMetrics{
+		Endpoint:         info.endpoint,
+		Model:            info.model,
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+	})
+}
@@ -0,0 +1,146 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+type fakeMetricsRecorder struct {
+	mu       sync.Mutex
+	requests []openai.RequestMetrics
+	tokens   []openai.TokenMetrics
+	ttfts    []string
+}
+
+func (r *fakeMetricsRecorder) RecordRequest(metrics openai.RequestMetrics) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, metrics)
+}
+
+func (r *fakeMetricsRecorder) RecordTokens(metrics openai.TokenMetrics) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokens = append(r.tokens, metrics)
+}
+
+func (r *fakeMetricsRecorder) RecordTimeToFirstToken(endpoint, model string, _ time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ttfts = append(r.ttfts, endpoint+" "+model)
+}
+
+func setupMetricsTestServer(t *testing.T) (*openai.Client, *fakeMetricsRecorder) {
+	t.Helper()
+	recorder := &fakeMetricsRecorder{}
+	client, server := setupOpenAITestServerWithConfig(t, func(config *openai.ClientConfig) {
+		config.Metrics = recorder
+	})
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") == "text/event-stream" {
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, `data: {"id":"1","choices":[{"index":0,"delta":{"content":"hi"}}]}`+"\n\n")
+			fmt.Fprint(w, `data: {"id":"1","choices":[],"usage":{"prompt_tokens":3,"completion_tokens":1}}`+"\n\n")
+			fmt.Fprint(w, "data: [DONE]\n\n")
+			return
+		}
+		fmt.Fprint(w, `{"id":"chatcmpl-1","choices":[],"usage":{"prompt_tokens":5,"completion_tokens":2}}`)
+	})
+	server.RegisterHandler("/v1/threads/thread_abc123/runs/run_abc123", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":{"message":"not found","type":"invalid_request_error"}}`)
+	})
+	return client, recorder
+}
+
+func TestMetricsRecorder(t *testing.T) {
+	client, recorder := setupMetricsTestServer(t)
+	ctx := context.Background()
+	request := openai.ChatCompletionRequest{
+		Model:    openai.GPT4o,
+		Messages: []openai.ChatCompletionMessage{openai.UserMessage("Hello")},
+	}
+
+	_, err := client.CreateChatCompletion(ctx, request)
+	checks.NoError(t, err)
+
+	stream, err := client.CreateChatCompletionStream(ctx, request)
+	checks.NoError(t, err)
+	for {
+		_, err = stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		checks.NoError(t, err)
+	}
+	stream.Close()
+
+	_, err = client.RetrieveRun(ctx, "thread_abc123", "run_abc123")
+	checks.HasError(t, err)
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	wantRequests := []openai.RequestMetrics{
+		{Method: http.MethodPost, Endpoint: "/chat/completions", Model: openai.GPT4o, StatusCode: http.StatusOK},
+		{Method: http.MethodPost, Endpoint: "/chat/completions", Model: openai.GPT4o, StatusCode: http.StatusOK, Stream: true},
+		{Method: http.MethodGet, Endpoint: "/threads/{id}/runs/{id}", StatusCode: http.StatusNotFound},
+	}
+	if len(recorder.requests) != len(wantRequests) {
+		t.Fatalf("expected %d requests, got %+v", len(wantRequests), recorder.requests)
+	}
+	for i, want := range wantRequests {
+		got := recorder.requests[i]
+		got.Duration = 0
+		if got != want {
+			t.Errorf("request %d: got %+v, want %+v", i, got, want)
+		}
+	}
+
+	wantTokens := []openai.TokenMetrics{
+		{Endpoint: "/chat/completions", Model: openai.GPT4o, PromptTokens: 5, CompletionTokens: 2},
+		{Endpoint: "/chat/completions", Model: openai.GPT4o, PromptTokens: 3, CompletionTokens: 1},
+	}
+	if len(recorder.tokens) != len(wantTokens) {
+		t.Fatalf("expected %d token records, got %+v", len(wantTokens), recorder.tokens)
+	}
+	for i, want := range wantTokens {
+		if recorder.tokens[i] != want {
+			t.Errorf("tokens %d: got %+v, want %+v", i, recorder.tokens[i], want)
+		}
+	}
+
+	if len(recorder.ttfts) != 1 || recorder.ttfts[0] != "/chat/completions "+openai.GPT4o {
+		t.Errorf("expected one time-to-first-token record, got %v", recorder.ttfts)
+	}
+}
+
+func TestMetricsRecorderNetworkError(t *testing.T) {
+	recorder := &fakeMetricsRecorder{}
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = "http://127.0.0.1:1/v1"
+	config.Metrics = recorder
+	client := openai.NewClientWithConfig(config)
+
+	_, err := client.CreateEmbeddings(context.Background(), openai.EmbeddingRequest{
+		Input: []string{"hello"},
+		Model: openai.SmallEmbedding3,
+	})
+	checks.HasError(t, err)
+	if len(recorder.requests) != 1 {
+		t.Fatalf("expected 1 request, got %+v", recorder.requests)
+	}
+	got := recorder.requests[0]
+	if got.StatusCode != 0 || got.Err == nil || got.Endpoint != "/embeddings" || got.Model != string(openai.SmallEmbedding3) {
+		t.Errorf("unexpected metrics %+v", got)
+	}
+}
@@ -0,0 +1,78 @@
+package openai
+
+import (
+	"sync"
+	"time"
+)
+
+// modelCache caches the model list and the models retrieved by a client for
+// ClientConfig.ModelCacheTTL.
+type modelCache struct {
+	ttl time.Duration
+
+	mu          sync.Mutex
+	list        ModelsList
+	listExpires time.Time
+	models      map[string]cachedModel
+}
+
+type cachedModel struct {
+	model   Model
+	expires time.Time
+}
+
+func newModelCache(ttl time.Duration) *modelCache {
+	return &modelCache{ttl: ttl, models: make(map[string]cachedModel)}
+}
+
+// getList returns a copy of the cached model list, if fresh.
+func (m *modelCache) getList() (ModelsList, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if time.Now().After(m.listExpires) {
+		return ModelsList{}, false
+	}
+	list := m.list
+	list.Models = append([]Model(nil), m.list.Models...)
+	return list, true
+}
+
+// getModel returns a cached model, from the model list or a GetModel call.
+func (m *modelCache) getModel(id string) (Model, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	if cached, ok := m.models[id]; ok && now.Before(cached.expires) {
+		return cached.model, true
+	}
+	if now.Before(m.listExpires) {
+		for _, model := range m.list.Models {
+			if model.ID == id {
+				return model, true
+			}
+		}
+	}
+	return Model{}, false
+}
+
+func (m *modelCache) setList(list ModelsList) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.list = list
+	m.list.Models = append([]Model(nil), list.Models...)
+	m.listExpires = time.Now().Add(m.ttl)
+}
+
+func (m *modelCache) setModel(model Model) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.models[model.ID] = cachedModel{model: model, expires: time.Now().Add(m.ttl)}
+}
+
+// invalidate drops the cached list and model id, after id was deleted.
+func (m *modelCache) invalidate(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.models, id)
+	m.listExpires = time.Time{}
+}
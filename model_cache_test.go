@@ -0,0 +1,122 @@
+package openai_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+const testModelsList = `{"object":"list","data":[` +
+	`{"id":"gpt-4o","owned_by":"system"},` +
+	`{"id":"gpt-4o-mini","owned_by":"system"},` +
+	`{"id":"ft:gpt-4o-mini:acme::abc","owned_by":"acme"}]}`
+
+// setupModelCacheServer returns a client caching models for ttl and a
+// counter of the requests reaching the server.
+func setupModelCacheServer(t *testing.T, ttl time.Duration) (*openai.Client, *int) {
+	t.Helper()
+	client, server := setupOpenAITestServerWithConfig(t, func(config *openai.ClientConfig) {
+		config.ModelCacheTTL = ttl
+	})
+	requests := 0
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		fmt.Fprint(w, testModelsList)
+	})
+	server.RegisterHandler("/v1/models/gpt-5", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method == http.MethodDelete {
+			fmt.Fprint(w, `{"id":"gpt-5","deleted":true}`)
+			return
+		}
+		fmt.Fprint(w, `{"id":"gpt-5","owned_by":"system"}`)
+	})
+	return client, &requests
+}
+
+func TestModelExistsCached(t *testing.T) {
+	client, requests := setupModelCacheServer(t, time.Minute)
+	ctx := context.Background()
+
+	for _, id := range []string{"gpt-4o", "gpt-4o-mini", "o3"} {
+		exists, err := client.ModelExists(ctx, id)
+		checks.NoError(t, err)
+		if exists != (id != "o3") {
+			t.Errorf("ModelExists(%q) = %v", id, exists)
+		}
+	}
+	model, err := client.GetModel(ctx, "gpt-4o-mini")
+	checks.NoError(t, err)
+	if model.ID != "gpt-4o-mini" {
+		t.Errorf("model = %+v", model)
+	}
+	if *requests != 1 {
+		t.Errorf("requests = %d, want 1", *requests)
+	}
+
+	// Models missing from the list are retrieved, then cached.
+	for i := 0; i < 2; i++ {
+		_, err = client.GetModel(ctx, "gpt-5")
+		checks.NoError(t, err)
+	}
+	if *requests != 2 {
+		t.Errorf("requests = %d, want 2", *requests)
+	}
+
+	// Deleting a model invalidates the cache.
+	_, err = client.DeleteFineTuneModel(ctx, "gpt-5")
+	checks.NoError(t, err)
+	_, err = client.ListModels(ctx)
+	checks.NoError(t, err)
+	if *requests != 4 {
+		t.Errorf("requests = %d, want 4", *requests)
+	}
+}
+
+func TestModelCacheExpires(t *testing.T) {
+	client, requests := setupModelCacheServer(t, time.Millisecond)
+	_, err := client.ListModels(context.Background())
+	checks.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+	_, err = client.ListModels(context.Background())
+	checks.NoError(t, err)
+	if *requests != 2 {
+		t.Errorf("requests = %d, want 2", *requests)
+	}
+}
+
+func TestModelsNotCachedByDefault(t *testing.T) {
+	client, requests := setupModelCacheServer(t, 0)
+	for i := 0; i < 2; i++ {
+		_, err := client.ModelExists(context.Background(), "gpt-4o")
+		checks.NoError(t, err)
+	}
+	if *requests != 2 {
+		t.Errorf("requests = %d, want 2", *requests)
+	}
+}
+
+func TestListModelsWithFilter(t *testing.T) {
+	client, _ := setupModelCacheServer(t, 0)
+	tests := []struct {
+		filter openai.ModelFilter
+		want   int
+	}{
+		{openai.ModelFilter{}, 3},
+		{openai.ModelFilter{OwnedBy: "acme"}, 1},
+		{openai.ModelFilter{IDPrefix: "gpt-4o"}, 2},
+		{openai.ModelFilter{OwnedBy: "system", IDPrefix: "ft:"}, 0},
+	}
+	for _, tt := range tests {
+		models, err := client.ListModelsWithFilter(context.Background(), tt.filter)
+		checks.NoError(t, err)
+		if len(models.Models) != tt.want {
+			t.Errorf("%+v: %d models, want %d", tt.filter, len(models.Models), tt.want)
+		}
+	}
+}
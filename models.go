@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 )
 
 // Model struct represents an OpenAPI model.
@@ -53,19 +55,78 @@ type ModelsList struct {
 
 // ListModels Lists the currently available models,
 // and provides basic information about each model such as the model id and parent.
+// The list is cached when ClientConfig.ModelCacheTTL is set.
 func (c *Client) ListModels(ctx context.Context) (models ModelsList, err error) {
+	if c.models != nil {
+		if cached, ok := c.models.getList(); ok {
+			return cached, nil
+		}
+	}
 	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL("/models"))
 	if err != nil {
 		return
 	}
 
 	err = c.sendRequest(req, &models)
+	if err == nil && c.models != nil {
+		c.models.setList(models)
+	}
+	return
+}
+
+// ModelFilter selects models in ListModelsWithFilter. Empty fields match all
+// models.
+type ModelFilter struct {
+	OwnedBy  string
+	IDPrefix string
+}
+
+func (f ModelFilter) match(model Model) bool {
+	return (f.OwnedBy == "" || model.OwnedBy == f.OwnedBy) && strings.HasPrefix(model.ID, f.IDPrefix)
+}
+
+// ListModelsWithFilter lists the available models matching filter. The API
+// does not filter models, so the filter is applied to the full list.
+func (c *Client) ListModelsWithFilter(ctx context.Context, filter ModelFilter) (models ModelsList, err error) {
+	models, err = c.ListModels(ctx)
+	if err != nil {
+		return
+	}
+	filtered := make([]Model, 0, len(models.Models))
+	for _, model := range models.Models {
+		if filter.match(model) {
+			filtered = append(filtered, model)
+		}
+	}
+	models.Models = filtered
 	return
 }
 
+// ModelExists reports whether the model is available, looking it up in the
+// model list. Set ClientConfig.ModelCacheTTL to check several models with a
+// single request.
+func (c *Client) ModelExists(ctx context.Context, modelID string) (bool, error) {
+	models, err := c.ListModels(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, model := range models.Models {
+		if model.ID == modelID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // GetModel Retrieves a model instance, providing basic information about
-// the model such as the owner and permissioning.
+// the model such as the owner and permissioning. The model is served from the
+// cache when ClientConfig.ModelCacheTTL is set.
 func (c *Client) GetModel(ctx context.Context, modelID string) (model Model, err error) {
+	if c.models != nil {
+		if cached, ok := c.models.getModel(modelID); ok {
+			return cached, nil
+		}
+	}
 	urlSuffix := fmt.Sprintf("/models/%s", modelID)
 	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
 	if err != nil {
@@ -73,6 +134,9 @@ func (c *Client) GetModel(ctx context.Context, modelID string) (model Model, err
 	}
 
 	err = c.sendRequest(req, &model)
+	if err == nil && c.models != nil {
+		c.models.setModel(model)
+	}
 	return
 }
 
@@ -80,11 +144,26 @@ func (c *Client) GetModel(ctx context.Context, modelID string) (model Model, err
 // role in your organization to delete a model.
 func (c *Client) DeleteFineTuneModel(ctx context.Context, modelID string) (
 	response FineTuneModelDeleteResponse, err error) {
-	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL("/models/"+modelID))
+	return c.DeleteModel(ctx, modelID)
+}
+
+// DeleteModel deletes a fine-tuned model, such as
+// "ft:gpt-4o-mini-2024-07-18:acme::abc123". Failures the API explains are
+// returned as a *ModelDeleteError matching ErrModelNotFound,
+// ErrModelNotOwned or ErrModelInUse with errors.Is.
+func (c *Client) DeleteModel(ctx context.Context, modelID string) (response FineTuneModelDeleteResponse, err error) {
+	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL("/models/"+url.PathEscape(modelID)))
 	if err != nil {
 		return
 	}
 
 	err = c.sendRequest(req, &response)
+	if err != nil {
+		err = newModelDeleteError(modelID, err)
+		return
+	}
+	if c.models != nil {
+		c.models.invalidate(modelID)
+	}
 	return
 }
@@ -184,6 +184,6 @@ func (c *Client) Moderations(ctx context.Context,
 		return
 	}
 
-	err = c.sendRequest(req, &response)
+	err = c.sendRequestCoalesced(req, "", &response)
 	return
 }
@@ -0,0 +1,123 @@
+package openai
+
+import (
+	"context"
+	"strings"
+)
+
+// MessageModerationResult is the moderation result of one message of a
+// conversation.
+type MessageModerationResult struct {
+	// MessageIndex is the index of the message in the moderated slice.
+	MessageIndex int
+	Result
+}
+
+// ConversationModeration is the result of ModerateMessages.
+type ConversationModeration struct {
+	// Results holds one entry per message with text or image content, in
+	// message order.
+	Results []MessageModerationResult
+	// Flagged reports whether any message was flagged.
+	Flagged bool
+}
+
+// ModerateMessages moderates the text and image content of a conversation,
+// mapping the results back to the messages. Text-only messages are moderated
+// in a single request, split into windows like ModerateText when too long;
+// messages with images need a request each, since the API returns one result
+// per multi-modal input. model defaults to ModerationOmniLatest, the only
+// model accepting images.
+func (c *Client) ModerateMessages(
+	ctx context.Context,
+	messages []ChatCompletionMessage,
+	model string,
+) (moderation ConversationModeration, err error) {
+	if model == "" {
+		model = ModerationOmniLatest
+	}
+
+	var (
+		texts        []string
+		textMessages []int
+		results      = make(map[int][]Result)
+	)
+	for i, message := range messages {
+		text, images := flattenMessageForModeration(message)
+		// Text too long for a single request is moderated in windows along
+		// with the text-only messages.
+		longText := len([]rune(text)) > moderationWindowSize
+		if text != "" && (len(images) == 0 || longText) {
+			texts = append(texts, text)
+			textMessages = append(textMessages, i)
+		}
+		if len(images) == 0 {
+			continue
+		}
+
+		var items []ModerationRequestItem
+		if text != "" && !longText {
+			items = append(items, ModerationRequestItem{Type: ModerationItemTypeText, Text: text})
+		}
+		for _, url := range images {
+			items = append(items, ModerationRequestItem{
+				Type:     ModerationItemTypeImageURL,
+				ImageURL: ModerationImageURL{URL: url},
+			})
+		}
+		var response ModerationResponse
+		response, err = c.Moderations(ctx, ModerationArrayRequest{Input: items, Model: model})
+		if err != nil {
+			return
+		}
+		results[i] = append(results[i], response.Results...)
+	}
+
+	if len(texts) > 0 {
+		var textResults []Result
+		textResults, err = c.moderateTexts(ctx, texts, model)
+		if err != nil {
+			return
+		}
+		for j, result := range textResults {
+			results[textMessages[j]] = append(results[textMessages[j]], result)
+		}
+	}
+
+	for i := range messages {
+		if len(results[i]) == 0 {
+			continue
+		}
+		result := mergeModerationResults(results[i])
+		moderation.Results = append(moderation.Results, MessageModerationResult{MessageIndex: i, Result: result})
+		moderation.Flagged = moderation.Flagged || result.Flagged
+	}
+	return
+}
+
+// flattenMessageForModeration returns the text of the message and the URLs
+// of its images.
+func flattenMessageForModeration(message ChatCompletionMessage) (text string, images []string) {
+	parts := []string{message.Content}
+	for _, part := range message.MultiContent {
+		switch part.Type {
+		case ChatMessagePartTypeText:
+			parts = append(parts, part.Text)
+		case ChatMessagePartTypeImageURL:
+			if part.ImageURL != nil {
+				images = append(images, part.ImageURL.URL)
+			}
+		}
+	}
+	for _, call := range message.ToolCalls {
+		parts = append(parts, call.Function.Arguments)
+	}
+
+	var nonEmpty []string
+	for _, p := range parts {
+		if p != "" {
+			nonEmpty = append(nonEmpty, p)
+		}
+	}
+	return strings.Join(nonEmpty, "\n"), images
+}
@@ -0,0 +1,79 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+// handleModerationByContent flags every input containing "bad", returning
+// one result per string input and a single result for multi-modal inputs.
+func handleModerationByContent(t *testing.T, requests *[]json.RawMessage) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Input json.RawMessage `json:"input"`
+		}
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		*requests = append(*requests, req.Input)
+
+		var inputs []string
+		var items []openai.ModerationRequestItem
+		if json.Unmarshal(req.Input, &inputs) != nil {
+			checks.NoError(t, json.Unmarshal(req.Input, &items))
+			var combined []string
+			for _, item := range items {
+				combined = append(combined, item.Text, item.ImageURL.URL)
+			}
+			inputs = []string{strings.Join(combined, " ")}
+		}
+
+		var response openai.ModerationResponse
+		for _, input := range inputs {
+			flagged := strings.Contains(input, "bad")
+			result := openai.Result{Flagged: flagged}
+			if flagged {
+				result.CategoryScores.Violence = 0.9
+			}
+			response.Results = append(response.Results, result)
+		}
+		checks.NoError(t, json.NewEncoder(w).Encode(response))
+	}
+}
+
+func TestModerateMessages(t *testing.T) {
+	var requests []json.RawMessage
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/moderations", handleModerationByContent(t, &requests))
+
+	messages := []openai.ChatCompletionMessage{
+		openai.SystemMessage("Be helpful."),
+		openai.UserMessageParts(
+			openai.TextPart("What is in this picture?"),
+			openai.ImageURLPart("https://example.com/bad.png", openai.ImageURLDetailAuto),
+		),
+		openai.AssistantMessage(""),
+		openai.UserMessage("something bad"),
+	}
+	moderation, err := client.ModerateMessages(context.Background(), messages, "")
+	checks.NoError(t, err)
+
+	if len(requests) != 2 {
+		t.Errorf("expected one request for the image message and one for the text messages, got %d", len(requests))
+	}
+	if !moderation.Flagged || len(moderation.Results) != 3 {
+		t.Fatalf("unexpected moderation: %+v", moderation)
+	}
+	wantIndices := []int{0, 1, 3}
+	wantFlagged := []bool{false, true, true}
+	for i, result := range moderation.Results {
+		if result.MessageIndex != wantIndices[i] || result.Flagged != wantFlagged[i] {
+			t.Errorf("result %d: expected message %d flagged=%v, got %+v", i, wantIndices[i], wantFlagged[i], result)
+		}
+	}
+}
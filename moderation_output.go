@@ -0,0 +1,113 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const defaultStreamModerationWindow = 1000
+
+// OutputFlaggedError reports generated content flagged by the moderation
+// model. It wraps ErrOutputFlagged.
+type OutputFlaggedError struct {
+	// Index is the index of the flagged choice.
+	Index int
+	// Text is the moderated text: the content of the choice, or the window
+	// of it that was flagged for streams.
+	Text   string
+	Result Result
+}
+
+func (e *OutputFlaggedError) Error() string {
+	return fmt.Sprintf("choice %d: %s", e.Index, ErrOutputFlagged)
+}
+
+func (e *OutputFlaggedError) Unwrap() error {
+	return ErrOutputFlagged
+}
+
+// ModerateOutput moderates the messages of the choices of a response before
+// they are forwarded to users, like ModerateMessages does for the input, and
+// returns an *OutputFlaggedError for the first flagged choice. model
+// defaults to ModerationOmniLatest. For streams, moderate the response
+// returned by Accumulate, or use ModerateStreamOutput.
+func (c *Client) ModerateOutput(ctx context.Context, response ChatCompletionResponse, model string) error {
+	messages := make([]ChatCompletionMessage, len(response.Choices))
+	for i, choice := range response.Choices {
+		messages[i] = choice.Message
+	}
+	moderation, err := c.ModerateMessages(ctx, messages, model)
+	if err != nil {
+		return fmt.Errorf("error, moderating output: %w", err)
+	}
+	for _, result := range moderation.Results {
+		if result.Flagged {
+			choice := response.Choices[result.MessageIndex]
+			return &OutputFlaggedError{Index: choice.Index, Text: choice.Message.Content, Result: result.Result}
+		}
+	}
+	return nil
+}
+
+// StreamModerationOptions configures ModerateStreamOutput.
+type StreamModerationOptions struct {
+	// Model is the moderation model, ModerationOmniLatest by default.
+	Model string
+	// Window is the number of bytes of content of a choice streamed between
+	// two checks, 1000 by default.
+	Window int
+}
+
+// ModerateStreamOutput moderates the content of the choices of a stream in
+// sliding windows as it is read: each time a choice has streamed
+// opts.Window more bytes, and when it finishes, the new content is moderated
+// along with the end of the previous window, so that text spanning two
+// windows is caught. Reading the stream then fails with an
+// *OutputFlaggedError instead of delivering the chunk that completed a
+// flagged window, or with the error of the moderation request.
+//
+// Content is delivered as it streams, so up to a window of it may have been
+// forwarded when it is flagged. To check the whole reply before forwarding
+// any of it, moderate the result of Accumulate with ModerateOutput instead.
+func (c *Client) ModerateStreamOutput(ctx context.Context, stream *ChatCompletionStream, opts StreamModerationOptions) {
+	window := opts.Window
+	if window <= 0 {
+		window = defaultStreamModerationWindow
+	}
+	contents := make(map[int]*strings.Builder)
+	checked := make(map[int]int)
+	stream.AddTransform(func(chunk *ChatCompletionStreamResponse) error {
+		for _, choice := range chunk.Choices {
+			content, ok := contents[choice.Index]
+			if !ok {
+				content = &strings.Builder{}
+				contents[choice.Index] = content
+			}
+			content.WriteString(choice.Delta.Content)
+			pending := content.Len() - checked[choice.Index]
+			if pending < window && !(choice.FinishReason.IsFinished() && pending > 0) {
+				continue
+			}
+
+			text := content.String()
+			start := checked[choice.Index] - moderationWindowOverlap
+			if start < 0 {
+				start = 0
+			}
+			for start > 0 && !utf8.RuneStart(text[start]) {
+				start--
+			}
+			checked[choice.Index] = len(text)
+			result, err := c.ModerateText(ctx, text[start:], opts.Model)
+			if err != nil {
+				return fmt.Errorf("error, moderating output: %w", err)
+			}
+			if result.Flagged {
+				return &OutputFlaggedError{Index: choice.Index, Text: text[start:], Result: result}
+			}
+		}
+		return nil
+	})
+}
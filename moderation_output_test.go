@@ -0,0 +1,86 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestModerateOutput(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/moderations", handleFlagWordModeration(t, "attack"))
+
+	response := openai.ChatCompletionResponse{Choices: []openai.ChatCompletionChoice{
+		{Index: 0, Message: openai.AssistantMessage("Here is a recipe.")},
+		{Index: 1, Message: openai.AssistantMessage("Here is how to attack.")},
+	}}
+	err := client.ModerateOutput(context.Background(), response, "")
+	var flagged *openai.OutputFlaggedError
+	if !errors.As(err, &flagged) || !errors.Is(err, openai.ErrOutputFlagged) {
+		t.Fatalf("expected *OutputFlaggedError, got %v", err)
+	}
+	if flagged.Index != 1 || flagged.Text != "Here is how to attack." || !flagged.Result.Flagged {
+		t.Errorf("flagged output = %+v", flagged)
+	}
+
+	response.Choices = response.Choices[:1]
+	checks.NoError(t, client.ModerateOutput(context.Background(), response, ""), "unflagged output")
+}
+
+func TestModerateStreamOutput(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	var moderated []string
+	server.RegisterHandler("/v1/moderations", func(w http.ResponseWriter, r *http.Request) {
+		var req openai.ModerationStrArrayRequest
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		moderated = append(moderated, req.Input...)
+		results := make([]openai.Result, len(req.Input))
+		for i, input := range req.Input {
+			results[i].Flagged = strings.Contains(input, "attack")
+		}
+		checks.NoError(t, json.NewEncoder(w).Encode(openai.ModerationResponse{Results: results}))
+	})
+
+	chunk := func(content string, finish openai.FinishReason) openai.ChatCompletionStreamResponse {
+		return openai.ChatCompletionStreamResponse{Choices: []openai.ChatCompletionStreamChoice{
+			{Delta: openai.ChatCompletionStreamChoiceDelta{Content: content}, FinishReason: finish},
+		}}
+	}
+
+	stream := openai.NewChatCompletionStream(&mockStreamReader{responses: []openai.ChatCompletionStreamResponse{
+		chunk("one two ", ""), chunk("three four ", ""), chunk("five", openai.FinishReasonStop),
+	}})
+	client.ModerateStreamOutput(context.Background(), stream, openai.StreamModerationOptions{Window: 10})
+	content, _ := readContent(t, stream)
+	if content != "one two three four five" {
+		t.Errorf("content = %q", content)
+	}
+	want := []string{"one two three four ", "one two three four five"}
+	if strings.Join(moderated, "|") != strings.Join(want, "|") {
+		t.Errorf("moderated windows = %q, want %q", moderated, want)
+	}
+
+	stream = openai.NewChatCompletionStream(&mockStreamReader{responses: []openai.ChatCompletionStreamResponse{
+		chunk("Sure, to att", ""), chunk("ack a castle", ""), chunk(" you need", openai.FinishReasonStop),
+	}})
+	client.ModerateStreamOutput(context.Background(), stream, openai.StreamModerationOptions{Window: 20})
+	var delivered strings.Builder
+	for stream.Next() {
+		delivered.WriteString(stream.Current().Choices[0].Delta.Content)
+	}
+	var flagged *openai.OutputFlaggedError
+	if !errors.As(stream.Err(), &flagged) || flagged.Text != "Sure, to attack a castle" {
+		t.Fatalf("expected *OutputFlaggedError for the first window, got %v", stream.Err())
+	}
+	if delivered.String() != "Sure, to att" {
+		t.Errorf("delivered %q before the flag", delivered.String())
+	}
+}
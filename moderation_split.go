@@ -0,0 +1,146 @@
+package openai
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// The moderation models only consider a bounded prompt, so longer texts are
+// moderated in overlapping windows. Sizes are in characters, about 2000 and
+// 50 tokens of English text.
+const (
+	moderationWindowSize    = 8000
+	moderationWindowOverlap = 200
+	moderationMaxBatchSize  = 32
+)
+
+// ModerateText moderates a text of any length. Texts exceeding the input
+// limit of the moderation models are split into overlapping windows whose
+// results are merged: a category is flagged if any window flags it, with the
+// highest score of all windows. model defaults to ModerationOmniLatest.
+func (c *Client) ModerateText(ctx context.Context, text, model string) (Result, error) {
+	results, err := c.moderateTexts(ctx, []string{text}, model)
+	if err != nil {
+		return Result{}, err
+	}
+	return results[0], nil
+}
+
+// moderateTexts moderates texts of any length, returning one merged result
+// per text.
+func (c *Client) moderateTexts(ctx context.Context, texts []string, model string) ([]Result, error) {
+	if model == "" {
+		model = ModerationOmniLatest
+	}
+
+	var (
+		windows []string
+		owners  []int
+	)
+	for i, text := range texts {
+		for _, window := range splitModerationText(text, moderationWindowSize, moderationWindowOverlap) {
+			windows = append(windows, window)
+			owners = append(owners, i)
+		}
+	}
+
+	perText := make([][]Result, len(texts))
+	for start := 0; start < len(windows); start += moderationMaxBatchSize {
+		end := start + moderationMaxBatchSize
+		if end > len(windows) {
+			end = len(windows)
+		}
+		response, err := c.Moderations(ctx, ModerationStrArrayRequest{Input: windows[start:end], Model: model})
+		if err != nil {
+			return nil, err
+		}
+		for j, result := range response.Results {
+			if start+j < end {
+				owner := owners[start+j]
+				perText[owner] = append(perText[owner], result)
+			}
+		}
+	}
+
+	results := make([]Result, len(texts))
+	for i := range texts {
+		results[i] = mergeModerationResults(perText[i])
+	}
+	return results, nil
+}
+
+// splitModerationText splits text into windows of at most size runes, each
+// overlapping the previous one by overlap runes. Windows end at whitespace
+// when there is some in their second half.
+func splitModerationText(text string, size, overlap int) []string {
+	runes := []rune(text)
+	if len(runes) <= size {
+		return []string{text}
+	}
+
+	var windows []string
+	for start := 0; ; {
+		end := start + size
+		if end >= len(runes) {
+			windows = append(windows, string(runes[start:]))
+			return windows
+		}
+		for cut := end; cut > start+size/2; cut-- {
+			if unicode.IsSpace(runes[cut]) {
+				end = cut
+				break
+			}
+		}
+		windows = append(windows, strings.TrimSpace(string(runes[start:end])))
+		start = end - overlap
+	}
+}
+
+// mergeModerationResults combines the results of the windows of one text.
+func mergeModerationResults(results []Result) Result {
+	if len(results) == 1 {
+		return results[0]
+	}
+	var merged Result
+	for _, result := range results {
+		merged.Flagged = merged.Flagged || result.Flagged
+		mergeModerationFields(reflect.ValueOf(&merged.Categories).Elem(), reflect.ValueOf(result.Categories))
+		mergeModerationFields(reflect.ValueOf(&merged.CategoryScores).Elem(), reflect.ValueOf(result.CategoryScores))
+		mergeModerationFields(reflect.ValueOf(&merged.CategoryAppliedInputTypes).Elem(),
+			reflect.ValueOf(result.CategoryAppliedInputTypes))
+	}
+	return merged
+}
+
+// mergeModerationFields merges the per-category fields of src into dst:
+// booleans are OR-ed, scores take the maximum and input types are unioned.
+func mergeModerationFields(dst, src reflect.Value) {
+	for i := 0; i < dst.NumField(); i++ {
+		d, s := dst.Field(i), src.Field(i)
+		switch d.Kind() { //nolint:exhaustive // the result structs only use these kinds
+		case reflect.Bool:
+			d.SetBool(d.Bool() || s.Bool())
+		case reflect.Float64:
+			if s.Float() > d.Float() {
+				d.SetFloat(s.Float())
+			}
+		case reflect.Slice:
+			for j := 0; j < s.Len(); j++ {
+				if !sliceContains(d, s.Index(j)) {
+					d.Set(reflect.Append(d, s.Index(j)))
+				}
+			}
+		}
+	}
+}
+
+func sliceContains(slice, value reflect.Value) bool {
+	for i := 0; i < slice.Len(); i++ {
+		if slice.Index(i).Interface() == value.Interface() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestModerateTextSplitsLongInputs(t *testing.T) {
+	var requests []json.RawMessage
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/moderations", handleModerationByContent(t, &requests))
+
+	text := strings.Repeat("fine words ", 2000) + "something bad"
+	result, err := client.ModerateText(context.Background(), text, "")
+	checks.NoError(t, err)
+
+	if len(requests) != 1 {
+		t.Fatalf("expected the windows to be sent in one request, got %d", len(requests))
+	}
+	var windows []string
+	checks.NoError(t, json.Unmarshal(requests[0], &windows))
+	if len(windows) != 3 {
+		t.Fatalf("expected 3 windows, got %d", len(windows))
+	}
+	for i, window := range windows {
+		if len([]rune(window)) > 8000 {
+			t.Errorf("window %d is %d characters long", i, len([]rune(window)))
+		}
+		if i > 0 && !strings.Contains(windows[i-1], window[:100]) {
+			t.Errorf("window %d does not overlap the previous one", i)
+		}
+	}
+	if !result.Flagged || result.CategoryScores.Violence != 0.9 {
+		t.Errorf("expected the flag of the last window to be kept, got %+v", result)
+	}
+
+	requests = nil
+	result, err = client.ModerateText(context.Background(), "short and fine", openai.ModerationTextLatest)
+	checks.NoError(t, err)
+	if result.Flagged || len(requests) != 1 || string(requests[0]) != `["short and fine"]` {
+		t.Errorf("expected short text to be sent as is, got %s", requests)
+	}
+}
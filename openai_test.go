@@ -1,6 +1,8 @@
 package openai_test
 
 import (
+	"testing"
+
 	"github.com/sashabaranov/go-openai"
 	"github.com/sashabaranov/go-openai/internal/test"
 )
@@ -16,6 +18,26 @@ func setupOpenAITestServer() (client *openai.Client, server *test.ServerTest, te
 	return
 }
 
+// setupOpenAITestServerWithConfig is like setupOpenAITestServer, with the
+// client config passed to configure, when not nil, before the client is
+// created. The server is closed when the test ends.
+func setupOpenAITestServerWithConfig(
+	t *testing.T,
+	configure func(*openai.ClientConfig),
+) (*openai.Client, *test.ServerTest) {
+	t.Helper()
+	server := test.NewTestServer()
+	ts := server.OpenAITestServer()
+	ts.Start()
+	t.Cleanup(ts.Close)
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	if configure != nil {
+		configure(&config)
+	}
+	return openai.NewClientWithConfig(config), server
+}
+
 func setupAzureTestServer() (client *openai.Client, server *test.ServerTest, teardown func()) {
 	server = test.NewTestServer()
 	ts := server.OpenAITestServer()
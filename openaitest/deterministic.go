@@ -0,0 +1,354 @@
+// Package openaitest provides test doubles for code built on the openai
+// package.
+package openaitest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"math/rand"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+const (
+	defaultWords               = 16
+	defaultEmbeddingDimensions = 16
+)
+
+var vocabulary = strings.Fields(`the a model answer question data system user token stream
+	result value request response quick brown fox jumps over lazy dog alpha beta gamma delta
+	river mountain cloud signal vector matrix graph node edge cache queue shard replica`)
+
+// TemplateData is passed to DeterministicClient.Template.
+type TemplateData struct {
+	Model string
+	// Prompt is the last user message, or the prompt of a legacy completion.
+	Prompt string
+	// Text is the generated filler text.
+	Text string
+}
+
+// DeterministicClient is an openai.CompletionClient that fabricates
+// completions and embeddings locally. The output only depends on Seed and on
+// the request, so repeated runs produce identical results, which makes it
+// suitable for load tests and offline development.
+type DeterministicClient struct {
+	// Seed varies the generated output between clients.
+	Seed int64
+	// Template renders the completion content from TemplateData. The default
+	// is "{{.Text}}".
+	Template string
+	// Words is the number of generated words, 16 by default.
+	Words int
+	// EmbeddingDimensions is the length of the generated embeddings, 16 by
+	// default. Embeddings are unit vectors.
+	EmbeddingDimensions int
+
+	// Latency is waited before every response, and ChunkLatency before every
+	// chunk of a stream.
+	Latency      time.Duration
+	ChunkLatency time.Duration
+
+	// FailEvery makes every n-th request fail with Err, or with a 500
+	// APIError if Err is nil.
+	FailEvery int
+	Err       error
+
+	mu       sync.Mutex
+	requests int
+	tmpl     *template.Template
+}
+
+var _ openai.CompletionClient = (*DeterministicClient)(nil)
+
+func (c *DeterministicClient) CreateChatCompletion(
+	ctx context.Context,
+	request openai.ChatCompletionRequest,
+) (openai.ChatCompletionResponse, error) {
+	content, err := c.generate(ctx, request.Model, lastUserMessage(request.Messages), request)
+	if err != nil {
+		return openai.ChatCompletionResponse{}, err
+	}
+	return openai.ChatCompletionResponse{
+		ID:     c.id("chatcmpl", request),
+		Object: "chat.completion",
+		Model:  request.Model,
+		Choices: []openai.ChatCompletionChoice{{
+			Message:      openai.AssistantMessage(content),
+			FinishReason: openai.FinishReasonStop,
+		}},
+		Usage: usage(request, content),
+	}, nil
+}
+
+func (c *DeterministicClient) CreateChatCompletionStream(
+	ctx context.Context,
+	request openai.ChatCompletionRequest,
+) (*openai.ChatCompletionStream, error) {
+	// Streamed and non-streamed requests produce the same content.
+	seed := request
+	seed.Stream = false
+	seed.StreamOptions = nil
+	content, err := c.generate(ctx, request.Model, lastUserMessage(request.Messages), seed)
+	if err != nil {
+		return nil, err
+	}
+
+	id := c.id("chatcmpl", seed)
+	chunk := func(delta openai.ChatCompletionStreamChoiceDelta) openai.ChatCompletionStreamResponse {
+		return openai.ChatCompletionStreamResponse{
+			ID:      id,
+			Object:  "chat.completion.chunk",
+			Model:   request.Model,
+			Choices: []openai.ChatCompletionStreamChoice{{Delta: delta}},
+		}
+	}
+	chunks := []openai.ChatCompletionStreamResponse{chunk(openai.ChatCompletionStreamChoiceDelta{
+		Role: openai.ChatMessageRoleAssistant,
+	})}
+	for _, word := range strings.SplitAfter(content, " ") {
+		if word == "" {
+			continue
+		}
+		chunks = append(chunks, chunk(openai.ChatCompletionStreamChoiceDelta{Content: word}))
+	}
+	last := chunk(openai.ChatCompletionStreamChoiceDelta{})
+	last.Choices[0].FinishReason = openai.FinishReasonStop
+	chunks = append(chunks, last)
+	if request.StreamOptions != nil && request.StreamOptions.IncludeUsage {
+		u := usage(request, content)
+		chunks = append(chunks, openai.ChatCompletionStreamResponse{
+			ID:      id,
+			Object:  "chat.completion.chunk",
+			Model:   request.Model,
+			Choices: []openai.ChatCompletionStreamChoice{},
+			Usage:   &u,
+		})
+	}
+
+	return openai.NewChatCompletionStream(&chunkReader{ctx: ctx, chunks: chunks, latency: c.ChunkLatency}), nil
+}
+
+func (c *DeterministicClient) CreateCompletion(
+	ctx context.Context,
+	request openai.CompletionRequest,
+) (openai.CompletionResponse, error) {
+	prompt := fmt.Sprint(request.Prompt)
+	if s, ok := request.Prompt.(string); ok {
+		prompt = s
+	}
+	text, err := c.generate(ctx, request.Model, prompt, request)
+	if err != nil {
+		return openai.CompletionResponse{}, err
+	}
+	u := usage(request, text)
+	return openai.CompletionResponse{
+		ID:     c.id("cmpl", request),
+		Object: "text_completion",
+		Model:  request.Model,
+		Choices: []openai.CompletionChoice{{
+			Text:         text,
+			FinishReason: string(openai.FinishReasonStop),
+		}},
+		Usage: &u,
+	}, nil
+}
+
+func (c *DeterministicClient) CreateEmbeddings(
+	ctx context.Context,
+	conv openai.EmbeddingRequestConverter,
+) (openai.EmbeddingResponse, error) {
+	request := conv.Convert()
+	if err := c.begin(ctx); err != nil {
+		return openai.EmbeddingResponse{}, err
+	}
+
+	var inputs []string
+	switch input := request.Input.(type) {
+	case string:
+		inputs = []string{input}
+	case []string:
+		inputs = input
+	default:
+		inputs = []string{fmt.Sprint(input)}
+	}
+	dimensions := request.Dimensions
+	if dimensions == 0 {
+		dimensions = c.EmbeddingDimensions
+	}
+	if dimensions == 0 {
+		dimensions = defaultEmbeddingDimensions
+	}
+
+	response := openai.EmbeddingResponse{Object: "list", Model: request.Model}
+	for i, input := range inputs {
+		response.Data = append(response.Data, openai.Embedding{
+			Object:    "embedding",
+			Embedding: c.vector(input, dimensions),
+			Index:     i,
+		})
+		response.Usage.PromptTokens += len(strings.Fields(input))
+	}
+	response.Usage.TotalTokens = response.Usage.PromptTokens
+	return response, nil
+}
+
+// begin simulates latency and injects failures.
+func (c *DeterministicClient) begin(ctx context.Context) error {
+	c.mu.Lock()
+	c.requests++
+	fail := c.FailEvery > 0 && c.requests%c.FailEvery == 0
+	c.mu.Unlock()
+
+	if err := sleep(ctx, c.Latency); err != nil {
+		return err
+	}
+	if !fail {
+		return nil
+	}
+	if c.Err != nil {
+		return c.Err
+	}
+	return &openai.APIError{
+		Type:           "server_error",
+		Message:        "injected failure",
+		HTTPStatus:     "500 Internal Server Error",
+		HTTPStatusCode: 500,
+	}
+}
+
+func (c *DeterministicClient) generate(ctx context.Context, model, prompt string, request any) (string, error) {
+	if err := c.begin(ctx); err != nil {
+		return "", err
+	}
+
+	words := c.Words
+	if words == 0 {
+		words = defaultWords
+	}
+	rng := rand.New(rand.NewSource(c.hash(request))) //nolint:gosec // determinism is the point
+	text := make([]string, words)
+	for i := range text {
+		text[i] = vocabulary[rng.Intn(len(vocabulary))]
+	}
+
+	tmpl, err := c.template()
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	err = tmpl.Execute(&b, TemplateData{Model: model, Prompt: prompt, Text: strings.Join(text, " ")})
+	return b.String(), err
+}
+
+func (c *DeterministicClient) template() (*template.Template, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.tmpl != nil {
+		return c.tmpl, nil
+	}
+	text := c.Template
+	if text == "" {
+		text = "{{.Text}}"
+	}
+	tmpl, err := template.New("completion").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	c.tmpl = tmpl
+	return tmpl, nil
+}
+
+func (c *DeterministicClient) vector(input string, dimensions int) []float32 {
+	rng := rand.New(rand.NewSource(c.hash(input))) //nolint:gosec // determinism is the point
+	vector := make([]float32, dimensions)
+	var norm float64
+	for i := range vector {
+		v := rng.NormFloat64()
+		vector[i] = float32(v)
+		norm += v * v
+	}
+	norm = math.Sqrt(norm)
+	for i := range vector {
+		vector[i] = float32(float64(vector[i]) / norm)
+	}
+	return vector
+}
+
+func (c *DeterministicClient) id(prefix string, request any) string {
+	return fmt.Sprintf("%s-%016x", prefix, uint64(c.hash(request)))
+}
+
+// hash derives a seed from the client seed and the JSON encoding of v.
+func (c *DeterministicClient) hash(v any) int64 {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%d:", c.Seed)
+	_ = json.NewEncoder(h).Encode(v)
+	return int64(h.Sum64())
+}
+
+func lastUserMessage(messages []openai.ChatCompletionMessage) string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == openai.ChatMessageRoleUser {
+			return messages[i].Content
+		}
+	}
+	return ""
+}
+
+// usage approximates token counts with word counts.
+func usage(request any, output string) openai.Usage {
+	data, _ := json.Marshal(request)
+	prompt := len(strings.Fields(string(data)))
+	completion := len(strings.Fields(output))
+	return openai.Usage{
+		PromptTokens:     prompt,
+		CompletionTokens: completion,
+		TotalTokens:      prompt + completion,
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type chunkReader struct {
+	ctx     context.Context
+	chunks  []openai.ChatCompletionStreamResponse
+	latency time.Duration
+}
+
+func (r *chunkReader) Recv() (openai.ChatCompletionStreamResponse, error) {
+	if len(r.chunks) == 0 {
+		return openai.ChatCompletionStreamResponse{}, io.EOF
+	}
+	if err := sleep(r.ctx, r.latency); err != nil {
+		return openai.ChatCompletionStreamResponse{}, err
+	}
+	chunk := r.chunks[0]
+	r.chunks = r.chunks[1:]
+	return chunk, nil
+}
+
+func (r *chunkReader) Close() error {
+	r.chunks = nil
+	return nil
+}
@@ -0,0 +1,128 @@
+package openaitest_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+	"github.com/sashabaranov/go-openai/openaitest"
+)
+
+func chatRequest(question string) openai.ChatCompletionRequest {
+	return openai.ChatCompletionRequest{
+		Model:    openai.GPT4oMini,
+		Messages: []openai.ChatCompletionMessage{openai.UserMessage(question)},
+	}
+}
+
+func TestDeterministicClientChatCompletion(t *testing.T) {
+	ctx := context.Background()
+	client := &openaitest.DeterministicClient{Seed: 1, Words: 5}
+
+	first, err := client.CreateChatCompletion(ctx, chatRequest("Hello"))
+	checks.NoError(t, err)
+	again, err := client.CreateChatCompletion(ctx, chatRequest("Hello"))
+	checks.NoError(t, err)
+	content := first.Choices[0].Message.Content
+	if again.Choices[0].Message.Content != content || again.ID != first.ID {
+		t.Error("expected identical requests to produce identical responses")
+	}
+	if words := len(strings.Fields(content)); words != 5 {
+		t.Errorf("expected 5 words, got %d in %q", words, content)
+	}
+
+	other, err := (&openaitest.DeterministicClient{Seed: 2, Words: 5}).CreateChatCompletion(ctx, chatRequest("Hello"))
+	checks.NoError(t, err)
+	if other.Choices[0].Message.Content == content {
+		t.Error("expected a different seed to produce different content")
+	}
+}
+
+func TestDeterministicClientTemplate(t *testing.T) {
+	client := &openaitest.DeterministicClient{Template: "[{{.Model}}] You said: {{.Prompt}}"}
+	resp, err := client.CreateChatCompletion(context.Background(), chatRequest("ping"))
+	checks.NoError(t, err)
+	if want := "[gpt-4o-mini] You said: ping"; resp.Choices[0].Message.Content != want {
+		t.Errorf("expected %q, got %q", want, resp.Choices[0].Message.Content)
+	}
+}
+
+func TestDeterministicClientStream(t *testing.T) {
+	ctx := context.Background()
+	client := &openaitest.DeterministicClient{Seed: 3}
+
+	resp, err := client.CreateChatCompletion(ctx, chatRequest("Hello"))
+	checks.NoError(t, err)
+
+	request := chatRequest("Hello")
+	request.StreamOptions = &openai.StreamOptions{IncludeUsage: true}
+	stream, err := client.CreateChatCompletionStream(ctx, request)
+	checks.NoError(t, err)
+	defer stream.Close()
+	streamed, err := stream.Accumulate()
+	checks.NoError(t, err)
+
+	if streamed.Choices[0].Message.Content != resp.Choices[0].Message.Content {
+		t.Errorf("expected streamed content %q, got %q",
+			resp.Choices[0].Message.Content, streamed.Choices[0].Message.Content)
+	}
+	if streamed.Choices[0].FinishReason != openai.FinishReasonStop || streamed.Usage.CompletionTokens == 0 {
+		t.Errorf("unexpected final state: %+v", streamed)
+	}
+}
+
+func TestDeterministicClientEmbeddings(t *testing.T) {
+	client := &openaitest.DeterministicClient{EmbeddingDimensions: 8}
+	resp, err := client.CreateEmbeddings(context.Background(), openai.EmbeddingRequestStrings{
+		Input: []string{"a", "b", "a"},
+		Model: openai.SmallEmbedding3,
+	})
+	checks.NoError(t, err)
+	if len(resp.Data) != 3 || len(resp.Data[0].Embedding) != 8 {
+		t.Fatalf("unexpected embeddings shape: %+v", resp.Data)
+	}
+
+	var norm float64
+	for _, v := range resp.Data[0].Embedding {
+		norm += float64(v) * float64(v)
+	}
+	if math.Abs(norm-1) > 1e-5 {
+		t.Errorf("expected a unit vector, got squared norm %f", norm)
+	}
+	same, err := resp.Data[0].DotProduct(&resp.Data[2])
+	checks.NoError(t, err)
+	if math.Abs(float64(same)-1) > 1e-5 {
+		t.Errorf("expected equal inputs to produce equal embeddings, got dot product %f", same)
+	}
+}
+
+func TestDeterministicClientFailures(t *testing.T) {
+	ctx := context.Background()
+	client := &openaitest.DeterministicClient{FailEvery: 2}
+
+	_, err := client.CreateCompletion(ctx, openai.CompletionRequest{Model: openai.GPT3Dot5TurboInstruct, Prompt: "x"})
+	checks.NoError(t, err)
+	_, err = client.CreateCompletion(ctx, openai.CompletionRequest{Model: openai.GPT3Dot5TurboInstruct, Prompt: "x"})
+	var apiErr *openai.APIError
+	if !errors.As(err, &apiErr) || apiErr.HTTPStatusCode != 500 {
+		t.Fatalf("expected injected 500 APIError, got %v", err)
+	}
+
+	custom := errors.New("boom")
+	client = &openaitest.DeterministicClient{FailEvery: 1, Err: custom}
+	_, err = client.CreateChatCompletion(ctx, chatRequest("Hello"))
+	checks.ErrorIs(t, err, custom)
+}
+
+func TestDeterministicClientLatency(t *testing.T) {
+	client := &openaitest.DeterministicClient{Latency: time.Second}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := client.CreateChatCompletion(ctx, chatRequest("Hello"))
+	checks.ErrorIs(t, err, context.DeadlineExceeded)
+}
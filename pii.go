@@ -0,0 +1,209 @@
+package openai
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// PIIDetector finds personally identifiable information in text.
+type PIIDetector interface {
+	// Kind names the type of PII found, e.g. "EMAIL". It is used in the
+	// replacement markers.
+	Kind() string
+	// Detect returns the [start, end) byte offsets of every match in text.
+	Detect(text string) [][]int
+}
+
+type regexpPIIDetector struct {
+	kind    string
+	pattern *regexp.Regexp
+}
+
+// NewRegexpPIIDetector creates a detector reporting every match of pattern as
+// PII of the given kind.
+func NewRegexpPIIDetector(kind string, pattern *regexp.Regexp) PIIDetector {
+	return &regexpPIIDetector{kind: kind, pattern: pattern}
+}
+
+func (d *regexpPIIDetector) Kind() string {
+	return d.kind
+}
+
+func (d *regexpPIIDetector) Detect(text string) [][]int {
+	return d.pattern.FindAllStringIndex(text, -1)
+}
+
+// Built-in detectors. They are deliberately simple and tuned for US formats;
+// supply custom detectors for anything stricter.
+var (
+	EmailPIIDetector = NewRegexpPIIDetector("EMAIL",
+		regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`))
+	PhonePIIDetector = NewRegexpPIIDetector("PHONE",
+		regexp.MustCompile(`(?:\+\d{1,3}[\s.-]?)?(?:\(\d{3}\)|\b\d{3})[\s.-]?\d{3}[\s.-]\d{4}\b`))
+	SSNPIIDetector = NewRegexpPIIDetector("SSN",
+		regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`))
+)
+
+// PIIRedactionMode selects how detected PII is replaced.
+type PIIRedactionMode int
+
+const (
+	// PIIRedact replaces PII with a marker of its kind, e.g. [EMAIL]. The
+	// original values cannot be restored.
+	PIIRedact PIIRedactionMode = iota
+	// PIITokenize replaces PII with numbered placeholders, e.g. [EMAIL_1],
+	// which Restore maps back to the original values. Equal values share a
+	// placeholder, so the model can still tell them apart.
+	PIITokenize
+)
+
+// PIIRedactor removes PII from outbound chat messages and restores
+// placeholders in responses. The client has no middleware chain, so redaction
+// wraps the call explicitly:
+//
+//	redactor := openai.NewPIIRedactor(openai.PIITokenize)
+//	resp, err := client.CreateChatCompletion(ctx, redactor.RedactRequest(req))
+//	if err == nil {
+//		redactor.RestoreResponse(&resp)
+//	}
+//
+// A redactor accumulates placeholders across calls; use one per conversation.
+type PIIRedactor struct {
+	mode      PIIRedactionMode
+	detectors []PIIDetector
+
+	mu           sync.Mutex
+	placeholders map[string]string
+	originals    map[string]string
+	counters     map[string]int
+}
+
+// NewPIIRedactor creates a redactor using the given detectors, or the
+// built-in email, phone and SSN detectors if none are given.
+func NewPIIRedactor(mode PIIRedactionMode, detectors ...PIIDetector) *PIIRedactor {
+	if len(detectors) == 0 {
+		detectors = []PIIDetector{EmailPIIDetector, PhonePIIDetector, SSNPIIDetector}
+	}
+	return &PIIRedactor{
+		mode:         mode,
+		detectors:    detectors,
+		placeholders: make(map[string]string),
+		originals:    make(map[string]string),
+		counters:     make(map[string]int),
+	}
+}
+
+type piiMatch struct {
+	start, end int
+	kind       string
+}
+
+// RedactText replaces the PII found in text.
+func (r *PIIRedactor) RedactText(text string) string {
+	var matches []piiMatch
+	for _, detector := range r.detectors {
+		for _, loc := range detector.Detect(text) {
+			matches = append(matches, piiMatch{start: loc[0], end: loc[1], kind: detector.Kind()})
+		}
+	}
+	if len(matches) == 0 {
+		return text
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].start < matches[j].start
+	})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		if m.start < last {
+			// Overlaps a previous match, which takes precedence.
+			continue
+		}
+		b.WriteString(text[last:m.start])
+		b.WriteString(r.replacement(m.kind, text[m.start:m.end]))
+		last = m.end
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+func (r *PIIRedactor) replacement(kind, value string) string {
+	if r.mode == PIIRedact {
+		return "[" + kind + "]"
+	}
+	if placeholder, ok := r.placeholders[value]; ok {
+		return placeholder
+	}
+	r.counters[kind]++
+	placeholder := fmt.Sprintf("[%s_%d]", kind, r.counters[kind])
+	r.placeholders[value] = placeholder
+	r.originals[placeholder] = value
+	return placeholder
+}
+
+// RedactMessages returns a copy of the messages with PII replaced in their
+// text content, text parts and tool call arguments.
+func (r *PIIRedactor) RedactMessages(messages []ChatCompletionMessage) []ChatCompletionMessage {
+	redacted := make([]ChatCompletionMessage, len(messages))
+	for i, message := range messages {
+		message.Content = r.RedactText(message.Content)
+		if message.MultiContent != nil {
+			parts := make([]ChatMessagePart, len(message.MultiContent))
+			for j, part := range message.MultiContent {
+				part.Text = r.RedactText(part.Text)
+				parts[j] = part
+			}
+			message.MultiContent = parts
+		}
+		if message.ToolCalls != nil {
+			calls := make([]ToolCall, len(message.ToolCalls))
+			for j, call := range message.ToolCalls {
+				call.Function.Arguments = r.RedactText(call.Function.Arguments)
+				calls[j] = call
+			}
+			message.ToolCalls = calls
+		}
+		redacted[i] = message
+	}
+	return redacted
+}
+
+// RedactRequest returns a copy of the request with PII removed from its messages.
+func (r *PIIRedactor) RedactRequest(request ChatCompletionRequest) ChatCompletionRequest {
+	request.Messages = r.RedactMessages(request.Messages)
+	return request
+}
+
+// Restore replaces the placeholders issued by the redactor with the original
+// values. It returns text unchanged in PIIRedact mode.
+func (r *PIIRedactor) Restore(text string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.originals) == 0 || !strings.Contains(text, "[") {
+		return text
+	}
+	pairs := make([]string, 0, 2*len(r.originals))
+	for placeholder, original := range r.originals {
+		pairs = append(pairs, placeholder, original)
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
+
+// RestoreResponse restores placeholders in the content and tool call
+// arguments of every choice of the response.
+func (r *PIIRedactor) RestoreResponse(response *ChatCompletionResponse) {
+	for i := range response.Choices {
+		message := &response.Choices[i].Message
+		message.Content = r.Restore(message.Content)
+		for j := range message.ToolCalls {
+			message.ToolCalls[j].Function.Arguments = r.Restore(message.ToolCalls[j].Function.Arguments)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package openai_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+func TestPIIRedactorRedact(t *testing.T) {
+	redactor := openai.NewPIIRedactor(openai.PIIRedact)
+	got := redactor.RedactText("Mail jane.doe@example.com or call (555) 123-4567, SSN 123-45-6789.")
+	want := "Mail [EMAIL] or call [PHONE], SSN [SSN]."
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if restored := redactor.Restore(got); restored != got {
+		t.Errorf("redact mode should not restore, got %q", restored)
+	}
+}
+
+func TestPIIRedactorTokenizeAndRestore(t *testing.T) {
+	employeeID := openai.NewRegexpPIIDetector("EMPLOYEE", regexp.MustCompile(`\bE\d{6}\b`))
+	redactor := openai.NewPIIRedactor(openai.PIITokenize, openai.EmailPIIDetector, employeeID)
+
+	messages := []openai.ChatCompletionMessage{
+		openai.UserMessage("I am E123456, reach me at a@example.com."),
+		openai.UserMessageParts(openai.TextPart("Also cc b@example.com and a@example.com.")),
+	}
+	request := redactor.RedactRequest(openai.ChatCompletionRequest{Messages: messages})
+
+	if got, want := request.Messages[0].Content, "I am [EMPLOYEE_1], reach me at [EMAIL_1]."; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if got, want := request.Messages[1].MultiContent[0].Text, "Also cc [EMAIL_2] and [EMAIL_1]."; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if messages[0].Content != "I am E123456, reach me at a@example.com." {
+		t.Error("original messages should not be modified")
+	}
+
+	response := openai.ChatCompletionResponse{
+		Choices: []openai.ChatCompletionChoice{{
+			Message: openai.ChatCompletionMessage{
+				Content: "Sending to [EMAIL_1] and [EMAIL_2] for [EMPLOYEE_1].",
+				ToolCalls: []openai.ToolCall{{
+					Function: openai.FunctionCall{Name: "send", Arguments: `{"to":"[EMAIL_2]"}`},
+				}},
+			},
+		}},
+	}
+	redactor.RestoreResponse(&response)
+	message := response.Choices[0].Message
+	if want := "Sending to a@example.com and b@example.com for E123456."; message.Content != want {
+		t.Errorf("expected %q, got %q", want, message.Content)
+	}
+	if want := `{"to":"b@example.com"}`; message.ToolCalls[0].Function.Arguments != want {
+		t.Errorf("expected %q, got %q", want, message.ToolCalls[0].Function.Arguments)
+	}
+}
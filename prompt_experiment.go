@@ -0,0 +1,161 @@
+package openai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// Metadata keys tagging the requests of a PromptExperiment.
+const (
+	MetadataKeyPromptExperiment = "prompt_experiment"
+	MetadataKeyPromptVariant    = "prompt_variant"
+)
+
+// PromptVariant is a version of the system prompt tested by a
+// PromptExperiment.
+type PromptVariant struct {
+	// Name identifies the variant in the metadata of requests, such as "v2".
+	Name string
+	// SystemPrompt replaces the system prompt of the requests assigned to
+	// the variant. When empty, their prompt is left unchanged, as for a
+	// control keeping the prompt of the application.
+	SystemPrompt string
+	// Weight is the relative share of units assigned to the variant, 1 when
+	// not positive.
+	Weight int
+}
+
+// PromptExperiment A/B tests system prompts. Each unit, a user or a tenant,
+// is assigned a variant from the hash of its identifier, so it sees the same
+// prompt across requests and clients.
+//
+// Set as ClientConfig.PromptExperiment, it applies to the chat completion and
+// response requests of the client that have a unit: an end-user identifier,
+// or a unit set with WithExperimentUnit. Requests without one are left out
+// of the experiment. The experiment and variant are recorded in the
+// metadata of stored requests, the only ones keeping it.
+type PromptExperiment struct {
+	// Name identifies the experiment in the metadata of requests. Renaming
+	// it reshuffles the assignment of units to variants.
+	Name string
+	// Variants are the prompts tested. The first is the control, returned
+	// by Variant without a unit.
+	Variants []PromptVariant
+}
+
+type experimentUnitKey struct{}
+
+// WithExperimentUnit returns a context that assigns the requests made with
+// it to the variants of the client's PromptExperiment by unit, such as a
+// tenant ID, instead of by the end-user identifier of the request.
+func WithExperimentUnit(ctx context.Context, unit string) context.Context {
+	return context.WithValue(ctx, experimentUnitKey{}, unit)
+}
+
+// Variant returns the variant assigned to unit, or the control when unit is
+// empty. It returns the zero PromptVariant when there are no variants.
+func (e *PromptExperiment) Variant(unit string) PromptVariant {
+	if len(e.Variants) == 0 {
+		return PromptVariant{}
+	}
+	if unit == "" {
+		return e.Variants[0]
+	}
+	total := 0
+	for _, variant := range e.Variants {
+		total += variantWeight(variant)
+	}
+	sum := sha256.Sum256([]byte(e.Name + "\x00" + unit))
+	bucket := int(binary.BigEndian.Uint64(sum[:8]) % uint64(total))
+	for _, variant := range e.Variants {
+		if bucket -= variantWeight(variant); bucket < 0 {
+			return variant
+		}
+	}
+	return e.Variants[len(e.Variants)-1]
+}
+
+func variantWeight(variant PromptVariant) int {
+	if variant.Weight <= 0 {
+		return 1
+	}
+	return variant.Weight
+}
+
+// tags returns the metadata with the experiment and variant added, without
+// modifying it.
+func (e *PromptExperiment) tags(metadata map[string]string, variant PromptVariant) map[string]string {
+	tagged := make(map[string]string, len(metadata)+2)
+	for k, v := range metadata {
+		tagged[k] = v
+	}
+	tagged[MetadataKeyPromptExperiment] = e.Name
+	tagged[MetadataKeyPromptVariant] = variant.Name
+	return tagged
+}
+
+// experimentUnit returns the unit of a request: the one set on ctx with
+// WithExperimentUnit, else its end-user identifier.
+func (c *Client) experimentUnit(ctx context.Context, user string) string {
+	if unit, _ := ctx.Value(experimentUnitKey{}).(string); unit != "" {
+		return unit
+	}
+	return c.resolveUser(ctx, user)
+}
+
+// experimentVariant returns the variant of the client's PromptExperiment
+// assigned to a request, or false when the request is not in the experiment.
+func (c *Client) experimentVariant(ctx context.Context, user string) (PromptVariant, bool) {
+	experiment := c.config.PromptExperiment
+	if experiment == nil || len(experiment.Variants) == 0 {
+		return PromptVariant{}, false
+	}
+	unit := c.experimentUnit(ctx, user)
+	if unit == "" {
+		return PromptVariant{}, false
+	}
+	return experiment.Variant(unit), true
+}
+
+// applyChatExperiment sets the system prompt of the request to the variant
+// of the client's PromptExperiment assigned to it, replacing its leading
+// system or developer message, and tags its metadata with the variant.
+func (c *Client) applyChatExperiment(ctx context.Context, request *ChatCompletionRequest) {
+	variant, ok := c.experimentVariant(ctx, request.User)
+	if !ok {
+		return
+	}
+	if variant.SystemPrompt != "" {
+		messages := make([]ChatCompletionMessage, 0, len(request.Messages)+1)
+		if len(request.Messages) > 0 &&
+			(request.Messages[0].Role == ChatMessageRoleSystem || request.Messages[0].Role == ChatMessageRoleDeveloper) {
+			system := request.Messages[0]
+			system.Content = variant.SystemPrompt
+			system.MultiContent = nil
+			messages = append(append(messages, system), request.Messages[1:]...)
+		} else {
+			messages = append(append(messages, SystemMessage(variant.SystemPrompt)), request.Messages...)
+		}
+		request.Messages = messages
+	}
+	if request.isStored() {
+		request.Metadata = c.config.PromptExperiment.tags(request.Metadata, variant)
+	}
+}
+
+// applyResponseExperiment sets the instructions of the request to the
+// variant of the client's PromptExperiment assigned to it, and tags its
+// metadata with the variant.
+func (c *Client) applyResponseExperiment(ctx context.Context, request *ResponseRequest) {
+	variant, ok := c.experimentVariant(ctx, request.User)
+	if !ok {
+		return
+	}
+	if variant.SystemPrompt != "" {
+		request.Instructions = variant.SystemPrompt
+	}
+	if request.isStored() {
+		request.Metadata = c.config.PromptExperiment.tags(request.Metadata, variant)
+	}
+}
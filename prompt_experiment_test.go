@@ -0,0 +1,143 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestPromptExperimentVariant(t *testing.T) {
+	experiment := &openai.PromptExperiment{
+		Name: "tone",
+		Variants: []openai.PromptVariant{
+			{Name: "control", SystemPrompt: "Be helpful."},
+			{Name: "friendly", SystemPrompt: "Be helpful and friendly.", Weight: 3},
+		},
+	}
+	if v := experiment.Variant(""); v.Name != "control" {
+		t.Errorf("variant without unit = %q, want control", v.Name)
+	}
+
+	counts := map[string]int{}
+	for i := 0; i < 4000; i++ {
+		unit := fmt.Sprintf("user-%d", i)
+		variant := experiment.Variant(unit)
+		if again := experiment.Variant(unit); again.Name != variant.Name {
+			t.Fatalf("unit %s assigned %q then %q", unit, variant.Name, again.Name)
+		}
+		counts[variant.Name]++
+	}
+	if counts["control"] < 800 || counts["control"] > 1200 {
+		t.Errorf("control assigned %d units of 4000, want about 1000", counts["control"])
+	}
+
+	if v := (&openai.PromptExperiment{}).Variant("user-1"); v.Name != "" {
+		t.Errorf("variant without variants = %v", v)
+	}
+}
+
+func TestClientPromptExperiment(t *testing.T) {
+	var body struct {
+		Messages     []openai.ChatCompletionMessage `json:"messages"`
+		Instructions string                         `json:"instructions"`
+		Metadata     map[string]string              `json:"metadata"`
+	}
+	capture := func(response string) func(http.ResponseWriter, *http.Request) {
+		return func(w http.ResponseWriter, r *http.Request) {
+			data, _ := io.ReadAll(r.Body)
+			body.Messages, body.Instructions, body.Metadata = nil, "", nil
+			_ = json.Unmarshal(data, &body)
+			fmt.Fprint(w, response)
+		}
+	}
+	experiment := &openai.PromptExperiment{
+		Name: "tone",
+		Variants: []openai.PromptVariant{
+			{Name: "control", SystemPrompt: "Be helpful."},
+			{Name: "friendly", SystemPrompt: "Be helpful and friendly."},
+		},
+	}
+	client, server := setupOpenAITestServerWithConfig(t, func(config *openai.ClientConfig) {
+		config.PromptExperiment = experiment
+	})
+	server.RegisterHandler("/v1/chat/completions", capture(`{"id":"chatcmpl-1","choices":[]}`))
+	server.RegisterHandler("/v1/responses", capture(`{"id":"resp_1","status":"completed"}`))
+
+	want := experiment.Variant("tenant-42")
+	ctx := openai.WithExperimentUnit(openai.WithUser(context.Background(), "user-1"), "tenant-42")
+	messages := []openai.ChatCompletionMessage{
+		openai.SystemMessage("Original prompt."),
+		openai.UserMessage("Hello!"),
+	}
+	metadata := map[string]string{"feature": "support"}
+	_, err := client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+		Model:    openai.GPT4oMini,
+		Messages: messages,
+		Store:    true,
+		Metadata: metadata,
+	})
+	checks.NoError(t, err, "CreateChatCompletion error")
+	if len(body.Messages) != 2 || body.Messages[0].Content != want.SystemPrompt {
+		t.Errorf("messages = %v, want the system prompt of %s", body.Messages, want.Name)
+	}
+	if body.Metadata[openai.MetadataKeyPromptExperiment] != "tone" ||
+		body.Metadata[openai.MetadataKeyPromptVariant] != want.Name || body.Metadata["feature"] != "support" {
+		t.Errorf("metadata = %v", body.Metadata)
+	}
+	if messages[0].Content != "Original prompt." || len(metadata) != 1 {
+		t.Error("caller request modified")
+	}
+
+	_, err = client.CreateChatCompletion(context.Background(), openai.ChatCompletionRequest{
+		Model:    openai.GPT4oMini,
+		Messages: []openai.ChatCompletionMessage{openai.UserMessage("Hello!")},
+	})
+	checks.NoError(t, err, "CreateChatCompletion error")
+	if len(body.Messages) != 1 || body.Metadata != nil {
+		t.Errorf("request without unit = %v, %v, want it left out of the experiment", body.Messages, body.Metadata)
+	}
+
+	want = experiment.Variant("user-7")
+	_, err = client.CreateResponse(context.Background(), openai.ResponseRequest{
+		Model: openai.GPT4oMini,
+		Input: "Hello!",
+		User:  "user-7",
+	})
+	checks.NoError(t, err, "CreateResponse error")
+	if body.Instructions != want.SystemPrompt || body.Metadata[openai.MetadataKeyPromptVariant] != want.Name {
+		t.Errorf("response request instructions = %q, metadata = %v", body.Instructions, body.Metadata)
+	}
+}
+
+func TestClientPromptExperimentKeepsPrompt(t *testing.T) {
+	var body openai.ChatCompletionRequest
+	client, server := setupOpenAITestServerWithConfig(t, func(config *openai.ClientConfig) {
+		config.PromptExperiment = &openai.PromptExperiment{
+			Name:     "tone",
+			Variants: []openai.PromptVariant{{Name: "control"}},
+		}
+	})
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		body = openai.ChatCompletionRequest{}
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&body), "request body")
+		fmt.Fprint(w, `{"id":"chatcmpl-1","choices":[]}`)
+	})
+
+	_, err := client.CreateChatCompletion(openai.WithUser(context.Background(), "user-1"), openai.ChatCompletionRequest{
+		Model:    openai.GPT4oMini,
+		Messages: []openai.ChatCompletionMessage{openai.SystemMessage("App prompt."), openai.UserMessage("Hello!")},
+	})
+	checks.NoError(t, err, "CreateChatCompletion error")
+	if len(body.Messages) != 2 || body.Messages[0].Content != "App prompt." {
+		t.Errorf("messages = %v, want the prompt of the application", body.Messages)
+	}
+	if body.Metadata != nil {
+		t.Errorf("variant tags sent with an unstored completion: %v", body.Metadata)
+	}
+}
@@ -0,0 +1,111 @@
+package openai
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// proxyFunc returns a transport Proxy function sending requests through
+// proxyURL, except those to hosts matched by noProxy, in the NO_PROXY format.
+// Like http.ProxyFromEnvironment, requests to localhost and loopback
+// addresses are never proxied.
+func proxyFunc(proxyURL *url.URL, noProxy string) func(*http.Request) (*url.URL, error) {
+	rules := parseNoProxy(noProxy)
+	return func(req *http.Request) (*url.URL, error) {
+		host, port := req.URL.Hostname(), req.URL.Port()
+		if port == "" {
+			port = "80"
+			if req.URL.Scheme == "https" {
+				port = "443"
+			}
+		}
+		if isLoopback(host) {
+			return nil, nil
+		}
+		for _, rule := range rules {
+			if rule.match(strings.ToLower(host), port) {
+				return nil, nil
+			}
+		}
+		return proxyURL, nil
+	}
+}
+
+// noProxyFromEnvironment returns the NO_PROXY environment variable, or
+// no_proxy.
+func noProxyFromEnvironment() string {
+	if noProxy := os.Getenv("NO_PROXY"); noProxy != "" {
+		return noProxy
+	}
+	return os.Getenv("no_proxy")
+}
+
+func isLoopback(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// noProxyRule is an entry of NO_PROXY: "*", an IP address, a CIDR range or a
+// domain, optionally with a port. A domain matches itself and its
+// subdomains; with a leading dot, only its subdomains.
+type noProxyRule struct {
+	all     bool
+	network *net.IPNet
+	ip      net.IP
+	domain  string
+	port    string
+}
+
+func parseNoProxy(noProxy string) []noProxyRule {
+	var rules []noProxyRule
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		switch {
+		case entry == "":
+			continue
+		case entry == "*":
+			rules = append(rules, noProxyRule{all: true})
+			continue
+		}
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			rules = append(rules, noProxyRule{network: network})
+			continue
+		}
+		var rule noProxyRule
+		if host, port, err := net.SplitHostPort(entry); err == nil {
+			entry, rule.port = host, port
+		}
+		if ip := net.ParseIP(entry); ip != nil {
+			rule.ip = ip
+		} else {
+			rule.domain = strings.TrimPrefix(entry, "*")
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func (r noProxyRule) match(host, port string) bool {
+	if r.all {
+		return true
+	}
+	if r.port != "" && r.port != port {
+		return false
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return (r.network != nil && r.network.Contains(ip)) || (r.ip != nil && r.ip.Equal(ip))
+	}
+	if r.domain == "" {
+		return false
+	}
+	if strings.HasPrefix(r.domain, ".") {
+		return strings.HasSuffix(host, r.domain)
+	}
+	return host == r.domain || strings.HasSuffix(host, "."+r.domain)
+}
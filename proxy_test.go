@@ -0,0 +1,90 @@
+package openai //nolint:testpackage // testing private proxy matching
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestProxyFuncNoProxy(t *testing.T) {
+	proxy, _ := url.Parse("socks5://egress.internal:1080")
+	proxyFor := proxyFunc(proxy, "internal.example, .corp.example, 10.0.0.0/8, 192.168.1.5, api.local:8443, *.wild.example")
+
+	tests := []struct {
+		url     string
+		proxied bool
+	}{
+		{"https://api.openai.com/v1/models", true},
+		{"https://internal.example/v1", false},
+		{"https://llm.internal.example/v1", false},
+		{"https://corp.example/v1", true},
+		{"https://llm.corp.example/v1", false},
+		{"http://10.1.2.3/v1", false},
+		{"http://11.1.2.3/v1", true},
+		{"http://192.168.1.5/v1", false},
+		{"https://api.local:8443/v1", false},
+		{"https://api.local/v1", true},
+		{"https://x.wild.example/v1", false},
+		{"http://localhost:8080/v1", false},
+		{"http://127.0.0.1:8080/v1", false},
+		{"http://[::1]:8080/v1", false},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+		got, err := proxyFor(req)
+		checks.NoError(t, err)
+		if (got != nil) != tt.proxied {
+			t.Errorf("%s: proxy = %v, want proxied %v", tt.url, got, tt.proxied)
+		}
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://api.openai.com/v1/models", nil)
+	if got, _ := proxyFunc(proxy, "*")(req); got != nil {
+		t.Errorf("NO_PROXY=* proxied the request through %v", got)
+	}
+}
+
+func TestClientConfigProxyURL(t *testing.T) {
+	t.Setenv("NO_PROXY", "")
+	t.Setenv("no_proxy", "")
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Proxied requests carry the absolute URL of their target.
+		proxied = append(proxied, r.URL.String())
+		if r.URL.Path == "/v1/chat/completions" {
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"Hi\"}}]}\n\ndata: [DONE]\n\n")
+			return
+		}
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	}))
+	defer proxy.Close()
+	proxyURL, _ := url.Parse(proxy.URL)
+
+	config := DefaultConfig("token")
+	config.BaseURL = "http://api.example.test/v1"
+	config.ProxyURL = proxyURL
+	client := NewClientWithConfig(config)
+
+	_, err := client.ListModels(context.Background())
+	checks.NoError(t, err)
+	stream, err := client.CreateChatCompletionStream(context.Background(), ChatCompletionRequest{
+		Model:    GPT4oMini,
+		Messages: []ChatCompletionMessage{UserMessage("Hi")},
+	})
+	checks.NoError(t, err)
+	checks.NoError(t, stream.Drain())
+	stream.Close()
+
+	if len(proxied) != 2 || proxied[0] != "http://api.example.test/v1/models" {
+		t.Errorf("proxied requests = %v", proxied)
+	}
+	if config.Transport != nil {
+		t.Error("the caller's config should not be modified")
+	}
+}
@@ -0,0 +1,100 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const defaultRAGSystemPrompt = "Answer the question using only the numbered sources provided. " +
+	"Cite the sources you use with their markers, e.g. [1]. " +
+	"If the sources do not contain the answer, say so."
+
+var (
+	ErrRAGMissingTokenizer = errors.New("rag prompt requires a tokenizer")
+	ErrRAGBudgetTooSmall   = errors.New("token budget is too small for the question alone")
+)
+
+// Passage is a retrieved piece of text to ground an answer in.
+type Passage struct {
+	// Source identifies where the passage comes from, e.g. a document title
+	// or URL. It is shown next to the attribution marker.
+	Source string
+	Text   string
+}
+
+// RAGPromptOptions configures BuildRAGPrompt.
+type RAGPromptOptions struct {
+	// Tokenizer counts the tokens of the messages. It is required.
+	Tokenizer Tokenizer
+	// MaxTokens is the token budget of the whole message list.
+	MaxTokens int
+	// SystemPrompt replaces the default instructions.
+	SystemPrompt string
+}
+
+// RAGPrompt is the result of BuildRAGPrompt.
+type RAGPrompt struct {
+	Messages []ChatCompletionMessage
+	// Included lists the passages that fit in the budget; the passage at
+	// index i is cited with the marker [i+1].
+	Included []Passage
+	// Omitted lists the passages that did not fit.
+	Omitted []Passage
+}
+
+// BuildRAGPrompt assembles the messages answering question from the
+// retrieved passages. Passages are expected in decreasing order of relevance
+// and are packed greedily: each one is included if it still fits in the
+// budget, otherwise it is skipped in favor of shorter ones that follow.
+func BuildRAGPrompt(question string, passages []Passage, opts RAGPromptOptions) (RAGPrompt, error) {
+	if opts.Tokenizer == nil {
+		return RAGPrompt{}, ErrRAGMissingTokenizer
+	}
+	systemPrompt := opts.SystemPrompt
+	if systemPrompt == "" {
+		systemPrompt = defaultRAGSystemPrompt
+	}
+
+	build := func(blocks []string) []ChatCompletionMessage {
+		return []ChatCompletionMessage{
+			SystemMessage(systemPrompt),
+			UserMessage("Sources:\n\n" + strings.Join(blocks, "\n\n") + "\n\nQuestion: " + question),
+		}
+	}
+	used, err := CountMessageTokens(opts.Tokenizer, build(nil))
+	if err != nil {
+		return RAGPrompt{}, err
+	}
+	if used > opts.MaxTokens {
+		return RAGPrompt{}, fmt.Errorf("%w: %d tokens needed, %d available", ErrRAGBudgetTooSmall, used, opts.MaxTokens)
+	}
+
+	var (
+		prompt RAGPrompt
+		blocks []string
+	)
+	for _, passage := range passages {
+		block := formatPassage(len(prompt.Included)+1, passage)
+		tokens, encodeErr := opts.Tokenizer.Encode(block + "\n\n")
+		if encodeErr != nil {
+			return RAGPrompt{}, encodeErr
+		}
+		if used+len(tokens) > opts.MaxTokens {
+			prompt.Omitted = append(prompt.Omitted, passage)
+			continue
+		}
+		used += len(tokens)
+		blocks = append(blocks, block)
+		prompt.Included = append(prompt.Included, passage)
+	}
+	prompt.Messages = build(blocks)
+	return prompt, nil
+}
+
+func formatPassage(marker int, passage Passage) string {
+	if passage.Source == "" {
+		return fmt.Sprintf("[%d] %s", marker, passage.Text)
+	}
+	return fmt.Sprintf("[%d] (%s) %s", marker, passage.Source, passage.Text)
+}
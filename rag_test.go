@@ -0,0 +1,47 @@
+package openai_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestBuildRAGPrompt(t *testing.T) {
+	passages := []openai.Passage{
+		{Source: "doc-a", Text: "one two three"},
+		{Source: "doc-b", Text: strings.Repeat("long ", 10)},
+		{Source: "doc-c", Text: "short"},
+	}
+	opts := openai.RAGPromptOptions{
+		Tokenizer:    fakeTokenizer,
+		MaxTokens:    26,
+		SystemPrompt: "Use sources.",
+	}
+	prompt, err := openai.BuildRAGPrompt("why?", passages, opts)
+	checks.NoError(t, err)
+
+	if len(prompt.Included) != 2 || prompt.Included[1].Source != "doc-c" {
+		t.Errorf("expected doc-a and doc-c to be included, got %+v", prompt.Included)
+	}
+	if len(prompt.Omitted) != 1 || prompt.Omitted[0].Source != "doc-b" {
+		t.Errorf("expected doc-b to be omitted, got %+v", prompt.Omitted)
+	}
+	want := "Sources:\n\n[1] (doc-a) one two three\n\n[2] (doc-c) short\n\nQuestion: why?"
+	if prompt.Messages[1].Content != want {
+		t.Errorf("expected %q, got %q", want, prompt.Messages[1].Content)
+	}
+	tokens, err := openai.CountMessageTokens(fakeTokenizer, prompt.Messages)
+	checks.NoError(t, err)
+	if tokens > opts.MaxTokens {
+		t.Errorf("prompt uses %d tokens, over the budget of %d", tokens, opts.MaxTokens)
+	}
+
+	opts.MaxTokens = 10
+	_, err = openai.BuildRAGPrompt("why?", passages, opts)
+	checks.ErrorIs(t, err, openai.ErrRAGBudgetTooSmall)
+
+	_, err = openai.BuildRAGPrompt("why?", passages, openai.RAGPromptOptions{MaxTokens: 100})
+	checks.ErrorIs(t, err, openai.ErrRAGMissingTokenizer)
+}
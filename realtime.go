@@ -0,0 +1,273 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+)
+
+const realtimeSuffix = "/realtime"
+
+// RealtimeIntent selects the kind of realtime session to open.
+type RealtimeIntent string
+
+const (
+	// RealtimeIntentTranscription opens a transcription-only session, which
+	// produces transcripts of the input audio but never responds.
+	RealtimeIntentTranscription RealtimeIntent = "transcription"
+)
+
+// RealtimeConn is a message-oriented connection to the realtime API, such as
+// a websocket. The package does not depend on a websocket implementation;
+// dial RealtimeURL with RealtimeHeader using the library of your choice and
+// adapt the connection to this interface.
+type RealtimeConn interface {
+	ReadMessage(ctx context.Context) ([]byte, error)
+	WriteMessage(ctx context.Context, data []byte) error
+	Close() error
+}
+
+// RealtimeURL returns the websocket URL of a realtime session. Model is
+// ignored by transcription sessions, which pick the model with
+// RealtimeTranscriptionSessionUpdateEvent instead.
+func (c *Client) RealtimeURL(model string, intent RealtimeIntent) string {
+	query := url.Values{}
+	if model != "" {
+		query.Set("model", model)
+	}
+	if intent != "" {
+		query.Set("intent", string(intent))
+	}
+	u := c.fullURL(withQuery(realtimeSuffix, query))
+	switch {
+	case strings.HasPrefix(u, "https://"):
+		u = "wss://" + strings.TrimPrefix(u, "https://")
+	case strings.HasPrefix(u, "http://"):
+		u = "ws://" + strings.TrimPrefix(u, "http://")
+	}
+	return u
+}
+
+// RealtimeHeader returns the headers to send when dialing RealtimeURL. When
+// resolving the credentials fails, the headers carry no API key and dialing
+// fails to authenticate; use RealtimeHeaderContext to get the error.
+func (c *Client) RealtimeHeader() http.Header {
+	header, _ := c.RealtimeHeaderContext(context.Background())
+	return header
+}
+
+// RealtimeHeaderContext is like RealtimeHeader, with the credentials resolved
+// for ctx by ClientConfig.CredentialResolver.
+func (c *Client) RealtimeHeaderContext(ctx context.Context) (http.Header, error) {
+	req := &http.Request{Header: make(http.Header)}
+	creds, err := c.credentials(ctx)
+	c.setCommonHeaders(req, creds)
+	if value := c.betaHeaderValue(BetaRealtime); value != "" {
+		req.Header.Set(betaHeader, value)
+	}
+	return req.Header, err
+}
+
+// RealtimeAudioFormat is the encoding of realtime input and output audio.
+type RealtimeAudioFormat string
+
+const (
+	RealtimeAudioFormatPCM16    RealtimeAudioFormat = "pcm16"
+	RealtimeAudioFormatG711ULaw RealtimeAudioFormat = "g711_ulaw"
+	RealtimeAudioFormatG711ALaw RealtimeAudioFormat = "g711_alaw"
+)
+
+// RealtimeInputAudioTranscription configures the transcription of input
+// audio. Language is an ISO-639-1 code and Prompt guides the style or
+// vocabulary of the transcript.
+type RealtimeInputAudioTranscription struct {
+	Model    string `json:"model,omitempty"`
+	Language string `json:"language,omitempty"`
+	Prompt   string `json:"prompt,omitempty"`
+}
+
+type RealtimeTurnDetectionType string
+
+const (
+	RealtimeTurnDetectionServerVAD   RealtimeTurnDetectionType = "server_vad"
+	RealtimeTurnDetectionSemanticVAD RealtimeTurnDetectionType = "semantic_vad"
+)
+
+// RealtimeTurnDetection configures voice activity detection.
+type RealtimeTurnDetection struct {
+	Type              RealtimeTurnDetectionType `json:"type"`
+	Threshold         float64                   `json:"threshold,omitempty"`
+	PrefixPaddingMs   int                       `json:"prefix_padding_ms,omitempty"`
+	SilenceDurationMs int                       `json:"silence_duration_ms,omitempty"`
+	// Eagerness is only used by semantic VAD: "low", "medium", "high" or "auto".
+	Eagerness string `json:"eagerness,omitempty"`
+}
+
+// RealtimeSessionConfig is the configuration of a conversational realtime
+// session, sent with session.update.
+type RealtimeSessionConfig struct {
+	Modalities              []string                         `json:"modalities,omitempty"`
+	Instructions            string                           `json:"instructions,omitempty"`
+	Voice                   string                           `json:"voice,omitempty"`
+	InputAudioFormat        RealtimeAudioFormat              `json:"input_audio_format,omitempty"`
+	OutputAudioFormat       RealtimeAudioFormat              `json:"output_audio_format,omitempty"`
+	InputAudioTranscription *RealtimeInputAudioTranscription `json:"input_audio_transcription,omitempty"`
+	TurnDetection           *RealtimeTurnDetection           `json:"turn_detection,omitempty"`
+	Temperature             float32                          `json:"temperature,omitempty"`
+}
+
+// RealtimeTranscriptionSessionConfig is the configuration of a
+// transcription-only session, sent with transcription_session.update.
+type RealtimeTranscriptionSessionConfig struct {
+	InputAudioFormat        RealtimeAudioFormat              `json:"input_audio_format,omitempty"`
+	InputAudioTranscription *RealtimeInputAudioTranscription `json:"input_audio_transcription,omitempty"`
+	TurnDetection           *RealtimeTurnDetection           `json:"turn_detection,omitempty"`
+	// Include requests additional fields, e.g. "item.input_audio_transcription.logprobs".
+	Include []string `json:"include,omitempty"`
+}
+
+// RealtimeSessionUpdateEvent updates a conversational session.
+type RealtimeSessionUpdateEvent struct {
+	EventID string                `json:"event_id,omitempty"`
+	Type    string                `json:"type"`
+	Session RealtimeSessionConfig `json:"session"`
+}
+
+// RealtimeTranscriptionSessionUpdateEvent updates a transcription session.
+type RealtimeTranscriptionSessionUpdateEvent struct {
+	EventID string                             `json:"event_id,omitempty"`
+	Type    string                             `json:"type"`
+	Session RealtimeTranscriptionSessionConfig `json:"session"`
+}
+
+const (
+	RealtimeEventSessionUpdate              = "session.update"
+	RealtimeEventTranscriptionSessionUpdate = "transcription_session.update"
+
+	RealtimeEventError                       = "error"
+	RealtimeEventSessionCreated              = "session.created"
+	RealtimeEventSessionUpdated              = "session.updated"
+	RealtimeEventTranscriptionSessionUpdated = "transcription_session.updated"
+	RealtimeEventTranscriptionDelta          = "conversation.item.input_audio_transcription.delta"
+	RealtimeEventTranscriptionCompleted      = "conversation.item.input_audio_transcription.completed"
+	RealtimeEventTranscriptionFailed         = "conversation.item.input_audio_transcription.failed"
+)
+
+func NewRealtimeSessionUpdateEvent(session RealtimeSessionConfig) RealtimeSessionUpdateEvent {
+	return RealtimeSessionUpdateEvent{Type: RealtimeEventSessionUpdate, Session: session}
+}
+
+func NewRealtimeTranscriptionSessionUpdateEvent(
+	session RealtimeTranscriptionSessionConfig,
+) RealtimeTranscriptionSessionUpdateEvent {
+	return RealtimeTranscriptionSessionUpdateEvent{Type: RealtimeEventTranscriptionSessionUpdate, Session: session}
+}
+
+// RealtimeEvent is an event received from the server. Decode it into one of
+// the typed events according to Type.
+type RealtimeEvent struct {
+	Type    string
+	EventID string
+	Raw     json.RawMessage
+}
+
+func (e RealtimeEvent) Decode(v any) error {
+	return json.Unmarshal(e.Raw, v)
+}
+
+// RealtimeTranscriptionDeltaEvent carries a partial transcript of an input
+// audio item, for live captions.
+type RealtimeTranscriptionDeltaEvent struct {
+	EventID      string `json:"event_id"`
+	Type         string `json:"type"`
+	ItemID       string `json:"item_id"`
+	ContentIndex int    `json:"content_index"`
+	Delta        string `json:"delta"`
+}
+
+// RealtimeTranscriptionCompletedEvent carries the final transcript of an
+// input audio item.
+type RealtimeTranscriptionCompletedEvent struct {
+	EventID      string `json:"event_id"`
+	Type         string `json:"type"`
+	ItemID       string `json:"item_id"`
+	ContentIndex int    `json:"content_index"`
+	Transcript   string `json:"transcript"`
+}
+
+// RealtimeErrorEvent reports an error, such as a failed transcription or an
+// invalid client event.
+type RealtimeErrorEvent struct {
+	EventID string        `json:"event_id"`
+	Type    string        `json:"type"`
+	Error   RealtimeError `json:"error"`
+}
+
+type RealtimeError struct {
+	Type    string `json:"type"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+	Param   string `json:"param,omitempty"`
+	EventID string `json:"event_id,omitempty"`
+}
+
+func (e *RealtimeError) Error() string {
+	return fmt.Sprintf("realtime error: %s: %s", e.Type, e.Message)
+}
+
+var ErrRealtimeSessionClosed = errors.New("realtime session is closed")
+
+// RealtimeSession sends and receives realtime events over a RealtimeConn.
+// Send and Recv may be called from different goroutines.
+type RealtimeSession struct {
+	conn   RealtimeConn
+	closed int32
+}
+
+func NewRealtimeSession(conn RealtimeConn) *RealtimeSession {
+	return &RealtimeSession{conn: conn}
+}
+
+// Send encodes a client event, e.g. a RealtimeTranscriptionSessionUpdateEvent,
+// and writes it to the connection.
+func (s *RealtimeSession) Send(ctx context.Context, event any) error {
+	if atomic.LoadInt32(&s.closed) != 0 {
+		return ErrRealtimeSessionClosed
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return s.conn.WriteMessage(ctx, data)
+}
+
+// Recv reads the next server event.
+func (s *RealtimeSession) Recv(ctx context.Context) (RealtimeEvent, error) {
+	if atomic.LoadInt32(&s.closed) != 0 {
+		return RealtimeEvent{}, ErrRealtimeSessionClosed
+	}
+	data, err := s.conn.ReadMessage(ctx)
+	if err != nil {
+		return RealtimeEvent{}, err
+	}
+	var header struct {
+		Type    string `json:"type"`
+		EventID string `json:"event_id"`
+	}
+	if err = json.Unmarshal(data, &header); err != nil {
+		return RealtimeEvent{}, err
+	}
+	return RealtimeEvent{Type: header.Type, EventID: header.EventID, Raw: data}, nil
+}
+
+func (s *RealtimeSession) Close() error {
+	if !atomic.CompareAndSwapInt32(&s.closed, 0, 1) {
+		return nil
+	}
+	return s.conn.Close()
+}
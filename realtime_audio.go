@@ -0,0 +1,183 @@
+package openai
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"time"
+)
+
+const (
+	RealtimeEventInputAudioBufferAppend = "input_audio_buffer.append"
+	RealtimeEventInputAudioBufferCommit = "input_audio_buffer.commit"
+	RealtimeEventInputAudioBufferClear  = "input_audio_buffer.clear"
+
+	defaultRealtimeAudioChunkDuration = 100 * time.Millisecond
+	realtimePCM16SampleRate           = 24000
+	realtimeG711SampleRate            = 8000
+)
+
+var ErrRealtimeAudioFormatUnsupported = errors.New("unsupported realtime audio format")
+
+// RealtimeInputAudioBufferAppendEvent appends base64-encoded audio to the
+// input buffer.
+type RealtimeInputAudioBufferAppendEvent struct {
+	EventID string `json:"event_id,omitempty"`
+	Type    string `json:"type"`
+	Audio   string `json:"audio"`
+}
+
+// RealtimeInputAudioBufferEvent commits or clears the input buffer.
+type RealtimeInputAudioBufferEvent struct {
+	EventID string `json:"event_id,omitempty"`
+	Type    string `json:"type"`
+}
+
+// RealtimeTurnDetectionUpdateEvent switches voice activity detection on or
+// off. Unlike RealtimeSessionUpdateEvent it sends a null turn_detection when
+// TurnDetection is nil, which disables VAD.
+type RealtimeTurnDetectionUpdateEvent struct {
+	EventID string `json:"event_id,omitempty"`
+	Type    string `json:"type"`
+	Session struct {
+		TurnDetection *RealtimeTurnDetection `json:"turn_detection"`
+	} `json:"session"`
+}
+
+func NewRealtimeInputAudioBufferAppendEvent(audio []byte) RealtimeInputAudioBufferAppendEvent {
+	return RealtimeInputAudioBufferAppendEvent{
+		Type:  RealtimeEventInputAudioBufferAppend,
+		Audio: base64.StdEncoding.EncodeToString(audio),
+	}
+}
+
+// NewRealtimePushToTalkEvent disables voice activity detection, so the input
+// buffer is only committed with RealtimeEventInputAudioBufferCommit. Use
+// RealtimeEventSessionUpdate or RealtimeEventTranscriptionSessionUpdate as
+// eventType, depending on the session.
+func NewRealtimePushToTalkEvent(eventType string) RealtimeTurnDetectionUpdateEvent {
+	return NewRealtimeTurnDetectionUpdateEvent(eventType, nil)
+}
+
+// NewRealtimeTurnDetectionUpdateEvent enables voice activity detection with
+// the given configuration, or disables it if turnDetection is nil.
+func NewRealtimeTurnDetectionUpdateEvent(
+	eventType string,
+	turnDetection *RealtimeTurnDetection,
+) RealtimeTurnDetectionUpdateEvent {
+	event := RealtimeTurnDetectionUpdateEvent{Type: eventType}
+	event.Session.TurnDetection = turnDetection
+	return event
+}
+
+// RealtimeAudioOptions describes the audio read by a RealtimeAudioChunker.
+type RealtimeAudioOptions struct {
+	// Format is the encoding of the audio, PCM16 by default. PCM16 audio must
+	// be 24kHz mono little-endian, G.711 audio 8kHz mono.
+	Format RealtimeAudioFormat
+	// ChunkDuration is the length of audio sent per append event, 100ms by
+	// default.
+	ChunkDuration time.Duration
+	// Paced makes RealtimeSession.StreamAudio wait ChunkDuration between
+	// events, simulating a live microphone when the reader is a file.
+	Paced bool
+}
+
+// RealtimeAudioChunker splits audio into append events that hold whole
+// samples of ChunkDuration each.
+type RealtimeAudioChunker struct {
+	r              io.Reader
+	buf            []byte
+	bytesPerSample int
+}
+
+func NewRealtimeAudioChunker(r io.Reader, opts RealtimeAudioOptions) (*RealtimeAudioChunker, error) {
+	sampleRate, bytesPerSample := realtimePCM16SampleRate, 2
+	switch opts.Format {
+	case "", RealtimeAudioFormatPCM16:
+	case RealtimeAudioFormatG711ULaw, RealtimeAudioFormatG711ALaw:
+		sampleRate, bytesPerSample = realtimeG711SampleRate, 1
+	default:
+		return nil, ErrRealtimeAudioFormatUnsupported
+	}
+	duration := opts.ChunkDuration
+	if duration <= 0 {
+		duration = defaultRealtimeAudioChunkDuration
+	}
+	samples := int(int64(sampleRate) * int64(duration) / int64(time.Second))
+	if samples < 1 {
+		samples = 1
+	}
+	return &RealtimeAudioChunker{
+		r:              r,
+		buf:            make([]byte, samples*bytesPerSample),
+		bytesPerSample: bytesPerSample,
+	}, nil
+}
+
+// Next returns the next append event, or io.EOF once the reader is
+// exhausted. The last event may be shorter; a trailing partial sample is
+// dropped.
+func (c *RealtimeAudioChunker) Next() (RealtimeInputAudioBufferAppendEvent, error) {
+	n, err := io.ReadFull(c.r, c.buf)
+	n -= n % c.bytesPerSample
+	if n == 0 {
+		if err == nil || errors.Is(err, io.ErrUnexpectedEOF) {
+			err = io.EOF
+		}
+		return RealtimeInputAudioBufferAppendEvent{}, err
+	}
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return RealtimeInputAudioBufferAppendEvent{}, err
+	}
+	return NewRealtimeInputAudioBufferAppendEvent(c.buf[:n]), nil
+}
+
+// StreamAudio reads r until EOF and sends its audio as append events. It does
+// not commit the buffer; with push-to-talk, call CommitAudio afterwards.
+func (s *RealtimeSession) StreamAudio(ctx context.Context, r io.Reader, opts RealtimeAudioOptions) error {
+	chunker, err := NewRealtimeAudioChunker(r, opts)
+	if err != nil {
+		return err
+	}
+	var ticker *time.Ticker
+	if opts.Paced {
+		duration := opts.ChunkDuration
+		if duration <= 0 {
+			duration = defaultRealtimeAudioChunkDuration
+		}
+		ticker = time.NewTicker(duration)
+		defer ticker.Stop()
+	}
+
+	for first := true; ; first = false {
+		event, err := chunker.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if ticker != nil && !first {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+			}
+		}
+		if err = s.Send(ctx, event); err != nil {
+			return err
+		}
+	}
+}
+
+// CommitAudio commits the input buffer, ending the user turn.
+func (s *RealtimeSession) CommitAudio(ctx context.Context) error {
+	return s.Send(ctx, RealtimeInputAudioBufferEvent{Type: RealtimeEventInputAudioBufferCommit})
+}
+
+// ClearAudio discards the input buffer.
+func (s *RealtimeSession) ClearAudio(ctx context.Context) error {
+	return s.Send(ctx, RealtimeInputAudioBufferEvent{Type: RealtimeEventInputAudioBufferClear})
+}
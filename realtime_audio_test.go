@@ -0,0 +1,108 @@
+package openai_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestRealtimeAudioChunker(t *testing.T) {
+	// 250ms of 24kHz PCM16 plus a dangling byte.
+	audio := make([]byte, 24000*2/4+1)
+	for i := range audio {
+		audio[i] = byte(i)
+	}
+	chunker, err := openai.NewRealtimeAudioChunker(bytes.NewReader(audio), openai.RealtimeAudioOptions{})
+	checks.NoError(t, err)
+
+	var sizes []int
+	var decoded []byte
+	for {
+		event, err := chunker.Next()
+		if err == io.EOF {
+			break
+		}
+		checks.NoError(t, err)
+		if event.Type != openai.RealtimeEventInputAudioBufferAppend {
+			t.Fatalf("unexpected event type %s", event.Type)
+		}
+		chunk, err := base64.StdEncoding.DecodeString(event.Audio)
+		checks.NoError(t, err)
+		sizes = append(sizes, len(chunk))
+		decoded = append(decoded, chunk...)
+	}
+	if len(sizes) != 3 || sizes[0] != 4800 || sizes[1] != 4800 || sizes[2] != 2400 {
+		t.Errorf("expected 100ms chunks and a 50ms remainder, got %v", sizes)
+	}
+	if !bytes.Equal(decoded, audio[:len(audio)-1]) {
+		t.Error("expected the audio to round-trip without the partial sample")
+	}
+
+	chunker, err = openai.NewRealtimeAudioChunker(bytes.NewReader(make([]byte, 1000)), openai.RealtimeAudioOptions{
+		Format:        openai.RealtimeAudioFormatG711ULaw,
+		ChunkDuration: 20 * time.Millisecond,
+	})
+	checks.NoError(t, err)
+	event, err := chunker.Next()
+	checks.NoError(t, err)
+	if chunk, _ := base64.StdEncoding.DecodeString(event.Audio); len(chunk) != 160 {
+		t.Errorf("expected 20ms of 8kHz G.711 to be 160 bytes, got %d", len(chunk))
+	}
+
+	_, err = openai.NewRealtimeAudioChunker(nil, openai.RealtimeAudioOptions{Format: "mp3"})
+	checks.ErrorIs(t, err, openai.ErrRealtimeAudioFormatUnsupported)
+}
+
+func TestRealtimeSessionPushToTalk(t *testing.T) {
+	conn := &fakeRealtimeConn{}
+	session := openai.NewRealtimeSession(conn)
+	ctx := context.Background()
+
+	checks.NoError(t, session.Send(ctx, openai.NewRealtimePushToTalkEvent(openai.RealtimeEventSessionUpdate)))
+	start := time.Now()
+	checks.NoError(t, session.StreamAudio(ctx, bytes.NewReader(make([]byte, 3*480)), openai.RealtimeAudioOptions{
+		ChunkDuration: 10 * time.Millisecond,
+		Paced:         true,
+	}))
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("expected paced events to take at least 20ms, took %s", elapsed)
+	}
+	checks.NoError(t, session.CommitAudio(ctx))
+
+	var types []string
+	for _, data := range conn.written {
+		var event struct{ Type string }
+		checks.NoError(t, json.Unmarshal(data, &event))
+		types = append(types, event.Type)
+	}
+	want := []string{"session.update", "input_audio_buffer.append", "input_audio_buffer.append",
+		"input_audio_buffer.append", "input_audio_buffer.commit"}
+	if len(types) != len(want) {
+		t.Fatalf("expected events %v, got %v", want, types)
+	}
+	for i := range want {
+		if types[i] != want[i] {
+			t.Errorf("event %d: expected %s, got %s", i, want[i], types[i])
+		}
+	}
+	if got := string(conn.written[0]); got != `{"type":"session.update","session":{"turn_detection":null}}` {
+		t.Errorf("expected push-to-talk to disable turn detection, got %s", got)
+	}
+
+	vad := openai.NewRealtimeTurnDetectionUpdateEvent(openai.RealtimeEventTranscriptionSessionUpdate,
+		&openai.RealtimeTurnDetection{Type: openai.RealtimeTurnDetectionServerVAD, SilenceDurationMs: 500})
+	data, err := json.Marshal(vad)
+	checks.NoError(t, err)
+	want0 := `{"type":"transcription_session.update","session":{"turn_detection":` +
+		`{"type":"server_vad","silence_duration_ms":500}}}`
+	if string(data) != want0 {
+		t.Errorf("expected %s, got %s", want0, data)
+	}
+}
@@ -0,0 +1,79 @@
+package openai
+
+import (
+	"context"
+	"net/http"
+)
+
+// RealtimeSessionRequest configures a realtime session created through the
+// REST API, typically to hand its client secret to a browser WebRTC client.
+type RealtimeSessionRequest struct {
+	Model string `json:"model"`
+	RealtimeSessionConfig
+}
+
+// RealtimeTranscriptionSessionRequest configures a transcription session
+// created through the REST API.
+type RealtimeTranscriptionSessionRequest struct {
+	RealtimeTranscriptionSessionConfig
+}
+
+// RealtimeClientSecret is an ephemeral key that authenticates a client with
+// the realtime API until ExpiresAt, a Unix timestamp.
+type RealtimeClientSecret struct {
+	Value     string `json:"value"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+// RealtimeSessionResponse is a created realtime session.
+type RealtimeSessionResponse struct {
+	ID           string               `json:"id"`
+	Object       string               `json:"object"`
+	Model        string               `json:"model"`
+	ClientSecret RealtimeClientSecret `json:"client_secret"`
+	RealtimeSessionConfig
+
+	httpHeader
+}
+
+// RealtimeTranscriptionSessionResponse is a created transcription session.
+type RealtimeTranscriptionSessionResponse struct {
+	ID           string               `json:"id"`
+	Object       string               `json:"object"`
+	ClientSecret RealtimeClientSecret `json:"client_secret"`
+	RealtimeTranscriptionSessionConfig
+
+	httpHeader
+}
+
+// CreateRealtimeSession mints an ephemeral client secret for a realtime
+// session, so that browsers can connect over WebRTC without holding the API
+// key.
+func (c *Client) CreateRealtimeSession(
+	ctx context.Context,
+	request RealtimeSessionRequest,
+) (response RealtimeSessionResponse, err error) {
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(realtimeSuffix+"/sessions"), withBody(request))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// CreateRealtimeTranscriptionSession mints an ephemeral client secret for a
+// transcription session.
+func (c *Client) CreateRealtimeTranscriptionSession(
+	ctx context.Context,
+	request RealtimeTranscriptionSessionRequest,
+) (response RealtimeTranscriptionSessionResponse, err error) {
+	req, err := c.newRequest(ctx, http.MethodPost,
+		c.fullURL(realtimeSuffix+"/transcription_sessions"), withBody(request))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
@@ -0,0 +1,64 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestCreateRealtimeSession(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var body map[string]any
+	server.RegisterHandler("/v1/realtime/sessions", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &body)
+		fmt.Fprint(w, `{"id":"sess_1","object":"realtime.session","model":"gpt-4o-realtime-preview",`+
+			`"voice":"alloy","client_secret":{"value":"ek_abc","expires_at":1700000060}}`)
+	})
+
+	session, err := client.CreateRealtimeSession(context.Background(), openai.RealtimeSessionRequest{
+		Model: "gpt-4o-realtime-preview",
+		RealtimeSessionConfig: openai.RealtimeSessionConfig{
+			Voice:         "alloy",
+			TurnDetection: &openai.RealtimeTurnDetection{Type: openai.RealtimeTurnDetectionServerVAD},
+		},
+	})
+	checks.NoError(t, err)
+	if session.ClientSecret.Value != "ek_abc" || session.ClientSecret.ExpiresAt != 1700000060 || session.Voice != "alloy" {
+		t.Errorf("unexpected session %+v", session)
+	}
+	if body["model"] != "gpt-4o-realtime-preview" || body["voice"] != "alloy" || body["turn_detection"] == nil {
+		t.Errorf("expected the session config to be flattened into the request, got %v", body)
+	}
+}
+
+func TestCreateRealtimeTranscriptionSession(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler("/v1/realtime/transcription_sessions", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"id":"sess_2","object":"realtime.transcription_session",`+
+			`"input_audio_transcription":{"model":"gpt-4o-transcribe"},`+
+			`"client_secret":{"value":"ek_def","expires_at":1700000060}}`)
+	})
+
+	session, err := client.CreateRealtimeTranscriptionSession(context.Background(),
+		openai.RealtimeTranscriptionSessionRequest{})
+	checks.NoError(t, err)
+	if session.ClientSecret.Value != "ek_def" || session.InputAudioTranscription == nil ||
+		session.InputAudioTranscription.Model != "gpt-4o-transcribe" {
+		t.Errorf("unexpected session %+v", session)
+	}
+}
@@ -0,0 +1,128 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+// fakeRealtimeConn records written messages and replays scripted ones.
+type fakeRealtimeConn struct {
+	written [][]byte
+	replies []string
+	closed  bool
+}
+
+func (c *fakeRealtimeConn) ReadMessage(ctx context.Context) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(c.replies) == 0 {
+		return nil, io.EOF
+	}
+	reply := c.replies[0]
+	c.replies = c.replies[1:]
+	return []byte(reply), nil
+}
+
+func (c *fakeRealtimeConn) WriteMessage(_ context.Context, data []byte) error {
+	c.written = append(c.written, data)
+	return nil
+}
+
+func (c *fakeRealtimeConn) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestRealtimeURL(t *testing.T) {
+	config := openai.DefaultConfig("token")
+	config.OrgID = "org"
+	client := openai.NewClientWithConfig(config)
+
+	got := client.RealtimeURL("", openai.RealtimeIntentTranscription)
+	if want := "wss://api.openai.com/v1/realtime?intent=transcription"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+	got = client.RealtimeURL("gpt-4o-realtime-preview", "")
+	if want := "wss://api.openai.com/v1/realtime?model=gpt-4o-realtime-preview"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	header := client.RealtimeHeader()
+	if header.Get("Authorization") != "Bearer token" || header.Get("OpenAI-Organization") != "org" ||
+		header.Get("OpenAI-Beta") != "realtime=v1" {
+		t.Errorf("unexpected headers %v", header)
+	}
+}
+
+func TestRealtimeTranscriptionSession(t *testing.T) {
+	conn := &fakeRealtimeConn{replies: []string{
+		`{"type":"transcription_session.updated","event_id":"ev_1"}`,
+		`{"type":"conversation.item.input_audio_transcription.delta","event_id":"ev_2",` +
+			`"item_id":"item_1","content_index":0,"delta":"Hel"}`,
+		`{"type":"conversation.item.input_audio_transcription.completed","event_id":"ev_3",` +
+			`"item_id":"item_1","content_index":0,"transcript":"Hello"}`,
+	}}
+	session := openai.NewRealtimeSession(conn)
+	ctx := context.Background()
+
+	err := session.Send(ctx, openai.NewRealtimeTranscriptionSessionUpdateEvent(openai.RealtimeTranscriptionSessionConfig{
+		InputAudioFormat: openai.RealtimeAudioFormatPCM16,
+		InputAudioTranscription: &openai.RealtimeInputAudioTranscription{
+			Model:    "gpt-4o-transcribe",
+			Language: "en",
+			Prompt:   "Expect technical terms.",
+		},
+	}))
+	checks.NoError(t, err)
+	var sent map[string]any
+	checks.NoError(t, json.Unmarshal(conn.written[0], &sent))
+	if sent["type"] != "transcription_session.update" {
+		t.Errorf("unexpected event type %v", sent["type"])
+	}
+	transcription := sent["session"].(map[string]any)["input_audio_transcription"].(map[string]any)
+	if transcription["model"] != "gpt-4o-transcribe" || transcription["language"] != "en" {
+		t.Errorf("unexpected transcription config %v", transcription)
+	}
+
+	event, err := session.Recv(ctx)
+	checks.NoError(t, err)
+	if event.Type != openai.RealtimeEventTranscriptionSessionUpdated || event.EventID != "ev_1" {
+		t.Errorf("unexpected event %+v", event)
+	}
+
+	var captions string
+	for {
+		event, err = session.Recv(ctx)
+		if err != nil {
+			break
+		}
+		switch event.Type {
+		case openai.RealtimeEventTranscriptionDelta:
+			var delta openai.RealtimeTranscriptionDeltaEvent
+			checks.NoError(t, event.Decode(&delta))
+			captions += delta.Delta
+		case openai.RealtimeEventTranscriptionCompleted:
+			var completed openai.RealtimeTranscriptionCompletedEvent
+			checks.NoError(t, event.Decode(&completed))
+			if completed.ItemID != "item_1" || completed.Transcript != "Hello" {
+				t.Errorf("unexpected completed event %+v", completed)
+			}
+		}
+	}
+	checks.ErrorIs(t, err, io.EOF)
+	if captions != "Hel" {
+		t.Errorf("expected captions from the delta events, got %q", captions)
+	}
+
+	checks.NoError(t, session.Close())
+	if !conn.closed {
+		t.Error("expected the connection to be closed")
+	}
+	checks.ErrorIs(t, session.Send(ctx, openai.RealtimeSessionUpdateEvent{}), openai.ErrRealtimeSessionClosed)
+}
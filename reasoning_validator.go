@@ -38,12 +38,7 @@ func NewReasoningValidator() *ReasoningValidator {
 
 // Validate performs all validation checks for reasoning models.
 func (v *ReasoningValidator) Validate(request ChatCompletionRequest) error {
-	o1Series := strings.HasPrefix(request.Model, "o1")
-	o3Series := strings.HasPrefix(request.Model, "o3")
-	o4Series := strings.HasPrefix(request.Model, "o4")
-	gpt5Series := strings.HasPrefix(request.Model, "gpt-5")
-
-	if !o1Series && !o3Series && !o4Series && !gpt5Series {
+	if !isReasoningModel(request.Model) {
 		return nil
 	}
 
@@ -54,6 +49,17 @@ func (v *ReasoningValidator) Validate(request ChatCompletionRequest) error {
 	return nil
 }
 
+// isReasoningModel reports whether model belongs to a reasoning series, whose
+// sampling parameters are fixed.
+func isReasoningModel(model string) bool {
+	for _, prefix := range []string{"o1", "o3", "o4", "gpt-5"} {
+		if strings.HasPrefix(model, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 // validateReasoningModelParams checks reasoning model parameters.
 func (v *ReasoningValidator) validateReasoningModelParams(request ChatCompletionRequest) error {
 	if request.MaxTokens > 0 {
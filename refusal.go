@@ -0,0 +1,61 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrModelRefused is matched, with errors.Is, by the *RefusalError returned
+// when the model refused to answer.
+var ErrModelRefused = errors.New("the model refused to answer")
+
+// RefusalError holds the refusal of a choice, which replaces its content when
+// the model declines a request, typically with Structured Outputs.
+type RefusalError struct {
+	Index   int
+	Refusal string
+}
+
+func (e *RefusalError) Error() string {
+	return fmt.Sprintf("choice %d: %s: %s", e.Index, ErrModelRefused, e.Refusal)
+}
+
+func (e *RefusalError) Unwrap() error {
+	return ErrModelRefused
+}
+
+// CheckRefusal returns a *RefusalError for the first choice of the response
+// whose message is a refusal, or nil.
+func (r ChatCompletionResponse) CheckRefusal() error {
+	for _, choice := range r.Choices {
+		if choice.Message.Refusal != "" {
+			return &RefusalError{Index: choice.Index, Refusal: choice.Message.Refusal}
+		}
+	}
+	return nil
+}
+
+// FailOnRefusal makes reading the stream fail with a *RefusalError, holding
+// the whole refusal, when a choice that streamed refusal deltas finishes.
+func (s *ChatCompletionStream) FailOnRefusal() {
+	refusals := make(map[int]*strings.Builder)
+	s.AddTransform(func(chunk *ChatCompletionStreamResponse) error {
+		for _, choice := range chunk.Choices {
+			if choice.Delta.Refusal != "" {
+				refusal, ok := refusals[choice.Index]
+				if !ok {
+					refusal = &strings.Builder{}
+					refusals[choice.Index] = refusal
+				}
+				refusal.WriteString(choice.Delta.Refusal)
+			}
+		}
+		for _, choice := range chunk.Choices {
+			if refusal, ok := refusals[choice.Index]; ok && choice.FinishReason.IsFinished() {
+				return &RefusalError{Index: choice.Index, Refusal: refusal.String()}
+			}
+		}
+		return nil
+	})
+}
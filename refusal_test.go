@@ -0,0 +1,54 @@
+package openai_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestChatCompletionResponseCheckRefusal(t *testing.T) {
+	response := openai.ChatCompletionResponse{Choices: []openai.ChatCompletionChoice{
+		{Index: 0, Message: openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Content: "Sure."}},
+	}}
+	checks.NoError(t, response.CheckRefusal())
+
+	response.Choices = append(response.Choices, openai.ChatCompletionChoice{
+		Index:   1,
+		Message: openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Refusal: "I can't help with that."},
+	})
+	err := response.CheckRefusal()
+	checks.ErrorIs(t, err, openai.ErrModelRefused)
+	var refusalErr *openai.RefusalError
+	if !errors.As(err, &refusalErr) || refusalErr.Index != 1 || refusalErr.Refusal != "I can't help with that." {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestChatCompletionStreamFailOnRefusal(t *testing.T) {
+	stream := openai.NewChatCompletionStream(&mockStreamReader{responses: []openai.ChatCompletionStreamResponse{
+		{Choices: []openai.ChatCompletionStreamChoice{{Delta: openai.ChatCompletionStreamChoiceDelta{Refusal: "I can't "}}}},
+		{Choices: []openai.ChatCompletionStreamChoice{{Delta: openai.ChatCompletionStreamChoiceDelta{Refusal: "help."}}}},
+		{Choices: []openai.ChatCompletionStreamChoice{{FinishReason: openai.FinishReasonStop}}},
+	}})
+	stream.FailOnRefusal()
+
+	chunks, err := stream.Collect()
+	if len(chunks) != 2 {
+		t.Errorf("expected the 2 refusal chunks to be delivered, got %d", len(chunks))
+	}
+	var refusalErr *openai.RefusalError
+	if !errors.As(err, &refusalErr) || refusalErr.Refusal != "I can't help." {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestChatCompletionStreamFailOnRefusalContent(t *testing.T) {
+	stream := openai.NewChatCompletionStream(&mockStreamReader{responses: []openai.ChatCompletionStreamResponse{
+		{Choices: []openai.ChatCompletionStreamChoice{{Delta: openai.ChatCompletionStreamChoiceDelta{Content: "Hi"}}}},
+		{Choices: []openai.ChatCompletionStreamChoice{{FinishReason: openai.FinishReasonStop}}},
+	}})
+	stream.FailOnRefusal()
+	checks.NoError(t, stream.Drain())
+}
@@ -0,0 +1,64 @@
+package openai
+
+// applyChatDefaults fills in the fields of a chat completion request that it
+// does not set from the defaults of the client.
+func (c *Client) applyChatDefaults(request *ChatCompletionRequest) {
+	if request.Model == "" {
+		request.Model = c.config.DefaultModel
+	}
+	if t := c.config.DefaultTemperature; t != nil && !request.hasTemperature() && !isReasoningModel(request.Model) {
+		request.SetTemperature(*t)
+	}
+	if request.isStored() {
+		request.Metadata = mergeDefaultMetadata(c.config.DefaultMetadata, request.Metadata)
+	}
+}
+
+// applyResponseDefaults fills in the fields of a response request that it
+// does not set from the defaults of the client.
+func (c *Client) applyResponseDefaults(request *ResponseRequest) {
+	if request.Model == "" {
+		request.Model = c.config.DefaultModel
+	}
+	if t := c.config.DefaultTemperature; t != nil && !request.hasTemperature() && !isReasoningModel(request.Model) {
+		request.SetTemperature(*t)
+	}
+	if request.isStored() {
+		request.Metadata = mergeDefaultMetadata(c.config.DefaultMetadata, request.Metadata)
+	}
+}
+
+// isStored reports whether the completion is stored, the only case where
+// its metadata is kept.
+func (r *ChatCompletionRequest) isStored() bool {
+	return r.Store
+}
+
+// isStored reports whether the response is stored, which is the default.
+func (r *ResponseRequest) isStored() bool {
+	return r.Store == nil || *r.Store
+}
+
+func (r *ChatCompletionRequest) hasTemperature() bool {
+	return r.Temperature != 0 || r.explicit&explicitTemperature != 0
+}
+
+func (r *ResponseRequest) hasTemperature() bool {
+	return r.Temperature != 0 || r.explicit&explicitTemperature != 0
+}
+
+// mergeDefaultMetadata returns the metadata of a request with the default
+// keys it does not set added, without modifying either map.
+func mergeDefaultMetadata(defaults, metadata map[string]string) map[string]string {
+	if len(defaults) == 0 {
+		return metadata
+	}
+	merged := make(map[string]string, len(defaults)+len(metadata))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for k, v := range metadata {
+		merged[k] = v
+	}
+	return merged
+}
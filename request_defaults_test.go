@@ -0,0 +1,84 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestRequestDefaults(t *testing.T) {
+	var body map[string]any
+	capture := func(response string) func(http.ResponseWriter, *http.Request) {
+		return func(w http.ResponseWriter, r *http.Request) {
+			data, _ := io.ReadAll(r.Body)
+			body = nil
+			_ = json.Unmarshal(data, &body)
+			fmt.Fprint(w, response)
+		}
+	}
+	temperature := float32(0.3)
+
+	client, server := setupOpenAITestServerWithConfig(t, func(config *openai.ClientConfig) {
+		config.DefaultModel = openai.GPT4oMini
+		config.DefaultEmbeddingModel = openai.SmallEmbedding3
+		config.DefaultTemperature = &temperature
+		config.DefaultMetadata = map[string]string{"service": "billing", "env": "prod"}
+		config.DefaultUser = "user-1"
+	})
+	server.RegisterHandler("/v1/chat/completions", capture(`{"id":"chatcmpl-1","choices":[]}`))
+	server.RegisterHandler("/v1/responses", capture(`{"id":"resp_1","status":"completed"}`))
+	server.RegisterHandler("/v1/embeddings", capture(`{"object":"list","data":[]}`))
+	ctx := context.Background()
+	messages := []openai.ChatCompletionMessage{openai.UserMessage("Hello!")}
+
+	_, err := client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+		Messages: messages,
+		Store:    true,
+		Metadata: map[string]string{"env": "staging"},
+	})
+	checks.NoError(t, err, "CreateChatCompletion error")
+	metadata, _ := body["metadata"].(map[string]any)
+	if body["model"] != openai.GPT4oMini || body["temperature"] != 0.3 || body["safety_identifier"] != "user-1" ||
+		metadata["service"] != "billing" || metadata["env"] != "staging" {
+		t.Errorf("chat request with defaults = %v", body)
+	}
+
+	request := openai.ChatCompletionRequest{Model: openai.GPT4o, Messages: messages}
+	request.SetTemperature(0)
+	_, err = client.CreateChatCompletion(ctx, request)
+	checks.NoError(t, err, "CreateChatCompletion error")
+	if body["model"] != openai.GPT4o || body["temperature"] != 0.0 || body["metadata"] != nil {
+		t.Errorf("request overriding the defaults = %v", body)
+	}
+
+	_, err = client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{Model: openai.O3Mini, Messages: messages})
+	checks.NoError(t, err, "CreateChatCompletion error")
+	if _, ok := body["temperature"]; ok {
+		t.Errorf("default temperature sent to a reasoning model: %v", body)
+	}
+
+	_, err = client.CreateResponse(ctx, openai.ResponseRequest{Input: "Hello!"})
+	checks.NoError(t, err, "CreateResponse error")
+	if body["model"] != openai.GPT4oMini || body["temperature"] != 0.3 || body["metadata"] == nil {
+		t.Errorf("response request with defaults = %v", body)
+	}
+
+	store := false
+	_, err = client.CreateResponse(ctx, openai.ResponseRequest{Input: "Hello!", Store: &store})
+	checks.NoError(t, err, "CreateResponse error")
+	if body["metadata"] != nil {
+		t.Errorf("default metadata sent with an unstored response: %v", body)
+	}
+
+	_, err = client.CreateEmbeddings(ctx, openai.EmbeddingRequestStrings{Input: []string{"hello"}})
+	checks.NoError(t, err, "CreateEmbeddings error")
+	if body["model"] != string(openai.SmallEmbedding3) {
+		t.Errorf("embedding request with defaults = %v", body)
+	}
+}
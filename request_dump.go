@@ -0,0 +1,111 @@
+package openai
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// maxDumpBodySize caps the body kept by a RequestDump, so that file uploads
+// do not stay in memory.
+const maxDumpBodySize = 64 << 10
+
+// RequestDump is a copy of an outgoing request, with its headers scrubbed by
+// ClientConfig.HeaderScrubber, kept when ClientConfig.CaptureLastRequest is
+// set.
+type RequestDump struct {
+	Method string
+	URL    string
+	Header http.Header
+	// Body is the uncompressed request body, cut to 64KiB.
+	Body          []byte
+	BodyTruncated bool
+}
+
+// String formats the dump like an HTTP/1.1 request.
+func (d *RequestDump) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n", d.Method, d.URL)
+	keys := make([]string, 0, len(d.Header))
+	for key := range d.Header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range d.Header[key] {
+			fmt.Fprintf(&b, "%s: %s\n", key, value)
+		}
+	}
+	b.WriteString("\n")
+	b.Write(d.Body)
+	if d.BodyTruncated {
+		b.WriteString("\n[truncated]")
+	}
+	return b.String()
+}
+
+// requestRecorder keeps the last request of a client.
+type requestRecorder struct {
+	mu   sync.Mutex
+	last *RequestDump
+}
+
+func (r *requestRecorder) record(req *http.Request, header http.Header) {
+	dump := &RequestDump{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: header,
+	}
+	dump.Body, dump.BodyTruncated = dumpBody(req)
+
+	r.mu.Lock()
+	r.last = dump
+	r.mu.Unlock()
+}
+
+// dumpBody reads a copy of the body of req, decompressing it if needed.
+func dumpBody(req *http.Request) ([]byte, bool) {
+	if req.GetBody == nil {
+		return nil, false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	defer body.Close()
+
+	reader := io.Reader(body)
+	if req.Header.Get("Content-Encoding") == "gzip" {
+		zr, zErr := gzip.NewReader(body)
+		if zErr != nil {
+			return nil, false
+		}
+		defer zr.Close()
+		reader = zr
+	}
+	data, _ := io.ReadAll(io.LimitReader(reader, maxDumpBodySize+1))
+	if len(data) > maxDumpBodySize {
+		return data[:maxDumpBodySize], true
+	}
+	return data, false
+}
+
+// DumpLastRequest returns the last request sent by the client, by any
+// goroutine, or nil if ClientConfig.CaptureLastRequest is not set or no
+// request was sent yet. It helps reproduce requests rejected by the API:
+//
+//	if _, err := client.CreateChatCompletion(ctx, req); err != nil {
+//		log.Printf("%v\n%s", err, client.DumpLastRequest())
+//	}
+func (c *Client) DumpLastRequest() *RequestDump {
+	if c.lastRequest == nil {
+		return nil
+	}
+	c.lastRequest.mu.Lock()
+	defer c.lastRequest.mu.Unlock()
+	return c.lastRequest.last
+}
@@ -0,0 +1,88 @@
+package openai_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestDumpLastRequest(t *testing.T) {
+	for _, threshold := range []int{0, 1} {
+		client, server := setupOpenAITestServerWithConfig(t, func(config *openai.ClientConfig) {
+			config.CaptureLastRequest = true
+			config.RequestCompressionThreshold = threshold
+			config.RequestCompressionEndpoints = []string{"/chat/completions"}
+		})
+		server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":{"message":"bad request","type":"invalid_request_error"}}`)) //nolint:errcheck
+		})
+		if client.DumpLastRequest() != nil {
+			t.Fatal("expected no dump before the first request")
+		}
+
+		_, err := client.CreateChatCompletion(context.Background(), openai.ChatCompletionRequest{
+			Model:    openai.GPT4oMini,
+			Messages: []openai.ChatCompletionMessage{openai.UserMessage("hello")},
+		})
+		checks.HasError(t, err)
+
+		dump := client.DumpLastRequest()
+		if dump == nil {
+			t.Fatalf("threshold %d: expected a dump", threshold)
+		}
+		if dump.Method != http.MethodPost || !strings.HasSuffix(dump.URL, "/v1/chat/completions") {
+			t.Errorf("threshold %d: request = %s %s", threshold, dump.Method, dump.URL)
+		}
+		if got := dump.Header.Get("Authorization"); got != "[REDACTED]" {
+			t.Errorf("threshold %d: Authorization = %q", threshold, got)
+		}
+		if !strings.Contains(string(dump.Body), `"content":"hello"`) {
+			t.Errorf("threshold %d: body = %s", threshold, dump.Body)
+		}
+		if strings.Contains(dump.String(), test.GetTestToken()) {
+			t.Errorf("threshold %d: dump leaks the token", threshold)
+		}
+	}
+}
+
+func TestDumpLastRequestDisabled(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(`{"object":"list","data":[]}`)) //nolint:errcheck
+	})
+	_, err := client.ListModels(context.Background())
+	checks.NoError(t, err)
+	if client.DumpLastRequest() != nil {
+		t.Error("expected no dump without CaptureLastRequest")
+	}
+}
+
+func TestDumpLastRequestTruncatesBody(t *testing.T) {
+	client, server := setupOpenAITestServerWithConfig(t, func(config *openai.ClientConfig) {
+		config.CaptureLastRequest = true
+	})
+	server.RegisterHandler("/v1/embeddings", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"message":"too long"}}`)) //nolint:errcheck
+	})
+
+	_, err := client.CreateEmbeddings(context.Background(), openai.EmbeddingRequestStrings{
+		Input: []string{strings.Repeat("a", 100<<10)},
+		Model: openai.SmallEmbedding3,
+	})
+	checks.HasError(t, err)
+	dump := client.DumpLastRequest()
+	if !dump.BodyTruncated || len(dump.Body) != 64<<10 {
+		t.Errorf("truncated = %v, len = %d", dump.BodyTruncated, len(dump.Body))
+	}
+	if !strings.HasSuffix(dump.String(), "[truncated]") {
+		t.Error("expected the formatted dump to mark the truncation")
+	}
+}
@@ -0,0 +1,70 @@
+package openai
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// chatCompletionDefaults are the API defaults of chat completion parameters.
+// Parameters set to their default are dropped from the canonical encoding, as
+// the API treats them the same as unset ones.
+var chatCompletionDefaults = map[string]any{
+	"temperature":         1.0,
+	"top_p":               1.0,
+	"n":                   1.0,
+	"presence_penalty":    0.0,
+	"frequency_penalty":   0.0,
+	"logprobs":            false,
+	"parallel_tool_calls": true,
+	"store":               false,
+	"service_tier":        string(ServiceTierAuto),
+}
+
+// CanonicalChatCompletionRequest returns the canonical JSON encoding of a
+// request: object keys are sorted at every level, the streaming fields are
+// removed and parameters set to their API default are omitted. Requests that
+// the API treats the same have the same canonical encoding.
+func CanonicalChatCompletionRequest(request ChatCompletionRequest) ([]byte, error) {
+	request.Stream = false
+	request.StreamOptions = nil
+	data, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var fields map[string]any
+	if err = decoder.Decode(&fields); err != nil {
+		return nil, err
+	}
+	for key, def := range chatCompletionDefaults {
+		if value, ok := fields[key]; ok && isDefaultValue(value, def) {
+			delete(fields, key)
+		}
+	}
+	// Maps are encoded with sorted keys.
+	return json.Marshal(fields)
+}
+
+func isDefaultValue(value, def any) bool {
+	if n, ok := value.(json.Number); ok {
+		f, err := n.Float64()
+		return err == nil && f == def
+	}
+	return value == def
+}
+
+// HashChatCompletionRequest returns the hex-encoded SHA-256 hash of the
+// canonical encoding of a request. It is stable across processes, making it
+// suitable for deduplicating queued requests.
+func HashChatCompletionRequest(request ChatCompletionRequest) (string, error) {
+	data, err := CanonicalChatCompletionRequest(request)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
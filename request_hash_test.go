@@ -0,0 +1,60 @@
+package openai_test
+
+import (
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestCanonicalChatCompletionRequest(t *testing.T) {
+	request := openai.ChatCompletionRequest{
+		Model:    openai.GPT4oMini,
+		Messages: []openai.ChatCompletionMessage{openai.UserMessage("Hello")},
+		Metadata: map[string]string{"b": "2", "a": "1"},
+	}
+	data, err := openai.CanonicalChatCompletionRequest(request)
+	checks.NoError(t, err)
+	want := `{"messages":[{"content":"Hello","role":"user"}],"metadata":{"a":"1","b":"2"},"model":"gpt-4o-mini"}`
+	if string(data) != want {
+		t.Errorf("canonical encoding = %s, want %s", data, want)
+	}
+
+	withDefaults := request
+	withDefaults.Temperature = 1
+	withDefaults.SetN(1)
+	withDefaults.SetPresencePenalty(0)
+	withDefaults.ParallelToolCalls = true
+	withDefaults.Stream = true
+	data, err = openai.CanonicalChatCompletionRequest(withDefaults)
+	checks.NoError(t, err)
+	if string(data) != want {
+		t.Errorf("parameters set to their default should be omitted, got %s", data)
+	}
+}
+
+func TestHashChatCompletionRequest(t *testing.T) {
+	request := openai.ChatCompletionRequest{
+		Model:    openai.GPT4oMini,
+		Messages: []openai.ChatCompletionMessage{openai.UserMessage("Hello")},
+	}
+	hash, err := openai.HashChatCompletionRequest(request)
+	checks.NoError(t, err)
+	if len(hash) != 64 {
+		t.Errorf("expected a hex SHA-256 hash, got %q", hash)
+	}
+
+	request.TopP = 1
+	sameHash, err := openai.HashChatCompletionRequest(request)
+	checks.NoError(t, err)
+	if hash != sameHash {
+		t.Error("a default top_p should not change the hash")
+	}
+
+	request.SetTemperature(0)
+	otherHash, err := openai.HashChatCompletionRequest(request)
+	checks.NoError(t, err)
+	if hash == otherHash {
+		t.Error("an explicit zero temperature should change the hash")
+	}
+}
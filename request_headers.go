@@ -0,0 +1,58 @@
+package openai
+
+import (
+	"context"
+	"net/http"
+)
+
+// TraceHeaders are the headers copied from an incoming request by
+// WithTraceHeaders: W3C trace context and request IDs.
+var TraceHeaders = []string{"traceparent", "tracestate", "X-Request-Id"}
+
+type requestHeadersKey struct{}
+
+// WithRequestHeaders returns a context that adds header to the requests made
+// with it, e.g. to propagate tracing headers without full OpenTelemetry
+// instrumentation. Headers set by the client, such as Authorization, are not
+// overridden. Calls accumulate, later values replacing earlier ones.
+func WithRequestHeaders(ctx context.Context, header http.Header) context.Context {
+	merged := requestHeadersFrom(ctx).Clone()
+	if merged == nil {
+		merged = make(http.Header, len(header))
+	}
+	for key, values := range header {
+		merged[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+	}
+	return context.WithValue(ctx, requestHeadersKey{}, merged)
+}
+
+// WithTraceHeaders returns a context that forwards the TraceHeaders of
+// incoming, typically the request served by a gateway, to the requests made
+// with it, connecting both in distributed traces.
+func WithTraceHeaders(ctx context.Context, incoming *http.Request) context.Context {
+	header := make(http.Header)
+	for _, key := range TraceHeaders {
+		if values := incoming.Header.Values(key); len(values) > 0 {
+			header[http.CanonicalHeaderKey(key)] = values
+		}
+	}
+	if len(header) == 0 {
+		return ctx
+	}
+	return WithRequestHeaders(ctx, header)
+}
+
+func requestHeadersFrom(ctx context.Context) http.Header {
+	header, _ := ctx.Value(requestHeadersKey{}).(http.Header)
+	return header
+}
+
+// setContextHeaders adds the headers attached to the context of req that the
+// client has not set.
+func setContextHeaders(req *http.Request) {
+	for key, values := range requestHeadersFrom(req.Context()) {
+		if req.Header.Get(key) == "" {
+			req.Header[key] = append([]string(nil), values...)
+		}
+	}
+}
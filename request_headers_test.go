@@ -0,0 +1,57 @@
+package openai_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestWithTraceHeaders(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	var got http.Header
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	})
+
+	incoming := httptest.NewRequest(http.MethodGet, "/chat", nil)
+	incoming.Header.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	incoming.Header.Set("X-Request-Id", "req-123")
+	incoming.Header.Set("Cookie", "session=secret")
+
+	ctx := openai.WithTraceHeaders(context.Background(), incoming)
+	ctx = openai.WithRequestHeaders(ctx, http.Header{
+		"x-tenant":      {"acme"},
+		"Authorization": {"Bearer stolen"},
+	})
+	_, err := client.ListModels(ctx)
+	checks.NoError(t, err)
+
+	if got.Get("Traceparent") != incoming.Header.Get("Traceparent") {
+		t.Errorf("traceparent = %q", got.Get("Traceparent"))
+	}
+	if got.Get("X-Request-Id") != "req-123" || got.Get("X-Tenant") != "acme" {
+		t.Errorf("unexpected headers %v", got)
+	}
+	if got.Get("Cookie") != "" {
+		t.Error("only trace headers should be forwarded")
+	}
+	if got.Get("Authorization") != "Bearer "+test.GetTestToken() {
+		t.Errorf("client headers should not be overridden, got %q", got.Get("Authorization"))
+	}
+}
+
+func TestWithTraceHeadersWithoutTrace(t *testing.T) {
+	ctx := context.Background()
+	incoming := httptest.NewRequest(http.MethodGet, "/chat", nil)
+	if openai.WithTraceHeaders(ctx, incoming) != ctx {
+		t.Error("a request without trace headers should leave the context unchanged")
+	}
+}
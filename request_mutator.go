@@ -0,0 +1,55 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+)
+
+// RequestMutator modifies chat completion requests before they are sent,
+// after the client defaults are applied and before validation, so that
+// platform teams can inject system prompts, enforce the allowed models or
+// strip disallowed parameters in one place. An error fails the request
+// without sending it.
+//
+// The slices and maps of the request are shared with the caller: replace
+// them rather than modifying them in place, for example to prepend a system
+// message:
+//
+//	request.Messages = append([]openai.ChatCompletionMessage{policy}, request.Messages...)
+type RequestMutator func(ctx context.Context, request *ChatCompletionRequest) error
+
+// ResponseRequestMutator is the RequestMutator of response requests.
+type ResponseRequestMutator func(ctx context.Context, request *ResponseRequest) error
+
+// EmbeddingRequestMutator is the RequestMutator of embedding requests.
+type EmbeddingRequestMutator func(ctx context.Context, request *EmbeddingRequest) error
+
+func (c *Client) mutateChatRequest(ctx context.Context, request *ChatCompletionRequest) error {
+	if c.config.RequestMutator == nil {
+		return nil
+	}
+	if err := c.config.RequestMutator(ctx, request); err != nil {
+		return fmt.Errorf("error, mutating request: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) mutateResponseRequest(ctx context.Context, request *ResponseRequest) error {
+	if c.config.ResponseRequestMutator == nil {
+		return nil
+	}
+	if err := c.config.ResponseRequestMutator(ctx, request); err != nil {
+		return fmt.Errorf("error, mutating request: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) mutateEmbeddingRequest(ctx context.Context, request *EmbeddingRequest) error {
+	if c.config.EmbeddingRequestMutator == nil {
+		return nil
+	}
+	if err := c.config.EmbeddingRequestMutator(ctx, request); err != nil {
+		return fmt.Errorf("error, mutating request: %w", err)
+	}
+	return nil
+}
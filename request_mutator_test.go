@@ -0,0 +1,91 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestRequestMutator(t *testing.T) {
+	var body map[string]any
+	requests := 0
+	capture := func(response string) func(http.ResponseWriter, *http.Request) {
+		return func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			data, _ := io.ReadAll(r.Body)
+			body = nil
+			_ = json.Unmarshal(data, &body)
+			fmt.Fprint(w, response)
+		}
+	}
+	errModelNotAllowed := errors.New("model not allowed")
+
+	client, server := setupOpenAITestServerWithConfig(t, func(config *openai.ClientConfig) {
+		config.RequestMutator = func(_ context.Context, request *openai.ChatCompletionRequest) error {
+			if request.Model != openai.GPT4oMini {
+				return errModelNotAllowed
+			}
+			request.Messages = append([]openai.ChatCompletionMessage{openai.SystemMessage("Be polite.")},
+				request.Messages...)
+			request.LogitBias = nil
+			return nil
+		}
+		config.ResponseRequestMutator = func(_ context.Context, request *openai.ResponseRequest) error {
+			request.Instructions = "Be polite."
+			return nil
+		}
+		config.EmbeddingRequestMutator = func(_ context.Context, request *openai.EmbeddingRequest) error {
+			request.Dimensions = 256
+			return nil
+		}
+	})
+	server.RegisterHandler("/v1/chat/completions", capture(`{"id":"chatcmpl-1","choices":[]}`))
+	server.RegisterHandler("/v1/responses", capture(`{"id":"resp_1","status":"completed"}`))
+	server.RegisterHandler("/v1/embeddings", capture(`{"object":"list","data":[]}`))
+	ctx := context.Background()
+
+	messages := []openai.ChatCompletionMessage{openai.UserMessage("Hello!")}
+	_, err := client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+		Model:     openai.GPT4oMini,
+		Messages:  messages,
+		LogitBias: map[string]int{"50256": -100},
+	})
+	checks.NoError(t, err, "CreateChatCompletion error")
+	sent, _ := body["messages"].([]any)
+	if len(sent) != 2 || body["logit_bias"] != nil {
+		t.Errorf("mutated chat request = %v", body)
+	}
+	if len(messages) != 1 || messages[0].Content != "Hello!" {
+		t.Errorf("caller messages modified: %v", messages)
+	}
+
+	_, err = client.CreateChatCompletionStream(ctx, openai.ChatCompletionRequest{Model: openai.GPT4o, Messages: messages})
+	if !errors.Is(err, errModelNotAllowed) {
+		t.Fatalf("expected errModelNotAllowed, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("rejected request was sent")
+	}
+
+	_, err = client.CreateResponse(ctx, openai.ResponseRequest{Model: openai.GPT4oMini, Input: "Hello!"})
+	checks.NoError(t, err, "CreateResponse error")
+	if body["instructions"] != "Be polite." {
+		t.Errorf("mutated response request = %v", body)
+	}
+
+	_, err = client.CreateEmbeddings(ctx, openai.EmbeddingRequestStrings{
+		Model: openai.SmallEmbedding3,
+		Input: []string{"hello"},
+	})
+	checks.NoError(t, err, "CreateEmbeddings error")
+	if body["dimensions"] != 256.0 {
+		t.Errorf("mutated embedding request = %v", body)
+	}
+}
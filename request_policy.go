@@ -0,0 +1,162 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+var ErrPolicyViolation = errors.New("request violates the client policy")
+
+// PolicyRule names a rule of a RequestPolicy.
+type PolicyRule string
+
+const (
+	PolicyRuleModel       PolicyRule = "model"
+	PolicyRuleTemperature PolicyRule = "temperature"
+	PolicyRuleMaxTokens   PolicyRule = "max_tokens"
+	PolicyRuleTool        PolicyRule = "tool"
+)
+
+// PolicyViolation is a rule of a RequestPolicy broken by a request. Value is
+// the offending value, such as the model or the name of a tool.
+type PolicyViolation struct {
+	Rule  PolicyRule
+	Value string
+}
+
+func (v PolicyViolation) String() string {
+	switch v.Rule {
+	case PolicyRuleModel:
+		return fmt.Sprintf("model %q is not allowed", v.Value)
+	case PolicyRuleTemperature:
+		return fmt.Sprintf("temperature %s is above the maximum", v.Value)
+	case PolicyRuleMaxTokens:
+		return fmt.Sprintf("max tokens %s is above the maximum", v.Value)
+	case PolicyRuleTool:
+		return fmt.Sprintf("tool %q is banned", v.Value)
+	default:
+		return fmt.Sprintf("%s %q is not allowed", v.Rule, v.Value)
+	}
+}
+
+// PolicyViolationError lists the rules a request breaks. It wraps
+// ErrPolicyViolation.
+type PolicyViolationError struct {
+	Violations []PolicyViolation
+}
+
+func (e *PolicyViolationError) Error() string {
+	violations := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		violations[i] = violation.String()
+	}
+	return fmt.Sprintf("%s: %s", ErrPolicyViolation, strings.Join(violations, "; "))
+}
+
+func (e *PolicyViolationError) Unwrap() error {
+	return ErrPolicyViolation
+}
+
+// RequestPolicy restricts the chat completion and response requests of a
+// client, so that a wrapper shared across teams can guarantee they stay
+// within the rules of the platform. Requests breaking it fail with a
+// *PolicyViolationError without being sent. Zero fields do not restrict
+// anything.
+type RequestPolicy struct {
+	// AllowedModels lists the models requests may use, as names or patterns
+	// such as "gpt-4o*" in the syntax of path.Match.
+	AllowedModels []string
+	// MaxTemperature is the highest temperature requests may set.
+	MaxTemperature *float32
+	// MaxTokens is the highest MaxTokens and MaxCompletionTokens of chat
+	// completion requests and MaxOutputTokens of response requests. Requests
+	// leaving them unset are not restricted.
+	MaxTokens int
+	// BannedTools lists the tools requests may not offer, as tool types such
+	// as "code_interpreter" or function names.
+	BannedTools []string
+}
+
+// CheckChatCompletion returns a *PolicyViolationError listing the rules the
+// request breaks, or nil.
+func (p *RequestPolicy) CheckChatCompletion(request ChatCompletionRequest) error {
+	var violations []PolicyViolation
+	violations = p.checkModel(violations, request.Model)
+	violations = p.checkTemperature(violations, request.Temperature)
+	violations = p.checkMaxTokens(violations, request.MaxTokens)
+	violations = p.checkMaxTokens(violations, request.MaxCompletionTokens)
+	for _, tool := range request.Tools {
+		name := string(tool.Type)
+		if tool.Type == ToolTypeFunction && tool.Function != nil {
+			name = tool.Function.Name
+		}
+		violations = p.checkTool(violations, string(tool.Type), name)
+	}
+	for _, function := range request.Functions {
+		violations = p.checkTool(violations, string(ToolTypeFunction), function.Name)
+	}
+	return policyError(violations)
+}
+
+// CheckResponse returns a *PolicyViolationError listing the rules the
+// request breaks, or nil.
+func (p *RequestPolicy) CheckResponse(request ResponseRequest) error {
+	var violations []PolicyViolation
+	violations = p.checkModel(violations, request.Model)
+	violations = p.checkTemperature(violations, request.Temperature)
+	violations = p.checkMaxTokens(violations, request.MaxOutputTokens)
+	for _, tool := range request.Tools {
+		name := string(tool.Type)
+		if tool.Type == ResponseToolTypeFunction {
+			name = tool.Name
+		}
+		violations = p.checkTool(violations, string(tool.Type), name)
+	}
+	return policyError(violations)
+}
+
+func (p *RequestPolicy) checkModel(violations []PolicyViolation, model string) []PolicyViolation {
+	if len(p.AllowedModels) == 0 {
+		return violations
+	}
+	for _, pattern := range p.AllowedModels {
+		if matched, _ := path.Match(pattern, model); matched || pattern == model {
+			return violations
+		}
+	}
+	return append(violations, PolicyViolation{Rule: PolicyRuleModel, Value: model})
+}
+
+func (p *RequestPolicy) checkTemperature(violations []PolicyViolation, temperature float32) []PolicyViolation {
+	if p.MaxTemperature == nil || temperature <= *p.MaxTemperature {
+		return violations
+	}
+	value := fmt.Sprint(temperature)
+	return append(violations, PolicyViolation{Rule: PolicyRuleTemperature, Value: value})
+}
+
+func (p *RequestPolicy) checkMaxTokens(violations []PolicyViolation, maxTokens int) []PolicyViolation {
+	if p.MaxTokens <= 0 || maxTokens <= p.MaxTokens {
+		return violations
+	}
+	value := fmt.Sprint(maxTokens)
+	return append(violations, PolicyViolation{Rule: PolicyRuleMaxTokens, Value: value})
+}
+
+func (p *RequestPolicy) checkTool(violations []PolicyViolation, toolType, name string) []PolicyViolation {
+	for _, banned := range p.BannedTools {
+		if banned == toolType || banned == name {
+			return append(violations, PolicyViolation{Rule: PolicyRuleTool, Value: name})
+		}
+	}
+	return violations
+}
+
+func policyError(violations []PolicyViolation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	return &PolicyViolationError{Violations: violations}
+}
@@ -0,0 +1,131 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestRequestPolicyCheckChatCompletion(t *testing.T) {
+	maxTemperature := float32(1)
+	policy := &openai.RequestPolicy{
+		AllowedModels:  []string{openai.GPT4oMini, "gpt-4.1*"},
+		MaxTemperature: &maxTemperature,
+		MaxTokens:      1000,
+		BannedTools:    []string{"delete_account", string(openai.ToolTypeFileSearch)},
+	}
+	tool := func(name string) openai.Tool {
+		return openai.Tool{Type: openai.ToolTypeFunction, Function: &openai.FunctionDefinition{Name: name}}
+	}
+
+	testCases := []struct {
+		name       string
+		request    openai.ChatCompletionRequest
+		violations []openai.PolicyViolation
+	}{
+		{
+			name: "allowed",
+			request: openai.ChatCompletionRequest{
+				Model:               "gpt-4.1-mini",
+				Temperature:         1,
+				MaxCompletionTokens: 1000,
+				Tools:               []openai.Tool{tool("get_weather")},
+			},
+		},
+		{
+			name:       "model",
+			request:    openai.ChatCompletionRequest{Model: openai.GPT4o},
+			violations: []openai.PolicyViolation{{Rule: openai.PolicyRuleModel, Value: openai.GPT4o}},
+		},
+		{
+			name: "several",
+			request: openai.ChatCompletionRequest{
+				Model:       openai.GPT4oMini,
+				Temperature: 1.5,
+				MaxTokens:   4000,
+				Tools:       []openai.Tool{tool("delete_account"), {Type: openai.ToolTypeFileSearch}},
+			},
+			violations: []openai.PolicyViolation{
+				{Rule: openai.PolicyRuleTemperature, Value: "1.5"},
+				{Rule: openai.PolicyRuleMaxTokens, Value: "4000"},
+				{Rule: openai.PolicyRuleTool, Value: "delete_account"},
+				{Rule: openai.PolicyRuleTool, Value: "file_search"},
+			},
+		},
+		{
+			name: "deprecated functions",
+			request: openai.ChatCompletionRequest{
+				Model:     openai.GPT4oMini,
+				Functions: []openai.FunctionDefinition{{Name: "delete_account"}},
+			},
+			violations: []openai.PolicyViolation{{Rule: openai.PolicyRuleTool, Value: "delete_account"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := policy.CheckChatCompletion(tc.request)
+			if tc.violations == nil {
+				checks.NoError(t, err, "CheckChatCompletion error")
+				return
+			}
+			var policyErr *openai.PolicyViolationError
+			if !errors.As(err, &policyErr) || !errors.Is(err, openai.ErrPolicyViolation) {
+				t.Fatalf("expected *PolicyViolationError, got %v", err)
+			}
+			if !reflect.DeepEqual(policyErr.Violations, tc.violations) {
+				t.Errorf("violations = %v, want %v", policyErr.Violations, tc.violations)
+			}
+		})
+	}
+}
+
+func TestRequestPolicyCheckResponse(t *testing.T) {
+	policy := &openai.RequestPolicy{
+		MaxTokens:   500,
+		BannedTools: []string{string(openai.ResponseToolTypeCodeInterpreter)},
+	}
+	err := policy.CheckResponse(openai.ResponseRequest{
+		Model:           openai.GPT4oMini,
+		MaxOutputTokens: 200,
+		Tools:           []openai.ResponseTool{{Type: openai.ResponseToolTypeFunction, Name: "lookup"}},
+	})
+	checks.NoError(t, err, "CheckResponse error")
+
+	err = policy.CheckResponse(openai.ResponseRequest{
+		Model:           openai.GPT4oMini,
+		MaxOutputTokens: 800,
+		Tools:           []openai.ResponseTool{openai.NewCodeInterpreterTool()},
+	})
+	want := "request violates the client policy: max tokens 800 is above the maximum; " +
+		`tool "code_interpreter" is banned`
+	if err == nil || err.Error() != want {
+		t.Errorf("CheckResponse error = %v, want %s", err, want)
+	}
+}
+
+func TestClientRequestPolicy(t *testing.T) {
+	sent := false
+	client, server := setupOpenAITestServerWithConfig(t, func(config *openai.ClientConfig) {
+		config.Policy = &openai.RequestPolicy{AllowedModels: []string{openai.GPT4oMini}}
+	})
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		sent = true
+		w.Write([]byte(`{"id":"chatcmpl-1","choices":[]}`))
+	})
+
+	_, err := client.CreateChatCompletion(context.Background(), openai.ChatCompletionRequest{
+		Model:    openai.GPT4,
+		Messages: []openai.ChatCompletionMessage{openai.UserMessage("Hello!")},
+	})
+	if !errors.Is(err, openai.ErrPolicyViolation) {
+		t.Fatalf("expected ErrPolicyViolation, got %v", err)
+	}
+	if sent {
+		t.Error("request breaking the policy was sent")
+	}
+}
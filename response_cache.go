@@ -0,0 +1,178 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// CacheStore is the storage backend of the response cache. Implement it on
+// top of Redis, memcached or similar to share the cache between processes.
+type CacheStore interface {
+	// Get returns the value stored under key, reporting false on a miss.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value under key. A zero ttl means no expiration.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// ResponseCache caches non-streaming chat completion responses, keyed by a
+// hash of the request and, with a ClientConfig.CredentialResolver, of the
+// credentials of the tenant. Requests with temperature 0 and a fixed seed
+// benefit the most; responses to sampled requests are served as-is once
+// cached.
+//
+// Store errors are treated as cache misses, so an unavailable backend never
+// fails a request. Cached responses carry no HTTP headers.
+type ResponseCache struct {
+	Store CacheStore
+	TTL   time.Duration
+
+	// Semantic, when set, also serves responses to prompts similar to a
+	// previously cached one.
+	Semantic *SemanticCache
+}
+
+type cacheBypassKey struct{}
+
+// WithCacheBypass returns a context that makes the client skip the response
+// cache for requests made with it. Fresh responses are still stored.
+func WithCacheBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassKey{}, true)
+}
+
+func cacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(cacheBypassKey{}).(bool)
+	return bypass
+}
+
+// ChatCompletionCacheKey returns the cache key of a request, based on
+// HashChatCompletionRequest.
+func ChatCompletionCacheKey(request ChatCompletionRequest) (string, error) {
+	hash, err := HashChatCompletionRequest(request)
+	if err != nil {
+		return "", err
+	}
+	return "chat:" + hash, nil
+}
+
+type chatCompletionCacheLookup struct {
+	key       string
+	tenant    string
+	namespace string
+	vector    []float32
+}
+
+// tenantCacheKey scopes key to the tenant of the request, if any.
+func tenantCacheKey(tenant, key string) string {
+	if tenant == "" {
+		return key
+	}
+	return "tenant:" + tenant + ":" + key
+}
+
+// lookupChatCompletionCache fills response from the configured cache and
+// reports whether it was a hit. The returned lookup is passed to
+// storeChatCompletionCache after a miss.
+func (c *Client) lookupChatCompletionCache(
+	ctx context.Context,
+	request ChatCompletionRequest,
+	response *ChatCompletionResponse,
+) (lookup chatCompletionCacheLookup, hit bool, err error) {
+	cache := c.config.ResponseCache
+	if cache == nil {
+		return
+	}
+	if lookup.key, err = ChatCompletionCacheKey(request); err != nil {
+		return
+	}
+	if lookup.tenant, err = c.tenantKey(ctx); err != nil {
+		return
+	}
+	lookup.key = tenantCacheKey(lookup.tenant, lookup.key)
+	if cacheBypassed(ctx) {
+		return
+	}
+	if cache.get(ctx, lookup.key, response) {
+		return lookup, true, nil
+	}
+	if cache.Semantic != nil {
+		hit = c.lookupSemanticCache(ctx, cache, request, &lookup, response)
+	}
+	return
+}
+
+func (c *Client) storeChatCompletionCache(
+	ctx context.Context,
+	lookup chatCompletionCacheLookup,
+	response ChatCompletionResponse,
+) {
+	cache := c.config.ResponseCache
+	if cache == nil || !cache.set(ctx, lookup.key, response) {
+		return
+	}
+	if cache.Semantic != nil && lookup.vector != nil {
+		_ = cache.Semantic.Index.Add(ctx, lookup.namespace, lookup.key, lookup.vector)
+	}
+}
+
+func (rc *ResponseCache) get(ctx context.Context, key string, response *ChatCompletionResponse) bool {
+	data, ok, err := rc.Store.Get(ctx, key)
+	if err != nil || !ok {
+		return false
+	}
+	return json.Unmarshal(data, response) == nil
+}
+
+func (rc *ResponseCache) set(ctx context.Context, key string, response ChatCompletionResponse) bool {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return false
+	}
+	return rc.Store.Set(ctx, key, data, rc.TTL) == nil
+}
+
+// MemoryCacheStore is an in-process CacheStore.
+type MemoryCacheStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryCacheEntry
+	now     func() time.Time
+}
+
+type memoryCacheEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryCacheStore creates an empty MemoryCacheStore.
+func NewMemoryCacheStore() *MemoryCacheStore {
+	return &MemoryCacheStore{
+		entries: make(map[string]memoryCacheEntry),
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryCacheStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if !entry.expires.IsZero() && !s.now().Before(entry.expires) {
+		delete(s.entries, key)
+		return nil, false, nil
+	}
+	return entry.value, true, nil
+}
+
+func (s *MemoryCacheStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry := memoryCacheEntry{value: value}
+	if ttl > 0 {
+		entry.expires = s.now().Add(ttl)
+	}
+	s.entries[key] = entry
+	return nil
+}
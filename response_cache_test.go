@@ -0,0 +1,119 @@
+package openai_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestChatCompletionCacheKey(t *testing.T) {
+	request := openai.ChatCompletionRequest{
+		Model:    openai.GPT4oMini,
+		Messages: []openai.ChatCompletionMessage{openai.UserMessage("Hello")},
+	}
+	key, err := openai.ChatCompletionCacheKey(request)
+	checks.NoError(t, err)
+
+	streamed := request
+	streamed.Stream = true
+	streamed.StreamOptions = &openai.StreamOptions{IncludeUsage: true}
+	streamedKey, err := openai.ChatCompletionCacheKey(streamed)
+	checks.NoError(t, err)
+	if key != streamedKey {
+		t.Error("stream fields should not change the cache key")
+	}
+
+	request.Temperature = 0.5
+	otherKey, err := openai.ChatCompletionCacheKey(request)
+	checks.NoError(t, err)
+	if key == otherKey {
+		t.Error("different parameters should change the cache key")
+	}
+}
+
+func TestMemoryCacheStoreTTL(t *testing.T) {
+	ctx := context.Background()
+	store := openai.NewMemoryCacheStore()
+	checks.NoError(t, store.Set(ctx, "forever", []byte("a"), 0))
+	checks.NoError(t, store.Set(ctx, "short", []byte("b"), time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+
+	if value, ok, _ := store.Get(ctx, "forever"); !ok || string(value) != "a" {
+		t.Errorf("expected entry without TTL to be kept, got %q, %v", value, ok)
+	}
+	if _, ok, _ := store.Get(ctx, "short"); ok {
+		t.Error("expected expired entry to be a miss")
+	}
+}
+
+func TestChatCompletionsResponseCache(t *testing.T) {
+	calls := 0
+	client, server := setupOpenAITestServerWithConfig(t, func(config *openai.ClientConfig) {
+		config.ResponseCache = &openai.ResponseCache{Store: openai.NewMemoryCacheStore(), TTL: time.Minute}
+	})
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		fmt.Fprintf(w, `{"id":"chatcmpl-%d","object":"chat.completion","choices":[]}`, calls)
+	})
+
+	request := openai.ChatCompletionRequest{
+		Model:    openai.GPT4oMini,
+		Seed:     new(int),
+		Messages: []openai.ChatCompletionMessage{openai.UserMessage("Hello")},
+	}
+	ctx := context.Background()
+	first, err := client.CreateChatCompletion(ctx, request)
+	checks.NoError(t, err)
+	second, err := client.CreateChatCompletion(ctx, request)
+	checks.NoError(t, err)
+	if calls != 1 || second.ID != first.ID {
+		t.Errorf("expected cached response, got %d calls and ID %q", calls, second.ID)
+	}
+
+	bypassed, err := client.CreateChatCompletion(openai.WithCacheBypass(ctx), request)
+	checks.NoError(t, err)
+	if calls != 2 || bypassed.ID != "chatcmpl-2" {
+		t.Errorf("expected bypass to hit the server, got %d calls and ID %q", calls, bypassed.ID)
+	}
+	refreshed, err := client.CreateChatCompletion(ctx, request)
+	checks.NoError(t, err)
+	if refreshed.ID != "chatcmpl-2" {
+		t.Errorf("expected bypassed response to refresh the cache, got ID %q", refreshed.ID)
+	}
+}
+
+func TestChatCompletionsResponseCacheTenants(t *testing.T) {
+	calls := 0
+	client, server := setupOpenAITestServerWithConfig(t, func(config *openai.ClientConfig) {
+		config.ResponseCache = &openai.ResponseCache{Store: openai.NewMemoryCacheStore()}
+		config.CredentialResolver = resolveTenant
+	})
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprintf(w, `{"id":"%s-%d","object":"chat.completion","choices":[]}`, r.Header.Get("OpenAI-Project"), calls)
+	})
+
+	request := openai.ChatCompletionRequest{
+		Model:    openai.GPT4oMini,
+		Messages: []openai.ChatCompletionMessage{openai.UserMessage("Hello")},
+	}
+	acme := context.WithValue(context.Background(), tenantKey{}, tenant{org: "org-acme", project: "proj-acme"})
+	globex := context.WithValue(context.Background(), tenantKey{}, tenant{org: "org-globex", project: "proj-globex"})
+	first, err := client.CreateChatCompletion(acme, request)
+	checks.NoError(t, err)
+	other, err := client.CreateChatCompletion(globex, request)
+	checks.NoError(t, err)
+	if other.ID != "proj-globex-2" {
+		t.Errorf("expected a fresh response for another tenant, got %q", other.ID)
+	}
+	cached, err := client.CreateChatCompletion(acme, request)
+	checks.NoError(t, err)
+	if calls != 2 || cached.ID != first.ID {
+		t.Errorf("expected cached response for the same tenant, got %d calls and ID %q", calls, cached.ID)
+	}
+}
@@ -0,0 +1,443 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const responsesSuffix = "/responses"
+
+// ResponseItemType is the type of an input or output item of the Responses
+// API.
+type ResponseItemType string
+
+const (
+	ResponseItemTypeMessage            ResponseItemType = "message"
+	ResponseItemTypeFunctionCall       ResponseItemType = "function_call"
+	ResponseItemTypeFunctionCallOutput ResponseItemType = "function_call_output"
+)
+
+// ResponseContentType is the type of a part of a message item.
+type ResponseContentType string
+
+const (
+	ResponseContentTypeInputText  ResponseContentType = "input_text"
+	ResponseContentTypeOutputText ResponseContentType = "output_text"
+	ResponseContentTypeRefusal    ResponseContentType = "refusal"
+)
+
+// ResponseToolType is the type of a tool available to a response.
+type ResponseToolType string
+
+const (
+	ResponseToolTypeFunction ResponseToolType = "function"
+)
+
+// ResponseTool is a tool the model may call. Only the fields of its Type are
+// used.
+type ResponseTool struct {
+	Type ResponseToolType `json:"type"`
+
+	// Function tools.
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Parameters  any    `json:"parameters,omitempty"`
+	Strict      bool   `json:"strict,omitempty"`
+
+	// Computer use tools.
+	DisplayWidth  int                 `json:"display_width,omitempty"`
+	DisplayHeight int                 `json:"display_height,omitempty"`
+	Environment   ComputerEnvironment `json:"environment,omitempty"`
+
+	// File search tools.
+	VectorStoreIDs []string                  `json:"vector_store_ids,omitempty"`
+	MaxNumResults  int                       `json:"max_num_results,omitempty"`
+	RankingOptions *FileSearchRankingOptions `json:"ranking_options,omitempty"`
+	Filters        any                       `json:"filters,omitempty"`
+
+	// Web search tools. SearchContextSize is "low", "medium" or "high".
+	SearchContextSize string                 `json:"search_context_size,omitempty"`
+	UserLocation      *WebSearchUserLocation `json:"user_location,omitempty"`
+
+	// MCP tools. RequireApproval is MCPApprovalAlways, MCPApprovalNever or an
+	// MCPToolApprovalFilter.
+	ServerLabel     string            `json:"server_label,omitempty"`
+	ServerURL       string            `json:"server_url,omitempty"`
+	AllowedTools    []string          `json:"allowed_tools,omitempty"`
+	RequireApproval any               `json:"require_approval,omitempty"`
+	Headers         map[string]string `json:"headers,omitempty"`
+
+	// Code interpreter tools. Container is the ID of an existing container
+	// or a CodeInterpreterContainer.
+	Container any `json:"container,omitempty"`
+}
+
+// ResponseContent is a part of a message item.
+type ResponseContent struct {
+	Type        ResponseContentType  `json:"type"`
+	Text        string               `json:"text,omitempty"`
+	Annotations []ResponseAnnotation `json:"annotations,omitempty"`
+	Refusal     string               `json:"refusal,omitempty"`
+
+	// Image and file input parts.
+	ImageURL string         `json:"image_url,omitempty"`
+	FileID   string         `json:"file_id,omitempty"`
+	Detail   ImageURLDetail `json:"detail,omitempty"`
+	Filename string         `json:"filename,omitempty"`
+	FileData string         `json:"file_data,omitempty"`
+}
+
+// ResponseItem is an input or output item of the Responses API. Items of the
+// output of a response can be passed back as input. Only the fields of its
+// Type are used.
+type ResponseItem struct {
+	Type   ResponseItemType `json:"type"`
+	ID     string           `json:"id,omitempty"`
+	Status string           `json:"status,omitempty"`
+
+	// Message items.
+	Role    string            `json:"role,omitempty"`
+	Content []ResponseContent `json:"content,omitempty"`
+
+	// Tool call items and their outputs.
+	CallID    string              `json:"call_id,omitempty"`
+	Name      string              `json:"name,omitempty"`
+	Arguments string              `json:"arguments,omitempty"`
+	Output    *ResponseItemOutput `json:"output,omitempty"`
+
+	// Computer call items and their outputs.
+	Action                   *ComputerAction       `json:"action,omitempty"`
+	PendingSafetyChecks      []ComputerSafetyCheck `json:"pending_safety_checks,omitempty"`
+	AcknowledgedSafetyChecks []ComputerSafetyCheck `json:"acknowledged_safety_checks,omitempty"`
+
+	// File search call items.
+	Queries []string           `json:"queries,omitempty"`
+	Results []FileSearchResult `json:"results,omitempty"`
+
+	// Code interpreter call items. Outputs are only set when
+	// ResponseIncludeCodeInterpreterCallOutputs is requested.
+	Code        string                  `json:"code,omitempty"`
+	ContainerID string                  `json:"container_id,omitempty"`
+	Outputs     []CodeInterpreterOutput `json:"outputs,omitempty"`
+
+	// Reasoning items. EncryptedContent is opaque and must be passed back
+	// unchanged.
+	Summary          []ResponseReasoningSummary `json:"summary,omitempty"`
+	EncryptedContent string                     `json:"encrypted_content,omitempty"`
+
+	// MCP items. Name, Arguments and Output are shared with function calls.
+	ServerLabel       string          `json:"server_label,omitempty"`
+	Tools             []MCPListedTool `json:"tools,omitempty"`
+	Error             string          `json:"error,omitempty"`
+	ApprovalRequestID string          `json:"approval_request_id,omitempty"`
+	Approve           *bool           `json:"approve,omitempty"`
+	Reason            string          `json:"reason,omitempty"`
+
+	// WebSearchAction is the action of web search call items, which share
+	// the "action" key with computer call items.
+	WebSearchAction *WebSearchAction `json:"-"`
+}
+
+func (i ResponseItem) MarshalJSON() ([]byte, error) {
+	type item ResponseItem
+	switch i.Type {
+	case ResponseItemTypeWebSearchCall:
+		return json.Marshal(struct {
+			item
+			Action *WebSearchAction `json:"action,omitempty"`
+		}{item(i), i.WebSearchAction})
+	case ResponseItemTypeReasoning:
+		// The summary is required when reasoning items are passed back, even
+		// if empty.
+		summary := i.Summary
+		if summary == nil {
+			summary = []ResponseReasoningSummary{}
+		}
+		return json.Marshal(struct {
+			item
+			Summary []ResponseReasoningSummary `json:"summary"`
+		}{item(i), summary})
+	default:
+		return json.Marshal(item(i))
+	}
+}
+
+func (i *ResponseItem) UnmarshalJSON(data []byte) error {
+	type item ResponseItem
+	raw := struct {
+		*item
+		Action json.RawMessage `json:"action"`
+	}{item: (*item)(i)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw.Action) == 0 || string(raw.Action) == "null" {
+		return nil
+	}
+	if i.Type == ResponseItemTypeWebSearchCall {
+		i.WebSearchAction = &WebSearchAction{}
+		return json.Unmarshal(raw.Action, i.WebSearchAction)
+	}
+	i.Action = &ComputerAction{}
+	return json.Unmarshal(raw.Action, i.Action)
+}
+
+// ResponseItemOutput is the output of a tool call item: a string for function
+// calls, an object for other tools.
+type ResponseItemOutput struct {
+	Text       string
+	Screenshot *ComputerScreenshot
+}
+
+func (o ResponseItemOutput) MarshalJSON() ([]byte, error) {
+	if o.Screenshot != nil {
+		return json.Marshal(o.Screenshot)
+	}
+	return json.Marshal(o.Text)
+}
+
+func (o *ResponseItemOutput) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '{' {
+		o.Screenshot = &ComputerScreenshot{}
+		return json.Unmarshal(data, o.Screenshot)
+	}
+	return json.Unmarshal(data, &o.Text)
+}
+
+// NewResponseInputMessage returns a message item with a single input_text
+// part.
+func NewResponseInputMessage(role, text string) ResponseItem {
+	return ResponseItem{
+		Type:    ResponseItemTypeMessage,
+		Role:    role,
+		Content: []ResponseContent{{Type: ResponseContentTypeInputText, Text: text}},
+	}
+}
+
+// NewResponseFunctionCallOutput returns the output of a function call item.
+func NewResponseFunctionCallOutput(callID, output string) ResponseItem {
+	return ResponseItem{
+		Type:   ResponseItemTypeFunctionCallOutput,
+		CallID: callID,
+		Output: &ResponseItemOutput{Text: output},
+	}
+}
+
+// ResponseRequest represents a request to the Responses API.
+type ResponseRequest struct {
+	Model string `json:"model,omitempty"`
+	// Prompt invokes a prompt template, which may replace Model,
+	// Instructions and other fields.
+	Prompt *ResponsePrompt `json:"prompt,omitempty"`
+	// Input is either a string or a []ResponseItem.
+	Input        any    `json:"input,omitempty"`
+	Instructions string `json:"instructions,omitempty"`
+	// PreviousResponseID continues the conversation of a stored response,
+	// whose input and output become the context of this one.
+	PreviousResponseID string             `json:"previous_response_id,omitempty"`
+	Tools              []ResponseTool     `json:"tools,omitempty"`
+	ToolChoice         any                `json:"tool_choice,omitempty"`
+	MaxOutputTokens    int                `json:"max_output_tokens,omitempty"`
+	Temperature        float32            `json:"temperature,omitempty"`
+	TopP               float32            `json:"top_p,omitempty"`
+	Reasoning          *ResponseReasoning `json:"reasoning,omitempty"`
+	// Text configures the format of the text output, such as a JSON schema
+	// built with NewResponseJSONSchemaFormat.
+	Text *ResponseTextConfig `json:"text,omitempty"`
+	// Truncation is "auto" or "disabled".
+	Truncation string `json:"truncation,omitempty"`
+	// Include requests additional output data, such as search results.
+	Include []ResponseInclude `json:"include,omitempty"`
+	User    string            `json:"user,omitempty"`
+	// SafetyIdentifier is a stable identifier of the end user, such as a
+	// hash of their username, used to detect policy violations.
+	SafetyIdentifier string `json:"safety_identifier,omitempty"`
+	// Background runs the response asynchronously. Poll it with GetResponse
+	// or WaitForResponse, or stream it and resume with ResumeResponseStream.
+	Background bool `json:"background,omitempty"`
+	Stream     bool `json:"stream,omitempty"`
+	// Store controls whether the response is stored for later retrieval and
+	// chaining. The API stores responses unless it is set to false.
+	Store    *bool             `json:"store,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// explicit holds the fields set with SetTemperature and SetTopP, which
+	// are sent even when zero.
+	explicit explicitFields
+}
+
+type ResponseUsage struct {
+	InputTokens         int                       `json:"input_tokens"`
+	OutputTokens        int                       `json:"output_tokens"`
+	TotalTokens         int                       `json:"total_tokens"`
+	InputTokensDetails  *ResponseInputTokensUsage `json:"input_tokens_details,omitempty"`
+	OutputTokensDetails *ResponseOutputTokenUsage `json:"output_tokens_details,omitempty"`
+}
+
+type ResponseInputTokensUsage struct {
+	CachedTokens int `json:"cached_tokens"`
+}
+
+type ResponseOutputTokenUsage struct {
+	ReasoningTokens int `json:"reasoning_tokens"`
+}
+
+type ResponseError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type ResponseIncompleteDetails struct {
+	Reason string `json:"reason"`
+}
+
+// Statuses of a ModelResponse.
+const (
+	ResponseStatusQueued     = "queued"
+	ResponseStatusInProgress = "in_progress"
+	ResponseStatusCompleted  = "completed"
+	ResponseStatusFailed     = "failed"
+	ResponseStatusCancelled  = "cancelled"
+	ResponseStatusIncomplete = "incomplete"
+)
+
+// ModelResponse is a model response created by the Responses API.
+type ModelResponse struct {
+	ID                 string                     `json:"id"`
+	Object             string                     `json:"object"`
+	CreatedAt          int64                      `json:"created_at"`
+	Model              string                     `json:"model"`
+	Status             string                     `json:"status"`
+	Background         bool                       `json:"background,omitempty"`
+	PreviousResponseID string                     `json:"previous_response_id,omitempty"`
+	Text               *ResponseTextConfig        `json:"text,omitempty"`
+	Prompt             *ResponsePrompt            `json:"prompt,omitempty"`
+	Metadata           map[string]string          `json:"metadata,omitempty"`
+	Output             []ResponseItem             `json:"output"`
+	Usage              *ResponseUsage             `json:"usage,omitempty"`
+	Error              *ResponseError             `json:"error,omitempty"`
+	IncompleteDetails  *ResponseIncompleteDetails `json:"incomplete_details,omitempty"`
+
+	httpHeader
+}
+
+// OutputText concatenates the output_text parts of the message items.
+func (r ModelResponse) OutputText() string {
+	var b strings.Builder
+	for _, item := range r.Output {
+		if item.Type != ResponseItemTypeMessage {
+			continue
+		}
+		for _, content := range item.Content {
+			if content.Type == ResponseContentTypeOutputText {
+				b.WriteString(content.Text)
+			}
+		}
+	}
+	return b.String()
+}
+
+// Done reports whether the response reached a terminal status.
+func (r ModelResponse) Done() bool {
+	switch r.Status {
+	case ResponseStatusQueued, ResponseStatusInProgress:
+		return false
+	default:
+		return true
+	}
+}
+
+// CreateResponse creates a model response.
+func (c *Client) CreateResponse(ctx context.Context, request ResponseRequest) (response ModelResponse, err error) {
+	if request.Stream {
+		err = ErrResponseStreamNotSupported
+		return
+	}
+	c.applyResponseDefaults(&request)
+	c.applyResponseExperiment(ctx, &request)
+	if err = c.mutateResponseRequest(ctx, &request); err != nil {
+		return
+	}
+	if c.config.Policy != nil {
+		if err = c.config.Policy.CheckResponse(request); err != nil {
+			return
+		}
+	}
+	if err = validateMetadata(request.Metadata); err != nil {
+		return
+	}
+
+	request.SafetyIdentifier = c.resolveSafetyIdentifier(ctx, request.SafetyIdentifier, request.User)
+	request.User = c.resolveUser(ctx, request.User)
+	if err = c.admit(ctx, request.Model); err != nil {
+		return
+	}
+	req, err := c.newRequest(
+		ctx,
+		http.MethodPost,
+		c.fullURL(responsesSuffix, withModel(request.Model)),
+		withBody(request),
+	)
+	if err != nil {
+		return
+	}
+
+	start := time.Now()
+	err = c.sendRequest(req, &response)
+	// Background responses return before the model has run.
+	if !request.Background {
+		c.observeLatency(request.Model, start, err)
+	}
+	if err == nil && response.Usage != nil {
+		c.recordTokens(req, response.Usage.InputTokens, response.Usage.OutputTokens)
+	}
+	return
+}
+
+// GetResponse retrieves a response, e.g. to poll a background response.
+func (c *Client) GetResponse(ctx context.Context, responseID string) (response ModelResponse, err error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(responsesSuffix+"/"+responseID))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// CancelResponse cancels a background response.
+func (c *Client) CancelResponse(ctx context.Context, responseID string) (response ModelResponse, err error) {
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(responsesSuffix+"/"+responseID+"/cancel"))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// WaitForResponse polls a background response every interval until it
+// reaches a terminal status or ctx is done.
+func (c *Client) WaitForResponse(
+	ctx context.Context,
+	responseID string,
+	interval time.Duration,
+) (response ModelResponse, err error) {
+	for {
+		response, err = c.GetResponse(ctx, responseID)
+		if err != nil || response.Done() {
+			return
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return response, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	ResponseToolTypeCodeInterpreter ResponseToolType = "code_interpreter"
+
+	ResponseItemTypeCodeInterpreterCall ResponseItemType = "code_interpreter_call"
+
+	// ResponseAnnotationTypeContainerFileCitation references a file the code
+	// interpreter created in its container.
+	ResponseAnnotationTypeContainerFileCitation ResponseAnnotationType = "container_file_citation"
+)
+
+// CodeInterpreterContainer is the container of a code_interpreter tool
+// created on demand. It is a value of ResponseTool.Container, which otherwise
+// holds the ID of an existing container.
+type CodeInterpreterContainer struct {
+	// Type is "auto".
+	Type    string   `json:"type"`
+	FileIDs []string `json:"file_ids,omitempty"`
+}
+
+// NewCodeInterpreterTool returns the code_interpreter tool running in a new
+// container with access to the given files.
+func NewCodeInterpreterTool(fileIDs ...string) ResponseTool {
+	return ResponseTool{
+		Type:      ResponseToolTypeCodeInterpreter,
+		Container: CodeInterpreterContainer{Type: "auto", FileIDs: fileIDs},
+	}
+}
+
+// CodeInterpreterOutputType is the type of an output of a code interpreter
+// call.
+type CodeInterpreterOutputType string
+
+const (
+	CodeInterpreterOutputTypeLogs  CodeInterpreterOutputType = "logs"
+	CodeInterpreterOutputTypeImage CodeInterpreterOutputType = "image"
+)
+
+// CodeInterpreterOutput is an output of a code interpreter call, in run steps
+// and code_interpreter_call items. Only the fields of its Type are used:
+// images are referenced by Image in run steps and by URL in responses.
+type CodeInterpreterOutput struct {
+	Type  CodeInterpreterOutputType `json:"type"`
+	Logs  string                    `json:"logs,omitempty"`
+	Image *ImageFile                `json:"image,omitempty"`
+	URL   string                    `json:"url,omitempty"`
+}
+
+// ContainerFile references a file created by the code interpreter in a
+// container. Download it with Client.GetContainerFileContent.
+type ContainerFile struct {
+	ContainerID string
+	FileID      string
+	Filename    string
+}
+
+// ContainerFiles returns the files created by the code interpreter that the
+// output text cites, in order, each once.
+func (r ModelResponse) ContainerFiles() []ContainerFile {
+	var files []ContainerFile
+	seen := make(map[ContainerFile]bool)
+	for _, citation := range r.Citations() {
+		if citation.Type != ResponseAnnotationTypeContainerFileCitation {
+			continue
+		}
+		file := ContainerFile{ContainerID: citation.ContainerID, FileID: citation.FileID, Filename: citation.Filename}
+		if !seen[file] {
+			seen[file] = true
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// GetContainerFileContent downloads a file of a code interpreter container,
+// such as a chart or a CSV file generated by a code_interpreter_call.
+func (c *Client) GetContainerFileContent(
+	ctx context.Context,
+	containerID string,
+	fileID string,
+) (content RawResponse, err error) {
+	urlSuffix := fmt.Sprintf("/containers/%s/files/%s/content", url.PathEscape(containerID), url.PathEscape(fileID))
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
+	if err != nil {
+		return
+	}
+
+	return c.sendRequestRaw(req)
+}
@@ -0,0 +1,68 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestResponseCodeInterpreter(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var request map[string]any
+	server.RegisterHandler("/v1/responses", func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &request)
+		fmt.Fprint(w, `{"id":"resp_1","status":"completed","output":[`+
+			`{"type":"code_interpreter_call","id":"ci_1","status":"completed","code":"plot()",`+
+			`"container_id":"cntr_1","outputs":[{"type":"logs","logs":"done\n"},`+
+			`{"type":"image","url":"https://example.com/plot.png"}]},`+
+			`{"type":"message","role":"assistant","content":[{"type":"output_text","text":"See plot.png.",`+
+			`"annotations":[{"type":"container_file_citation","start_index":4,"end_index":12,`+
+			`"container_id":"cntr_1","file_id":"cfile_1","filename":"plot.png"},`+
+			`{"type":"container_file_citation","start_index":0,"end_index":3,`+
+			`"container_id":"cntr_1","file_id":"cfile_1","filename":"plot.png"}]}]}]}`)
+	})
+	server.RegisterHandler("/v1/containers/cntr_1/files/cfile_1/content", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, "png")
+	})
+
+	ctx := context.Background()
+	response, err := client.CreateResponse(ctx, openai.ResponseRequest{
+		Model:   "gpt-4.1",
+		Input:   "Plot it",
+		Tools:   []openai.ResponseTool{openai.NewCodeInterpreterTool("file_1")},
+		Include: []openai.ResponseInclude{openai.ResponseIncludeCodeInterpreterCallOutputs},
+	})
+	checks.NoError(t, err, "CreateResponse error")
+
+	tools, _ := json.Marshal(request["tools"])
+	if string(tools) != `[{"container":{"file_ids":["file_1"],"type":"auto"},"type":"code_interpreter"}]` {
+		t.Errorf("unexpected tools %s", tools)
+	}
+	call := response.Output[0]
+	if call.Code != "plot()" || call.ContainerID != "cntr_1" || len(call.Outputs) != 2 ||
+		call.Outputs[0].Logs != "done\n" || call.Outputs[1].URL != "https://example.com/plot.png" {
+		t.Errorf("unexpected code interpreter call %+v", call)
+	}
+
+	files := response.ContainerFiles()
+	want := openai.ContainerFile{ContainerID: "cntr_1", FileID: "cfile_1", Filename: "plot.png"}
+	if len(files) != 1 || files[0] != want {
+		t.Fatalf("ContainerFiles() = %+v", files)
+	}
+	content, err := client.GetContainerFileContent(ctx, files[0].ContainerID, files[0].FileID)
+	checks.NoError(t, err, "GetContainerFileContent error")
+	defer content.Close()
+	data, _ := io.ReadAll(content)
+	if string(data) != "png" {
+		t.Errorf("downloaded %q", data)
+	}
+}
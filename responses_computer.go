@@ -0,0 +1,123 @@
+package openai
+
+import "encoding/json"
+
+const (
+	ResponseToolTypeComputerUsePreview ResponseToolType = "computer_use_preview"
+
+	ResponseItemTypeComputerCall       ResponseItemType = "computer_call"
+	ResponseItemTypeComputerCallOutput ResponseItemType = "computer_call_output"
+)
+
+// ComputerEnvironment is the environment controlled by the computer use tool.
+type ComputerEnvironment string
+
+const (
+	ComputerEnvironmentBrowser ComputerEnvironment = "browser"
+	ComputerEnvironmentMac     ComputerEnvironment = "mac"
+	ComputerEnvironmentWindows ComputerEnvironment = "windows"
+	ComputerEnvironmentUbuntu  ComputerEnvironment = "ubuntu"
+)
+
+// NewComputerUseTool returns the computer_use_preview tool for a display of
+// the given size. Requests using it must set Truncation to "auto".
+func NewComputerUseTool(width, height int, environment ComputerEnvironment) ResponseTool {
+	return ResponseTool{
+		Type:          ResponseToolTypeComputerUsePreview,
+		DisplayWidth:  width,
+		DisplayHeight: height,
+		Environment:   environment,
+	}
+}
+
+// ComputerActionType is the type of an action requested by a computer_call.
+type ComputerActionType string
+
+const (
+	ComputerActionClick       ComputerActionType = "click"
+	ComputerActionDoubleClick ComputerActionType = "double_click"
+	ComputerActionDrag        ComputerActionType = "drag"
+	ComputerActionKeypress    ComputerActionType = "keypress"
+	ComputerActionMove        ComputerActionType = "move"
+	ComputerActionScreenshot  ComputerActionType = "screenshot"
+	ComputerActionScroll      ComputerActionType = "scroll"
+	ComputerActionTypeText    ComputerActionType = "type"
+	ComputerActionWait        ComputerActionType = "wait"
+)
+
+type ComputerPoint struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// ComputerAction is an action to perform on the computer. Only the fields of
+// its Type are used.
+type ComputerAction struct {
+	Type ComputerActionType `json:"type"`
+	// X and Y are used by click, double_click, move and scroll.
+	X int `json:"x,omitempty"`
+	Y int `json:"y,omitempty"`
+	// Button is "left", "right", "wheel", "back" or "forward".
+	Button  string          `json:"button,omitempty"`
+	ScrollX int             `json:"scroll_x,omitempty"`
+	ScrollY int             `json:"scroll_y,omitempty"`
+	Text    string          `json:"text,omitempty"`
+	Keys    []string        `json:"keys,omitempty"`
+	Path    []ComputerPoint `json:"path,omitempty"`
+}
+
+func (a ComputerAction) MarshalJSON() ([]byte, error) {
+	type action ComputerAction
+	switch a.Type {
+	case ComputerActionClick, ComputerActionDoubleClick, ComputerActionMove, ComputerActionScroll:
+		// Zero is a valid coordinate.
+		return json.Marshal(struct {
+			action
+			X int `json:"x"`
+			Y int `json:"y"`
+		}{action(a), a.X, a.Y})
+	default:
+		return json.Marshal(action(a))
+	}
+}
+
+// ComputerSafetyCheck is a check raised with a computer_call, such as a
+// suspected prompt injection. Pending checks must be confirmed by the user
+// and passed back with the call output as acknowledged checks.
+type ComputerSafetyCheck struct {
+	ID      string `json:"id"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ComputerScreenshot is the output of a computer_call.
+type ComputerScreenshot struct {
+	Type     string `json:"type"`
+	ImageURL string `json:"image_url,omitempty"`
+	FileID   string `json:"file_id,omitempty"`
+}
+
+// NewComputerCallOutput returns the output of a computer_call, a screenshot
+// of the screen after performing its action. imageURL is usually a base64
+// data URL.
+func NewComputerCallOutput(callID, imageURL string, acknowledged ...ComputerSafetyCheck) ResponseItem {
+	return ResponseItem{
+		Type:   ResponseItemTypeComputerCallOutput,
+		CallID: callID,
+		Output: &ResponseItemOutput{
+			Screenshot: &ComputerScreenshot{Type: "computer_screenshot", ImageURL: imageURL},
+		},
+		AcknowledgedSafetyChecks: acknowledged,
+	}
+}
+
+// ComputerCalls returns the computer_call items of the output.
+func (r ModelResponse) ComputerCalls() []ResponseItem {
+	var calls []ResponseItem
+	for _, item := range r.Output {
+		if item.Type == ResponseItemTypeComputerCall {
+			calls = append(calls, item)
+		}
+	}
+	return calls
+}
@@ -0,0 +1,66 @@
+package openai
+
+import (
+	"context"
+	"sync"
+)
+
+// ResponsesConversation chains responses with previous_response_id, so that
+// follow-up turns only send the new input. It is safe for concurrent use,
+// although turns are naturally sequential.
+type ResponsesConversation struct {
+	client *Client
+	// base is copied into every request.
+	base ResponseRequest
+
+	mu             sync.Mutex
+	lastResponseID string
+}
+
+// NewResponsesConversation starts a conversation whose requests are based on
+// base, e.g. to set the model, instructions and tools. Set
+// base.PreviousResponseID to continue an existing conversation.
+func NewResponsesConversation(client *Client, base ResponseRequest) *ResponsesConversation {
+	return &ResponsesConversation{
+		client:         client,
+		base:           base,
+		lastResponseID: base.PreviousResponseID,
+	}
+}
+
+// Send creates the next response of the conversation from input items, such
+// as user messages or tool outputs.
+func (c *ResponsesConversation) Send(ctx context.Context, input ...ResponseItem) (ModelResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	request := c.base
+	request.Input = input
+	request.PreviousResponseID = c.lastResponseID
+	response, err := c.client.CreateResponse(ctx, request)
+	if err != nil {
+		return response, err
+	}
+	c.lastResponseID = response.ID
+	return response, nil
+}
+
+// SendText sends a user message.
+func (c *ResponsesConversation) SendText(ctx context.Context, text string) (ModelResponse, error) {
+	return c.Send(ctx, NewResponseInputMessage(ChatMessageRoleUser, text))
+}
+
+// LastResponseID returns the ID of the latest response, which the next turn
+// continues from.
+func (c *ResponsesConversation) LastResponseID() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastResponseID
+}
+
+// Reset forgets the conversation so that the next turn starts a new one.
+func (c *ResponsesConversation) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastResponseID = ""
+}
@@ -0,0 +1,62 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestResponsesConversation(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var requests []openai.ResponseRequest
+	server.RegisterHandler("/v1/responses", func(w http.ResponseWriter, r *http.Request) {
+		var request openai.ResponseRequest
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &request)
+		requests = append(requests, request)
+		if len(requests) == 3 {
+			http.Error(w, `{"error":{"message":"boom"}}`, http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintf(w, `{"id":"resp_%d","status":"completed","previous_response_id":%q}`,
+			len(requests), request.PreviousResponseID)
+	})
+
+	conversation := openai.NewResponsesConversation(client, openai.ResponseRequest{
+		Model:        "gpt-4.1",
+		Instructions: "Be brief.",
+	})
+	ctx := context.Background()
+	_, err := conversation.SendText(ctx, "Hi")
+	checks.NoError(t, err)
+	response, err := conversation.Send(ctx, openai.NewResponseFunctionCallOutput("call_1", "42"))
+	checks.NoError(t, err)
+	if response.PreviousResponseID != "resp_1" || conversation.LastResponseID() != "resp_2" {
+		t.Errorf("expected the second turn to continue the first, got %+v", response)
+	}
+
+	_, err = conversation.SendText(ctx, "And then?")
+	checks.HasError(t, err)
+	if conversation.LastResponseID() != "resp_2" {
+		t.Errorf("expected a failed turn to keep the last response, got %s", conversation.LastResponseID())
+	}
+
+	conversation.Reset()
+	_, err = conversation.SendText(ctx, "New topic")
+	checks.NoError(t, err)
+
+	wantPrevious := []string{"", "resp_1", "resp_2", ""}
+	for i, request := range requests {
+		if request.PreviousResponseID != wantPrevious[i] || request.Instructions != "Be brief." {
+			t.Errorf("request %d: unexpected request %+v", i, request)
+		}
+	}
+}
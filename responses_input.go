@@ -0,0 +1,56 @@
+package openai
+
+const (
+	ResponseContentTypeInputImage ResponseContentType = "input_image"
+	ResponseContentTypeInputFile  ResponseContentType = "input_file"
+)
+
+// NewResponseInputMessageParts returns a message item made of several content
+// parts, such as text, images and files.
+//
+//	openai.NewResponseInputMessageParts(openai.ChatMessageRoleUser,
+//		openai.ResponseInputText("Summarize this contract."),
+//		openai.ResponseInputFileID(file.ID),
+//	)
+func NewResponseInputMessageParts(role string, parts ...ResponseContent) ResponseItem {
+	return ResponseItem{Type: ResponseItemTypeMessage, Role: role, Content: parts}
+}
+
+// ResponseInputText returns an input_text part.
+func ResponseInputText(text string) ResponseContent {
+	return ResponseContent{Type: ResponseContentTypeInputText, Text: text}
+}
+
+// ResponseInputImageURL returns an input_image part referencing url, which
+// can be an http(s) URL or a data URL.
+func ResponseInputImageURL(url string, detail ImageURLDetail) ResponseContent {
+	return ResponseContent{Type: ResponseContentTypeInputImage, ImageURL: url, Detail: detail}
+}
+
+// ResponseInputImageData returns an input_image part from raw image bytes of
+// the given MIME type (e.g. "image/png"), embedded as a base64 data URL.
+func ResponseInputImageData(data []byte, mimeType string, detail ImageURLDetail) ResponseContent {
+	return ResponseInputImageURL(dataURL(mimeType, data), detail)
+}
+
+// ResponseInputImageFileID returns an input_image part referencing an
+// uploaded file, created with the "vision" or "user_data" purpose.
+func ResponseInputImageFileID(fileID string, detail ImageURLDetail) ResponseContent {
+	return ResponseContent{Type: ResponseContentTypeInputImage, FileID: fileID, Detail: detail}
+}
+
+// ResponseInputFileID returns an input_file part referencing an uploaded
+// file, such as a PDF.
+func ResponseInputFileID(fileID string) ResponseContent {
+	return ResponseContent{Type: ResponseContentTypeInputFile, FileID: fileID}
+}
+
+// ResponseInputFileData returns an input_file part from raw file bytes of the
+// given MIME type (e.g. "application/pdf").
+func ResponseInputFileData(filename, mimeType string, data []byte) ResponseContent {
+	return ResponseContent{
+		Type:     ResponseContentTypeInputFile,
+		Filename: filename,
+		FileData: dataURL(mimeType, data),
+	}
+}
@@ -0,0 +1,33 @@
+package openai_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestResponseInputParts(t *testing.T) {
+	item := openai.NewResponseInputMessageParts(openai.ChatMessageRoleUser,
+		openai.ResponseInputText("Compare these"),
+		openai.ResponseInputImageURL("https://example.com/a.png", openai.ImageURLDetailLow),
+		openai.ResponseInputImageData([]byte("png"), "image/png", openai.ImageURLDetailAuto),
+		openai.ResponseInputImageFileID("file_img", openai.ImageURLDetailHigh),
+		openai.ResponseInputFileID("file_pdf"),
+		openai.ResponseInputFileData("contract.pdf", "application/pdf", []byte("%PDF")),
+	)
+	data, err := json.Marshal(item)
+	checks.NoError(t, err)
+
+	want := `{"type":"message","role":"user","content":[` +
+		`{"type":"input_text","text":"Compare these"},` +
+		`{"type":"input_image","image_url":"https://example.com/a.png","detail":"low"},` +
+		`{"type":"input_image","image_url":"data:image/png;base64,cG5n","detail":"auto"},` +
+		`{"type":"input_image","file_id":"file_img","detail":"high"},` +
+		`{"type":"input_file","file_id":"file_pdf"},` +
+		`{"type":"input_file","filename":"contract.pdf","file_data":"data:application/pdf;base64,JVBERg=="}]}`
+	if string(data) != want {
+		t.Errorf("unexpected item\n got: %s\nwant: %s", data, want)
+	}
+}
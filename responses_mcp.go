@@ -0,0 +1,64 @@
+package openai
+
+const (
+	ResponseToolTypeMCP ResponseToolType = "mcp"
+
+	ResponseItemTypeMCPListTools        ResponseItemType = "mcp_list_tools"
+	ResponseItemTypeMCPCall             ResponseItemType = "mcp_call"
+	ResponseItemTypeMCPApprovalRequest  ResponseItemType = "mcp_approval_request"
+	ResponseItemTypeMCPApprovalResponse ResponseItemType = "mcp_approval_response"
+)
+
+// MCPApproval values of ResponseTool.RequireApproval.
+const (
+	MCPApprovalAlways = "always"
+	MCPApprovalNever  = "never"
+)
+
+// MCPToolApprovalFilter requires approval for some tools of an MCP server
+// only. It is a value of ResponseTool.RequireApproval.
+type MCPToolApprovalFilter struct {
+	Always *MCPToolNames `json:"always,omitempty"`
+	Never  *MCPToolNames `json:"never,omitempty"`
+}
+
+type MCPToolNames struct {
+	ToolNames []string `json:"tool_names"`
+}
+
+// NewMCPTool returns an mcp tool for a remote MCP server. Set AllowedTools,
+// RequireApproval and Headers on the result as needed.
+func NewMCPTool(serverLabel, serverURL string) ResponseTool {
+	return ResponseTool{Type: ResponseToolTypeMCP, ServerLabel: serverLabel, ServerURL: serverURL}
+}
+
+// MCPListedTool is a tool advertised by an MCP server in an mcp_list_tools
+// item.
+type MCPListedTool struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	InputSchema any    `json:"input_schema,omitempty"`
+	Annotations any    `json:"annotations,omitempty"`
+}
+
+// NewMCPApprovalResponse approves or denies an mcp_approval_request item.
+func NewMCPApprovalResponse(approvalRequestID string, approve bool, reason string) ResponseItem {
+	return ResponseItem{
+		Type:              ResponseItemTypeMCPApprovalResponse,
+		ApprovalRequestID: approvalRequestID,
+		Approve:           &approve,
+		Reason:            reason,
+	}
+}
+
+// MCPApprovalRequests returns the mcp_approval_request items of the output.
+// Answer them with NewMCPApprovalResponse in a follow-up request.
+func (r ModelResponse) MCPApprovalRequests() []ResponseItem {
+	var requests []ResponseItem
+	for _, item := range r.Output {
+		if item.Type == ResponseItemTypeMCPApprovalRequest {
+			requests = append(requests, item)
+		}
+	}
+	return requests
+}
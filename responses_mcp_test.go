@@ -0,0 +1,82 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestResponseMCPTool(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var requests []map[string]any
+	server.RegisterHandler("/v1/responses", func(w http.ResponseWriter, r *http.Request) {
+		var request map[string]any
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &request)
+		requests = append(requests, request)
+		if len(requests) > 1 {
+			fmt.Fprint(w, `{"id":"resp_2","status":"completed","output":[{"type":"mcp_call","id":"mcp_2",`+
+				`"server_label":"docs","name":"search","arguments":"{\"q\":\"go\"}","output":"3 results","error":null}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"id":"resp_1","status":"completed","output":[`+
+			`{"type":"mcp_list_tools","id":"mcpl_1","server_label":"docs","tools":[`+
+			`{"name":"search","input_schema":{"type":"object"}}]},`+
+			`{"type":"mcp_approval_request","id":"mcpr_1","server_label":"docs","name":"search",`+
+			`"arguments":"{\"q\":\"go\"}"}]}`)
+	})
+
+	tool := openai.NewMCPTool("docs", "https://mcp.example.com/sse")
+	tool.AllowedTools = []string{"search"}
+	tool.RequireApproval = openai.MCPToolApprovalFilter{
+		Never: &openai.MCPToolNames{ToolNames: []string{"list"}},
+	}
+	ctx := context.Background()
+	response, err := client.CreateResponse(ctx, openai.ResponseRequest{
+		Model: "gpt-4.1",
+		Input: "Search the docs for go",
+		Tools: []openai.ResponseTool{tool},
+	})
+	checks.NoError(t, err)
+
+	sent := requests[0]["tools"].([]any)[0].(map[string]any)
+	if sent["type"] != "mcp" || sent["server_label"] != "docs" || sent["server_url"] != "https://mcp.example.com/sse" {
+		t.Errorf("unexpected tool %v", sent)
+	}
+	never := sent["require_approval"].(map[string]any)["never"].(map[string]any)
+	if names := never["tool_names"].([]any); len(names) != 1 || names[0] != "list" {
+		t.Errorf("unexpected approval filter %v", sent["require_approval"])
+	}
+
+	if listed := response.Output[0]; len(listed.Tools) != 1 || listed.Tools[0].Name != "search" {
+		t.Errorf("unexpected listed tools %+v", listed)
+	}
+	approvals := response.MCPApprovalRequests()
+	if len(approvals) != 1 || approvals[0].ID != "mcpr_1" || approvals[0].Arguments != `{"q":"go"}` {
+		t.Fatalf("unexpected approval requests %+v", approvals)
+	}
+
+	response, err = client.CreateResponse(ctx, openai.ResponseRequest{
+		Model: "gpt-4.1",
+		Input: []openai.ResponseItem{openai.NewMCPApprovalResponse(approvals[0].ID, false, "")},
+		Tools: []openai.ResponseTool{tool},
+	})
+	checks.NoError(t, err)
+	approval := requests[1]["input"].([]any)[0].(map[string]any)
+	if approval["type"] != "mcp_approval_response" || approval["approval_request_id"] != "mcpr_1" ||
+		approval["approve"] != false {
+		t.Errorf("expected an explicit denial, got %v", approval)
+	}
+	call := response.Output[0]
+	if call.Type != openai.ResponseItemTypeMCPCall || call.Output == nil || call.Output.Text != "3 results" {
+		t.Errorf("unexpected mcp call %+v", call)
+	}
+}
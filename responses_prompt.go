@@ -0,0 +1,44 @@
+package openai
+
+import "encoding/json"
+
+// ResponsePrompt references a prompt template managed in the dashboard.
+// Version defaults to the current version of the prompt.
+type ResponsePrompt struct {
+	ID        string                            `json:"id"`
+	Version   string                            `json:"version,omitempty"`
+	Variables map[string]ResponsePromptVariable `json:"variables,omitempty"`
+}
+
+// ResponsePromptVariable is the value of a prompt variable: either text, or
+// an input part such as an image or a file.
+type ResponsePromptVariable struct {
+	Text    string
+	Content *ResponseContent
+}
+
+// ResponsePromptText returns a text prompt variable.
+func ResponsePromptText(text string) ResponsePromptVariable {
+	return ResponsePromptVariable{Text: text}
+}
+
+// ResponsePromptContent returns a prompt variable holding an input part, e.g.
+// ResponseInputImageURL or ResponseInputFileID.
+func ResponsePromptContent(content ResponseContent) ResponsePromptVariable {
+	return ResponsePromptVariable{Content: &content}
+}
+
+func (v ResponsePromptVariable) MarshalJSON() ([]byte, error) {
+	if v.Content != nil {
+		return json.Marshal(v.Content)
+	}
+	return json.Marshal(v.Text)
+}
+
+func (v *ResponsePromptVariable) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '{' {
+		v.Content = &ResponseContent{}
+		return json.Unmarshal(data, v.Content)
+	}
+	return json.Unmarshal(data, &v.Text)
+}
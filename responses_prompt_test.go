@@ -0,0 +1,55 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestResponsePrompt(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var body []byte
+	server.RegisterHandler("/v1/responses", func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		fmt.Fprint(w, `{"id":"resp_1","status":"completed","prompt":{"id":"pmpt_1","version":"2",`+
+			`"variables":{"customer":"Ada","receipt":{"type":"input_file","file_id":"file_1"}}}}`)
+	})
+
+	response, err := client.CreateResponse(context.Background(), openai.ResponseRequest{
+		Prompt: &openai.ResponsePrompt{
+			ID:      "pmpt_1",
+			Version: "2",
+			Variables: map[string]openai.ResponsePromptVariable{
+				"customer": openai.ResponsePromptText("Ada"),
+				"receipt":  openai.ResponsePromptContent(openai.ResponseInputFileID("file_1")),
+			},
+		},
+	})
+	checks.NoError(t, err)
+
+	want := `{"prompt":{"id":"pmpt_1","version":"2","variables":{"customer":"Ada",` +
+		`"receipt":{"type":"input_file","file_id":"file_1"}}}}`
+	if string(body) != want {
+		t.Errorf("unexpected request\n got: %s\nwant: %s", body, want)
+	}
+
+	variables := response.Prompt.Variables
+	if variables["customer"].Text != "Ada" || variables["receipt"].Content == nil ||
+		variables["receipt"].Content.FileID != "file_1" {
+		t.Errorf("unexpected variables %+v", variables)
+	}
+	data, err := json.Marshal(response.Prompt)
+	checks.NoError(t, err)
+	if string(data) != `{"id":"pmpt_1","version":"2","variables":{"customer":"Ada",`+
+		`"receipt":{"type":"input_file","file_id":"file_1"}}}` {
+		t.Errorf("expected the prompt to round-trip, got %s", data)
+	}
+}
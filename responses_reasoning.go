@@ -0,0 +1,53 @@
+package openai
+
+import "strings"
+
+const (
+	ResponseItemTypeReasoning ResponseItemType = "reasoning"
+
+	// ResponseIncludeReasoningEncryptedContent returns reasoning items with
+	// their encrypted content, so they can be passed back as input when
+	// responses are not stored.
+	ResponseIncludeReasoningEncryptedContent ResponseInclude = "reasoning.encrypted_content"
+
+	ResponseEventReasoningSummaryPartAdded = "response.reasoning_summary_part.added"
+	ResponseEventReasoningSummaryPartDone  = "response.reasoning_summary_part.done"
+	ResponseEventReasoningSummaryTextDelta = "response.reasoning_summary_text.delta"
+	ResponseEventReasoningSummaryTextDone  = "response.reasoning_summary_text.done"
+)
+
+// ReasoningSummaryMode values of ResponseReasoning.Summary.
+const (
+	ReasoningSummaryAuto     = "auto"
+	ReasoningSummaryConcise  = "concise"
+	ReasoningSummaryDetailed = "detailed"
+)
+
+// ResponseReasoning configures reasoning models. Effort is "low", "medium" or
+// "high"; Summary requests a summary of the reasoning, shown in reasoning
+// output items.
+type ResponseReasoning struct {
+	Effort  string `json:"effort,omitempty"`
+	Summary string `json:"summary,omitempty"`
+}
+
+// ResponseReasoningSummary is a part of the summary of a reasoning item.
+type ResponseReasoningSummary struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// ReasoningSummary concatenates the summaries of the reasoning items,
+// separating parts with blank lines.
+func (r ModelResponse) ReasoningSummary() string {
+	var parts []string
+	for _, item := range r.Output {
+		if item.Type != ResponseItemTypeReasoning {
+			continue
+		}
+		for _, summary := range item.Summary {
+			parts = append(parts, summary.Text)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
@@ -0,0 +1,89 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestResponseReasoningItems(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var requests []map[string]any
+	server.RegisterHandler("/v1/responses", func(w http.ResponseWriter, r *http.Request) {
+		var request map[string]any
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &request)
+		requests = append(requests, request)
+		fmt.Fprint(w, `{"id":"resp_1","status":"completed","output":[{"type":"reasoning","id":"rs_1",`+
+			`"summary":[{"type":"summary_text","text":"Compared options."},{"type":"summary_text","text":"Picked B."}],`+
+			`"encrypted_content":"gAAAA"},{"type":"message","role":"assistant",`+
+			`"content":[{"type":"output_text","text":"B"}]}]}`)
+	})
+
+	ctx := context.Background()
+	response, err := client.CreateResponse(ctx, openai.ResponseRequest{
+		Model:     "o4-mini",
+		Input:     "A or B?",
+		Reasoning: &openai.ResponseReasoning{Effort: "high", Summary: openai.ReasoningSummaryAuto},
+		Include:   []openai.ResponseInclude{openai.ResponseIncludeReasoningEncryptedContent},
+	})
+	checks.NoError(t, err)
+	reasoning := requests[0]["reasoning"].(map[string]any)
+	if reasoning["effort"] != "high" || reasoning["summary"] != "auto" {
+		t.Errorf("unexpected reasoning options %v", reasoning)
+	}
+	if got := response.ReasoningSummary(); got != "Compared options.\n\nPicked B." {
+		t.Errorf("unexpected summary %q", got)
+	}
+
+	// Stateless reuse: pass the reasoning item back unchanged.
+	item := response.Output[0]
+	item.Summary = nil
+	_, err = client.CreateResponse(ctx, openai.ResponseRequest{
+		Model: "o4-mini",
+		Input: []openai.ResponseItem{item, openai.NewResponseInputMessage(openai.ChatMessageRoleUser, "Why?")},
+	})
+	checks.NoError(t, err)
+	sent := requests[1]["input"].([]any)[0].(map[string]any)
+	if sent["encrypted_content"] != "gAAAA" || sent["summary"] == nil {
+		t.Errorf("expected the encrypted content and an empty summary, got %v", sent)
+	}
+}
+
+func TestResponseStreamReasoningSummary(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler("/v1/responses", func(w http.ResponseWriter, _ *http.Request) {
+		writeResponseEvents(w,
+			`{"type":"response.reasoning_summary_part.added","sequence_number":0,"item_id":"rs_1",`+
+				`"summary_index":0,"part":{"type":"summary_text","text":""}}`,
+			`{"type":"response.reasoning_summary_text.delta","sequence_number":1,"item_id":"rs_1",`+
+				`"summary_index":0,"delta":"Thinking"}`,
+			`{"type":"response.reasoning_summary_text.delta","sequence_number":2,"item_id":"rs_1",`+
+				`"summary_index":1,"delta":"Done"}`,
+		)
+	})
+
+	stream, err := client.CreateResponseStream(context.Background(), openai.ResponseRequest{Model: "o4-mini"})
+	checks.NoError(t, err)
+	defer stream.Close()
+	summaries := map[int]string{}
+	for stream.Next() {
+		if event := stream.Current(); event.Type == openai.ResponseEventReasoningSummaryTextDelta {
+			summaries[event.SummaryIndex] += event.Delta
+		}
+	}
+	checks.NoError(t, stream.Err())
+	if summaries[0] != "Thinking" || summaries[1] != "Done" {
+		t.Errorf("unexpected summaries %v", summaries)
+	}
+}
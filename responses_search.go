@@ -0,0 +1,121 @@
+package openai
+
+const (
+	ResponseToolTypeFileSearch       ResponseToolType = "file_search"
+	ResponseToolTypeWebSearchPreview ResponseToolType = "web_search_preview"
+
+	ResponseItemTypeFileSearchCall ResponseItemType = "file_search_call"
+	ResponseItemTypeWebSearchCall  ResponseItemType = "web_search_call"
+)
+
+// ResponseInclude selects additional output data to return with a response.
+type ResponseInclude string
+
+const (
+	// ResponseIncludeFileSearchCallResults includes the results, with their
+	// text, of file_search_call items.
+	ResponseIncludeFileSearchCallResults ResponseInclude = "file_search_call.results"
+	// ResponseIncludeWebSearchCallActionSources includes the sources consulted
+	// by web_search_call items.
+	ResponseIncludeWebSearchCallActionSources ResponseInclude = "web_search_call.action.sources"
+	// ResponseIncludeCodeInterpreterCallOutputs includes the logs and images
+	// of code_interpreter_call items.
+	ResponseIncludeCodeInterpreterCallOutputs ResponseInclude = "code_interpreter_call.outputs"
+	// ResponseIncludeComputerCallOutputImageURL includes the image URLs of
+	// computer call outputs.
+	ResponseIncludeComputerCallOutputImageURL ResponseInclude = "computer_call_output.output.image_url"
+)
+
+// NewFileSearchTool returns the file_search tool over the given vector
+// stores.
+func NewFileSearchTool(vectorStoreIDs ...string) ResponseTool {
+	return ResponseTool{Type: ResponseToolTypeFileSearch, VectorStoreIDs: vectorStoreIDs}
+}
+
+// FileSearchRankingOptions tunes the ranking of file search results, for the
+// Responses API as well as assistants and runs.
+type FileSearchRankingOptions struct {
+	// Ranker is one of the FileSearchRanker constants.
+	Ranker string `json:"ranker,omitempty"`
+	// ScoreThreshold drops the results scoring below it, between 0 and 1.
+	ScoreThreshold float64 `json:"score_threshold,omitempty"`
+}
+
+const (
+	FileSearchRankerAuto            = "auto"
+	FileSearchRankerDefault20240821 = "default_2024_08_21"
+)
+
+// WebSearchUserLocation approximates the location of the user to localize web
+// search results.
+type WebSearchUserLocation struct {
+	Type     string `json:"type"`
+	City     string `json:"city,omitempty"`
+	Country  string `json:"country,omitempty"`
+	Region   string `json:"region,omitempty"`
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// FileSearchResult is a chunk found by a file_search_call. Text is only set
+// when ResponseIncludeFileSearchCallResults is requested.
+type FileSearchResult struct {
+	FileID     string         `json:"file_id"`
+	Filename   string         `json:"filename"`
+	Score      float64        `json:"score"`
+	Text       string         `json:"text,omitempty"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+}
+
+// WebSearchAction is the action performed by a web_search_call.
+type WebSearchAction struct {
+	Type  string `json:"type"`
+	Query string `json:"query,omitempty"`
+	// Sources is only set when ResponseIncludeWebSearchCallActionSources is
+	// requested.
+	Sources []WebSearchSource `json:"sources,omitempty"`
+}
+
+type WebSearchSource struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// ResponseAnnotationType is the type of an annotation of output text.
+type ResponseAnnotationType string
+
+const (
+	ResponseAnnotationTypeURLCitation  ResponseAnnotationType = "url_citation"
+	ResponseAnnotationTypeFileCitation ResponseAnnotationType = "file_citation"
+)
+
+// ResponseAnnotation attributes a span of output text to a web page or a
+// file. StartIndex and EndIndex are only set for URL and container file
+// citations, Index for file citations.
+type ResponseAnnotation struct {
+	Type        ResponseAnnotationType `json:"type"`
+	StartIndex  int                    `json:"start_index,omitempty"`
+	EndIndex    int                    `json:"end_index,omitempty"`
+	URL         string                 `json:"url,omitempty"`
+	Title       string                 `json:"title,omitempty"`
+	Index       int                    `json:"index,omitempty"`
+	FileID      string                 `json:"file_id,omitempty"`
+	Filename    string                 `json:"filename,omitempty"`
+	ContainerID string                 `json:"container_id,omitempty"`
+}
+
+// Citations returns the annotations of the output_text parts of the message
+// items.
+func (r ModelResponse) Citations() []ResponseAnnotation {
+	var citations []ResponseAnnotation
+	for _, item := range r.Output {
+		if item.Type != ResponseItemTypeMessage {
+			continue
+		}
+		for _, content := range item.Content {
+			if content.Type == ResponseContentTypeOutputText {
+				citations = append(citations, content.Annotations...)
+			}
+		}
+	}
+	return citations
+}
@@ -0,0 +1,79 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestResponseSearchCalls(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var request map[string]any
+	server.RegisterHandler("/v1/responses", func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &request)
+		fmt.Fprint(w, `{"id":"resp_1","status":"completed","output":[`+
+			`{"type":"file_search_call","id":"fs_1","status":"completed","queries":["refund policy"],`+
+			`"results":[{"file_id":"file_1","filename":"policy.pdf","score":0.92,"text":"Refunds within 30 days"}]},`+
+			`{"type":"web_search_call","id":"ws_1","status":"completed","action":{"type":"search",`+
+			`"query":"refund law","sources":[{"type":"url","url":"https://example.com/law"}]}},`+
+			`{"type":"message","role":"assistant","content":[{"type":"output_text","text":"30 days.",`+
+			`"annotations":[{"type":"file_citation","index":7,"file_id":"file_1","filename":"policy.pdf"},`+
+			`{"type":"url_citation","start_index":0,"end_index":8,"url":"https://example.com/law","title":"Law"}]}]}]}`)
+	})
+
+	response, err := client.CreateResponse(context.Background(), openai.ResponseRequest{
+		Model: "gpt-4.1",
+		Input: "What is the refund policy?",
+		Tools: []openai.ResponseTool{
+			openai.NewFileSearchTool("vs_1"),
+			{Type: openai.ResponseToolTypeWebSearchPreview, SearchContextSize: "low"},
+		},
+		Include: []openai.ResponseInclude{
+			openai.ResponseIncludeFileSearchCallResults,
+			openai.ResponseIncludeWebSearchCallActionSources,
+		},
+	})
+	checks.NoError(t, err)
+
+	include := request["include"].([]any)
+	if len(include) != 2 || include[0] != "file_search_call.results" {
+		t.Errorf("unexpected include %v", include)
+	}
+	if tools := request["tools"].([]any); tools[0].(map[string]any)["vector_store_ids"] == nil {
+		t.Errorf("expected the vector stores to be sent, got %v", tools)
+	}
+
+	fileSearch := response.Output[0]
+	if len(fileSearch.Results) != 1 || fileSearch.Results[0].Score != 0.92 ||
+		fileSearch.Results[0].Text != "Refunds within 30 days" || fileSearch.Queries[0] != "refund policy" {
+		t.Errorf("unexpected file search call %+v", fileSearch)
+	}
+	webSearch := response.Output[1]
+	if webSearch.Action != nil || webSearch.WebSearchAction == nil || webSearch.WebSearchAction.Query != "refund law" ||
+		len(webSearch.WebSearchAction.Sources) != 1 {
+		t.Errorf("unexpected web search call %+v", webSearch)
+	}
+
+	citations := response.Citations()
+	if len(citations) != 2 || citations[0].FileID != "file_1" ||
+		citations[1].Type != openai.ResponseAnnotationTypeURLCitation || citations[1].EndIndex != 8 {
+		t.Errorf("unexpected citations %+v", citations)
+	}
+
+	data, err := json.Marshal(webSearch)
+	checks.NoError(t, err)
+	var roundTrip openai.ResponseItem
+	checks.NoError(t, json.Unmarshal(data, &roundTrip))
+	if roundTrip.WebSearchAction == nil || roundTrip.WebSearchAction.Sources[0].URL != "https://example.com/law" {
+		t.Errorf("expected the web search action to round-trip, got %s", data)
+	}
+}
@@ -0,0 +1,156 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+var ErrResponseStreamNotSupported = errors.New("streaming is not supported with this method, please use CreateResponseStream") //nolint:lll
+
+// Types of ResponseStreamEvent.
+const (
+	ResponseEventCreated         = "response.created"
+	ResponseEventQueued          = "response.queued"
+	ResponseEventInProgress      = "response.in_progress"
+	ResponseEventCompleted       = "response.completed"
+	ResponseEventFailed          = "response.failed"
+	ResponseEventIncomplete      = "response.incomplete"
+	ResponseEventOutputItemAdded = "response.output_item.added"
+	ResponseEventOutputItemDone  = "response.output_item.done"
+	ResponseEventOutputTextDelta = "response.output_text.delta"
+	ResponseEventOutputTextDone  = "response.output_text.done"
+	ResponseEventError           = "error"
+)
+
+// ResponseStreamEvent is an event of a streamed response. Only the fields of
+// its Type are set.
+type ResponseStreamEvent struct {
+	Type string `json:"type"`
+	// SequenceNumber orders the events of a response. Pass the last one seen
+	// to ResumeResponseStream to continue a dropped stream.
+	SequenceNumber int `json:"sequence_number"`
+
+	// Response is set by the response lifecycle events.
+	Response *ModelResponse `json:"response,omitempty"`
+
+	ItemID       string        `json:"item_id,omitempty"`
+	OutputIndex  int           `json:"output_index"`
+	ContentIndex int           `json:"content_index"`
+	SummaryIndex int           `json:"summary_index"`
+	Item         *ResponseItem `json:"item,omitempty"`
+	Delta        string        `json:"delta,omitempty"`
+	Text         string        `json:"text,omitempty"`
+	// Part is set by reasoning summary part events.
+	Part *ResponseReasoningSummary `json:"part,omitempty"`
+
+	// Error events.
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// ResponseStream is a stream of response events.
+type ResponseStream struct {
+	*Stream[ResponseStreamEvent]
+
+	responseID     string
+	sequenceNumber int
+}
+
+// ResponseID returns the ID of the streamed response, once received.
+func (s *ResponseStream) ResponseID() string {
+	return s.responseID
+}
+
+// SequenceNumber returns the sequence number of the last event received, or
+// -1 if none was.
+func (s *ResponseStream) SequenceNumber() int {
+	return s.sequenceNumber
+}
+
+func newResponseStream(reader StreamReader[ResponseStreamEvent], responseID string) *ResponseStream {
+	stream := &ResponseStream{
+		Stream:         NewStream[ResponseStreamEvent](reader),
+		responseID:     responseID,
+		sequenceNumber: -1,
+	}
+	stream.AddTransform(func(event *ResponseStreamEvent) error {
+		stream.sequenceNumber = event.SequenceNumber
+		if event.Response != nil && event.Response.ID != "" {
+			stream.responseID = event.Response.ID
+		}
+		return nil
+	})
+	return stream
+}
+
+// CreateResponseStream creates a model response and streams its events.
+func (c *Client) CreateResponseStream(ctx context.Context, request ResponseRequest) (*ResponseStream, error) {
+	c.applyResponseDefaults(&request)
+	c.applyResponseExperiment(ctx, &request)
+	if err := c.mutateResponseRequest(ctx, &request); err != nil {
+		return nil, err
+	}
+	if c.config.Policy != nil {
+		if err := c.config.Policy.CheckResponse(request); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateMetadata(request.Metadata); err != nil {
+		return nil, err
+	}
+
+	request.Stream = true
+	request.SafetyIdentifier = c.resolveSafetyIdentifier(ctx, request.SafetyIdentifier, request.User)
+	request.User = c.resolveUser(ctx, request.User)
+	req, err := c.newRequest(
+		ctx,
+		http.MethodPost,
+		c.fullURL(responsesSuffix, withModel(request.Model)),
+		withBody(request),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := sendRequestStream[ResponseStreamEvent](c, req)
+	if err != nil {
+		return nil, err
+	}
+	stream := newResponseStream(resp, "")
+	if c.config.Metrics != nil {
+		stream.AddTransform(func(event *ResponseStreamEvent) error {
+			if event.Type == ResponseEventCompleted && event.Response != nil && event.Response.Usage != nil {
+				c.recordTokens(req, event.Response.Usage.InputTokens, event.Response.Usage.OutputTokens)
+			}
+			return nil
+		})
+	}
+	return stream, nil
+}
+
+// ResumeResponseStream streams the events of a background response that come
+// after startingAfter, typically the SequenceNumber of a dropped stream.
+func (c *Client) ResumeResponseStream(
+	ctx context.Context,
+	responseID string,
+	startingAfter int,
+) (*ResponseStream, error) {
+	query := url.Values{}
+	query.Set("stream", "true")
+	query.Set("starting_after", fmt.Sprint(startingAfter))
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(withQuery(responsesSuffix+"/"+responseID, query)))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := sendRequestStream[ResponseStreamEvent](c, req)
+	if err != nil {
+		return nil, err
+	}
+	stream := newResponseStream(resp, responseID)
+	stream.sequenceNumber = startingAfter
+	return stream, nil
+}
@@ -0,0 +1,129 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func writeResponseEvents(w http.ResponseWriter, events ...string) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	for _, event := range events {
+		var header struct{ Type string }
+		_ = json.Unmarshal([]byte(event), &header)
+		fmt.Fprintf(w, "event: %s\ndata: %s\n\n", header.Type, event)
+	}
+}
+
+func TestResponseStreamResume(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var request map[string]any
+	server.RegisterHandler("/v1/responses", func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &request)
+		// The connection drops after the first delta.
+		writeResponseEvents(w,
+			`{"type":"response.created","sequence_number":0,"response":{"id":"resp_1","status":"queued"}}`,
+			`{"type":"response.output_text.delta","sequence_number":1,"item_id":"msg_1","delta":"Hel"}`,
+		)
+	})
+	var resumeQuery string
+	server.RegisterHandler("/v1/responses/resp_1", func(w http.ResponseWriter, r *http.Request) {
+		resumeQuery = r.URL.RawQuery
+		writeResponseEvents(w,
+			`{"type":"response.output_text.delta","sequence_number":2,"item_id":"msg_1","delta":"lo"}`,
+			`{"type":"response.completed","sequence_number":3,"response":{"id":"resp_1","status":"completed"}}`,
+		)
+	})
+
+	ctx := context.Background()
+	stream, err := client.CreateResponseStream(ctx, openai.ResponseRequest{
+		Model:      "o3",
+		Input:      "Think for a long time",
+		Background: true,
+	})
+	checks.NoError(t, err)
+	if request["stream"] != true || request["background"] != true {
+		t.Errorf("expected a streamed background request, got %v", request)
+	}
+
+	var text string
+	for stream.Next() {
+		if event := stream.Current(); event.Type == openai.ResponseEventOutputTextDelta {
+			text += event.Delta
+		}
+	}
+	checks.NoError(t, stream.Err())
+	stream.Close()
+	if stream.ResponseID() != "resp_1" || stream.SequenceNumber() != 1 {
+		t.Fatalf("unexpected stream position %s/%d", stream.ResponseID(), stream.SequenceNumber())
+	}
+
+	resumed, err := client.ResumeResponseStream(ctx, stream.ResponseID(), stream.SequenceNumber())
+	checks.NoError(t, err)
+	defer resumed.Close()
+	if resumeQuery != "starting_after=1&stream=true" {
+		t.Errorf("unexpected resume query %q", resumeQuery)
+	}
+	events, err := resumed.Collect()
+	checks.NoError(t, err)
+	for _, event := range events {
+		text += event.Delta
+	}
+	last := events[len(events)-1]
+	if text != "Hello" || last.Type != openai.ResponseEventCompleted || last.Response.Status != "completed" ||
+		resumed.SequenceNumber() != 3 {
+		t.Errorf("unexpected resumed stream: text %q, last %+v", text, last)
+	}
+}
+
+func TestResponseBackgroundPolling(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	polls := 0
+	server.RegisterHandler("/v1/responses/resp_1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		polls++
+		status := openai.ResponseStatusInProgress
+		if polls == 3 {
+			status = openai.ResponseStatusCompleted
+		}
+		fmt.Fprintf(w, `{"id":"resp_1","status":%q,"background":true}`, status)
+	})
+	server.RegisterHandler("/v1/responses/resp_1/cancel", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		fmt.Fprint(w, `{"id":"resp_1","status":"cancelled","background":true}`)
+	})
+
+	ctx := context.Background()
+	response, err := client.WaitForResponse(ctx, "resp_1", time.Millisecond)
+	checks.NoError(t, err)
+	if polls != 3 || response.Status != openai.ResponseStatusCompleted || !response.Background {
+		t.Errorf("expected three polls until completion, got %d polls and %+v", polls, response)
+	}
+
+	response, err = client.CancelResponse(ctx, "resp_1")
+	checks.NoError(t, err)
+	if response.Status != openai.ResponseStatusCancelled || !response.Done() {
+		t.Errorf("unexpected cancelled response %+v", response)
+	}
+
+	_, err = client.CreateResponse(ctx, openai.ResponseRequest{Stream: true})
+	checks.ErrorIs(t, err, openai.ErrResponseStreamNotSupported)
+}
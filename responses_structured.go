@@ -0,0 +1,100 @@
+package openai
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/sashabaranov/go-openai/jsonschema"
+)
+
+var (
+	ErrResponseRefusal      = errors.New("the model refused to answer")
+	ErrResponseNoOutputText = errors.New("response has no output text")
+)
+
+// ResponseTextFormatType is the format of the text output of a response.
+type ResponseTextFormatType string
+
+const (
+	ResponseTextFormatTypeText       ResponseTextFormatType = "text"
+	ResponseTextFormatTypeJSONObject ResponseTextFormatType = "json_object"
+	ResponseTextFormatTypeJSONSchema ResponseTextFormatType = "json_schema"
+)
+
+// ResponseTextConfig configures the text output of a response.
+type ResponseTextConfig struct {
+	Format ResponseTextFormat `json:"format"`
+}
+
+// ResponseTextFormat is the format of the text output. Unlike chat
+// completions, the JSON schema fields are not nested.
+type ResponseTextFormat struct {
+	Type        ResponseTextFormatType `json:"type"`
+	Name        string                 `json:"name,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Schema      json.Marshaler         `json:"schema,omitempty"`
+	Strict      bool                   `json:"strict,omitempty"`
+}
+
+// NewResponseJSONSchemaFormat returns a strict json_schema text format whose
+// schema is generated from the type of v, typically a struct.
+//
+//	format, err := openai.NewResponseJSONSchemaFormat("invoice", Invoice{})
+//	request.Text = &openai.ResponseTextConfig{Format: format}
+//	...
+//	var invoice Invoice
+//	err = response.UnmarshalOutputText(&invoice)
+func NewResponseJSONSchemaFormat(name string, v any) (ResponseTextFormat, error) {
+	schema, err := jsonschema.GenerateSchemaForType(v)
+	if err != nil {
+		return ResponseTextFormat{}, err
+	}
+	return ResponseTextFormat{
+		Type:   ResponseTextFormatTypeJSONSchema,
+		Name:   name,
+		Schema: schema,
+		Strict: true,
+	}, nil
+}
+
+func (f *ResponseTextFormat) UnmarshalJSON(data []byte) error {
+	type format ResponseTextFormat
+	raw := struct {
+		*format
+		Schema json.RawMessage `json:"schema"`
+	}{format: (*format)(f)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw.Schema) > 0 && string(raw.Schema) != "null" {
+		var d jsonschema.Definition
+		if err := json.Unmarshal(raw.Schema, &d); err != nil {
+			return err
+		}
+		f.Schema = &d
+	}
+	return nil
+}
+
+// UnmarshalOutputText decodes the output text of a structured response into
+// v, validating it against the JSON schema echoed in the response if any. It
+// returns ErrResponseRefusal if the model refused instead.
+func (r ModelResponse) UnmarshalOutputText(v any) error {
+	for _, item := range r.Output {
+		for _, content := range item.Content {
+			if content.Type == ResponseContentTypeRefusal {
+				return ErrResponseRefusal
+			}
+		}
+	}
+	text := r.OutputText()
+	if text == "" {
+		return ErrResponseNoOutputText
+	}
+	if r.Text != nil {
+		if schema, ok := r.Text.Format.Schema.(*jsonschema.Definition); ok {
+			return schema.Unmarshal(text, v)
+		}
+	}
+	return json.Unmarshal([]byte(text), v)
+}
@@ -0,0 +1,99 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+	"github.com/sashabaranov/go-openai/jsonschema"
+)
+
+type structuredInvoice struct {
+	Number string  `json:"number"`
+	Total  float64 `json:"total"`
+}
+
+func TestNewResponseJSONSchemaFormat(t *testing.T) {
+	format, err := openai.NewResponseJSONSchemaFormat("invoice", structuredInvoice{})
+	checks.NoError(t, err, "NewResponseJSONSchemaFormat error")
+	if format.Type != openai.ResponseTextFormatTypeJSONSchema || format.Name != "invoice" || !format.Strict {
+		t.Errorf("format = %+v", format)
+	}
+	schema, ok := format.Schema.(*jsonschema.Definition)
+	if !ok || schema.Type != jsonschema.Object || schema.Properties["total"].Type != jsonschema.Number {
+		t.Errorf("schema = %+v", format.Schema)
+	}
+
+	if _, err = openai.NewResponseJSONSchemaFormat("channel", make(chan int)); err == nil {
+		t.Error("expected an error for a type without a JSON schema")
+	}
+}
+
+func TestCreateResponseWithJSONSchemaFormat(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var request map[string]any
+	server.RegisterHandler("/v1/responses", func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		checks.NoError(t, json.Unmarshal(data, &request), "request body")
+		text, _ := json.Marshal(request["text"])
+		fmt.Fprintf(w, `{"id":"resp_1","object":"response","status":"completed","text":%s,"output":[`+
+			`{"type":"message","id":"msg_1","role":"assistant","content":[`+
+			`{"type":"output_text","text":"{\"number\":\"INV-1\",\"total\":42.5}"}]}]}`, text)
+	})
+
+	format, err := openai.NewResponseJSONSchemaFormat("invoice", structuredInvoice{})
+	checks.NoError(t, err, "NewResponseJSONSchemaFormat error")
+	response, err := client.CreateResponse(context.Background(), openai.ResponseRequest{
+		Model: openai.GPT4oMini,
+		Input: "Extract the invoice.",
+		Text:  &openai.ResponseTextConfig{Format: format},
+	})
+	checks.NoError(t, err, "CreateResponse error")
+
+	sent, _ := request["text"].(map[string]any)["format"].(map[string]any)
+	properties, _ := sent["schema"].(map[string]any)["properties"].(map[string]any)
+	if sent["type"] != "json_schema" || sent["name"] != "invoice" || sent["strict"] != true ||
+		properties["number"] == nil {
+		t.Errorf("text.format sent = %v", sent)
+	}
+
+	var invoice structuredInvoice
+	checks.NoError(t, response.UnmarshalOutputText(&invoice), "UnmarshalOutputText error")
+	if invoice.Number != "INV-1" || invoice.Total != 42.5 {
+		t.Errorf("invoice = %+v", invoice)
+	}
+}
+
+func TestUnmarshalOutputText(t *testing.T) {
+	message := func(content ...openai.ResponseContent) openai.ModelResponse {
+		return openai.ModelResponse{Output: []openai.ResponseItem{
+			{Type: openai.ResponseItemTypeMessage, Content: content},
+		}}
+	}
+	var invoice structuredInvoice
+
+	refused := message(openai.ResponseContent{Type: openai.ResponseContentTypeRefusal, Refusal: "I can't help."})
+	if err := refused.UnmarshalOutputText(&invoice); !errors.Is(err, openai.ErrResponseRefusal) {
+		t.Errorf("expected ErrResponseRefusal, got %v", err)
+	}
+
+	if err := message().UnmarshalOutputText(&invoice); !errors.Is(err, openai.ErrResponseNoOutputText) {
+		t.Errorf("expected ErrResponseNoOutputText, got %v", err)
+	}
+
+	format, err := openai.NewResponseJSONSchemaFormat("invoice", structuredInvoice{})
+	checks.NoError(t, err, "NewResponseJSONSchemaFormat error")
+	mismatched := message(openai.ResponseContent{Type: openai.ResponseContentTypeOutputText, Text: `{"number":7}`})
+	mismatched.Text = &openai.ResponseTextConfig{Format: format}
+	if err = mismatched.UnmarshalOutputText(&invoice); err == nil {
+		t.Error("expected an error for output not matching the schema")
+	}
+}
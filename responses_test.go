@@ -0,0 +1,121 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestCreateResponse(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var request map[string]any
+	server.RegisterHandler("/v1/responses", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &request)
+		fmt.Fprint(w, `{"id":"resp_1","object":"response","status":"completed","model":"gpt-4.1",`+
+			`"output":[{"type":"function_call","id":"fc_1","call_id":"call_1","name":"lookup","arguments":"{}"},`+
+			`{"type":"message","id":"msg_1","role":"assistant","content":[`+
+			`{"type":"output_text","text":"Hello, "},{"type":"output_text","text":"world"}]}],`+
+			`"usage":{"input_tokens":5,"output_tokens":3,"total_tokens":8}}`)
+	})
+
+	response, err := client.CreateResponse(context.Background(), openai.ResponseRequest{
+		Model: "gpt-4.1",
+		Input: []openai.ResponseItem{
+			openai.NewResponseInputMessage(openai.ChatMessageRoleUser, "Say hello"),
+			openai.NewResponseFunctionCallOutput("call_0", `{"ok":true}`),
+		},
+	})
+	checks.NoError(t, err)
+	if response.ID != "resp_1" || response.OutputText() != "Hello, world" || response.Usage.TotalTokens != 8 {
+		t.Errorf("unexpected response %+v", response)
+	}
+	if call := response.Output[0]; call.Type != openai.ResponseItemTypeFunctionCall || call.CallID != "call_1" {
+		t.Errorf("unexpected function call %+v", call)
+	}
+
+	input := request["input"].([]any)
+	if output := input[1].(map[string]any)["output"]; output != `{"ok":true}` {
+		t.Errorf("expected the function output to be sent as a string, got %v", output)
+	}
+}
+
+func TestComputerUseRoundTrip(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var requests []map[string]any
+	server.RegisterHandler("/v1/responses", func(w http.ResponseWriter, r *http.Request) {
+		var request map[string]any
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &request)
+		requests = append(requests, request)
+		fmt.Fprint(w, `{"id":"resp_1","status":"completed","output":[{"type":"computer_call","id":"cu_1",`+
+			`"call_id":"call_1","status":"completed","action":{"type":"click","button":"left","x":0,"y":12},`+
+			`"pending_safety_checks":[{"id":"sc_1","code":"malicious_instructions","message":"Careful"}]}]}`)
+	})
+
+	ctx := context.Background()
+	response, err := client.CreateResponse(ctx, openai.ResponseRequest{
+		Model:      "computer-use-preview",
+		Tools:      []openai.ResponseTool{openai.NewComputerUseTool(1024, 768, openai.ComputerEnvironmentBrowser)},
+		Input:      "Open the docs",
+		Truncation: "auto",
+	})
+	checks.NoError(t, err)
+	tool := requests[0]["tools"].([]any)[0].(map[string]any)
+	if tool["type"] != "computer_use_preview" || tool["display_width"] != 1024.0 || tool["environment"] != "browser" {
+		t.Errorf("unexpected tool %v", tool)
+	}
+
+	calls := response.ComputerCalls()
+	if len(calls) != 1 {
+		t.Fatalf("expected one computer call, got %d", len(calls))
+	}
+	call := calls[0]
+	if call.Action.Type != openai.ComputerActionClick || call.Action.Button != "left" || call.Action.Y != 12 {
+		t.Errorf("unexpected action %+v", call.Action)
+	}
+
+	_, err = client.CreateResponse(ctx, openai.ResponseRequest{
+		Model: "computer-use-preview",
+		Input: []openai.ResponseItem{
+			call,
+			openai.NewComputerCallOutput(call.CallID, "data:image/png;base64,AAAA", call.PendingSafetyChecks...),
+		},
+	})
+	checks.NoError(t, err)
+	input := requests[1]["input"].([]any)
+	action := input[0].(map[string]any)["action"].(map[string]any)
+	if x, ok := action["x"]; !ok || x != 0.0 {
+		t.Errorf("expected the zero x coordinate to be sent, got %v", action)
+	}
+	output := input[1].(map[string]any)
+	screenshot := output["output"].(map[string]any)
+	if output["type"] != "computer_call_output" || screenshot["type"] != "computer_screenshot" ||
+		screenshot["image_url"] != "data:image/png;base64,AAAA" {
+		t.Errorf("unexpected call output %v", output)
+	}
+	if acknowledged := output["acknowledged_safety_checks"].([]any); len(acknowledged) != 1 {
+		t.Errorf("expected the safety check to be acknowledged, got %v", acknowledged)
+	}
+
+	var item openai.ResponseItem
+	data, _ := json.Marshal(input[1])
+	checks.NoError(t, json.Unmarshal(data, &item))
+	if item.Output == nil || item.Output.Screenshot == nil || item.Output.Screenshot.ImageURL == "" {
+		t.Errorf("expected the screenshot output to decode, got %+v", item.Output)
+	}
+}
@@ -2,9 +2,11 @@ package openai
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 type Run struct {
@@ -182,6 +184,7 @@ type RunStep struct {
 	FailedAt    *int64         `json:"failed_at,omitempty"`
 	CompletedAt *int64         `json:"completed_at,omitempty"`
 	Metadata    map[string]any `json:"metadata"`
+	Usage       *Usage         `json:"usage,omitempty"`
 
 	httpHeader
 }
@@ -207,8 +210,143 @@ type StepDetails struct {
 	Type            RunStepType                 `json:"type"`
 	MessageCreation *StepDetailsMessageCreation `json:"message_creation,omitempty"`
 	ToolCalls       []ToolCall                  `json:"tool_calls,omitempty"`
+	// ToolCallDetails holds the same tool calls as ToolCalls, along with the
+	// details of built-in tools such as file search and code interpreter. It
+	// is filled when the step is decoded, and encoded instead of ToolCalls
+	// when set.
+	ToolCallDetails []RunStepToolCall `json:"-"`
 }
 
+func (d StepDetails) MarshalJSON() ([]byte, error) {
+	type stepDetails StepDetails
+	if d.ToolCallDetails == nil {
+		return json.Marshal(stepDetails(d))
+	}
+	return json.Marshal(struct {
+		stepDetails
+		ToolCalls []RunStepToolCall `json:"tool_calls,omitempty"`
+	}{stepDetails(d), d.ToolCallDetails})
+}
+
+func (d *StepDetails) UnmarshalJSON(data []byte) error {
+	type stepDetails StepDetails
+	var details struct {
+		stepDetails
+		ToolCalls []RunStepToolCall `json:"tool_calls,omitempty"`
+	}
+	if err := json.Unmarshal(data, &details); err != nil {
+		return err
+	}
+	*d = StepDetails(details.stepDetails)
+	d.ToolCallDetails = details.ToolCalls
+	if details.ToolCalls != nil {
+		d.ToolCalls = make([]ToolCall, len(details.ToolCalls))
+		for i, call := range details.ToolCalls {
+			d.ToolCalls[i] = call.ToolCall
+		}
+	}
+	return nil
+}
+
+// RunStepToolCall is a tool call made during a run step. Besides function
+// calls, it carries the details of built-in tools such as file search.
+// ToolCall holds the fields shared with chat tool calls.
+type RunStepToolCall struct {
+	ToolCall
+	FileSearch      *RunStepFileSearch      `json:"file_search,omitempty"`
+	CodeInterpreter *RunStepCodeInterpreter `json:"code_interpreter,omitempty"`
+}
+
+// RunStepCodeInterpreter holds the code run by a code_interpreter tool call
+// and its outputs. Images are files that can be downloaded with
+// Client.GetFileContent.
+type RunStepCodeInterpreter struct {
+	Input   string                  `json:"input"`
+	Outputs []CodeInterpreterOutput `json:"outputs"`
+}
+
+// Logs returns the logs output by the code, concatenated.
+func (c RunStepCodeInterpreter) Logs() string {
+	var logs strings.Builder
+	for _, output := range c.Outputs {
+		logs.WriteString(output.Logs)
+	}
+	return logs.String()
+}
+
+// RunStepFileSearch holds the results of a file_search tool call. Results are
+// only populated when the step is retrieved with
+// RunStepIncludeFileSearchResultContent.
+type RunStepFileSearch struct {
+	RankingOptions *RunStepFileSearchRankingOptions `json:"ranking_options,omitempty"`
+	Results        []RunStepFileSearchResult        `json:"results,omitempty"`
+}
+
+type RunStepFileSearchRankingOptions struct {
+	Ranker         string  `json:"ranker"`
+	ScoreThreshold float64 `json:"score_threshold"`
+}
+
+type RunStepFileSearchResult struct {
+	FileID   string                           `json:"file_id"`
+	FileName string                           `json:"file_name"`
+	Score    float64                          `json:"score"`
+	Content  []RunStepFileSearchResultContent `json:"content,omitempty"`
+}
+
+type RunStepFileSearchResultContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Text returns the text of the retrieved chunk, which is only available when
+// the step is retrieved with RunStepIncludeFileSearchResultContent.
+func (r RunStepFileSearchResult) Text() string {
+	texts := make([]string, 0, len(r.Content))
+	for _, content := range r.Content {
+		texts = append(texts, content.Text)
+	}
+	return strings.Join(texts, "")
+}
+
+// CodeInterpreterImageFileIDs returns the IDs of the images output by all the
+// code_interpreter tool calls of the step, in order.
+func (d StepDetails) CodeInterpreterImageFileIDs() []string {
+	var fileIDs []string
+	for _, call := range d.ToolCallDetails {
+		if call.CodeInterpreter == nil {
+			continue
+		}
+		for _, output := range call.CodeInterpreter.Outputs {
+			if output.Image != nil {
+				fileIDs = append(fileIDs, output.Image.FileID)
+			}
+		}
+	}
+	return fileIDs
+}
+
+// FileSearchResults returns the chunks retrieved by all the file_search tool
+// calls of the step, in order.
+func (d StepDetails) FileSearchResults() []RunStepFileSearchResult {
+	var results []RunStepFileSearchResult
+	for _, call := range d.ToolCallDetails {
+		if call.FileSearch != nil {
+			results = append(results, call.FileSearch.Results...)
+		}
+	}
+	return results
+}
+
+// RunStepInclude selects additional fields to return with run steps.
+type RunStepInclude string
+
+const (
+	// RunStepIncludeFileSearchResultContent includes the content of the
+	// chunks found by file search.
+	RunStepIncludeFileSearchResultContent RunStepInclude = "step_details.tool_calls[*].file_search.results[*].content"
+)
+
 type StepDetailsMessageCreation struct {
 	MessageID string `json:"message_id"`
 }
@@ -224,26 +362,22 @@ type RunStepList struct {
 	httpHeader
 }
 
-type Pagination struct {
-	Limit  *int
-	Order  *string
-	After  *string
-	Before *string
-}
-
 // CreateRun creates a new run.
 func (c *Client) CreateRun(
 	ctx context.Context,
 	threadID string,
 	request RunRequest,
 ) (response Run, err error) {
+	if err = validateMetadata(request.Metadata); err != nil {
+		return
+	}
+
 	urlSuffix := fmt.Sprintf("/threads/%s/runs", threadID)
 	req, err := c.newRequest(
 		ctx,
 		http.MethodPost,
 		c.fullURL(urlSuffix),
-		withBody(request),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBody(request))
 	if err != nil {
 		return
 	}
@@ -262,8 +396,7 @@ func (c *Client) RetrieveRun(
 	req, err := c.newRequest(
 		ctx,
 		http.MethodGet,
-		c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		c.fullURL(urlSuffix))
 	if err != nil {
 		return
 	}
@@ -279,13 +412,16 @@ func (c *Client) ModifyRun(
 	runID string,
 	request RunModifyRequest,
 ) (response Run, err error) {
+	if err = validateMetadata(request.Metadata); err != nil {
+		return
+	}
+
 	urlSuffix := fmt.Sprintf("/threads/%s/runs/%s", threadID, runID)
 	req, err := c.newRequest(
 		ctx,
 		http.MethodPost,
 		c.fullURL(urlSuffix),
-		withBody(request),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBody(request))
 	if err != nil {
 		return
 	}
@@ -300,31 +436,11 @@ func (c *Client) ListRuns(
 	threadID string,
 	pagination Pagination,
 ) (response RunList, err error) {
-	urlValues := url.Values{}
-	if pagination.Limit != nil {
-		urlValues.Add("limit", fmt.Sprintf("%d", *pagination.Limit))
-	}
-	if pagination.Order != nil {
-		urlValues.Add("order", *pagination.Order)
-	}
-	if pagination.After != nil {
-		urlValues.Add("after", *pagination.After)
-	}
-	if pagination.Before != nil {
-		urlValues.Add("before", *pagination.Before)
-	}
-
-	encodedValues := ""
-	if len(urlValues) > 0 {
-		encodedValues = "?" + urlValues.Encode()
-	}
-
-	urlSuffix := fmt.Sprintf("/threads/%s/runs%s", threadID, encodedValues)
+	urlSuffix := withQuery(fmt.Sprintf("/threads/%s/runs", threadID), pagination.values())
 	req, err := c.newRequest(
 		ctx,
 		http.MethodGet,
-		c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		c.fullURL(urlSuffix))
 	if err != nil {
 		return
 	}
@@ -344,8 +460,7 @@ func (c *Client) SubmitToolOutputs(
 		ctx,
 		http.MethodPost,
 		c.fullURL(urlSuffix),
-		withBody(request),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBody(request))
 	if err != nil {
 		return
 	}
@@ -363,8 +478,7 @@ func (c *Client) CancelRun(
 	req, err := c.newRequest(
 		ctx,
 		http.MethodPost,
-		c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		c.fullURL(urlSuffix))
 	if err != nil {
 		return
 	}
@@ -377,13 +491,19 @@ func (c *Client) CancelRun(
 func (c *Client) CreateThreadAndRun(
 	ctx context.Context,
 	request CreateThreadAndRunRequest) (response Run, err error) {
+	if err = validateMetadata(request.Metadata); err != nil {
+		return
+	}
+	if err = validateMetadata(request.Thread.Metadata); err != nil {
+		return
+	}
+
 	urlSuffix := "/threads/runs"
 	req, err := c.newRequest(
 		ctx,
 		http.MethodPost,
 		c.fullURL(urlSuffix),
-		withBody(request),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBody(request))
 	if err != nil {
 		return
 	}
@@ -392,19 +512,22 @@ func (c *Client) CreateThreadAndRun(
 	return
 }
 
-// RetrieveRunStep retrieves a run step.
+// RetrieveRunStep retrieves a run step. Include requests additional fields,
+// such as the content of file search results.
 func (c *Client) RetrieveRunStep(
 	ctx context.Context,
 	threadID string,
 	runID string,
 	stepID string,
+	include ...RunStepInclude,
 ) (response RunStep, err error) {
-	urlSuffix := fmt.Sprintf("/threads/%s/runs/%s/steps/%s", threadID, runID, stepID)
+	urlValues := url.Values{}
+	addRunStepInclude(urlValues, include)
+	urlSuffix := withQuery(fmt.Sprintf("/threads/%s/runs/%s/steps/%s", threadID, runID, stepID), urlValues)
 	req, err := c.newRequest(
 		ctx,
 		http.MethodGet,
-		c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		c.fullURL(urlSuffix))
 	if err != nil {
 		return
 	}
@@ -413,38 +536,22 @@ func (c *Client) RetrieveRunStep(
 	return
 }
 
-// ListRunSteps lists run steps.
+// ListRunSteps lists run steps. Include requests additional fields, such as
+// the content of file search results.
 func (c *Client) ListRunSteps(
 	ctx context.Context,
 	threadID string,
 	runID string,
 	pagination Pagination,
+	include ...RunStepInclude,
 ) (response RunStepList, err error) {
-	urlValues := url.Values{}
-	if pagination.Limit != nil {
-		urlValues.Add("limit", fmt.Sprintf("%d", *pagination.Limit))
-	}
-	if pagination.Order != nil {
-		urlValues.Add("order", *pagination.Order)
-	}
-	if pagination.After != nil {
-		urlValues.Add("after", *pagination.After)
-	}
-	if pagination.Before != nil {
-		urlValues.Add("before", *pagination.Before)
-	}
-
-	encodedValues := ""
-	if len(urlValues) > 0 {
-		encodedValues = "?" + urlValues.Encode()
-	}
-
-	urlSuffix := fmt.Sprintf("/threads/%s/runs/%s/steps%s", threadID, runID, encodedValues)
+	urlValues := pagination.values()
+	addRunStepInclude(urlValues, include)
+	urlSuffix := withQuery(fmt.Sprintf("/threads/%s/runs/%s/steps", threadID, runID), urlValues)
 	req, err := c.newRequest(
 		ctx,
 		http.MethodGet,
-		c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		c.fullURL(urlSuffix))
 	if err != nil {
 		return
 	}
@@ -452,3 +559,9 @@ func (c *Client) ListRunSteps(
 	err = c.sendRequest(req, &response)
 	return
 }
+
+func addRunStepInclude(urlValues url.Values, include []RunStepInclude) {
+	for _, field := range include {
+		urlValues.Add("include[]", string(field))
+	}
+}
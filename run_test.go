@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 )
 
@@ -235,3 +236,111 @@ func TestRun(t *testing.T) {
 	)
 	checks.NoError(t, err, "ListRunSteps error")
 }
+
+func TestRunStepInclude(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var queries []string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query()["include[]"]...)
+		step := `{"id":"step_1","step_details":{"type":"tool_calls","tool_calls":[{"id":"call_1",` +
+			`"type":"file_search","file_search":{"results":[{"file_id":"file_1","file_name":"a.txt",` +
+			`"score":0.8,"content":[{"type":"text","text":"chunk"}]}]}}]}}`
+		if strings.HasSuffix(r.URL.Path, "/steps") {
+			step = `{"object":"list","data":[` + step + `]}`
+		}
+		fmt.Fprint(w, step)
+	}
+	server.RegisterHandler("/v1/threads/thread_1/runs/run_1/steps/step_1", handler)
+	server.RegisterHandler("/v1/threads/thread_1/runs/run_1/steps", handler)
+
+	ctx := context.Background()
+	step, err := client.RetrieveRunStep(ctx, "thread_1", "run_1", "step_1",
+		openai.RunStepIncludeFileSearchResultContent)
+	checks.NoError(t, err, "RetrieveRunStep error")
+	calls := step.StepDetails.ToolCallDetails
+	if len(calls) != 1 || calls[0].ID != "call_1" || calls[0].FileSearch == nil ||
+		len(calls[0].FileSearch.Results) != 1 || calls[0].FileSearch.Results[0].Content[0].Text != "chunk" {
+		t.Fatalf("unexpected tool calls %+v", calls)
+	}
+
+	list, err := client.ListRunSteps(ctx, "thread_1", "run_1", openai.ListOptions{},
+		openai.RunStepIncludeFileSearchResultContent)
+	checks.NoError(t, err, "ListRunSteps error")
+	if len(list.RunSteps) != 1 {
+		t.Fatalf("expected one step, got %d", len(list.RunSteps))
+	}
+
+	want := string(openai.RunStepIncludeFileSearchResultContent)
+	if len(queries) != 2 || queries[0] != want || queries[1] != want {
+		t.Errorf("expected include[]=%s on both requests, got %v", want, queries)
+	}
+}
+
+func TestRunStepFileSearchResults(t *testing.T) {
+	var step openai.RunStep
+	err := json.Unmarshal([]byte(`{"id":"step_1","step_details":{"type":"tool_calls","tool_calls":[`+
+		`{"id":"call_1","type":"file_search","file_search":{`+
+		`"ranking_options":{"ranker":"default_2024_08_21","score_threshold":0.5},"results":[`+
+		`{"file_id":"file_1","file_name":"a.txt","score":0.9,"content":[{"type":"text","text":"one"},`+
+		`{"type":"text","text":" two"}]}]}},`+
+		`{"id":"call_2","type":"function","function":{"name":"f","arguments":"{}"}},`+
+		`{"id":"call_3","type":"file_search","file_search":{"results":[`+
+		`{"file_id":"file_2","file_name":"b.txt","score":0.6}]}}]}}`), &step)
+	checks.NoError(t, err, "Unmarshal error")
+
+	options := step.StepDetails.ToolCallDetails[0].FileSearch.RankingOptions
+	if options == nil || options.Ranker != openai.FileSearchRankerDefault20240821 || options.ScoreThreshold != 0.5 {
+		t.Errorf("ranking options = %+v", options)
+	}
+	if calls := step.StepDetails.ToolCalls; len(calls) != 3 || calls[1].Function.Name != "f" {
+		t.Errorf("ToolCalls = %+v", calls)
+	}
+	encoded, err := json.Marshal(step.StepDetails)
+	checks.NoError(t, err, "Marshal error")
+	if !strings.Contains(string(encoded), `"file_name":"b.txt"`) {
+		t.Errorf("encoded step details %s lost the file search results", encoded)
+	}
+	results := step.StepDetails.FileSearchResults()
+	if len(results) != 2 || results[0].Text() != "one two" || results[1].FileName != "b.txt" || results[1].Text() != "" {
+		t.Errorf("FileSearchResults() = %+v", results)
+	}
+}
+
+func TestRunFileSearchToolOptions(t *testing.T) {
+	tool := openai.Tool{
+		Type: openai.ToolTypeFileSearch,
+		FileSearch: &openai.FileSearchToolOptions{
+			MaxNumResults: 5,
+			RankingOptions: &openai.FileSearchRankingOptions{
+				Ranker:         openai.FileSearchRankerAuto,
+				ScoreThreshold: 0.4,
+			},
+		},
+	}
+	got, err := json.Marshal(openai.RunRequest{AssistantID: "asst_1", Tools: []openai.Tool{tool}})
+	checks.NoError(t, err, "Marshal error")
+	want := `"tools":[{"type":"file_search","file_search":{"max_num_results":5,` +
+		`"ranking_options":{"ranker":"auto","score_threshold":0.4}}}]`
+	if !strings.Contains(string(got), want) {
+		t.Errorf("run request %s does not contain %s", got, want)
+	}
+}
+
+func TestRunStepCodeInterpreter(t *testing.T) {
+	var step openai.RunStep
+	err := json.Unmarshal([]byte(`{"id":"step_1","step_details":{"type":"tool_calls","tool_calls":[`+
+		`{"id":"call_1","type":"code_interpreter","code_interpreter":{"input":"plot()","outputs":[`+
+		`{"type":"logs","logs":"one\n"},{"type":"image","image":{"file_id":"file_1"}},`+
+		`{"type":"logs","logs":"two\n"}]}}]}}`), &step)
+	checks.NoError(t, err, "Unmarshal error")
+
+	interpreter := step.StepDetails.ToolCallDetails[0].CodeInterpreter
+	if interpreter == nil || interpreter.Input != "plot()" || interpreter.Logs() != "one\ntwo\n" {
+		t.Fatalf("code interpreter = %+v", interpreter)
+	}
+	if ids := step.StepDetails.CodeInterpreterImageFileIDs(); len(ids) != 1 || ids[0] != "file_1" {
+		t.Errorf("CodeInterpreterImageFileIDs() = %v", ids)
+	}
+}
@@ -0,0 +1,315 @@
+package openai
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrRequestPreempted is returned by requests canceled by a Scheduler to make
+// room for higher priority traffic. They can be retried.
+var ErrRequestPreempted = errors.New("request preempted by higher priority traffic")
+
+// Priority orders the requests waiting in a Scheduler. Higher priorities are
+// dispatched first.
+type Priority int
+
+const (
+	// PriorityBatch is for background traffic that can wait or be preempted.
+	PriorityBatch Priority = -1
+	// PriorityNormal is the priority of requests without one.
+	PriorityNormal Priority = 0
+	// PriorityInteractive is for traffic a user is waiting on.
+	PriorityInteractive Priority = 1
+)
+
+type priorityKey struct{}
+
+// WithPriority returns a context that gives requests made with it the given
+// priority in the client's Scheduler.
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+func priorityFromContext(ctx context.Context) Priority {
+	priority, ok := ctx.Value(priorityKey{}).(Priority)
+	if !ok {
+		return PriorityNormal
+	}
+	return priority
+}
+
+// SchedulerConfig sets the budgets of a Scheduler. Zero values mean no limit.
+type SchedulerConfig struct {
+	// MaxConcurrency is the maximum number of requests in flight. Streaming
+	// requests hold their slot until the stream is closed.
+	MaxConcurrency int
+	// RequestsPerSecond is the rate at which requests are dispatched, with
+	// bursts of up to Burst requests (1 by default).
+	RequestsPerSecond float64
+	Burst             int
+	// Preempt makes a request that finds no free slot cancel the in-flight
+	// request with the lowest priority below its own, which then fails with
+	// ErrRequestPreempted. It requires MaxConcurrency.
+	Preempt bool
+}
+
+// Scheduler queues the requests of the clients using it, through
+// ClientConfig.Scheduler, and dispatches them by priority within its
+// concurrency and rate budgets. Set the priority of a request with
+// WithPriority. A Scheduler can be shared by several clients.
+type Scheduler struct {
+	config SchedulerConfig
+
+	mu       sync.Mutex
+	queue    schedulerQueue
+	seq      uint64
+	running  int
+	inflight map[*schedulerSlot]struct{}
+	tokens   float64
+	refilled time.Time
+	timer    *time.Timer
+}
+
+// NewScheduler returns a scheduler with the given budgets.
+func NewScheduler(config SchedulerConfig) *Scheduler {
+	if config.Burst <= 0 {
+		config.Burst = 1
+	}
+	return &Scheduler{
+		config:   config,
+		inflight: make(map[*schedulerSlot]struct{}),
+		tokens:   float64(config.Burst),
+		refilled: time.Now(),
+	}
+}
+
+// schedulerSlot is a dispatched request. Its context is canceled when the
+// request is preempted.
+type schedulerSlot struct {
+	priority Priority
+	ctx      context.Context
+	cancel   context.CancelFunc
+
+	// preempted and released are guarded by Scheduler.mu.
+	preempted bool
+	released  bool
+}
+
+type schedulerWaiter struct {
+	priority Priority
+	seq      uint64
+	index    int
+	ready    chan struct{}
+	slot     *schedulerSlot
+}
+
+// schedulerQueue is a heap of waiters by decreasing priority, then arrival.
+type schedulerQueue []*schedulerWaiter
+
+func (q schedulerQueue) Len() int { return len(q) }
+
+func (q schedulerQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q schedulerQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *schedulerQueue) Push(x any) {
+	w := x.(*schedulerWaiter) //nolint:forcetypeassert // only waiters are pushed
+	w.index = len(*q)
+	*q = append(*q, w)
+}
+
+func (q *schedulerQueue) Pop() any {
+	old := *q
+	w := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+	w.index = -1
+	return w
+}
+
+// acquire waits for a slot for a request with the given priority.
+func (s *Scheduler) acquire(ctx context.Context, priority Priority) (*schedulerSlot, error) {
+	slotCtx, cancel := context.WithCancel(ctx)
+	w := &schedulerWaiter{
+		priority: priority,
+		ready:    make(chan struct{}),
+		slot:     &schedulerSlot{priority: priority, ctx: slotCtx, cancel: cancel},
+	}
+
+	s.mu.Lock()
+	w.seq = s.seq
+	s.seq++
+	heap.Push(&s.queue, w)
+	s.dispatchLocked()
+	if w.index >= 0 && s.config.Preempt {
+		s.preemptLocked(priority)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return w.slot, nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		if w.index >= 0 {
+			heap.Remove(&s.queue, w.index)
+			s.mu.Unlock()
+			cancel()
+			return nil, ctx.Err()
+		}
+		s.mu.Unlock()
+		// The slot was granted concurrently.
+		s.release(w.slot)
+		return nil, ctx.Err()
+	}
+}
+
+// release frees the slot of a finished request.
+func (s *Scheduler) release(slot *schedulerSlot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if slot.released {
+		return
+	}
+	slot.released = true
+	slot.cancel()
+	delete(s.inflight, slot)
+	s.running--
+	s.dispatchLocked()
+}
+
+func (s *Scheduler) isPreempted(slot *schedulerSlot) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slot.preempted
+}
+
+// dispatchLocked grants slots to the waiters at the head of the queue while
+// the budgets allow it.
+func (s *Scheduler) dispatchLocked() {
+	for s.queue.Len() > 0 {
+		if s.config.MaxConcurrency > 0 && s.running >= s.config.MaxConcurrency {
+			return
+		}
+		if wait := s.takeTokenLocked(time.Now()); wait > 0 {
+			if s.timer == nil {
+				s.timer = time.AfterFunc(wait, func() {
+					s.mu.Lock()
+					s.timer = nil
+					s.dispatchLocked()
+					s.mu.Unlock()
+				})
+			}
+			return
+		}
+		w := heap.Pop(&s.queue).(*schedulerWaiter) //nolint:forcetypeassert // only waiters are pushed
+		s.running++
+		s.inflight[w.slot] = struct{}{}
+		close(w.ready)
+	}
+}
+
+// takeTokenLocked takes a token from the rate budget, or returns how long to
+// wait for the next one.
+func (s *Scheduler) takeTokenLocked(now time.Time) time.Duration {
+	rate := s.config.RequestsPerSecond
+	if rate <= 0 {
+		return 0
+	}
+	s.tokens += now.Sub(s.refilled).Seconds() * rate
+	if burst := float64(s.config.Burst); s.tokens > burst {
+		s.tokens = burst
+	}
+	s.refilled = now
+	if s.tokens >= 1 {
+		s.tokens--
+		return 0
+	}
+	return time.Duration((1 - s.tokens) / rate * float64(time.Second))
+}
+
+// preemptLocked cancels the in-flight request with the lowest priority below
+// priority, if the concurrency budget is exhausted.
+func (s *Scheduler) preemptLocked(priority Priority) {
+	if s.config.MaxConcurrency <= 0 || s.running < s.config.MaxConcurrency {
+		return
+	}
+	var victim *schedulerSlot
+	for slot := range s.inflight {
+		if slot.preempted || slot.priority >= priority {
+			continue
+		}
+		if victim == nil || slot.priority < victim.priority {
+			victim = slot
+		}
+	}
+	if victim != nil {
+		victim.preempted = true
+		victim.cancel()
+	}
+}
+
+// httpClient returns client with requests going through the scheduler.
+func (s *Scheduler) httpClient(client HTTPDoer) HTTPDoer {
+	if client == nil {
+		client = &http.Client{}
+	}
+	return &scheduledDoer{client: client, scheduler: s}
+}
+
+type scheduledDoer struct {
+	client    HTTPDoer
+	scheduler *Scheduler
+}
+
+func (d *scheduledDoer) Do(req *http.Request) (*http.Response, error) {
+	slot, err := d.scheduler.acquire(req.Context(), priorityFromContext(req.Context()))
+	if err != nil {
+		return nil, err
+	}
+	resp, err := d.client.Do(req.WithContext(slot.ctx))
+	if err != nil {
+		d.scheduler.release(slot)
+		if d.scheduler.isPreempted(slot) {
+			return nil, ErrRequestPreempted
+		}
+		return nil, err
+	}
+	resp.Body = &scheduledBody{ReadCloser: resp.Body, scheduler: d.scheduler, slot: slot}
+	return resp, nil
+}
+
+// scheduledBody holds the slot of a request until its body is closed.
+type scheduledBody struct {
+	io.ReadCloser
+	scheduler *Scheduler
+	slot      *schedulerSlot
+}
+
+func (b *scheduledBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) && b.scheduler.isPreempted(b.slot) {
+		err = ErrRequestPreempted
+	}
+	return n, err
+}
+
+func (b *scheduledBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.scheduler.release(b.slot)
+	return err
+}
@@ -0,0 +1,139 @@
+package openai //nolint:testpackage // testing private field
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func waitForQueued(t *testing.T, s *Scheduler, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		s.mu.Lock()
+		queued := s.queue.Len()
+		s.mu.Unlock()
+		if queued == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d queued requests", n)
+}
+
+func TestSchedulerPriorityOrder(t *testing.T) {
+	s := NewScheduler(SchedulerConfig{MaxConcurrency: 1})
+	ctx := context.Background()
+	first, err := s.acquire(ctx, PriorityNormal)
+	checks.NoError(t, err)
+
+	order := make(chan Priority, 3)
+	for i, priority := range []Priority{PriorityBatch, PriorityNormal, PriorityInteractive} {
+		go func(priority Priority) {
+			slot, acquireErr := s.acquire(ctx, priority)
+			if acquireErr != nil {
+				t.Error(acquireErr)
+				return
+			}
+			order <- priority
+			s.release(slot)
+		}(priority)
+		waitForQueued(t, s, i+1)
+	}
+
+	s.release(first)
+	for _, want := range []Priority{PriorityInteractive, PriorityNormal, PriorityBatch} {
+		if got := <-order; got != want {
+			t.Errorf("dispatched priority %d, want %d", got, want)
+		}
+	}
+}
+
+func TestSchedulerCanceledWaiter(t *testing.T) {
+	s := NewScheduler(SchedulerConfig{MaxConcurrency: 1})
+	first, err := s.acquire(context.Background(), PriorityNormal)
+	checks.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = s.acquire(ctx, PriorityNormal)
+	checks.ErrorIs(t, err, context.DeadlineExceeded)
+	if s.queue.Len() != 0 {
+		t.Errorf("canceled waiter should leave the queue, %d queued", s.queue.Len())
+	}
+
+	s.release(first)
+	s.release(first)
+	if s.running != 0 {
+		t.Errorf("running = %d, want 0", s.running)
+	}
+}
+
+func TestSchedulerRate(t *testing.T) {
+	s := NewScheduler(SchedulerConfig{RequestsPerSecond: 50, Burst: 2})
+	ctx := context.Background()
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		slot, err := s.acquire(ctx, PriorityNormal)
+		checks.NoError(t, err)
+		s.release(slot)
+	}
+	// Two requests are sent as a burst, the next two 20ms apart.
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("4 requests at 50/s with a burst of 2 took %v", elapsed)
+	}
+}
+
+func TestSchedulerPreemption(t *testing.T) {
+	started := make(chan struct{}, 1)
+	client, server := setupOpenAITestServerWithConfig(t, func(config *ClientConfig) {
+		config.Scheduler = NewScheduler(SchedulerConfig{MaxConcurrency: 1, Preempt: true})
+	})
+	server.RegisterHandler("/v1/embeddings", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Batch") != "" {
+			// The server notices the client going away once the body is read.
+			_, _ = io.Copy(io.Discard, r.Body)
+			started <- struct{}{}
+			<-r.Context().Done()
+			return
+		}
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	})
+
+	batchErr := make(chan error, 1)
+	go func() {
+		req, err := client.newRequest(
+			WithPriority(context.Background(), PriorityBatch),
+			http.MethodPost,
+			client.fullURL("/embeddings"),
+			withBody(EmbeddingRequest{Input: []string{"batch"}}),
+		)
+		if err != nil {
+			batchErr <- err
+			return
+		}
+		req.Header.Set("X-Batch", "1")
+		batchErr <- client.sendRequest(req, &EmbeddingResponse{})
+	}()
+	<-started
+
+	_, err := client.CreateEmbeddings(
+		WithPriority(context.Background(), PriorityInteractive),
+		EmbeddingRequest{Input: []string{"interactive"}},
+	)
+	checks.NoError(t, err)
+
+	err = <-batchErr
+	if !errors.Is(err, ErrRequestPreempted) {
+		t.Errorf("expected ErrRequestPreempted, got %v", err)
+	}
+	if !isRetryableError(err) {
+		t.Error("preempted requests should be retryable")
+	}
+}
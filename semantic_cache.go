@@ -0,0 +1,142 @@
+package openai
+
+import (
+	"context"
+	"math"
+	"strings"
+	"sync"
+)
+
+// VectorIndex stores the prompt embeddings of the semantic cache. Vectors are
+// partitioned by namespace, which groups requests that only differ in their
+// messages, so a prompt is never matched against one sent with a different
+// model or parameters.
+type VectorIndex interface {
+	Add(ctx context.Context, namespace, key string, vector []float32) error
+	// Nearest returns the key of the vector of the namespace most similar to
+	// vector, along with their cosine similarity. It reports false when the
+	// namespace is empty.
+	Nearest(ctx context.Context, namespace string, vector []float32) (key string, similarity float32, ok bool, err error)
+}
+
+// SemanticCache makes the ResponseCache serve a cached response when the
+// prompt embedding is at least Threshold cosine-similar to that of a cached
+// prompt. It suits FAQ-style traffic where users phrase the same question in
+// different ways.
+//
+// Every cache miss costs an embeddings request; embedding failures are treated
+// as misses.
+type SemanticCache struct {
+	Index          VectorIndex
+	EmbeddingModel EmbeddingModel
+	// Threshold is the minimum cosine similarity, e.g. 0.95.
+	Threshold float32
+}
+
+func (c *Client) lookupSemanticCache(
+	ctx context.Context,
+	cache *ResponseCache,
+	request ChatCompletionRequest,
+	lookup *chatCompletionCacheLookup,
+	response *ChatCompletionResponse,
+) bool {
+	semantic := cache.Semantic
+	prompt := semanticCachePrompt(request.Messages)
+	request.Messages = nil
+	namespace, err := ChatCompletionCacheKey(request)
+	if err != nil {
+		return false
+	}
+	namespace = tenantCacheKey(lookup.tenant, namespace)
+
+	embeddings, err := c.CreateEmbeddings(ctx, EmbeddingRequestStrings{
+		Input: []string{prompt},
+		Model: semantic.EmbeddingModel,
+	})
+	if err != nil || len(embeddings.Data) == 0 {
+		return false
+	}
+	lookup.namespace = namespace
+	lookup.vector = embeddings.Data[0].Embedding
+
+	key, similarity, ok, err := semantic.Index.Nearest(ctx, namespace, lookup.vector)
+	if err != nil || !ok || similarity < semantic.Threshold {
+		return false
+	}
+	return cache.get(ctx, key, response)
+}
+
+// semanticCachePrompt flattens the text of the messages into the string that
+// is embedded.
+func semanticCachePrompt(messages []ChatCompletionMessage) string {
+	var b strings.Builder
+	for _, message := range messages {
+		b.WriteString(message.Role)
+		b.WriteString(": ")
+		b.WriteString(message.Content)
+		for _, part := range message.MultiContent {
+			b.WriteString(part.Text)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// CosineSimilarity returns the cosine similarity of two vectors of the same
+// length, or ErrVectorLengthMismatch.
+func CosineSimilarity(a, b []float32) (float32, error) {
+	if len(a) != len(b) {
+		return 0, ErrVectorLengthMismatch
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0, nil
+	}
+	return float32(dot / (math.Sqrt(normA) * math.Sqrt(normB))), nil
+}
+
+// MemoryVectorIndex is an in-process VectorIndex performing exhaustive
+// search, which is adequate for a few thousand cached prompts.
+type MemoryVectorIndex struct {
+	mu      sync.RWMutex
+	vectors map[string]map[string][]float32
+}
+
+// NewMemoryVectorIndex creates an empty MemoryVectorIndex.
+func NewMemoryVectorIndex() *MemoryVectorIndex {
+	return &MemoryVectorIndex{vectors: make(map[string]map[string][]float32)}
+}
+
+func (x *MemoryVectorIndex) Add(_ context.Context, namespace, key string, vector []float32) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.vectors[namespace] == nil {
+		x.vectors[namespace] = make(map[string][]float32)
+	}
+	x.vectors[namespace][key] = vector
+	return nil
+}
+
+func (x *MemoryVectorIndex) Nearest(
+	_ context.Context,
+	namespace string,
+	vector []float32,
+) (key string, similarity float32, ok bool, err error) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	for candidate, v := range x.vectors[namespace] {
+		s, simErr := CosineSimilarity(vector, v)
+		if simErr != nil {
+			continue
+		}
+		if !ok || s > similarity {
+			key, similarity, ok = candidate, s, true
+		}
+	}
+	return key, similarity, ok, nil
+}
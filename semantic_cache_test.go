@@ -0,0 +1,128 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestCosineSimilarity(t *testing.T) {
+	similarity, err := openai.CosineSimilarity([]float32{1, 0}, []float32{2, 0})
+	checks.NoError(t, err)
+	if similarity != 1 {
+		t.Errorf("expected similarity 1, got %f", similarity)
+	}
+	similarity, err = openai.CosineSimilarity([]float32{1, 0}, []float32{0, 3})
+	checks.NoError(t, err)
+	if similarity != 0 {
+		t.Errorf("expected similarity 0, got %f", similarity)
+	}
+	_, err = openai.CosineSimilarity([]float32{1}, []float32{1, 2})
+	checks.ErrorIs(t, err, openai.ErrVectorLengthMismatch)
+}
+
+func TestChatCompletionsSemanticCache(t *testing.T) {
+	completions := 0
+	client, server := setupOpenAITestServerWithConfig(t, func(config *openai.ClientConfig) {
+		config.ResponseCache = &openai.ResponseCache{
+			Store: openai.NewMemoryCacheStore(),
+			Semantic: &openai.SemanticCache{
+				Index:          openai.NewMemoryVectorIndex(),
+				EmbeddingModel: openai.SmallEmbedding3,
+				Threshold:      0.95,
+			},
+		}
+	})
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		completions++
+		fmt.Fprintf(w, `{"id":"chatcmpl-%d","object":"chat.completion","choices":[]}`, completions)
+	})
+	server.RegisterHandler("/v1/embeddings", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Input []string `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		vector := []float32{0, 1}
+		if strings.Contains(strings.ToLower(req.Input[0]), "refund") {
+			vector = []float32{1, 0.1}
+		}
+		resp := openai.EmbeddingResponse{Data: []openai.Embedding{{Embedding: vector}}}
+		checks.NoError(t, json.NewEncoder(w).Encode(resp))
+	})
+	ctx := context.Background()
+
+	ask := func(model, question string) string {
+		resp, err := client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+			Model:    model,
+			Messages: []openai.ChatCompletionMessage{openai.UserMessage(question)},
+		})
+		checks.NoError(t, err)
+		return resp.ID
+	}
+
+	first := ask(openai.GPT4oMini, "How do I get a refund?")
+	if similar := ask(openai.GPT4oMini, "Can I have a refund please?"); similar != first {
+		t.Errorf("expected similar prompt to be served from cache, got %q", similar)
+	}
+	if other := ask(openai.GPT4oMini, "What are your opening hours?"); other == first {
+		t.Error("expected unrelated prompt to miss the cache")
+	}
+	if otherModel := ask(openai.GPT4o, "How do I get a refund?"); otherModel == first {
+		t.Error("expected prompt for another model to miss the cache")
+	}
+	if completions != 3 {
+		t.Errorf("expected 3 completions requests, got %d", completions)
+	}
+}
+
+func TestChatCompletionsSemanticCacheTenants(t *testing.T) {
+	completions := 0
+	client, server := setupOpenAITestServerWithConfig(t, func(config *openai.ClientConfig) {
+		config.ResponseCache = &openai.ResponseCache{
+			Store: openai.NewMemoryCacheStore(),
+			Semantic: &openai.SemanticCache{
+				Index:          openai.NewMemoryVectorIndex(),
+				EmbeddingModel: openai.SmallEmbedding3,
+				Threshold:      0.95,
+			},
+		}
+		config.CredentialResolver = resolveTenant
+	})
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		completions++
+		fmt.Fprintf(w, `{"id":"chatcmpl-%d","object":"chat.completion","choices":[]}`, completions)
+	})
+	server.RegisterHandler("/v1/embeddings", func(w http.ResponseWriter, _ *http.Request) {
+		resp := openai.EmbeddingResponse{Data: []openai.Embedding{{Embedding: []float32{1, 0}}}}
+		checks.NoError(t, json.NewEncoder(w).Encode(resp))
+	})
+
+	ask := func(tn tenant, question string) string {
+		ctx := context.WithValue(context.Background(), tenantKey{}, tn)
+		resp, err := client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+			Model:    openai.GPT4oMini,
+			Messages: []openai.ChatCompletionMessage{openai.UserMessage(question)},
+		})
+		checks.NoError(t, err)
+		return resp.ID
+	}
+
+	acme := tenant{org: "org-acme", project: "proj-acme"}
+	globex := tenant{org: "org-globex", project: "proj-globex"}
+	first := ask(acme, "How do I get a refund?")
+	if other := ask(globex, "Can I have a refund please?"); other == first {
+		t.Error("expected a similar prompt of another tenant to miss the cache")
+	}
+	if similar := ask(acme, "Can I have a refund please?"); similar != first {
+		t.Errorf("expected similar prompt of the same tenant to be served from cache, got %q", similar)
+	}
+}
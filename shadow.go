@@ -0,0 +1,174 @@
+package openai
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultShadowTimeout     = 60 * time.Second
+	defaultShadowMaxInFlight = 8
+)
+
+// ShadowConfig mirrors a sample of the chat completion requests of a client
+// to a second model, to compare it with the current one before an upgrade.
+type ShadowConfig struct {
+	// Model is the model the sampled requests are mirrored to. Parameters
+	// the shadow model does not support, such as a temperature for a
+	// reasoning model, make the shadow request fail.
+	Model string
+	// SampleRate is the fraction of successful requests mirrored, from 0
+	// to 1.
+	SampleRate float64
+	// OnCompare receives both responses of each mirrored request. It is
+	// called from the goroutine of the shadow request, concurrently with
+	// other calls.
+	OnCompare func(comparison ShadowComparison)
+	// Timeout bounds each shadow request, 60 seconds by default.
+	Timeout time.Duration
+	// MaxInFlight is the number of shadow requests running at once, 8 by
+	// default. Requests sampled while it is reached are not mirrored.
+	MaxInFlight int
+}
+
+// ShadowComparison holds the responses of the primary and shadow models to
+// a mirrored request.
+type ShadowComparison struct {
+	// Request is a copy of the request sent to the primary model.
+	Request        ChatCompletionRequest
+	Primary        ChatCompletionResponse
+	PrimaryLatency time.Duration
+	Shadow         ChatCompletionResponse
+	ShadowLatency  time.Duration
+	// ShadowErr is the error of the shadow request, in which case Shadow
+	// is empty.
+	ShadowErr error
+}
+
+// shadowMirror runs the shadow requests of a client.
+type shadowMirror struct {
+	config   ShadowConfig
+	inFlight chan struct{}
+}
+
+func newShadowMirror(config *ShadowConfig) *shadowMirror {
+	if config == nil || config.Model == "" || config.OnCompare == nil {
+		return nil
+	}
+	mirror := &shadowMirror{config: *config}
+	if mirror.config.Timeout <= 0 {
+		mirror.config.Timeout = defaultShadowTimeout
+	}
+	maxInFlight := mirror.config.MaxInFlight
+	if maxInFlight <= 0 {
+		maxInFlight = defaultShadowMaxInFlight
+	}
+	mirror.inFlight = make(chan struct{}, maxInFlight)
+	return mirror
+}
+
+// shadowChatCompletion mirrors a request the primary model answered to the
+// shadow model in the background, when it is sampled and a slot is free.
+// The shadow request keeps the values of ctx, such as the credentials of a
+// tenant, but not its cancellation.
+func (c *Client) shadowChatCompletion(
+	ctx context.Context,
+	request ChatCompletionRequest,
+	primary ChatCompletionResponse,
+	primaryLatency time.Duration,
+) {
+	mirror := c.shadows
+	if mirror == nil || rand.Float64() >= mirror.config.SampleRate { //nolint:gosec // sampling needs no crypto
+		return
+	}
+	select {
+	case mirror.inFlight <- struct{}{}:
+	default:
+		return
+	}
+
+	// The caller may reuse the slices and maps of the request once the
+	// primary call has returned.
+	request = cloneChatCompletionRequest(request)
+	go func() {
+		defer func() { <-mirror.inFlight }()
+		ctx, cancel := context.WithTimeout(detachedContext{ctx}, mirror.config.Timeout)
+		defer cancel()
+
+		comparison := ShadowComparison{Request: request, Primary: primary, PrimaryLatency: primaryLatency}
+		shadowRequest := request
+		shadowRequest.Model = mirror.config.Model
+		start := time.Now()
+		comparison.Shadow, comparison.ShadowErr = c.sendShadowRequest(ctx, shadowRequest)
+		comparison.ShadowLatency = time.Since(start)
+		mirror.config.OnCompare(comparison)
+	}()
+}
+
+func (c *Client) sendShadowRequest(
+	ctx context.Context,
+	request ChatCompletionRequest,
+) (response ChatCompletionResponse, err error) {
+	req, err := c.newRequest(
+		ctx,
+		http.MethodPost,
+		c.fullURL(chatCompletionsSuffix, withModel(request.Model)),
+		withBody(request),
+	)
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// cloneChatCompletionRequest copies the messages, tools and metadata of a
+// request, so that it no longer shares their memory with the original.
+func cloneChatCompletionRequest(request ChatCompletionRequest) ChatCompletionRequest {
+	if request.Messages != nil {
+		messages := make([]ChatCompletionMessage, len(request.Messages))
+		for i, message := range request.Messages {
+			message.MultiContent = append([]ChatMessagePart(nil), message.MultiContent...)
+			message.ToolCalls = append([]ToolCall(nil), message.ToolCalls...)
+			if message.FunctionCall != nil {
+				functionCall := *message.FunctionCall
+				message.FunctionCall = &functionCall
+			}
+			messages[i] = message
+		}
+		request.Messages = messages
+	}
+	if request.Tools != nil {
+		tools := make([]Tool, len(request.Tools))
+		for i, tool := range request.Tools {
+			if tool.Function != nil {
+				function := *tool.Function
+				tool.Function = &function
+			}
+			tools[i] = tool
+		}
+		request.Tools = tools
+	}
+	if request.Metadata != nil {
+		metadata := make(map[string]string, len(request.Metadata))
+		for k, v := range request.Metadata {
+			metadata[k] = v
+		}
+		request.Metadata = metadata
+	}
+	return request
+}
+
+// detachedContext keeps the values of its parent without its deadline and
+// cancellation.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (deadline time.Time, ok bool) { return }
+func (detachedContext) Done() <-chan struct{}                   { return nil }
+func (detachedContext) Err() error                              { return nil }
+func (c detachedContext) Value(key any) any                     { return c.parent.Value(key) }
@@ -0,0 +1,91 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func newShadowTestClient(t *testing.T, shadow *openai.ShadowConfig, requests *int32) *openai.Client {
+	t.Helper()
+	client, server := setupOpenAITestServerWithConfig(t, func(config *openai.ClientConfig) {
+		config.Shadow = shadow
+	})
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		var request openai.ChatCompletionRequest
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		content := fmt.Sprintf("from %s: %s", request.Model, request.Messages[0].Content)
+		fmt.Fprintf(w, `{"id":"chatcmpl-1","model":%q,"choices":[{"message":{"role":"assistant","content":%q}}]}`,
+			request.Model, content)
+	})
+	return client
+}
+
+func TestShadowChatCompletion(t *testing.T) {
+	comparisons := make(chan openai.ShadowComparison, 1)
+	var requests int32
+	client := newShadowTestClient(t, &openai.ShadowConfig{
+		Model:      openai.GPT4Dot1Mini,
+		SampleRate: 1,
+		OnCompare:  func(comparison openai.ShadowComparison) { comparisons <- comparison },
+	}, &requests)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	messages := []openai.ChatCompletionMessage{openai.UserMessage("Hello!")}
+	response, err := client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+		Model:    openai.GPT4oMini,
+		Messages: messages,
+	})
+	cancel()
+	// The caller reuses its messages once the call has returned.
+	messages[0].Content = "Goodbye!"
+	checks.NoError(t, err, "CreateChatCompletion error")
+	if response.Model != openai.GPT4oMini {
+		t.Errorf("primary response from %q", response.Model)
+	}
+
+	select {
+	case comparison := <-comparisons:
+		checks.NoError(t, comparison.ShadowErr, "shadow request error")
+		if comparison.Request.Model != openai.GPT4oMini || comparison.Primary.Model != openai.GPT4oMini {
+			t.Errorf("primary of comparison = %v", comparison.Primary)
+		}
+		if comparison.Request.Messages[0].Content != "Hello!" {
+			t.Errorf("request of comparison = %v", comparison.Request.Messages)
+		}
+		if comparison.Shadow.Choices[0].Message.Content != "from "+openai.GPT4Dot1Mini+": Hello!" {
+			t.Errorf("shadow of comparison = %v", comparison.Shadow)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no comparison delivered")
+	}
+}
+
+func TestShadowChatCompletionNotSampled(t *testing.T) {
+	var requests int32
+	client := newShadowTestClient(t, &openai.ShadowConfig{
+		Model:      openai.GPT4Dot1Mini,
+		SampleRate: 0,
+		OnCompare:  func(openai.ShadowComparison) { t.Error("unsampled request mirrored") },
+	}, &requests)
+
+	for i := 0; i < 10; i++ {
+		_, err := client.CreateChatCompletion(context.Background(), openai.ChatCompletionRequest{
+			Model:    openai.GPT4oMini,
+			Messages: []openai.ChatCompletionMessage{openai.UserMessage("Hello!")},
+		})
+		checks.NoError(t, err, "CreateChatCompletion error")
+	}
+	time.Sleep(50 * time.Millisecond)
+	if n := atomic.LoadInt32(&requests); n != 10 {
+		t.Errorf("%d requests sent, want 10", n)
+	}
+}
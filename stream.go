@@ -3,15 +3,156 @@ package openai
 import (
 	"context"
 	"errors"
+	"io"
 	"net/http"
+	"sort"
 )
 
 var (
 	ErrTooManyEmptyStreamMessages = errors.New("stream has sent too many empty messages")
+	ErrStreamRawNotSupported      = errors.New("underlying stream reader does not support raw reads")
+	ErrStreamClosed               = errors.New("stream closed before it ended")
 )
 
+// StreamReader is the source a Stream reads typed events from. The SSE reader
+// used by the client implements it; custom implementations can be injected
+// for testing or to adapt other transports.
+type StreamReader[T any] interface {
+	Recv() (T, error)
+	Close() error
+}
+
+// Stream is the common implementation shared by all streaming endpoints
+// (chat completions, legacy completions and any future event streams), so
+// header access, rate limits, iteration and collection behave the same
+// regardless of the payload type.
+type Stream[T any] struct {
+	reader StreamReader[T]
+
+	current T
+	err     error
+}
+
+// NewStream wraps a StreamReader into a Stream.
+func NewStream[T any](reader StreamReader[T]) *Stream[T] {
+	return &Stream[T]{reader: reader}
+}
+
+// Recv returns the next event of the stream. It returns io.EOF once the
+// stream has been fully consumed.
+func (s *Stream[T]) Recv() (T, error) {
+	return s.reader.Recv()
+}
+
+// RecvRaw returns the next raw event payload without decoding it. It is only
+// available when the underlying reader supports raw reads.
+func (s *Stream[T]) RecvRaw() ([]byte, error) {
+	if r, ok := s.reader.(interface{ RecvRaw() ([]byte, error) }); ok {
+		return r.RecvRaw()
+	}
+	return nil, ErrStreamRawNotSupported
+}
+
+// RecvRawNoCopy is like RecvRaw but avoids copying the payload, which is
+// only valid until the next read from the stream and must be copied to be
+// kept.
+func (s *Stream[T]) RecvRawNoCopy() ([]byte, error) {
+	if r, ok := s.reader.(interface{ RecvRawNoCopy() ([]byte, error) }); ok {
+		return r.RecvRawNoCopy()
+	}
+	return s.RecvRaw()
+}
+
+// RecvInto decodes the next event of the stream into event, reusing the
+// memory it already holds where the underlying reader supports it, so that
+// a loop receiving into the same value allocates less per event. Slices in
+// event, such as the choices of a chat completion chunk, are overwritten by
+// the next call and must be copied to be kept. It returns io.EOF once the
+// stream has been fully consumed.
+func (s *Stream[T]) RecvInto(event *T) error {
+	return recvInto(s.reader, event)
+}
+
+func recvInto[T any](reader StreamReader[T], event *T) (err error) {
+	if r, ok := reader.(interface{ RecvInto(*T) error }); ok {
+		return r.RecvInto(event)
+	}
+	*event, err = reader.Recv()
+	return
+}
+
+// Close closes the underlying connection.
+func (s *Stream[T]) Close() error {
+	return s.reader.Close()
+}
+
+// Header returns the HTTP response headers of the stream, or an empty header
+// if the underlying reader does not expose them.
+func (s *Stream[T]) Header() http.Header {
+	if h, ok := s.reader.(interface{ Header() http.Header }); ok {
+		return h.Header()
+	}
+	return http.Header{}
+}
+
+// GetRateLimitHeaders returns the rate limit headers sent with the stream.
+func (s *Stream[T]) GetRateLimitHeaders() RateLimitHeaders {
+	return newRateLimitHeaders(s.Header())
+}
+
+// Next advances the stream to the next event, which is then available
+// through Current. It returns false when the stream is exhausted or an error
+// occurred; use Err to tell the two apart.
+//
+//	for stream.Next() {
+//		chunk := stream.Current()
+//	}
+//	if err := stream.Err(); err != nil {
+//		...
+//	}
+func (s *Stream[T]) Next() bool {
+	if s.err != nil {
+		return false
+	}
+	s.current, s.err = s.reader.Recv()
+	return s.err == nil
+}
+
+// Current returns the event read by the last call to Next.
+func (s *Stream[T]) Current() T {
+	return s.current
+}
+
+// Err returns the error that stopped iteration, if any. Reaching the end of
+// the stream is not considered an error.
+func (s *Stream[T]) Err() error {
+	if errors.Is(s.err, io.EOF) {
+		return nil
+	}
+	return s.err
+}
+
+// Collect reads the remaining events of the stream and returns them. Events
+// received before an error are returned along with the error.
+func (s *Stream[T]) Collect() ([]T, error) {
+	var events []T
+	for s.Next() {
+		events = append(events, s.Current())
+	}
+	return events, s.Err()
+}
+
+// Drain reads and discards the remaining events of the stream, which is
+// useful when only transforms or callbacks consume them. It returns the error
+// that stopped reading, if any.
+func (s *Stream[T]) Drain() error {
+	for s.Next() { //nolint:revive // events are consumed by Next
+	}
+	return s.Err()
+}
+
 type CompletionStream struct {
-	*streamReader[CompletionResponse]
+	*Stream[CompletionResponse]
 }
 
 // CreateCompletionStream — API call to create a completion w/ streaming
@@ -28,12 +169,13 @@ func (c *Client) CreateCompletionStream(
 		return
 	}
 
-	if !checkPromptType(request.Prompt) {
-		err = ErrCompletionRequestPromptTypeNotSupported
+	request.Stream = true
+	if err = validateCompletionRequest(request); err != nil {
 		return
 	}
 
-	request.Stream = true
+	request.User = c.resolveUser(ctx, request.User)
+
 	req, err := c.newRequest(
 		ctx,
 		http.MethodPost,
@@ -49,7 +191,81 @@ func (c *Client) CreateCompletionStream(
 		return
 	}
 	stream = &CompletionStream{
-		streamReader: resp,
+		Stream: NewStream[CompletionResponse](resp),
 	}
 	return
 }
+
+// Accumulate reads the remaining chunks of the stream and assembles them into
+// the CompletionResponse a non-streaming request would have returned. Set
+// StreamOptions.IncludeUsage on the request to get Usage populated.
+func (s *CompletionStream) Accumulate() (CompletionResponse, error) {
+	acc := NewCompletionAccumulator()
+	for s.Next() {
+		acc.Add(s.Current())
+	}
+	return acc.Response(), s.Err()
+}
+
+// CompletionAccumulator assembles the chunks of a legacy completion stream,
+// including streams with n > 1, into a single CompletionResponse.
+type CompletionAccumulator struct {
+	response CompletionResponse
+	choices  map[int]*CompletionChoice
+}
+
+// NewCompletionAccumulator creates an empty CompletionAccumulator.
+func NewCompletionAccumulator() *CompletionAccumulator {
+	return &CompletionAccumulator{choices: make(map[int]*CompletionChoice)}
+}
+
+// Add consumes a stream chunk.
+func (a *CompletionAccumulator) Add(chunk CompletionResponse) {
+	if chunk.ID != "" {
+		a.response.ID = chunk.ID
+	}
+	if chunk.Object != "" {
+		a.response.Object = chunk.Object
+	}
+	if chunk.Created != 0 {
+		a.response.Created = chunk.Created
+	}
+	if chunk.Model != "" {
+		a.response.Model = chunk.Model
+	}
+	if chunk.Usage != nil {
+		usage := *chunk.Usage
+		a.response.Usage = &usage
+	}
+	for _, delta := range chunk.Choices {
+		choice, ok := a.choices[delta.Index]
+		if !ok {
+			choice = &CompletionChoice{Index: delta.Index}
+			a.choices[delta.Index] = choice
+		}
+		choice.Text += delta.Text
+		if delta.FinishReason != "" {
+			choice.FinishReason = delta.FinishReason
+		}
+		if len(choice.LogProbs.TokenLogprobs) == 0 {
+			choice.LogProbs.FirstTokenLogprobNull = delta.LogProbs.FirstTokenLogprobNull
+		}
+		choice.LogProbs.Tokens = append(choice.LogProbs.Tokens, delta.LogProbs.Tokens...)
+		choice.LogProbs.TokenLogprobs = append(choice.LogProbs.TokenLogprobs, delta.LogProbs.TokenLogprobs...)
+		choice.LogProbs.TopLogprobs = append(choice.LogProbs.TopLogprobs, delta.LogProbs.TopLogprobs...)
+		choice.LogProbs.TextOffset = append(choice.LogProbs.TextOffset, delta.LogProbs.TextOffset...)
+	}
+}
+
+// Response returns the response assembled so far, with choices ordered by index.
+func (a *CompletionAccumulator) Response() CompletionResponse {
+	response := a.response
+	response.Choices = make([]CompletionChoice, 0, len(a.choices))
+	for _, choice := range a.choices {
+		response.Choices = append(response.Choices, *choice)
+	}
+	sort.Slice(response.Choices, func(i, j int) bool {
+		return response.Choices[i].Index < response.Choices[j].Index
+	})
+	return response
+}
@@ -0,0 +1,133 @@
+package openai
+
+import (
+	"bufio"
+	"bytes"
+)
+
+// utf8BOM is the byte order mark a stream may start with.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// StreamEvent describes the server-sent event that carried the last chunk
+// received from a stream.
+type StreamEvent struct {
+	// Name is the event type set by an "event:" field. It is empty for
+	// unnamed events, which the SSE spec calls "message" events.
+	Name string
+	// ID is the last event ID set by an "id:" field. Unlike the name, it
+	// carries over to the following events until the server changes it.
+	ID string
+}
+
+// Event returns the server-sent event that carried the chunk returned by the
+// last call to Recv or Next. It is zero for JSON lines streams and for
+// streams whose reader does not track events, such as custom StreamReaders.
+// It must not be called concurrently with Recv.
+func (s *Stream[T]) Event() StreamEvent {
+	if r, ok := s.reader.(interface{ Event() StreamEvent }); ok {
+		return r.Event()
+	}
+	return StreamEvent{}
+}
+
+// sseParser holds the state of a server-sent events stream that spans lines:
+// the fields of the event being read and the line terminator of the last
+// line. The line and data buffers are reused from one event to the next.
+type sseParser struct {
+	name    string
+	id      string
+	current StreamEvent
+
+	line    []byte
+	data    []byte
+	started bool
+	skipLF  bool
+}
+
+// Event returns the event that carried the last dispatched payload.
+func (p *sseParser) Event() StreamEvent {
+	return p.current
+}
+
+// dispatched records that the payload of the event being read was returned
+// and starts a new event, keeping the last event ID as the spec requires.
+func (p *sseParser) dispatched() {
+	p.current = StreamEvent{Name: p.name, ID: p.id}
+	p.name = ""
+}
+
+// setField applies a non-data field to the event being read.
+func (p *sseParser) setField(field, value []byte) {
+	switch string(field) {
+	case "event":
+		// Compare before converting so repeated names do not allocate.
+		if p.name != string(value) {
+			p.name = string(value)
+		}
+	case "id":
+		// IDs containing NULL are ignored as the spec requires.
+		if bytes.IndexByte(value, 0) < 0 && p.id != string(value) {
+			p.id = string(value)
+		}
+	}
+}
+
+// readLine reads a line terminated by LF, CRLF or a lone CR, all of which
+// the SSE spec allows, and drops a byte order mark at the start of the
+// stream. A CR is treated as a terminator right away, and an LF following it
+// is skipped on the next read, so a CR at the end of a network read does not
+// block until more data arrives. The line is only valid until the next call.
+func (p *sseParser) readLine(r *bufio.Reader) ([]byte, error) {
+	p.line = p.line[:0]
+	for {
+		n := r.Buffered()
+		if n == 0 {
+			if _, err := r.Peek(1); err != nil {
+				return p.trimBOM(p.line), err
+			}
+			n = r.Buffered()
+		}
+		buf, _ := r.Peek(n)
+		if p.skipLF {
+			p.skipLF = false
+			if buf[0] == '\n' {
+				_, _ = r.Discard(1)
+				continue
+			}
+		}
+
+		i := bytes.IndexAny(buf, "\r\n")
+		if i < 0 {
+			p.line = append(p.line, buf...)
+			_, _ = r.Discard(n)
+			continue
+		}
+		p.line = append(p.line, buf[:i]...)
+		p.skipLF = buf[i] == '\r'
+		_, _ = r.Discard(i + 1)
+		return p.trimBOM(p.line), nil
+	}
+}
+
+func (p *sseParser) trimBOM(line []byte) []byte {
+	if p.started {
+		return line
+	}
+	p.started = true
+	return bytes.TrimPrefix(line, utf8BOM)
+}
+
+// parseSSEField splits a line into its field name and value, removing the
+// single space the spec allows after the colon. Comment lines have an empty
+// field name.
+func parseSSEField(line []byte) (field, value []byte) {
+	i := bytes.IndexByte(line, ':')
+	if i < 0 {
+		return line, nil
+	}
+	value = line[i+1:]
+	if len(value) > 0 && value[0] == ' ' {
+		value = value[1:]
+	}
+	return line[:i], value
+}
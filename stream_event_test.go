@@ -0,0 +1,209 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func sseChatStream(t *testing.T, body string) *openai.ChatCompletionStream {
+	t.Helper()
+	client, server := setupOpenAITestServerWithConfig(t, nil)
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, body)
+	})
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), openai.ChatCompletionRequest{
+		Model:    openai.GPT4oMini,
+		Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "Hi"}},
+	})
+	checks.NoError(t, err, "CreateChatCompletionStream error")
+	t.Cleanup(func() { stream.Close() })
+	return stream
+}
+
+func TestStreamSSEFields(t *testing.T) {
+	body := "\xEF\xBB\xBF: keep-alive comment\r\n" +
+		"event: delta\r\n" +
+		"id: 1\r\n" +
+		"data: {\"id\":\"1\",\r\n" +
+		"data:  \"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hel\"}}]}\r\n" +
+		"\r\n" +
+		"retry: 1000\r\n" +
+		"data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"lo\"}}]}\r\n" +
+		"\r\n" +
+		"event: done\r\n" +
+		"id: 2\r\n" +
+		"data: [DONE]\r\n\r\n"
+	stream := sseChatStream(t, body)
+
+	var (
+		content strings.Builder
+		events  []openai.StreamEvent
+	)
+	for stream.Next() {
+		for _, choice := range stream.Current().Choices {
+			content.WriteString(choice.Delta.Content)
+		}
+		events = append(events, stream.Event())
+	}
+	checks.NoError(t, stream.Err(), "stream error")
+	if content.String() != "Hello" {
+		t.Fatalf("content = %q, want %q", content.String(), "Hello")
+	}
+	want := []openai.StreamEvent{{Name: "delta", ID: "1"}, {ID: "1"}}
+	if len(events) != len(want) {
+		t.Fatalf("events = %+v, want %+v", events, want)
+	}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Errorf("event %d = %+v, want %+v", i, events[i], want[i])
+		}
+	}
+	if event := stream.Event(); event.Name != "done" || event.ID != "2" {
+		t.Errorf("final event = %+v, want done/2", event)
+	}
+}
+
+func TestStreamSSECarriageReturns(t *testing.T) {
+	body := "event: delta\r" +
+		"data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hi\"}}]}\r\r" +
+		"data: [DONE]\r\r"
+	stream := sseChatStream(t, body)
+
+	chunks, err := stream.Collect()
+	checks.NoError(t, err, "stream error")
+	if len(chunks) != 1 || chunks[0].Choices[0].Delta.Content != "Hi" {
+		t.Fatalf("chunks = %+v", chunks)
+	}
+}
+
+func TestStreamSSEFieldsAfterCompleteData(t *testing.T) {
+	// The data line is valid JSON on its own, but the event only ends at the
+	// blank line, so the fields after it still belong to it.
+	body := "data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hi\"}}]}\n" +
+		"event: delta\n" +
+		"id: 7\n" +
+		"\n" +
+		"data: [DONE]\n\n"
+	stream := sseChatStream(t, body)
+
+	chunk, err := stream.Recv()
+	checks.NoError(t, err, "Recv error")
+	if chunk.Choices[0].Delta.Content != "Hi" {
+		t.Fatalf("chunk = %+v", chunk)
+	}
+	if event := stream.Event(); event.Name != "delta" || event.ID != "7" {
+		t.Errorf("event = %+v, want delta/7", event)
+	}
+	_, err = stream.Recv()
+	checks.ErrorIs(t, err, io.EOF, "Recv after [DONE]")
+}
+
+func TestStreamSSEMultiLineError(t *testing.T) {
+	body := "data: {\"error\": {\n" +
+		"data:   \"message\": \"server overloaded\",\n" +
+		"data:   \"type\": \"server_error\"\n" +
+		"data: }}\n\n"
+	stream := sseChatStream(t, body)
+
+	_, err := stream.Recv()
+	var apiErr *openai.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Recv error = %v, want an APIError", err)
+	}
+	if apiErr.Message != "server overloaded" {
+		t.Errorf("error message = %q", apiErr.Message)
+	}
+}
+
+func TestStreamSSEEventThroughTransform(t *testing.T) {
+	body := "event: delta\n" +
+		"data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hi\"}}]}\n\n" +
+		"data: [DONE]\n\n"
+	stream := sseChatStream(t, body)
+	stream.AddTransform(func(*openai.ChatCompletionStreamResponse) error {
+		return nil
+	})
+
+	_, err := stream.Recv()
+	checks.NoError(t, err, "Recv error")
+	if event := stream.Event(); event.Name != "delta" {
+		t.Errorf("event = %+v, want delta", event)
+	}
+}
+
+func TestStreamSSEEventThroughPace(t *testing.T) {
+	body := "event: first\n" +
+		"data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hel\"}}]}\n\n" +
+		"event: second\n" +
+		"data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"lo\"}}]}\n\n" +
+		"data: [DONE]\n\n"
+	stream := sseChatStream(t, body)
+	stream.Pace(openai.StreamPaceOptions{Rate: 100})
+
+	for _, want := range []string{"first", "second"} {
+		_, err := stream.Recv()
+		checks.NoError(t, err, "Recv error")
+		// The next event may already be read ahead.
+		if event := stream.Event(); event.Name != want {
+			t.Errorf("event = %+v, want %s", event, want)
+		}
+	}
+	if stats := stream.Stats(); stats.Events != 2 {
+		t.Errorf("stats = %+v, want 2 events", stats)
+	}
+}
+
+func TestStreamSSEEventThroughDemuxer(t *testing.T) {
+	body := "event: first\n" +
+		"data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"a\"}}]}\n\n" +
+		"event: second\n" +
+		"data: {\"id\":\"1\",\"choices\":[{\"index\":1,\"delta\":{\"content\":\"x\"}}]}\n\n" +
+		"data: [DONE]\n\n"
+	demuxer := openai.NewChatCompletionStreamDemuxer(sseChatStream(t, body), 2)
+	first, second := demuxer.Choice(0), demuxer.Choice(1)
+
+	// Reading the second choice buffers the chunk of the first.
+	_, err := second.Recv()
+	checks.NoError(t, err, "Recv error")
+	_, err = first.Recv()
+	checks.NoError(t, err, "Recv error")
+	if event := first.Event(); event.Name != "first" {
+		t.Errorf("choice 0 event = %+v, want first", event)
+	}
+	if event := second.Event(); event.Name != "second" {
+		t.Errorf("choice 1 event = %+v, want second", event)
+	}
+	if stats := second.Stats(); stats.Events != 2 {
+		t.Errorf("stats = %+v, want 2 events", stats)
+	}
+}
+
+func TestStreamSSEEventThroughTee(t *testing.T) {
+	body := "event: first\n" +
+		"data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hel\"}}]}\n\n" +
+		"event: second\n" +
+		"data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"lo\"}}]}\n\n" +
+		"data: [DONE]\n\n"
+	branches := sseChatStream(t, body).Tee(2)
+
+	_, err := branches[1].Collect()
+	checks.NoError(t, err, "Collect error")
+	_, err = branches[0].Recv()
+	checks.NoError(t, err, "Recv error")
+	if event := branches[0].Event(); event.Name != "first" {
+		t.Errorf("branch 0 event = %+v, want first", event)
+	}
+	if stats := branches[0].Stats(); stats.Events != 2 {
+		t.Errorf("stats = %+v, want 2 events", stats)
+	}
+}
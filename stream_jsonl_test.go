@@ -0,0 +1,103 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+const jsonLinesChatStream = `{"id":"1","choices":[{"index":0,"delta":{"role":"assistant","content":"Hel"}}]}
+
+{"id":"1","choices":[{"index":0,"delta":{"content":"lo"}}]}
+{"id":"1","choices":[{"index":0,"delta":{},"finish_reason":"stop"}]}`
+
+func streamJSONLines(t *testing.T, contentType, body string, format openai.StreamFormat) (string, error) {
+	t.Helper()
+	client, server := setupOpenAITestServerWithConfig(t, func(config *openai.ClientConfig) {
+		config.StreamFormat = format
+	})
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		if contentType != "" {
+			w.Header().Set("Content-Type", contentType)
+		}
+		fmt.Fprint(w, body)
+	})
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), openai.ChatCompletionRequest{
+		Model:    openai.GPT4oMini,
+		Messages: []openai.ChatCompletionMessage{openai.UserMessage("Hi")},
+	})
+	checks.NoError(t, err)
+	defer stream.Close()
+	var content strings.Builder
+	for {
+		response, recvErr := stream.Recv()
+		if errors.Is(recvErr, io.EOF) {
+			return content.String(), nil
+		}
+		if recvErr != nil {
+			return content.String(), recvErr
+		}
+		content.WriteString(response.Choices[0].Delta.Content)
+	}
+}
+
+func TestChatCompletionStreamJSONLines(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		format      openai.StreamFormat
+	}{
+		{"content type", "application/x-ndjson", openai.StreamFormatAuto},
+		{"detected", "application/json", openai.StreamFormatAuto},
+		{"configured", "text/plain", openai.StreamFormatJSONLines},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := streamJSONLines(t, tt.contentType, jsonLinesChatStream+"\n", tt.format)
+			checks.NoError(t, err)
+			if content != "Hello" {
+				t.Errorf("content = %q", content)
+			}
+		})
+	}
+}
+
+func TestChatCompletionStreamJSONLinesDone(t *testing.T) {
+	content, err := streamJSONLines(t, "application/jsonl",
+		jsonLinesChatStream+"\n[DONE]\n"+`{"choices":[{"delta":{"content":"ignored"}}]}`, openai.StreamFormatAuto)
+	checks.NoError(t, err)
+	if content != "Hello" {
+		t.Errorf("content = %q", content)
+	}
+}
+
+func TestChatCompletionStreamJSONLinesError(t *testing.T) {
+	body := `{"choices":[{"index":0,"delta":{"content":"Hel"}}]}
+{"error":{"message":"overloaded","type":"server_error"}}
+`
+	content, err := streamJSONLines(t, "application/x-ndjson", body, openai.StreamFormatAuto)
+	var apiErr *openai.APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != "overloaded" {
+		t.Errorf("err = %v", err)
+	}
+	if content != "Hel" {
+		t.Errorf("content = %q", content)
+	}
+}
+
+func TestChatCompletionStreamSSEStillDetected(t *testing.T) {
+	body := "data: " + `{"choices":[{"index":0,"delta":{"content":"Hi"}}]}` + "\n\ndata: [DONE]\n\n"
+	content, err := streamJSONLines(t, "", body, openai.StreamFormatAuto)
+	checks.NoError(t, err)
+	if content != "Hi" {
+		t.Errorf("content = %q", content)
+	}
+}
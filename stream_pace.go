@@ -0,0 +1,191 @@
+package openai
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const defaultPaceBufferSize = 256
+
+// StreamPaceOptions configures Stream.Pace.
+type StreamPaceOptions struct {
+	// Rate is the maximum number of events released per second. Chat
+	// completion chunks mostly carry a single token, so for chat streams
+	// this is roughly a number of tokens per second.
+	Rate float64
+	// BufferSize is the number of events read ahead of the consumer,
+	// 256 by default. Reading from the API pauses once the buffer is full.
+	BufferSize int
+}
+
+// Pace releases the events received from the stream afterwards at no more
+// than opts.Rate per second, for downstream clients that are rate limited
+// or to smooth the display of bursty streams. Events are read ahead in the
+// background into a bounded buffer, so the API connection is not held up by
+// a slow rate; errors, including io.EOF, are delivered as soon as the events
+// before them have been released.
+//
+// Closing the stream stops reading from the API and releases the events
+// already buffered without pacing, so a reader can drain the tail of the
+// reply quickly. Reads then fail with ErrStreamClosed, or io.EOF when the
+// whole stream had been buffered.
+//
+// Pace does nothing when opts.Rate is not positive. Raw reads are not
+// available on a paced stream. Stats are measured as events arrive from the
+// API, so they include the events read ahead, while Event describes the last
+// event released.
+func (s *Stream[T]) Pace(opts StreamPaceOptions) {
+	if opts.Rate <= 0 {
+		return
+	}
+	bufferSize := opts.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = defaultPaceBufferSize
+	}
+	s.reader = &pacedReader[T]{
+		reader:   s.reader,
+		interval: time.Duration(float64(time.Second) / opts.Rate),
+		events:   make(chan pacedEvent[T], bufferSize),
+		done:     make(chan struct{}),
+	}
+}
+
+type pacedEvent[T any] struct {
+	event T
+	err   error
+	// sse is the Event of the underlying reader after reading event.
+	sse StreamEvent
+}
+
+type pacedReader[T any] struct {
+	reader   StreamReader[T]
+	interval time.Duration
+	events   chan pacedEvent[T]
+	done     chan struct{}
+
+	start     sync.Once
+	closeOnce sync.Once
+	next      time.Time
+	err       error
+	sse       StreamEvent
+}
+
+// readAhead reads the underlying stream into the buffer until it ends or
+// the paced reader is closed.
+func (r *pacedReader[T]) readAhead() {
+	for {
+		event, err := r.reader.Recv()
+		select {
+		case <-r.done:
+			return
+		default:
+		}
+		item := pacedEvent[T]{event: event, err: err}
+		if e, ok := r.reader.(interface{ Event() StreamEvent }); ok {
+			item.sse = e.Event()
+		}
+		select {
+		case r.events <- item:
+		case <-r.done:
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (r *pacedReader[T]) Recv() (event T, err error) {
+	if r.err != nil {
+		err = r.err
+		return
+	}
+	if !r.isClosed() {
+		r.start.Do(func() { go r.readAhead() })
+	}
+
+	var item pacedEvent[T]
+	closed := false
+	select {
+	case item = <-r.events:
+	case <-r.done:
+		closed = true
+	}
+	if closed {
+		// Drain the buffer without pacing.
+		select {
+		case item = <-r.events:
+		default:
+			r.err = ErrStreamClosed
+			err = r.err
+			return
+		}
+	}
+	if item.err != nil {
+		r.err = item.err
+		if r.isClosed() && !errors.Is(item.err, io.EOF) {
+			// The error is most likely caused by closing the connection.
+			r.err = ErrStreamClosed
+		}
+		err = r.err
+		return
+	}
+	event = item.event
+	r.sse = item.sse
+	if closed {
+		return
+	}
+
+	now := time.Now()
+	if wait := r.next.Sub(now); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-r.done:
+		}
+	} else {
+		r.next = now
+	}
+	r.next = r.next.Add(r.interval)
+	return
+}
+
+func (r *pacedReader[T]) isClosed() bool {
+	select {
+	case <-r.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (r *pacedReader[T]) Close() error {
+	var err error
+	r.closeOnce.Do(func() {
+		close(r.done)
+		err = r.reader.Close()
+	})
+	return err
+}
+
+func (r *pacedReader[T]) Header() http.Header {
+	if h, ok := r.reader.(interface{ Header() http.Header }); ok {
+		return h.Header()
+	}
+	return http.Header{}
+}
+
+func (r *pacedReader[T]) Stats() StreamStats {
+	if s, ok := r.reader.(interface{ Stats() StreamStats }); ok {
+		return s.Stats()
+	}
+	return StreamStats{}
+}
+
+func (r *pacedReader[T]) Event() StreamEvent {
+	return r.sse
+}
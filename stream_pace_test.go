@@ -0,0 +1,133 @@
+package openai_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+// countingStreamReader returns events forever, counting how many were read.
+type countingStreamReader struct {
+	mu    sync.Mutex
+	reads int
+}
+
+func (r *countingStreamReader) Recv() (openai.ChatCompletionStreamResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reads++
+	return openai.ChatCompletionStreamResponse{}, nil
+}
+
+func (r *countingStreamReader) Close() error {
+	return nil
+}
+
+func (r *countingStreamReader) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.reads
+}
+
+func TestStreamPace(t *testing.T) {
+	stream := openai.NewChatCompletionStream(&mockStreamReader{responses: interleavedChunks()})
+	stream.Pace(openai.StreamPaceOptions{Rate: 200})
+
+	start := time.Now()
+	content, sawUsage := readContent(t, stream)
+	elapsed := time.Since(start)
+
+	if content != "axyb!" || !sawUsage {
+		t.Errorf("paced stream delivered %q (usage %v)", content, sawUsage)
+	}
+	// Six events at 200 per second: the first is released immediately and
+	// each following one 5ms later.
+	if elapsed < 25*time.Millisecond {
+		t.Errorf("six events released in %v, want at least 25ms", elapsed)
+	}
+}
+
+func TestStreamPaceError(t *testing.T) {
+	errBroken := errors.New("connection reset")
+	stream := openai.NewChatCompletionStream(&errorAfterStreamReader{
+		mockStreamReader: mockStreamReader{responses: interleavedChunks()[:2]},
+		err:              errBroken,
+	})
+	stream.Pace(openai.StreamPaceOptions{Rate: 1000})
+
+	events, err := stream.Collect()
+	checks.ErrorIs(t, err, errBroken, "stream error not delivered")
+	if len(events) != 2 {
+		t.Errorf("got %d events before the error, want 2", len(events))
+	}
+	_, err = stream.Recv()
+	checks.ErrorIs(t, err, errBroken, "stream error not repeated")
+}
+
+func TestStreamPaceBufferAndClose(t *testing.T) {
+	reader := &countingStreamReader{}
+	stream := openai.NewChatCompletionStream(reader)
+	stream.Pace(openai.StreamPaceOptions{Rate: 0.5, BufferSize: 3})
+
+	_, err := stream.Recv()
+	checks.NoError(t, err, "first Recv error")
+	time.Sleep(20 * time.Millisecond)
+	// The delivered event, the buffered ones and the one waiting to be
+	// buffered.
+	if reads := reader.count(); reads > 5 {
+		t.Errorf("read %d events ahead with a buffer of 3", reads)
+	}
+
+	received := make(chan error, 1)
+	go func() {
+		_, recvErr := stream.Recv()
+		received <- recvErr
+	}()
+	time.Sleep(10 * time.Millisecond)
+	checks.NoError(t, stream.Close(), "Close error")
+
+	select {
+	case err = <-received:
+		checks.NoError(t, err, "pending Recv after Close")
+	case <-time.After(time.Second):
+		t.Fatal("Close did not release the pending Recv")
+	}
+
+	// The buffered events are drained without pacing, then the truncation
+	// is reported.
+	start := time.Now()
+	drained := 0
+	for ; drained < 10; drained++ {
+		if _, err = stream.Recv(); err != nil {
+			break
+		}
+	}
+	checks.ErrorIs(t, err, openai.ErrStreamClosed, "Recv after draining a closed stream")
+	if drained > 4 || time.Since(start) > time.Second {
+		t.Errorf("drained %d events in %v", drained, time.Since(start))
+	}
+}
+
+func TestStreamPaceCloseDrainsBufferedTail(t *testing.T) {
+	stream := openai.NewChatCompletionStream(&mockStreamReader{responses: interleavedChunks()})
+	stream.Pace(openai.StreamPaceOptions{Rate: 1})
+
+	_, err := stream.Recv()
+	checks.NoError(t, err, "first Recv error")
+	time.Sleep(20 * time.Millisecond)
+	checks.NoError(t, stream.Close(), "Close error")
+
+	start := time.Now()
+	events, err := stream.Collect()
+	checks.NoError(t, err, "Collect after Close")
+	if len(events) != len(interleavedChunks())-1 {
+		t.Errorf("drained %d events, want %d", len(events), len(interleavedChunks())-1)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("buffered tail released in %v, want no pacing", elapsed)
+	}
+}
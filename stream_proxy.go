@@ -0,0 +1,103 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+var ErrStreamingUnsupported = errors.New("response writer does not support flushing")
+
+// ProxyStream relays a stream to an HTTP client as server-sent events, in the
+// same format the OpenAI API uses: each event is written as a data: line and
+// flushed immediately, and the stream is terminated with data: [DONE].
+//
+// If the upstream stream fails, the error is forwarded as an error event
+// before returning it. If writing to the client fails, typically because it
+// disconnected, the upstream stream is closed and the write error returned.
+// Likewise, when ctx is done, such as the context of the incoming request
+// once the client is gone, the upstream stream is closed and ctx.Err()
+// returned.
+//
+//	stream, err := client.CreateChatCompletionStream(r.Context(), req)
+//	...
+//	err = openai.ProxyStream(r.Context(), w, stream.Stream)
+func ProxyStream[T any](ctx context.Context, w http.ResponseWriter, stream *Stream[T]) error {
+	var closeOnce sync.Once
+	closeStream := func() {
+		closeOnce.Do(func() { stream.Close() })
+	}
+	defer closeStream()
+
+	// Close the stream when ctx is done, which unblocks a pending Recv.
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			closeStream()
+		case <-stop:
+		}
+	}()
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return ErrStreamingUnsupported
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		event, err := stream.Recv()
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if errors.Is(err, io.EOF) {
+			return writeSSEData(w, flusher, []byte("[DONE]"))
+		}
+		if err != nil {
+			if writeErr := writeSSEError(w, flusher, err); writeErr != nil {
+				return writeErr
+			}
+			return err
+		}
+
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		if err = writeSSEData(w, flusher, data); err != nil {
+			return err
+		}
+	}
+}
+
+func writeSSEData(w io.Writer, flusher http.Flusher, data []byte) error {
+	if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+		return err
+	}
+	flusher.Flush()
+	return nil
+}
+
+func writeSSEError(w io.Writer, flusher http.Flusher, err error) error {
+	apiErr := &APIError{}
+	if !errors.As(err, &apiErr) {
+		apiErr = &APIError{Message: err.Error(), Type: "proxy_error"}
+	}
+	data, marshalErr := json.Marshal(ErrorResponse{Error: apiErr})
+	if marshalErr != nil {
+		return marshalErr
+	}
+	return writeSSEData(w, flusher, data)
+}
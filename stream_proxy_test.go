@@ -0,0 +1,118 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestProxyStream(t *testing.T) {
+	stream := openai.NewChatCompletionStream(&mockStreamReader{
+		responses: []openai.ChatCompletionStreamResponse{contentChunk("Hel"), contentChunk("lo")},
+	})
+	rec := httptest.NewRecorder()
+	checks.NoError(t, openai.ProxyStream(context.Background(), rec, stream.Stream), "ProxyStream returned error")
+
+	if rec.Header().Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("unexpected content type %q", rec.Header().Get("Content-Type"))
+	}
+	events := strings.Split(strings.TrimSuffix(rec.Body.String(), "\n\n"), "\n\n")
+	if len(events) != 3 || events[2] != "data: [DONE]" {
+		t.Fatalf("unexpected events: %q", events)
+	}
+	if !strings.HasPrefix(events[0], "data: {") || !strings.Contains(events[1], `"content":"lo"`) {
+		t.Fatalf("unexpected events: %q", events)
+	}
+}
+
+func TestProxyStreamForwardsUpstreamError(t *testing.T) {
+	errBroken := errors.New("upstream broke")
+	stream := openai.NewStream[openai.ChatCompletionStreamResponse](&errorAfterStreamReader{err: errBroken})
+	rec := httptest.NewRecorder()
+	err := openai.ProxyStream(context.Background(), rec, stream)
+	checks.ErrorIs(t, err, errBroken, "ProxyStream should return upstream error")
+	if !strings.Contains(rec.Body.String(), `data: {"error":{"message":"upstream broke"`) {
+		t.Fatalf("error event not forwarded: %q", rec.Body.String())
+	}
+}
+
+type disconnectedWriter struct {
+	*httptest.ResponseRecorder
+}
+
+var errClientGone = errors.New("client disconnected")
+
+func (disconnectedWriter) Write([]byte) (int, error) {
+	return 0, errClientGone
+}
+
+func TestProxyStreamClientDisconnect(t *testing.T) {
+	reader := &closeCountingStreamReader{mockStreamReader: mockStreamReader{
+		responses: []openai.ChatCompletionStreamResponse{contentChunk("Hel")},
+	}}
+	stream := openai.NewStream[openai.ChatCompletionStreamResponse](reader)
+	err := openai.ProxyStream(context.Background(), disconnectedWriter{httptest.NewRecorder()}, stream)
+	checks.ErrorIs(t, err, errClientGone, "ProxyStream should return write error")
+	if reader.closed != 1 {
+		t.Fatal("upstream stream should be closed on client disconnect")
+	}
+}
+
+// blockingStreamReader blocks in Recv until it is closed, like a stream
+// waiting for the next event from the API.
+type blockingStreamReader struct {
+	receiving chan struct{}
+	closed    chan struct{}
+}
+
+func (r *blockingStreamReader) Recv() (openai.ChatCompletionStreamResponse, error) {
+	close(r.receiving)
+	<-r.closed
+	return openai.ChatCompletionStreamResponse{}, errors.New("read on closed body")
+}
+
+func (r *blockingStreamReader) Close() error {
+	close(r.closed)
+	return nil
+}
+
+func TestProxyStreamContextCanceled(t *testing.T) {
+	reader := &blockingStreamReader{receiving: make(chan struct{}), closed: make(chan struct{})}
+	stream := openai.NewStream[openai.ChatCompletionStreamResponse](reader)
+	ctx, cancel := context.WithCancel(context.Background())
+	rec := httptest.NewRecorder()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- openai.ProxyStream(ctx, rec, stream)
+	}()
+	<-reader.receiving
+	cancel()
+
+	select {
+	case err := <-done:
+		checks.ErrorIs(t, err, context.Canceled, "ProxyStream should return the context error")
+	case <-time.After(time.Second):
+		t.Fatal("ProxyStream did not return after the context was canceled")
+	}
+	if strings.Contains(rec.Body.String(), "error") {
+		t.Errorf("error event written after the context was canceled: %q", rec.Body.String())
+	}
+}
+
+type plainWriter struct {
+	http.ResponseWriter
+}
+
+func TestProxyStreamRequiresFlusher(t *testing.T) {
+	stream := openai.NewStream[openai.ChatCompletionStreamResponse](&mockStreamReader{})
+	err := openai.ProxyStream(context.Background(), plainWriter{httptest.NewRecorder()}, stream)
+	checks.ErrorIs(t, err, openai.ErrStreamingUnsupported, "ProxyStream should require a flusher")
+}
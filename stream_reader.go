@@ -3,8 +3,11 @@ package openai
 import (
 	"bufio"
 	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"regexp"
 
@@ -12,30 +15,72 @@ import (
 )
 
 var (
-	headerData  = regexp.MustCompile(`^data:\s*`)
-	errorPrefix = regexp.MustCompile(`^data:\s*{"error":`)
+	dataPrefix = []byte("data:")
+	// jsonErrorObject matches the error objects of JSON lines streams.
+	jsonErrorObject = regexp.MustCompile(`^{\s*"error"\s*:`)
 )
 
+// StreamFormat is the wire format of streamed responses.
+type StreamFormat string
+
+const (
+	// StreamFormatAuto detects the format from the Content-Type of the
+	// response or, when it is missing or unknown, from its first line.
+	StreamFormatAuto StreamFormat = ""
+	// StreamFormatSSE is the server-sent events format of the OpenAI API.
+	StreamFormatSSE StreamFormat = "sse"
+	// StreamFormatJSONLines is newline-delimited JSON, one event object per
+	// line, as streamed by some OpenAI-compatible servers.
+	StreamFormatJSONLines StreamFormat = "jsonl"
+)
+
+// streamFormatOf returns the format announced by a Content-Type, or
+// StreamFormatAuto when it is unknown.
+func streamFormatOf(contentType string) StreamFormat {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return StreamFormatAuto
+	}
+	switch mediaType {
+	case "text/event-stream":
+		return StreamFormatSSE
+	case "application/x-ndjson", "application/ndjson", "application/jsonl",
+		"application/jsonlines", "application/x-jsonlines":
+		return StreamFormatJSONLines
+	}
+	return StreamFormatAuto
+}
+
 var _ ChatStreamReader = (*streamReader[ChatCompletionStreamResponse])(nil)
 
 type streamable interface {
-	ChatCompletionStreamResponse | CompletionResponse
+	ChatCompletionStreamResponse | CompletionResponse | ResponseStreamEvent
 }
 
 type streamReader[T streamable] struct {
 	emptyMessagesLimit uint
 	isFinished         bool
+	format             StreamFormat
 
 	reader         *bufio.Reader
 	response       *http.Response
 	errAccumulator utils.ErrorAccumulator
 	unmarshaler    utils.Unmarshaler
+	// onFirstEvent, when set, is called when the first event is received.
+	onFirstEvent func()
+	// eventEnded records that the last event was dispatched on its blank
+	// line, which counts as an empty message of the next read, as it did
+	// when events were dispatched on their data line.
+	eventEnded bool
+
+	sseParser
+	streamTimer
 
 	httpHeader
 }
 
 func (stream *streamReader[T]) Recv() (response T, err error) {
-	rawLine, err := stream.RecvRaw()
+	rawLine, err := stream.RecvRawNoCopy()
 	if err != nil {
 		return
 	}
@@ -47,7 +92,39 @@ func (stream *streamReader[T]) Recv() (response T, err error) {
 	return response, nil
 }
 
+// RecvInto decodes the next event into response, reusing the memory it
+// holds, such as the backing array of its choices.
+func (stream *streamReader[T]) RecvInto(response *T) error {
+	rawLine, err := stream.RecvRawNoCopy()
+	if err != nil {
+		return err
+	}
+	resetStreamResponse(response)
+	return stream.unmarshaler.Unmarshal(rawLine, response)
+}
+
+// resetStreamResponse clears a response before the next event is decoded
+// into it, keeping the memory that can be reused.
+func resetStreamResponse[T any](response *T) {
+	if r, ok := any(response).(interface{ reset() }); ok {
+		r.reset()
+		return
+	}
+	var zero T
+	*response = zero
+}
+
 func (stream *streamReader[T]) RecvRaw() ([]byte, error) {
+	rawLine, err := stream.RecvRawNoCopy()
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), rawLine...), nil
+}
+
+// RecvRawNoCopy is like RecvRaw, but the payload it returns is only valid
+// until the next read from the stream.
+func (stream *streamReader[T]) RecvRawNoCopy() ([]byte, error) {
 	if stream.isFinished {
 		return nil, io.EOF
 	}
@@ -59,47 +136,154 @@ func (stream *streamReader[T]) RecvRaw() ([]byte, error) {
 func (stream *streamReader[T]) processLines() ([]byte, error) {
 	var (
 		emptyMessagesCount uint
-		hasErrorPrefix     bool
+		hasData            bool
 	)
+	stream.data = stream.data[:0]
+	if stream.eventEnded {
+		emptyMessagesCount = 1
+		stream.eventEnded = false
+	}
+
+	if stream.format == StreamFormatJSONLines {
+		return stream.processJSONLines(nil)
+	}
 
 	for {
-		rawLine, readErr := stream.reader.ReadBytes('\n')
-		if readErr != nil || hasErrorPrefix {
+		rawLine, readErr := stream.readLine(stream.reader)
+		line := bytes.TrimSpace(rawLine)
+		if stream.format == StreamFormatAuto && len(line) > 0 {
+			// A complete JSON object on the first line starts a JSON
+			// lines stream; an error body spread over several lines
+			// stays with the SSE parser.
+			stream.format = StreamFormatSSE
+			if line[0] == '{' && json.Valid(line) {
+				stream.format = StreamFormatJSONLines
+				return stream.processJSONLines(line)
+			}
+		}
+
+		field, value := parseSSEField(line)
+		switch {
+		case len(line) == 0:
+			// A blank line ends the event being read, which is only
+			// dispatched then, even when its data is already valid JSON.
+			if hasData {
+				stream.eventEnded = true
+				return stream.dispatch(stream.data)
+			}
+			if readErr != nil {
+				break
+			}
+			if err := stream.skipLine(line, &emptyMessagesCount); err != nil {
+				return nil, err
+			}
+		case string(field) == "data":
+			if hasData {
+				stream.data = append(stream.data, '\n')
+			}
+			stream.data = append(stream.data, value...)
+			hasData = true
+		case len(field) == 0, string(field) == "event", string(field) == "id", string(field) == "retry":
+			// Comments and fields without a payload are skipped rather
+			// than mistaken for the start of an error.
+			stream.setField(field, value)
+			emptyMessagesCount++
+			if emptyMessagesCount > stream.emptyMessagesLimit {
+				return nil, ErrTooManyEmptyStreamMessages
+			}
+		default:
+			if err := stream.skipLine(line, &emptyMessagesCount); err != nil {
+				return nil, err
+			}
+		}
+
+		if readErr != nil {
+			// Data left at the end of the stream is returned, but data cut
+			// off by a failed read is incomplete.
+			if hasData && errors.Is(readErr, io.EOF) {
+				return stream.dispatch(stream.data)
+			}
+			stream.finish()
 			respErr := stream.unmarshalError()
 			if respErr != nil {
 				return nil, fmt.Errorf("error, %w", respErr.Error)
 			}
 			return nil, readErr
 		}
+	}
+}
+
+// skipLine accumulates a line that is not part of an event, which may be part
+// of an error body sent without the SSE framing.
+func (stream *streamReader[T]) skipLine(line []byte, emptyMessagesCount *uint) error {
+	if err := stream.errAccumulator.Write(line); err != nil {
+		return err
+	}
+	*emptyMessagesCount++
+	if *emptyMessagesCount > stream.emptyMessagesLimit {
+		return ErrTooManyEmptyStreamMessages
+	}
+	return nil
+}
 
-		noSpaceLine := bytes.TrimSpace(rawLine)
-		if errorPrefix.Match(noSpaceLine) {
-			hasErrorPrefix = true
+// processJSONLines returns the next event of a JSON lines stream, starting
+// with line when it is not nil.
+func (stream *streamReader[T]) processJSONLines(line []byte) ([]byte, error) {
+	var emptyMessagesCount uint
+	for {
+		var readErr error
+		if line == nil {
+			line, readErr = stream.readLine(stream.reader)
 		}
-		if !headerData.Match(noSpaceLine) || hasErrorPrefix {
-			if hasErrorPrefix {
-				noSpaceLine = headerData.ReplaceAll(noSpaceLine, nil)
-			}
-			writeErr := stream.errAccumulator.Write(noSpaceLine)
-			if writeErr != nil {
-				return nil, writeErr
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			if readErr != nil {
+				stream.finish()
+				return nil, readErr
 			}
+			line = nil
 			emptyMessagesCount++
 			if emptyMessagesCount > stream.emptyMessagesLimit {
 				return nil, ErrTooManyEmptyStreamMessages
 			}
-
 			continue
 		}
 
-		noPrefixLine := headerData.ReplaceAll(noSpaceLine, nil)
-		if string(noPrefixLine) == "[DONE]" {
+		// Tolerate servers prefixing lines like SSE data.
+		if bytes.HasPrefix(line, dataPrefix) {
+			line = bytes.TrimLeft(line[len(dataPrefix):], " \t")
+		}
+		return stream.dispatch(line)
+	}
+}
+
+// dispatch returns the data of an event, or the end of the stream or the
+// error it carries.
+func (stream *streamReader[T]) dispatch(data []byte) ([]byte, error) {
+	data = bytes.TrimSpace(data)
+	if stream.format != StreamFormatJSONLines {
+		stream.dispatched()
+	}
+	if string(data) == "[DONE]" {
+		stream.finish()
+		stream.isFinished = true
+		return nil, io.EOF
+	}
+	if jsonErrorObject.Match(data) {
+		var errResp ErrorResponse
+		if err := stream.unmarshaler.Unmarshal(data, &errResp); err == nil && errResp.Error != nil {
+			stream.finish()
 			stream.isFinished = true
-			return nil, io.EOF
+			return nil, fmt.Errorf("error, %w", errResp.Error)
 		}
+	}
 
-		return noPrefixLine, nil
+	stream.event()
+	if stream.onFirstEvent != nil {
+		stream.onFirstEvent()
+		stream.onFirstEvent = nil
 	}
+	return data, nil
 }
 
 func (stream *streamReader[T]) unmarshalError() (errResp *ErrorResponse) {
@@ -117,5 +301,6 @@ func (stream *streamReader[T]) unmarshalError() (errResp *ErrorResponse) {
 }
 
 func (stream *streamReader[T]) Close() error {
+	stream.finish()
 	return stream.response.Body.Close()
 }
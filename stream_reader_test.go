@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"errors"
+	"io"
 	"testing"
 
 	utils "github.com/sashabaranov/go-openai/internal"
@@ -76,3 +77,107 @@ func TestStreamReaderRecvRaw(t *testing.T) {
 		t.Fatalf("Did not return raw line: %v", string(rawLine))
 	}
 }
+
+func newTestStreamReader(body string) *streamReader[ChatCompletionStreamResponse] {
+	return &streamReader[ChatCompletionStreamResponse]{
+		emptyMessagesLimit: 10,
+		reader:             bufio.NewReader(bytes.NewReader([]byte(body))),
+		errAccumulator:     utils.NewErrorAccumulator(),
+		unmarshaler:        &utils.JSONUnmarshaler{},
+	}
+}
+
+func TestStreamReaderRecvRawCopies(t *testing.T) {
+	stream := newTestStreamReader("data: {\"id\":\"1\"}\n\ndata: {\"id\":\"2\"}\n\n")
+	first, err := stream.RecvRaw()
+	checks.NoError(t, err, "RecvRaw error")
+	_, err = stream.RecvRawNoCopy()
+	checks.NoError(t, err, "RecvRawNoCopy error")
+	if string(first) != `{"id":"1"}` {
+		t.Fatalf("RecvRaw payload was overwritten by the next read: %s", first)
+	}
+}
+
+func TestStreamReaderRecvInto(t *testing.T) {
+	stream := newTestStreamReader(
+		`data: {"id":"1","choices":[{"index":0,"delta":{"content":"Hi"}},{"index":1,"delta":{"content":"Yo"}}]}` + "\n\n" +
+			`data: {"id":"1","choices":[{"index":0,"delta":{},"finish_reason":"stop"}]}` + "\n\n" +
+			"data: [DONE]\n\n")
+
+	var chunk ChatCompletionStreamResponse
+	checks.NoError(t, stream.RecvInto(&chunk), "RecvInto error")
+	if len(chunk.Choices) != 2 || chunk.Choices[1].Delta.Content != "Yo" {
+		t.Fatalf("first chunk = %+v", chunk)
+	}
+	choices := chunk.Choices
+
+	checks.NoError(t, stream.RecvInto(&chunk), "RecvInto error")
+	if len(chunk.Choices) != 1 || chunk.Choices[0].FinishReason != FinishReasonStop {
+		t.Fatalf("second chunk = %+v", chunk)
+	}
+	if chunk.Choices[0].Delta.Content != "" {
+		t.Errorf("stale content was kept: %q", chunk.Choices[0].Delta.Content)
+	}
+	if &chunk.Choices[0] != &choices[0] {
+		t.Error("RecvInto did not reuse the choices")
+	}
+	if choices[1].Delta.Content != "" {
+		t.Error("unused choices were not cleared")
+	}
+
+	checks.ErrorIs(t, stream.RecvInto(&chunk), io.EOF, "RecvInto should return io.EOF at the end")
+}
+
+// repeatReader endlessly repeats data.
+type repeatReader struct {
+	data []byte
+	off  int
+}
+
+func (r *repeatReader) Read(p []byte) (int, error) {
+	n := copy(p, r.data[r.off:])
+	r.off = (r.off + n) % len(r.data)
+	return n, nil
+}
+
+func newBenchmarkStreamReader() *streamReader[ChatCompletionStreamResponse] {
+	//nolint:lll
+	chunk := `data: {"id":"chatcmpl-1","object":"chat.completion.chunk","created":1700000000,"model":"gpt-4o-mini","system_fingerprint":"fp_1","choices":[{"index":0,"delta":{"content":"Hello"},"finish_reason":null}]}` + "\n\n"
+	return &streamReader[ChatCompletionStreamResponse]{
+		emptyMessagesLimit: defaultEmptyMessagesLimit,
+		reader:             bufio.NewReader(&repeatReader{data: []byte(chunk)}),
+		errAccumulator:     utils.NewErrorAccumulator(),
+		unmarshaler:        &utils.JSONUnmarshaler{},
+	}
+}
+
+func BenchmarkStreamReaderRecv(b *testing.B) {
+	stream := newBenchmarkStreamReader()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := stream.Recv(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStreamReaderRecvInto(b *testing.B) {
+	stream := newBenchmarkStreamReader()
+	var chunk ChatCompletionStreamResponse
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := stream.RecvInto(&chunk); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStreamReaderRecvRawNoCopy(b *testing.B) {
+	stream := newBenchmarkStreamReader()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := stream.RecvRawNoCopy(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package openai
+
+import (
+	"sync"
+	"time"
+)
+
+// StreamStats are the latency statistics of a stream, measured by the reader
+// as events arrive from the network.
+type StreamStats struct {
+	// Events is the number of events received.
+	Events int
+	// TimeToFirstEvent runs from sending the request to receiving the first
+	// event, which for chat completions is the time to first token.
+	TimeToFirstEvent time.Duration
+	// InterArrivalTimes are the times between consecutive events.
+	InterArrivalTimes []time.Duration
+	// Duration runs from sending the request to the end of the stream, or to
+	// Close if it was closed before the end. It is zero while the stream is
+	// open.
+	Duration time.Duration
+}
+
+// MeanInterArrival returns the average time between consecutive events.
+func (s StreamStats) MeanInterArrival() time.Duration {
+	if len(s.InterArrivalTimes) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, d := range s.InterArrivalTimes {
+		total += d
+	}
+	return total / time.Duration(len(s.InterArrivalTimes))
+}
+
+// MaxInterArrival returns the longest time between consecutive events.
+func (s StreamStats) MaxInterArrival() time.Duration {
+	var longest time.Duration
+	for _, d := range s.InterArrivalTimes {
+		if d > longest {
+			longest = d
+		}
+	}
+	return longest
+}
+
+// Stats returns the latency statistics of the stream, complete once it has
+// been read to the end or closed. It is zero for streams whose reader does
+// not measure them, such as custom StreamReaders. It must not be called
+// concurrently with Recv.
+func (s *Stream[T]) Stats() StreamStats {
+	if r, ok := s.reader.(interface{ Stats() StreamStats }); ok {
+		return r.Stats()
+	}
+	return StreamStats{}
+}
+
+// streamTimer measures the statistics of a stream. It is safe for concurrent
+// use, so that wrappers reading the stream from another goroutine, such as
+// paced and teed streams, can forward Stats.
+type streamTimer struct {
+	mu    sync.Mutex
+	start time.Time
+	last  time.Time
+	stats StreamStats
+}
+
+// event records the arrival of an event.
+func (t *streamTimer) event() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	if t.stats.Events == 0 {
+		t.stats.TimeToFirstEvent = now.Sub(t.start)
+	} else {
+		t.stats.InterArrivalTimes = append(t.stats.InterArrivalTimes, now.Sub(t.last))
+	}
+	t.last = now
+	t.stats.Events++
+}
+
+// finish records the end of the stream, once.
+func (t *streamTimer) finish() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.stats.Duration == 0 && !t.start.IsZero() {
+		t.stats.Duration = time.Since(t.start)
+	}
+}
+
+func (t *streamTimer) Stats() StreamStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	stats := t.stats
+	stats.InterArrivalTimes = append([]time.Duration(nil), t.stats.InterArrivalTimes...)
+	return stats
+}
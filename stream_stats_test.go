@@ -0,0 +1,62 @@
+package openai_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestStreamStats(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher, _ := w.(http.Flusher)
+		for i := 0; i < 3; i++ {
+			time.Sleep(10 * time.Millisecond)
+			fmt.Fprintf(w, "data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"%d\"}}]}\n\n", i)
+			flusher.Flush()
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	})
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), openai.ChatCompletionRequest{
+		Model:    openai.GPT4o,
+		Messages: []openai.ChatCompletionMessage{openai.UserMessage("Hello")},
+	})
+	checks.NoError(t, err)
+	stream.AddTransform(func(*openai.ChatCompletionStreamResponse) error { return nil })
+
+	if stats := stream.Stats(); stats.Duration != 0 {
+		t.Errorf("expected no duration while the stream is open, got %v", stats.Duration)
+	}
+	_, err = stream.Collect()
+	checks.NoError(t, err)
+	stream.Close()
+
+	stats := stream.Stats()
+	if stats.Events != 3 || len(stats.InterArrivalTimes) != 2 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	if stats.TimeToFirstEvent < 10*time.Millisecond {
+		t.Errorf("time to first event = %v, want at least 10ms", stats.TimeToFirstEvent)
+	}
+	if stats.MaxInterArrival() < stats.MeanInterArrival() || stats.MeanInterArrival() <= 0 {
+		t.Errorf("unexpected inter-arrival times %v", stats.InterArrivalTimes)
+	}
+	if stats.Duration < stats.TimeToFirstEvent+stats.InterArrivalTimes[0]+stats.InterArrivalTimes[1] {
+		t.Errorf("duration %v is shorter than the events it spans", stats.Duration)
+	}
+}
+
+func TestStreamStatsCustomReader(t *testing.T) {
+	stream := openai.NewStream[openai.ChatCompletionStreamResponse](&mockStreamReader{})
+	if stats := stream.Stats(); stats.Events != 0 || stats.Duration != 0 {
+		t.Errorf("expected zero stats, got %+v", stats)
+	}
+}
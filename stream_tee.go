@@ -0,0 +1,177 @@
+package openai
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Tee splits the stream into n streams that each receive every event, for
+// example one forwarding deltas to a browser while another accumulates the
+// full message for persistence. Events are buffered per branch until read,
+// so branches may be consumed at different speeds or from different
+// goroutines. The underlying connection is closed once every branch has been
+// closed. The original stream must not be read after calling Tee.
+func (s *Stream[T]) Tee(n int) []*Stream[T] {
+	if n < 1 {
+		n = 1
+	}
+	source := newTeeSource(s.reader, n)
+	branches := make([]*Stream[T], n)
+	for i := range branches {
+		branches[i] = NewStream[T](&teeBranch[T]{source: source, index: i})
+	}
+	return branches
+}
+
+// Tee splits the chat completion stream into n streams that each receive
+// every chunk. See Stream.Tee.
+func (s *ChatCompletionStream) Tee(n int) []*ChatCompletionStream {
+	branches := s.Stream.Tee(n)
+	streams := make([]*ChatCompletionStream, len(branches))
+	for i, branch := range branches {
+		streams[i] = &ChatCompletionStream{Stream: branch}
+	}
+	return streams
+}
+
+type teeSource[T any] struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	reader StreamReader[T]
+	queues [][]teeItem[T]
+	closed []bool
+	open   int
+	err    error
+	// reading is set while a branch reads from reader, without holding mu,
+	// so that the other branches can be closed meanwhile. They wait on cond
+	// for the event instead of reading concurrently.
+	reading bool
+}
+
+// teeItem is an event buffered for a branch, with the server-sent event that
+// carried it.
+type teeItem[T any] struct {
+	event T
+	sse   StreamEvent
+}
+
+func newTeeSource[T any](reader StreamReader[T], n int) *teeSource[T] {
+	s := &teeSource[T]{
+		reader: reader,
+		queues: make([][]teeItem[T], n),
+		closed: make([]bool, n),
+		open:   n,
+	}
+	s.cond = sync.NewCond(&s.mu)
+	return s
+}
+
+func (s *teeSource[T]) recv(index int) (teeItem[T], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for {
+		if s.closed[index] {
+			return teeItem[T]{}, io.EOF
+		}
+		if len(s.queues[index]) > 0 {
+			item := s.queues[index][0]
+			s.queues[index] = s.queues[index][1:]
+			return item, nil
+		}
+		if s.err != nil {
+			return teeItem[T]{}, s.err
+		}
+		if s.reading {
+			s.cond.Wait()
+			continue
+		}
+
+		s.reading = true
+		s.mu.Unlock()
+		event, err := s.reader.Recv()
+		item := teeItem[T]{event: event}
+		if r, ok := s.reader.(interface{ Event() StreamEvent }); ok {
+			item.sse = r.Event()
+		}
+		s.mu.Lock()
+		s.reading = false
+		s.cond.Broadcast()
+		if err != nil {
+			s.err = err
+			continue
+		}
+		for i := range s.queues {
+			if !s.closed[i] {
+				s.queues[i] = append(s.queues[i], item)
+			}
+		}
+	}
+}
+
+// close closes a branch, and the reader once every branch is closed. The
+// reader is closed without holding mu, which also unblocks a pending read.
+func (s *teeSource[T]) close(index int) error {
+	s.mu.Lock()
+	if s.closed[index] {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed[index] = true
+	s.queues[index] = nil
+	s.open--
+	last := s.open == 0
+	s.cond.Broadcast()
+	s.mu.Unlock()
+
+	if last {
+		return s.reader.Close()
+	}
+	return nil
+}
+
+func (s *teeSource[T]) header() http.Header {
+	if h, ok := s.reader.(interface{ Header() http.Header }); ok {
+		return h.Header()
+	}
+	return http.Header{}
+}
+
+type teeBranch[T any] struct {
+	source *teeSource[T]
+	index  int
+	sse    StreamEvent
+}
+
+func (b *teeBranch[T]) Recv() (T, error) {
+	item, err := b.source.recv(b.index)
+	if err != nil {
+		return item.event, err
+	}
+	b.sse = item.sse
+	return item.event, nil
+}
+
+func (b *teeBranch[T]) Close() error {
+	return b.source.close(b.index)
+}
+
+func (b *teeBranch[T]) Header() http.Header {
+	return b.source.header()
+}
+
+// Stats returns the statistics of the underlying stream, shared by all the
+// branches.
+func (b *teeBranch[T]) Stats() StreamStats {
+	if s, ok := b.source.reader.(interface{ Stats() StreamStats }); ok {
+		return s.Stats()
+	}
+	return StreamStats{}
+}
+
+// Event returns the server-sent event that carried the last event of the
+// branch.
+func (b *teeBranch[T]) Event() StreamEvent {
+	return b.sse
+}
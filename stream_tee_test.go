@@ -0,0 +1,109 @@
+package openai_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+type closeCountingStreamReader struct {
+	mockStreamReader
+	closed int
+}
+
+func (r *closeCountingStreamReader) Close() error {
+	r.closed++
+	return nil
+}
+
+func TestChatCompletionStreamTee(t *testing.T) {
+	reader := &closeCountingStreamReader{mockStreamReader: mockStreamReader{
+		responses: []openai.ChatCompletionStreamResponse{contentChunk("Hel"), contentChunk("lo")},
+	}}
+	branches := openai.NewChatCompletionStream(reader).Tee(2)
+	if len(branches) != 2 {
+		t.Fatalf("expected 2 branches, got %d", len(branches))
+	}
+
+	var forwarded string
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer branches[0].Close()
+		for branches[0].Next() {
+			forwarded += branches[0].Current().Choices[0].Delta.Content
+		}
+	}()
+
+	acc := openai.NewChatCompletionAccumulator()
+	for branches[1].Next() {
+		acc.Add(branches[1].Current())
+	}
+	checks.NoError(t, branches[1].Err())
+	wg.Wait()
+
+	if forwarded != "Hello" || acc.Response().Choices[0].Message.Content != "Hello" {
+		t.Fatalf("branches diverged: %q / %q", forwarded, acc.Response().Choices[0].Message.Content)
+	}
+	if reader.closed != 0 {
+		t.Fatal("underlying stream closed before every branch was closed")
+	}
+	checks.NoError(t, branches[1].Close())
+	checks.NoError(t, branches[1].Close())
+	if reader.closed != 1 {
+		t.Fatalf("expected underlying stream to be closed once, got %d", reader.closed)
+	}
+}
+
+func TestStreamTeeClosedBranchStopsBuffering(t *testing.T) {
+	reader := &closeCountingStreamReader{mockStreamReader: mockStreamReader{
+		responses: []openai.ChatCompletionStreamResponse{{ID: "1"}, {ID: "2"}},
+	}}
+	branches := openai.NewStream[openai.ChatCompletionStreamResponse](reader).Tee(2)
+	checks.NoError(t, branches[0].Close())
+
+	chunks, err := branches[1].Collect()
+	checks.NoError(t, err)
+	if len(chunks) != 2 {
+		t.Fatalf("expected 2 chunks, got %d", len(chunks))
+	}
+	if branches[0].Next() {
+		t.Fatal("closed branch should not yield events")
+	}
+}
+
+func TestStreamTeeCloseDuringRecv(t *testing.T) {
+	reader := &blockingStreamReader{receiving: make(chan struct{}), closed: make(chan struct{})}
+	branches := openai.NewStream[openai.ChatCompletionStreamResponse](reader).Tee(2)
+
+	done := make(chan bool, 1)
+	go func() {
+		done <- branches[0].Next()
+	}()
+	<-reader.receiving
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		branches[0].Stats()
+		checks.NoError(t, branches[1].Close())
+		checks.NoError(t, branches[0].Close())
+	}()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("closing the branches blocked on the pending read")
+	}
+	select {
+	case next := <-done:
+		if next {
+			t.Error("expected the pending read to fail once the stream is closed")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("pending read not unblocked by closing the stream")
+	}
+}
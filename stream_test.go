@@ -326,6 +326,134 @@ func TestCreateCompletionStreamReturnTimeoutError(t *testing.T) {
 	}
 }
 
+func TestStreamIterator(t *testing.T) {
+	stream := openai.NewStream[openai.ChatCompletionStreamResponse](&mockStreamReader{
+		responses: []openai.ChatCompletionStreamResponse{{ID: "1"}, {ID: "2"}},
+	})
+	defer stream.Close()
+
+	var ids []string
+	for stream.Next() {
+		ids = append(ids, stream.Current().ID)
+	}
+	checks.NoError(t, stream.Err(), "Err should be nil at end of stream")
+	if len(ids) != 2 || ids[0] != "1" || ids[1] != "2" {
+		t.Fatalf("unexpected ids: %v", ids)
+	}
+	if stream.Next() {
+		t.Fatal("Next should keep returning false after the stream ended")
+	}
+}
+
+func TestStreamCollect(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/completions", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("x-ratelimit-remaining-requests", "42")
+		//nolint:lll
+		_, err := w.Write([]byte(`data: {"id":"1","choices":[{"text":"a"}]}
+
+data: {"id":"2","choices":[{"text":"b"}]}
+
+data: [DONE]
+
+`))
+		checks.NoError(t, err, "Write error")
+	})
+
+	stream, err := client.CreateCompletionStream(context.Background(), openai.CompletionRequest{
+		Prompt: "Ex falso quodlibet",
+		Model:  "text-davinci-002",
+	})
+	checks.NoError(t, err, "CreateCompletionStream returned error")
+	defer stream.Close()
+
+	if got := stream.GetRateLimitHeaders().RemainingRequests; got != 42 {
+		t.Fatalf("expected remaining requests 42, got %d", got)
+	}
+	chunks, err := stream.Collect()
+	checks.NoError(t, err, "Collect returned error")
+	if len(chunks) != 2 || chunks[1].Choices[0].Text != "b" {
+		t.Fatalf("unexpected chunks: %+v", chunks)
+	}
+}
+
+func TestCompletionStreamAccumulate(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/completions", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		//nolint:lll
+		_, err := w.Write([]byte(`data: {"id":"1","model":"gpt-3.5-turbo-instruct","choices":[{"index":0,"text":"Hel","logprobs":{"tokens":["Hel"],"token_logprobs":[-0.1]}},{"index":1,"text":"Bon"}]}
+
+data: {"id":"1","choices":[{"index":0,"text":"lo","finish_reason":"stop","logprobs":{"tokens":["lo"],"token_logprobs":[-0.2]}},{"index":1,"text":"jour","finish_reason":"length"}]}
+
+data: {"id":"1","choices":[],"usage":{"prompt_tokens":2,"completion_tokens":4,"total_tokens":6}}
+
+data: [DONE]
+
+`))
+		checks.NoError(t, err, "Write error")
+	})
+
+	stream, err := client.CreateCompletionStream(context.Background(), openai.CompletionRequest{
+		Prompt:        "Say hello",
+		Model:         openai.GPT3Dot5TurboInstruct,
+		N:             2,
+		StreamOptions: &openai.StreamOptions{IncludeUsage: true},
+	})
+	checks.NoError(t, err, "CreateCompletionStream returned error")
+	defer stream.Close()
+
+	resp, err := stream.Accumulate()
+	checks.NoError(t, err, "Accumulate returned error")
+	if len(resp.Choices) != 2 || resp.Choices[0].Text != "Hello" || resp.Choices[1].Text != "Bonjour" {
+		t.Fatalf("unexpected choices: %+v", resp.Choices)
+	}
+	if resp.Choices[0].FinishReason != "stop" || len(resp.Choices[0].LogProbs.Tokens) != 2 {
+		t.Fatalf("unexpected first choice: %+v", resp.Choices[0])
+	}
+	if resp.Usage == nil || resp.Usage.TotalTokens != 6 {
+		t.Fatalf("unexpected usage: %+v", resp.Usage)
+	}
+}
+
+func TestStreamCollectError(t *testing.T) {
+	errBroken := errors.New("broken pipe")
+	stream := openai.NewStream[openai.ChatCompletionStreamResponse](&errorAfterStreamReader{
+		mockStreamReader: mockStreamReader{responses: []openai.ChatCompletionStreamResponse{{ID: "1"}}},
+		err:              errBroken,
+	})
+	chunks, err := stream.Collect()
+	checks.ErrorIs(t, err, errBroken, "Collect should return reader error")
+	if len(chunks) != 1 {
+		t.Fatalf("expected chunks received before the error, got %d", len(chunks))
+	}
+}
+
+func TestStreamWithoutHeaderOrRawSupport(t *testing.T) {
+	stream := openai.NewStream[openai.ChatCompletionStreamResponse](&mockStreamReader{})
+	if len(stream.Header()) != 0 {
+		t.Fatal("expected empty header")
+	}
+	_, err := stream.RecvRaw()
+	checks.ErrorIs(t, err, openai.ErrStreamRawNotSupported, "RecvRaw should not be supported")
+}
+
+type errorAfterStreamReader struct {
+	mockStreamReader
+	err error
+}
+
+func (r *errorAfterStreamReader) Recv() (openai.ChatCompletionStreamResponse, error) {
+	resp, err := r.mockStreamReader.Recv()
+	if errors.Is(err, io.EOF) {
+		return resp, r.err
+	}
+	return resp, err
+}
+
 // Helper funcs.
 func compareResponses(r1, r2 openai.CompletionResponse) bool {
 	if r1.ID != r2.ID || r1.Object != r2.Object || r1.Created != r2.Created || r1.Model != r2.Model {
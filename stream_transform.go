@@ -0,0 +1,96 @@
+package openai
+
+import (
+	"errors"
+	"net/http"
+)
+
+// ErrSkipStreamEvent can be returned by a stream transform to drop the
+// current event instead of delivering it.
+var ErrSkipStreamEvent = errors.New("skip stream event")
+
+// StreamTransform modifies a stream event in place before it is delivered.
+// Returning ErrSkipStreamEvent drops the event; any other error aborts the
+// stream with that error.
+type StreamTransform[T any] func(event *T) error
+
+// AddTransform registers transforms applied, in order, to every event
+// received from the stream afterwards. Transforms run inside the reader, so
+// every consumer sees the transformed events, including streams obtained
+// through Tee, ProxyStream or a demuxer. This is intended for gateways that
+// need to redact content, rewrite the model name or strip fields centrally.
+//
+// Raw reads are not available on a transformed stream since they would
+// bypass the transforms.
+func (s *Stream[T]) AddTransform(transforms ...StreamTransform[T]) {
+	if len(transforms) == 0 {
+		return
+	}
+	s.reader = &transformReader[T]{reader: s.reader, transforms: transforms}
+}
+
+type transformReader[T any] struct {
+	reader     StreamReader[T]
+	transforms []StreamTransform[T]
+}
+
+func (r *transformReader[T]) Recv() (event T, err error) {
+	for {
+		event, err = r.reader.Recv()
+		if err != nil {
+			return
+		}
+		err = r.apply(&event)
+		if errors.Is(err, ErrSkipStreamEvent) {
+			continue
+		}
+		return
+	}
+}
+
+func (r *transformReader[T]) RecvInto(event *T) error {
+	for {
+		if err := recvInto(r.reader, event); err != nil {
+			return err
+		}
+		err := r.apply(event)
+		if errors.Is(err, ErrSkipStreamEvent) {
+			continue
+		}
+		return err
+	}
+}
+
+func (r *transformReader[T]) apply(event *T) error {
+	for _, transform := range r.transforms {
+		if err := transform(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *transformReader[T]) Close() error {
+	return r.reader.Close()
+}
+
+func (r *transformReader[T]) Stats() StreamStats {
+	if s, ok := r.reader.(interface{ Stats() StreamStats }); ok {
+		return s.Stats()
+	}
+	return StreamStats{}
+}
+
+func (r *transformReader[T]) Event() StreamEvent {
+	if e, ok := r.reader.(interface{ Event() StreamEvent }); ok {
+		return e.Event()
+	}
+	return StreamEvent{}
+}
+
+func (r *transformReader[T]) Header() http.Header {
+	if h, ok := r.reader.(interface{ Header() http.Header }); ok {
+		return h.Header()
+	}
+	return http.Header{}
+}
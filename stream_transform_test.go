@@ -0,0 +1,81 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestStreamAddTransform(t *testing.T) {
+	stream := openai.NewStream[openai.ChatCompletionStreamResponse](&mockStreamReader{
+		responses: []openai.ChatCompletionStreamResponse{contentChunk("secret"), contentChunk("hello")},
+	})
+	stream.AddTransform(
+		func(chunk *openai.ChatCompletionStreamResponse) error {
+			if chunk.Choices[0].Delta.Content == "secret" {
+				return openai.ErrSkipStreamEvent
+			}
+			return nil
+		},
+		func(chunk *openai.ChatCompletionStreamResponse) error {
+			chunk.Model = "gateway-model"
+			return nil
+		},
+	)
+
+	chunks, err := stream.Collect()
+	checks.NoError(t, err)
+	if len(chunks) != 1 || chunks[0].Model != "gateway-model" || chunks[0].Choices[0].Delta.Content != "hello" {
+		t.Fatalf("unexpected chunks: %+v", chunks)
+	}
+	_, err = stream.RecvRaw()
+	checks.ErrorIs(t, err, openai.ErrStreamRawNotSupported, "raw reads must not bypass transforms")
+}
+
+func TestStreamTransformError(t *testing.T) {
+	errRejected := errors.New("rejected")
+	stream := openai.NewStream[openai.ChatCompletionStreamResponse](&mockStreamReader{
+		responses: []openai.ChatCompletionStreamResponse{contentChunk("a")},
+	})
+	stream.AddTransform(func(*openai.ChatCompletionStreamResponse) error { return errRejected })
+	_, err := stream.Recv()
+	checks.ErrorIs(t, err, errRejected, "transform error should abort the stream")
+}
+
+func TestClientChatCompletionStreamTransforms(t *testing.T) {
+	client, server := setupOpenAITestServerWithConfig(t, func(config *openai.ClientConfig) {
+		config.ChatCompletionStreamTransforms = []openai.StreamTransform[openai.ChatCompletionStreamResponse]{
+			func(chunk *openai.ChatCompletionStreamResponse) error {
+				for i := range chunk.Choices {
+					chunk.Choices[i].Delta.ReasoningContent = ""
+					chunk.Choices[i].Delta.Content = strings.ToUpper(chunk.Choices[i].Delta.Content)
+				}
+				return nil
+			},
+		}
+	})
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		//nolint:lll
+		_, err := w.Write([]byte("data: {\"model\":\"gpt-4o\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"hi\",\"reasoning_content\":\"thinking\"}}]}\n\ndata: [DONE]\n\n"))
+		checks.NoError(t, err, "Write error")
+	})
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), openai.ChatCompletionRequest{
+		Model:    openai.GPT4o,
+		Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "Hello!"}},
+	})
+	checks.NoError(t, err, "CreateChatCompletionStream returned error")
+	defer stream.Close()
+
+	chunk, err := stream.Recv()
+	checks.NoError(t, err)
+	if chunk.Choices[0].Delta.Content != "HI" || chunk.Choices[0].Delta.ReasoningContent != "" {
+		t.Fatalf("transform not applied: %+v", chunk.Choices[0].Delta)
+	}
+}
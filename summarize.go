@@ -0,0 +1,115 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+const (
+	defaultSummarizeChunkTokens  = 2000
+	defaultSummarizeMapPrompt    = "Summarize the following excerpt of a longer document. Keep every key fact, name and number."      //nolint:lll
+	defaultSummarizeReducePrompt = "The following are summaries of consecutive parts of one document. Combine them into one summary." //nolint:lll
+)
+
+var ErrSummarizeMissingTokenizer = errors.New("summarize requires a tokenizer")
+
+// SummarizeOptions configures Summarize.
+type SummarizeOptions struct {
+	// Tokenizer is used to split the document. It is required.
+	Tokenizer Tokenizer
+	// ChunkTokens is the size of the chunks summarized in the map stage,
+	// 2000 tokens by default.
+	ChunkTokens int
+
+	// MapModel summarizes the chunks and ReduceModel combines the summaries,
+	// defaulting to MapModel.
+	MapModel    string
+	ReduceModel string
+	// MapPrompt and ReducePrompt are the system prompts of the two stages.
+	MapPrompt    string
+	ReducePrompt string
+	// MaxTokens limits the length of every summary.
+	MaxTokens int
+
+	// Parallel controls the concurrency and retries of each stage.
+	Parallel ParallelOptions
+}
+
+// Summarize summarizes a document of any length with a map-reduce strategy:
+// the document is split into chunks that are summarized concurrently, then
+// the summaries are combined, in as many rounds as needed to fit in a single
+// chunk, into a final summary.
+func (c *Client) Summarize(ctx context.Context, document string, opts SummarizeOptions) (string, error) {
+	if opts.Tokenizer == nil {
+		return "", ErrSummarizeMissingTokenizer
+	}
+	if opts.ChunkTokens <= 0 {
+		opts.ChunkTokens = defaultSummarizeChunkTokens
+	}
+	if opts.ReduceModel == "" {
+		opts.ReduceModel = opts.MapModel
+	}
+	if opts.MapPrompt == "" {
+		opts.MapPrompt = defaultSummarizeMapPrompt
+	}
+	if opts.ReducePrompt == "" {
+		opts.ReducePrompt = defaultSummarizeReducePrompt
+	}
+
+	chunks, err := SplitText(opts.Tokenizer, document, opts.ChunkTokens)
+	if err != nil {
+		return "", err
+	}
+	summaries, err := c.summarizeChunks(ctx, chunks, opts.MapModel, opts.MapPrompt, opts)
+	if err != nil {
+		return "", err
+	}
+
+	for len(summaries) > 1 {
+		combined := strings.Join(summaries, "\n\n")
+		groups, splitErr := SplitText(opts.Tokenizer, combined, opts.ChunkTokens)
+		if splitErr != nil {
+			return "", splitErr
+		}
+		if len(groups) >= len(summaries) {
+			// The summaries do not get any shorter, combine them all at once.
+			groups = []string{combined}
+		}
+		if summaries, err = c.summarizeChunks(ctx, groups, opts.ReduceModel, opts.ReducePrompt, opts); err != nil {
+			return "", err
+		}
+	}
+	if len(summaries) == 0 {
+		return "", nil
+	}
+	return summaries[0], nil
+}
+
+func (c *Client) summarizeChunks(
+	ctx context.Context,
+	chunks []string,
+	model, prompt string,
+	opts SummarizeOptions,
+) ([]string, error) {
+	requests := make([]ChatCompletionRequest, len(chunks))
+	for i, chunk := range chunks {
+		requests[i] = ChatCompletionRequest{
+			Model:               model,
+			MaxCompletionTokens: opts.MaxTokens,
+			Messages:            []ChatCompletionMessage{SystemMessage(prompt), UserMessage(chunk)},
+		}
+	}
+	responses, err := c.CreateChatCompletionsParallel(ctx, requests, opts.Parallel)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]string, len(responses))
+	for i, response := range responses {
+		if len(response.Choices) > 0 {
+			summaries[i] = strings.TrimSpace(response.Choices[0].Message.Content)
+		}
+	}
+	return summaries, nil
+}
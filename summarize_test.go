@@ -0,0 +1,69 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestSummarize(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		mapped  []string
+		reduced []string
+	)
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		var req openai.ChatCompletionRequest
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		input := req.Messages[1].Content
+
+		mu.Lock()
+		content := "final summary"
+		if req.Model == openai.GPT4oMini {
+			mapped = append(mapped, input)
+			content = "summary of " + strings.Fields(input)[0]
+		} else {
+			reduced = append(reduced, input)
+		}
+		mu.Unlock()
+		fmt.Fprintf(w, `{"choices":[{"message":{"role":"assistant","content":%q}}]}`, content)
+	})
+
+	// Paragraphs are 5 tokens long, so chunks of 15 tokens hold 3 of them.
+	var paragraphs []string
+	for i := 0; i < 10; i++ {
+		paragraphs = append(paragraphs, fmt.Sprintf("p%d alpha beta gamma delta", i))
+	}
+	summary, err := client.Summarize(context.Background(), strings.Join(paragraphs, "\n\n"), openai.SummarizeOptions{
+		Tokenizer:   fakeTokenizer,
+		ChunkTokens: 15,
+		MapModel:    openai.GPT4oMini,
+		ReduceModel: openai.GPT4o,
+	})
+	checks.NoError(t, err)
+	if summary != "final summary" {
+		t.Errorf("expected final summary, got %q", summary)
+	}
+	if len(mapped) != 4 {
+		t.Errorf("expected 4 chunks to be summarized, got %d", len(mapped))
+	}
+	want := "summary of p0\n\nsummary of p3\n\nsummary of p6\n\nsummary of p9"
+	if len(reduced) != 1 || reduced[0] != want {
+		t.Errorf("expected one reduce over the ordered summaries, got %q", reduced)
+	}
+}
+
+func TestSummarizeRequiresTokenizer(t *testing.T) {
+	client := openai.NewClient("token")
+	_, err := client.Summarize(context.Background(), "text", openai.SummarizeOptions{})
+	checks.ErrorIs(t, err, openai.ErrSummarizeMissingTokenizer)
+}
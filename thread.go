@@ -95,15 +95,24 @@ type ThreadMessage struct {
 	Metadata    map[string]any     `json:"metadata,omitempty"`
 }
 
+// ThreadAttachment attaches a file to a message and names the tools it
+// should be added to.
 type ThreadAttachment struct {
 	FileID string                 `json:"file_id"`
 	Tools  []ThreadAttachmentTool `json:"tools"`
 }
 
+// ThreadAttachmentTool names the tool an attachment is added to: Type is
+// either AssistantToolTypeCodeInterpreter or AssistantToolTypeFileSearch.
 type ThreadAttachmentTool struct {
 	Type string `json:"type"`
 }
 
+// NewThreadAttachmentTool returns the attachment tool of toolType.
+func NewThreadAttachmentTool(toolType AssistantToolType) ThreadAttachmentTool {
+	return ThreadAttachmentTool{Type: string(toolType)}
+}
+
 type ThreadDeleteResponse struct {
 	ID      string `json:"id"`
 	Object  string `json:"object"`
@@ -114,8 +123,11 @@ type ThreadDeleteResponse struct {
 
 // CreateThread creates a new thread.
 func (c *Client) CreateThread(ctx context.Context, request ThreadRequest) (response Thread, err error) {
-	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(threadsSuffix), withBody(request),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+	if err = validateMetadata(request.Metadata); err != nil {
+		return
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(threadsSuffix), withBody(request))
 	if err != nil {
 		return
 	}
@@ -127,8 +139,7 @@ func (c *Client) CreateThread(ctx context.Context, request ThreadRequest) (respo
 // RetrieveThread retrieves a thread.
 func (c *Client) RetrieveThread(ctx context.Context, threadID string) (response Thread, err error) {
 	urlSuffix := threadsSuffix + "/" + threadID
-	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
 	if err != nil {
 		return
 	}
@@ -143,9 +154,12 @@ func (c *Client) ModifyThread(
 	threadID string,
 	request ModifyThreadRequest,
 ) (response Thread, err error) {
+	if err = validateMetadata(request.Metadata); err != nil {
+		return
+	}
+
 	urlSuffix := threadsSuffix + "/" + threadID
-	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix), withBody(request),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix), withBody(request))
 	if err != nil {
 		return
 	}
@@ -160,8 +174,7 @@ func (c *Client) DeleteThread(
 	threadID string,
 ) (response ThreadDeleteResponse, err error) {
 	urlSuffix := threadsSuffix + "/" + threadID
-	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL(urlSuffix))
 	if err != nil {
 		return
 	}
@@ -0,0 +1,119 @@
+package openai
+
+import "strings"
+
+// Tokenizer converts text into the token IDs of a model's vocabulary. This
+// package does not ship BPE vocabularies; wrap a tokenizer library such as a
+// tiktoken port to implement it.
+type Tokenizer interface {
+	Encode(text string) ([]int, error)
+}
+
+// TokenizerFunc adapts a function to the Tokenizer interface.
+type TokenizerFunc func(text string) ([]int, error)
+
+func (f TokenizerFunc) Encode(text string) ([]int, error) {
+	return f(text)
+}
+
+// Overheads of the chat message format, as documented in the OpenAI cookbook
+// for the gpt-3.5-turbo and gpt-4 model families.
+const (
+	chatTokensPerMessage   = 3
+	chatTokensPerName      = 1
+	chatTokensReplyPriming = 3
+)
+
+// CountMessageTokens estimates the number of prompt tokens the messages use.
+// Text content, names and tool calls are counted; images, audio and files are
+// not, so the result is a lower bound for multimodal messages.
+func CountMessageTokens(tokenizer Tokenizer, messages []ChatCompletionMessage) (int, error) {
+	total := chatTokensReplyPriming
+	for _, message := range messages {
+		total += chatTokensPerMessage
+		texts := []string{message.Role, message.Content, message.ToolCallID}
+		for _, part := range message.MultiContent {
+			texts = append(texts, part.Text)
+		}
+		if message.FunctionCall != nil {
+			texts = append(texts, message.FunctionCall.Name, message.FunctionCall.Arguments)
+		}
+		for _, call := range message.ToolCalls {
+			texts = append(texts, call.Function.Name, call.Function.Arguments)
+		}
+		if message.Name != "" {
+			total += chatTokensPerName
+			texts = append(texts, message.Name)
+		}
+
+		for _, text := range texts {
+			if text == "" {
+				continue
+			}
+			tokens, err := tokenizer.Encode(text)
+			if err != nil {
+				return 0, err
+			}
+			total += len(tokens)
+		}
+	}
+	return total, nil
+}
+
+// SplitText splits text into chunks of at most maxTokens tokens, breaking
+// between paragraphs where possible and between words otherwise. Chunk sizes
+// are estimated by summing the token counts of their pieces, which can be
+// off by a few tokens at the joins.
+func SplitText(tokenizer Tokenizer, text string, maxTokens int) ([]string, error) {
+	var (
+		chunks  []string
+		current []string
+		size    int
+		sep     string
+	)
+	flush := func() {
+		if len(current) > 0 {
+			chunks = append(chunks, strings.Join(current, sep))
+		}
+		current, size = nil, 0
+	}
+	add := func(piece string, pieceSep string) error {
+		tokens, err := tokenizer.Encode(piece)
+		if err != nil {
+			return err
+		}
+		if sep != pieceSep || (size+len(tokens) > maxTokens && len(current) > 0) {
+			flush()
+		}
+		sep = pieceSep
+		current = append(current, piece)
+		size += len(tokens)
+		return nil
+	}
+
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+		tokens, err := tokenizer.Encode(paragraph)
+		if err != nil {
+			return nil, err
+		}
+		if len(tokens) <= maxTokens {
+			if err = add(paragraph, "\n\n"); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		flush()
+		for _, word := range strings.Fields(paragraph) {
+			if err = add(word, " "); err != nil {
+				return nil, err
+			}
+		}
+		flush()
+	}
+	flush()
+	return chunks, nil
+}
@@ -0,0 +1,27 @@
+package openai_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestSplitText(t *testing.T) {
+	text := "one two three\n\nfour five\n\n\n\nsix seven eight nine ten eleven\n\ntwelve"
+	chunks, err := openai.SplitText(fakeTokenizer, text, 5)
+	checks.NoError(t, err)
+	want := []string{
+		"one two three\n\nfour five",
+		"six seven eight nine ten",
+		"eleven",
+		"twelve",
+	}
+	if !reflect.DeepEqual(chunks, want) {
+		t.Errorf("expected %q, got %q", want, chunks)
+	}
+
+	_, err = openai.SplitText(fakeTokenizer, "bad\x00text", 5)
+	checks.HasError(t, err)
+}
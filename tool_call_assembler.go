@@ -0,0 +1,138 @@
+package openai
+
+import (
+	"encoding/json"
+	"fmt"
+
+	utils "github.com/sashabaranov/go-openai/internal"
+)
+
+// ToolCallAssembler rebuilds tool calls from streamed chat completion deltas.
+// Fragments are grouped by tool call index (or ID for providers that omit the
+// index), and the arguments received so far can be inspected at any time
+// with a lenient parser, so a UI can render a tool invocation before the
+// model has finished generating it.
+//
+//	assembler := openai.NewToolCallAssembler()
+//	for stream.Next() {
+//		assembler.Add(stream.Current())
+//		args, _ := assembler.PartialArguments(0)
+//		render(args["path"])
+//	}
+type ToolCallAssembler struct {
+	calls   []ToolCall
+	byIndex map[int]int
+	byID    map[string]int
+}
+
+// NewToolCallAssembler creates an empty ToolCallAssembler.
+func NewToolCallAssembler() *ToolCallAssembler {
+	return &ToolCallAssembler{
+		byIndex: make(map[int]int),
+		byID:    make(map[string]int),
+	}
+}
+
+// Add consumes the tool call deltas of the first choice of a stream chunk.
+// For requests with n > 1, feed each choice to its own assembler through AddDelta.
+func (a *ToolCallAssembler) Add(chunk ChatCompletionStreamResponse) {
+	for _, choice := range chunk.Choices {
+		if choice.Index == 0 {
+			a.AddDelta(choice.Delta)
+		}
+	}
+}
+
+// AddDelta consumes the tool call fragments of a single choice delta.
+func (a *ToolCallAssembler) AddDelta(delta ChatCompletionStreamChoiceDelta) {
+	for _, fragment := range delta.ToolCalls {
+		a.addFragment(fragment)
+	}
+}
+
+func (a *ToolCallAssembler) addFragment(fragment ToolCall) {
+	pos, ok := a.lookup(fragment)
+	if !ok {
+		pos = len(a.calls)
+		call := ToolCall{Type: ToolTypeFunction}
+		if fragment.Index != nil {
+			index := *fragment.Index
+			call.Index = &index
+			a.byIndex[index] = pos
+		}
+		a.calls = append(a.calls, call)
+	}
+
+	call := &a.calls[pos]
+	if fragment.ID != "" && call.ID == "" {
+		call.ID = fragment.ID
+		a.byID[fragment.ID] = pos
+	}
+	if fragment.Type != "" {
+		call.Type = fragment.Type
+	}
+	call.Function.Name += fragment.Function.Name
+	call.Function.Arguments += fragment.Function.Arguments
+}
+
+func (a *ToolCallAssembler) lookup(fragment ToolCall) (int, bool) {
+	if fragment.Index != nil {
+		pos, ok := a.byIndex[*fragment.Index]
+		return pos, ok
+	}
+	if fragment.ID != "" {
+		pos, ok := a.byID[fragment.ID]
+		return pos, ok
+	}
+	// Continuation fragments without index or ID belong to the latest call.
+	if len(a.calls) == 0 {
+		return 0, false
+	}
+	return len(a.calls) - 1, true
+}
+
+// Len returns the number of tool calls seen so far.
+func (a *ToolCallAssembler) Len() int {
+	return len(a.calls)
+}
+
+// ToolCalls returns the tool calls assembled so far, in the order they
+// started streaming. Once the stream has finished they can be appended to
+// the conversation as the assistant message's ToolCalls.
+func (a *ToolCallAssembler) ToolCalls() []ToolCall {
+	calls := make([]ToolCall, len(a.calls))
+	copy(calls, a.calls)
+	return calls
+}
+
+// PartialArguments parses the possibly incomplete arguments of the i-th tool
+// call. Truncated string values are returned as received so far; keys whose
+// value has not started streaming yet are omitted.
+func (a *ToolCallAssembler) PartialArguments(i int) (map[string]any, error) {
+	if i < 0 || i >= len(a.calls) {
+		return nil, fmt.Errorf("tool call %d not found, %d calls assembled", i, len(a.calls))
+	}
+	value, _, err := utils.ParsePartialJSON([]byte(a.calls[i].Function.Arguments))
+	if err != nil {
+		return nil, err
+	}
+	args, ok := value.(map[string]any)
+	if !ok {
+		return map[string]any{}, nil
+	}
+	return args, nil
+}
+
+// UnmarshalPartialArguments decodes the possibly incomplete arguments of the
+// i-th tool call into v, leaving fields that have not been received untouched.
+func (a *ToolCallAssembler) UnmarshalPartialArguments(i int, v any) error {
+	args, err := a.PartialArguments(i)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
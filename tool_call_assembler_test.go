@@ -0,0 +1,82 @@
+package openai_test
+
+import (
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func toolCallChunk(calls ...openai.ToolCall) openai.ChatCompletionStreamResponse {
+	return openai.ChatCompletionStreamResponse{
+		Choices: []openai.ChatCompletionStreamChoice{
+			{Delta: openai.ChatCompletionStreamChoiceDelta{ToolCalls: calls}},
+		},
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestToolCallAssembler(t *testing.T) {
+	assembler := openai.NewToolCallAssembler()
+	assembler.Add(toolCallChunk(openai.ToolCall{
+		Index: intPtr(0), ID: "call_1", Type: openai.ToolTypeFunction,
+		Function: openai.FunctionCall{Name: "read_file"},
+	}))
+	assembler.Add(toolCallChunk(openai.ToolCall{
+		Index: intPtr(0), Function: openai.FunctionCall{Arguments: `{"path": "/etc/`},
+	}))
+
+	args, err := assembler.PartialArguments(0)
+	checks.NoError(t, err, "PartialArguments returned error")
+	if args["path"] != "/etc/" {
+		t.Fatalf("expected partial path, got %v", args)
+	}
+
+	assembler.Add(toolCallChunk(
+		openai.ToolCall{Index: intPtr(0), Function: openai.FunctionCall{Arguments: `hosts"}`}},
+		openai.ToolCall{Index: intPtr(1), ID: "call_2", Function: openai.FunctionCall{Name: "list_dir"}},
+	))
+	assembler.Add(toolCallChunk(openai.ToolCall{
+		Index: intPtr(1), Function: openai.FunctionCall{Arguments: `{"dir": "/tmp", "depth": 2}`},
+	}))
+
+	calls := assembler.ToolCalls()
+	if assembler.Len() != 2 || len(calls) != 2 {
+		t.Fatalf("expected 2 tool calls, got %d", len(calls))
+	}
+	if calls[0].ID != "call_1" || calls[0].Function.Name != "read_file" ||
+		calls[0].Function.Arguments != `{"path": "/etc/hosts"}` {
+		t.Fatalf("unexpected first call: %+v", calls[0])
+	}
+
+	var dirArgs struct {
+		Dir   string `json:"dir"`
+		Depth int    `json:"depth"`
+	}
+	checks.NoError(t, assembler.UnmarshalPartialArguments(1, &dirArgs))
+	if dirArgs.Dir != "/tmp" || dirArgs.Depth != 2 {
+		t.Fatalf("unexpected decoded arguments: %+v", dirArgs)
+	}
+
+	_, err = assembler.PartialArguments(2)
+	checks.HasError(t, err, "PartialArguments should fail for unknown call")
+}
+
+func TestToolCallAssemblerWithoutIndex(t *testing.T) {
+	assembler := openai.NewToolCallAssembler()
+	assembler.AddDelta(openai.ChatCompletionStreamChoiceDelta{ToolCalls: []openai.ToolCall{
+		{ID: "a", Function: openai.FunctionCall{Name: "f", Arguments: `{"x":`}},
+	}})
+	assembler.AddDelta(openai.ChatCompletionStreamChoiceDelta{ToolCalls: []openai.ToolCall{
+		{Function: openai.FunctionCall{Arguments: `1}`}},
+		{ID: "b", Function: openai.FunctionCall{Name: "g", Arguments: `{}`}},
+	}})
+
+	calls := assembler.ToolCalls()
+	if len(calls) != 2 || calls[0].Function.Arguments != `{"x":1}` || calls[1].ID != "b" {
+		t.Fatalf("unexpected calls: %+v", calls)
+	}
+}
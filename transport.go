@@ -0,0 +1,119 @@
+package openai
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const defaultDialKeepAlive = 30 * time.Second
+
+// TransportConfig tunes the http.Transport the client constructs when
+// ClientConfig.Transport is set. It is ignored if HTTPClient already carries a
+// custom transport.
+type TransportConfig struct {
+	// DialTimeout limits the time spent establishing a connection.
+	DialTimeout time.Duration
+	// DialContext replaces the dialer, e.g. to go through a sidecar proxy.
+	// DialTimeout and UnixSocket are ignored when it is set.
+	DialContext func(ctx context.Context, network, addr string) (net.Conn, error)
+	// UnixSocket sends every request over the Unix domain socket at this
+	// path, as exposed by local inference servers. The host of BaseURL is
+	// then only used for the Host header:
+	//
+	//	config := openai.DefaultConfig("")
+	//	config.BaseURL = "http://localhost/v1"
+	//	config.Transport = &openai.TransportConfig{UnixSocket: "/run/llama.sock"}
+	UnixSocket string
+	// TLSClientConfig customizes TLS, e.g. to trust a private CA.
+	TLSClientConfig *tls.Config
+	// CABundle is a PEM bundle of CA certificates trusted in addition to the
+	// system roots, such as the CA of a TLS-intercepting proxy. It replaces
+	// TLSClientConfig.RootCAs. Connections fail with ErrInvalidCABundle when
+	// it holds no certificate.
+	CABundle []byte
+	// PinnedPublicKeys only accepts server certificate chains containing one
+	// of these public keys, in the format returned by PublicKeyPin. Other
+	// connections fail with ErrCertificateNotPinned. Pin a CA key, or several
+	// keys, to survive certificate rotations.
+	PinnedPublicKeys []string
+	// ProxyURL routes requests through an HTTP, HTTPS or SOCKS5 proxy, except
+	// those to the hosts listed in the NO_PROXY environment variable. When nil
+	// the proxy is taken from the environment, as with http.DefaultTransport.
+	// Unix socket connections never use a proxy.
+	ProxyURL *url.URL
+	// MaxIdleConnsPerHost raises the number of kept-alive connections to the
+	// API, which matters for highly concurrent callers. The net/http default
+	// is 2.
+	MaxIdleConnsPerHost int
+
+	// HTTP2ReadIdleTimeout makes the transport ping HTTP/2 connections that
+	// received no frame for this long. Without it long-lived streams over a
+	// dead connection hang until the OS gives up.
+	//
+	// It requires Go 1.24 or later, which the module does not: when built
+	// with an older Go it is silently ignored. Check HTTP2HealthChecksSupported
+	// to detect it.
+	HTTP2ReadIdleTimeout time.Duration
+	// HTTP2PingTimeout closes the connections whose ping is not answered
+	// within it, 15s by default. Like HTTP2ReadIdleTimeout, it requires Go
+	// 1.24 or later.
+	HTTP2PingTimeout time.Duration
+}
+
+// newTransport builds a transport from http.DefaultTransport with the
+// configured settings applied.
+func (tc *TransportConfig) newTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert // set by net/http
+
+	dialer := &net.Dialer{Timeout: tc.DialTimeout, KeepAlive: defaultDialKeepAlive}
+	switch {
+	case tc.DialContext != nil:
+		transport.DialContext = tc.DialContext
+	case tc.UnixSocket != "":
+		socket := tc.UnixSocket
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", socket)
+		}
+		// Requests never leave the host, an environment proxy would break them.
+		transport.Proxy = nil
+	default:
+		transport.DialContext = dialer.DialContext
+	}
+	tlsConfig, err := tc.tlsConfig()
+	switch {
+	case err != nil:
+		// Fail the connections rather than fall back to the system roots.
+		transport.DialContext = func(context.Context, string, string) (net.Conn, error) {
+			return nil, err
+		}
+	case tlsConfig != nil:
+		transport.TLSClientConfig = tlsConfig
+	}
+	if tc.ProxyURL != nil && tc.UnixSocket == "" {
+		transport.Proxy = proxyFunc(tc.ProxyURL, noProxyFromEnvironment())
+	}
+	if tc.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = tc.MaxIdleConnsPerHost
+	}
+	configureHTTP2(transport, tc)
+	return transport
+}
+
+// httpClient returns client with the configured transport installed, unless
+// the client is not an *http.Client or already has a transport.
+func (tc *TransportConfig) httpClient(client HTTPDoer) HTTPDoer {
+	if client == nil {
+		return &http.Client{Transport: tc.newTransport()}
+	}
+	hc, ok := client.(*http.Client)
+	if !ok || hc.Transport != nil {
+		return client
+	}
+	configured := *hc
+	configured.Transport = tc.newTransport()
+	return &configured
+}
@@ -0,0 +1,19 @@
+//go:build go1.24
+
+package openai
+
+import "net/http"
+
+// HTTP2HealthChecksSupported reports whether the HTTP/2 health checks of
+// TransportConfig are applied, which requires building with Go 1.24 or later.
+const HTTP2HealthChecksSupported = true
+
+func configureHTTP2(transport *http.Transport, tc *TransportConfig) {
+	if tc.HTTP2ReadIdleTimeout <= 0 {
+		return
+	}
+	transport.HTTP2 = &http.HTTP2Config{
+		SendPingTimeout: tc.HTTP2ReadIdleTimeout,
+		PingTimeout:     tc.HTTP2PingTimeout,
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !go1.24
+
+package openai
+
+import "net/http"
+
+// HTTP2HealthChecksSupported reports whether the HTTP/2 health checks of
+// TransportConfig are applied, which requires building with Go 1.24 or later.
+const HTTP2HealthChecksSupported = false
+
+// configureHTTP2 is a no-op: HTTP/2 health checks can only be configured
+// through net/http from Go 1.24 on.
+func configureHTTP2(_ *http.Transport, _ *TransportConfig) {}
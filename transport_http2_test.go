@@ -0,0 +1,20 @@
+//go:build go1.24
+
+package openai //nolint:testpackage // testing private transport construction
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTransportConfigHTTP2(t *testing.T) {
+	if !HTTP2HealthChecksSupported {
+		t.Fatal("HTTP/2 health checks should be supported from Go 1.24 on")
+	}
+	tc := &TransportConfig{HTTP2ReadIdleTimeout: 30 * time.Second, HTTP2PingTimeout: 5 * time.Second}
+	transport := tc.newTransport()
+	if transport.HTTP2 == nil || transport.HTTP2.SendPingTimeout != 30*time.Second ||
+		transport.HTTP2.PingTimeout != 5*time.Second {
+		t.Errorf("expected HTTP/2 health checks to be configured, got %+v", transport.HTTP2)
+	}
+}
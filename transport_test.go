@@ -0,0 +1,144 @@
+package openai //nolint:testpackage // testing private transport construction
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestTransportConfig(t *testing.T) {
+	proxy, _ := url.Parse("http://proxy.internal:3128")
+	tlsConfig := &tls.Config{ServerName: "api.internal", MinVersion: tls.VersionTLS12}
+
+	config := DefaultConfig("token")
+	config.HTTPClient = &http.Client{Timeout: time.Minute}
+	config.Transport = &TransportConfig{
+		DialTimeout:         time.Second,
+		TLSClientConfig:     tlsConfig,
+		ProxyURL:            proxy,
+		MaxIdleConnsPerHost: 64,
+	}
+	client := NewClientWithConfig(config)
+
+	hc, ok := client.config.HTTPClient.(*http.Client)
+	if !ok {
+		t.Fatalf("expected *http.Client, got %T", client.config.HTTPClient)
+	}
+	if hc.Timeout != time.Minute {
+		t.Errorf("expected client timeout to be preserved, got %v", hc.Timeout)
+	}
+	transport, ok := hc.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected *http.Transport, got %T", hc.Transport)
+	}
+	if transport.TLSClientConfig != tlsConfig || transport.MaxIdleConnsPerHost != 64 {
+		t.Errorf("transport settings not applied: %+v", transport)
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://api.openai.com/v1/models", nil)
+	if got, err := transport.Proxy(req); err != nil || got.String() != proxy.String() {
+		t.Errorf("expected proxy %v, got %v, %v", proxy, got, err)
+	}
+	if config.HTTPClient.(*http.Client).Transport != nil {
+		t.Error("the caller's http.Client should not be modified")
+	}
+}
+
+func TestTransportConfigKeepsCustomTransport(t *testing.T) {
+	custom := &http.Client{Transport: http.DefaultTransport}
+	config := DefaultConfig("token")
+	config.HTTPClient = custom
+	config.Transport = &TransportConfig{MaxIdleConnsPerHost: 64}
+	if client := NewClientWithConfig(config); client.config.HTTPClient != custom {
+		t.Error("expected an HTTP client with its own transport to be kept")
+	}
+}
+
+func TestTransportConfigUnixSocket(t *testing.T) {
+	dir, err := os.MkdirTemp("", "openai")
+	checks.NoError(t, err)
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "api.sock")
+
+	listener, err := net.Listen("unix", socket)
+	checks.NoError(t, err)
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			fmt.Fprint(w, `{"object":"list","data":[{"id":"local-model"}]}`)
+		}),
+		ReadHeaderTimeout: time.Second,
+	}
+	go server.Serve(listener) //nolint:errcheck // returns when the server is closed
+	defer server.Close()
+
+	config := DefaultConfig("")
+	config.BaseURL = "http://localhost/v1"
+	config.Transport = &TransportConfig{UnixSocket: socket}
+	models, err := NewClientWithConfig(config).ListModels(context.Background())
+	checks.NoError(t, err)
+	if len(models.Models) != 1 || models.Models[0].ID != "local-model" {
+		t.Errorf("unexpected models: %+v", models.Models)
+	}
+}
+
+func TestTransportConfigDialContext(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var dialed []string
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = "http://sidecar.invalid/v1"
+	config.Transport = &TransportConfig{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dialed = append(dialed, addr)
+			var d net.Dialer
+			return d.DialContext(ctx, network, ts.Listener.Addr().String())
+		},
+	}
+	_, err := NewClientWithConfig(config).ListModels(context.Background())
+	checks.NoError(t, err)
+	if len(dialed) != 1 || dialed[0] != "sidecar.invalid:80" {
+		t.Errorf("expected the custom dialer to be used, got %q", dialed)
+	}
+}
+
+func TestChainIsPinned(t *testing.T) {
+	leaf := &x509.Certificate{RawSubjectPublicKeyInfo: []byte("leaf")}
+	intermediate := &x509.Certificate{RawSubjectPublicKeyInfo: []byte("intermediate")}
+	pins := map[string]bool{PublicKeyPin(intermediate): true}
+
+	verified := tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{leaf, intermediate},
+		VerifiedChains:   [][]*x509.Certificate{{leaf, intermediate}},
+	}
+	if !chainIsPinned(verified, pins) {
+		t.Error("expected the pinned intermediate of a verified chain to match")
+	}
+	// Without verification, the intermediates sent by the peer are not
+	// trusted.
+	unverified := tls.ConnectionState{PeerCertificates: []*x509.Certificate{leaf, intermediate}}
+	if chainIsPinned(unverified, pins) {
+		t.Error("expected an unverified intermediate not to match")
+	}
+	if !chainIsPinned(unverified, map[string]bool{PublicKeyPin(leaf): true}) {
+		t.Error("expected the unverified leaf to match")
+	}
+	if chainIsPinned(tls.ConnectionState{}, pins) {
+		t.Error("expected no peer certificates not to match")
+	}
+}
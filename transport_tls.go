@@ -0,0 +1,92 @@
+package openai
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidCABundle      = errors.New("CA bundle holds no valid PEM certificate")
+	ErrCertificateNotPinned = errors.New("server certificate chain matches no pinned public key")
+)
+
+const publicKeyPinPrefix = "sha256/"
+
+// PublicKeyPin returns the pin of the public key of cert, for
+// TransportConfig.PinnedPublicKeys: "sha256/" followed by the base64 SHA-256
+// of its SubjectPublicKeyInfo, as printed by
+//
+//	openssl x509 -pubkey -noout | openssl pkey -pubin -outform der | openssl dgst -sha256 -binary | base64
+func PublicKeyPin(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return publicKeyPinPrefix + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// tlsConfig returns the TLS configuration of the transport, with the CA
+// bundle and public key pins applied.
+func (tc *TransportConfig) tlsConfig() (*tls.Config, error) {
+	if len(tc.CABundle) == 0 && len(tc.PinnedPublicKeys) == 0 {
+		return tc.TLSClientConfig, nil
+	}
+	var config *tls.Config
+	if tc.TLSClientConfig != nil {
+		config = tc.TLSClientConfig.Clone()
+	} else {
+		config = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	if len(tc.CABundle) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(tc.CABundle) {
+			return nil, ErrInvalidCABundle
+		}
+		config.RootCAs = pool
+	}
+	if len(tc.PinnedPublicKeys) == 0 {
+		return config, nil
+	}
+
+	pins := make(map[string]bool, len(tc.PinnedPublicKeys))
+	for _, pin := range tc.PinnedPublicKeys {
+		if !strings.HasPrefix(pin, publicKeyPinPrefix) {
+			pin = publicKeyPinPrefix + pin
+		}
+		pins[pin] = true
+	}
+	verify := config.VerifyConnection
+	config.VerifyConnection = func(cs tls.ConnectionState) error {
+		if !chainIsPinned(cs, pins) {
+			return ErrCertificateNotPinned
+		}
+		if verify != nil {
+			return verify(cs)
+		}
+		return nil
+	}
+	return config, nil
+}
+
+// chainIsPinned reports whether a certificate of the verified chains has a
+// pinned key. When verification is skipped, only the leaf certificate is
+// checked, as the rest of the peer certificates are chosen by the peer and an
+// attacker could append the pinned intermediate.
+func chainIsPinned(cs tls.ConnectionState, pins map[string]bool) bool {
+	if len(cs.VerifiedChains) == 0 {
+		return len(cs.PeerCertificates) > 0 && pins[PublicKeyPin(cs.PeerCertificates[0])]
+	}
+	for _, chain := range cs.VerifiedChains {
+		for _, cert := range chain {
+			if pins[PublicKeyPin(cert)] {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package openai_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestTransportConfigCABundleAndPins(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.StartTLS()
+	defer ts.Close()
+
+	cert := ts.Certificate()
+	bundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	otherKey := sha256.Sum256([]byte("other key"))
+	otherPin := base64.StdEncoding.EncodeToString(otherKey[:])
+
+	tests := []struct {
+		name    string
+		tc      openai.TransportConfig
+		wantErr error
+		fails   bool
+	}{
+		{"system roots only", openai.TransportConfig{}, nil, true},
+		{"CA bundle", openai.TransportConfig{CABundle: bundle}, nil, false},
+		{"invalid CA bundle", openai.TransportConfig{CABundle: []byte("not PEM")}, openai.ErrInvalidCABundle, true},
+		{"pinned", openai.TransportConfig{
+			CABundle:         bundle,
+			PinnedPublicKeys: []string{otherPin, openai.PublicKeyPin(cert)},
+		}, nil, false},
+		{"pin without prefix", openai.TransportConfig{
+			CABundle:         bundle,
+			PinnedPublicKeys: []string{openai.PublicKeyPin(cert)[len("sha256/"):]},
+		}, nil, false},
+		{"not pinned", openai.TransportConfig{
+			CABundle:         bundle,
+			PinnedPublicKeys: []string{otherPin},
+		}, openai.ErrCertificateNotPinned, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := openai.DefaultConfig(test.GetTestToken())
+			config.BaseURL = ts.URL + "/v1"
+			tc := tt.tc
+			config.Transport = &tc
+			client := openai.NewClientWithConfig(config)
+
+			_, err := client.ListModels(context.Background())
+			switch {
+			case tt.wantErr != nil:
+				checks.ErrorIs(t, err, tt.wantErr)
+			case tt.fails:
+				checks.HasError(t, err)
+			default:
+				checks.NoError(t, err)
+			}
+		})
+	}
+}
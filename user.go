@@ -0,0 +1,36 @@
+package openai
+
+import "context"
+
+type userKey struct{}
+
+// WithUser returns a context that sets the end-user identifier of requests
+// made with it that do not set their own user field. It takes precedence over
+// ClientConfig.DefaultUser. Chat completion and response requests setting
+// neither field also send it as their safety identifier.
+func WithUser(ctx context.Context, user string) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// resolveUser returns the user field to send: the request's own value, else
+// the one set on ctx with WithUser, else the client's DefaultUser.
+func (c *Client) resolveUser(ctx context.Context, user string) string {
+	if user != "" {
+		return user
+	}
+	if u, _ := ctx.Value(userKey{}).(string); u != "" {
+		return u
+	}
+	return c.config.DefaultUser
+}
+
+// resolveSafetyIdentifier returns the safety_identifier field to send: the
+// request's own value, else the user set on ctx with WithUser or the client's
+// DefaultUser. It is left empty when the request sets its own user field, so
+// that requests built by the caller are sent unchanged.
+func (c *Client) resolveSafetyIdentifier(ctx context.Context, safetyIdentifier, user string) string {
+	if safetyIdentifier != "" || user != "" {
+		return safetyIdentifier
+	}
+	return c.resolveUser(ctx, "")
+}
@@ -0,0 +1,105 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestDefaultUser(t *testing.T) {
+	client, server := setupOpenAITestServerWithConfig(t, func(config *openai.ClientConfig) {
+		config.DefaultUser = "default-user"
+	})
+	var gotUser, gotSafetyIdentifier string
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		var req openai.ChatCompletionRequest
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		gotUser, gotSafetyIdentifier = req.User, req.SafetyIdentifier
+		fmt.Fprint(w, `{"id":"chatcmpl-1","object":"chat.completion","choices":[]}`)
+	})
+	server.RegisterHandler("/v1/responses", func(w http.ResponseWriter, r *http.Request) {
+		var req openai.ResponseRequest
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		gotUser, gotSafetyIdentifier = req.User, req.SafetyIdentifier
+		fmt.Fprint(w, `{"id":"resp_1","status":"completed"}`)
+	})
+	server.RegisterHandler("/v1/embeddings", func(w http.ResponseWriter, r *http.Request) {
+		var req openai.EmbeddingRequest
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		gotUser = req.User
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	})
+
+	chatReq := openai.ChatCompletionRequest{
+		Model:    openai.GPT4o,
+		Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "Hello!"}},
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		user       string
+		want       string
+		wantSafety string
+	}{
+		{"config default", ctx, "", "default-user", "default-user"},
+		{"context overrides default", openai.WithUser(ctx, "ctx-user"), "", "ctx-user", "ctx-user"},
+		// An explicit user is sent as is, without a safety identifier.
+		{"request overrides context", openai.WithUser(ctx, "ctx-user"), "req-user", "req-user", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := chatReq
+			req.User = tt.user
+			_, err := client.CreateChatCompletion(tt.ctx, req)
+			checks.NoError(t, err)
+			if gotUser != tt.want || gotSafetyIdentifier != tt.wantSafety {
+				t.Errorf("chat user = %q, safety identifier = %q, want %q, %q",
+					gotUser, gotSafetyIdentifier, tt.want, tt.wantSafety)
+			}
+
+			_, err = client.CreateResponse(tt.ctx, openai.ResponseRequest{Model: openai.GPT4o, Input: "Hello!", User: tt.user})
+			checks.NoError(t, err)
+			if gotUser != tt.want || gotSafetyIdentifier != tt.wantSafety {
+				t.Errorf("response user = %q, safety identifier = %q, want %q, %q",
+					gotUser, gotSafetyIdentifier, tt.want, tt.wantSafety)
+			}
+
+			_, err = client.CreateEmbeddings(tt.ctx, openai.EmbeddingRequestStrings{
+				Input: []string{"hello"},
+				Model: openai.SmallEmbedding3,
+				User:  tt.user,
+			})
+			checks.NoError(t, err)
+			if gotUser != tt.want {
+				t.Errorf("embeddings user = %q, want %q", gotUser, tt.want)
+			}
+		})
+	}
+}
+
+func TestSafetyIdentifierOverridesUser(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	var got openai.ChatCompletionRequest
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		fmt.Fprint(w, `{"id":"chatcmpl-1","object":"chat.completion","choices":[]}`)
+	})
+
+	_, err := client.CreateChatCompletion(openai.WithUser(context.Background(), "ctx-user"), openai.ChatCompletionRequest{
+		Model:            openai.GPT4o,
+		Messages:         []openai.ChatCompletionMessage{openai.UserMessage("Hello!")},
+		SafetyIdentifier: "hashed-id",
+	})
+	checks.NoError(t, err)
+	if got.User != "ctx-user" || got.SafetyIdentifier != "hashed-id" {
+		t.Errorf("user = %q, safety identifier = %q", got.User, got.SafetyIdentifier)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"net/url"
 )
 
 const (
@@ -112,7 +111,6 @@ func (c *Client) CreateVectorStore(ctx context.Context, request VectorStoreReque
 		http.MethodPost,
 		c.fullURL(vectorStoresSuffix),
 		withBody(request),
-		withBetaAssistantVersion(c.config.AssistantVersion),
 	)
 
 	err = c.sendRequest(req, &response)
@@ -125,8 +123,7 @@ func (c *Client) RetrieveVectorStore(
 	vectorStoreID string,
 ) (response VectorStore, err error) {
 	urlSuffix := fmt.Sprintf("%s/%s", vectorStoresSuffix, vectorStoreID)
-	req, _ := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+	req, _ := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
 
 	err = c.sendRequest(req, &response)
 	return
@@ -139,8 +136,7 @@ func (c *Client) ModifyVectorStore(
 	request VectorStoreRequest,
 ) (response VectorStore, err error) {
 	urlSuffix := fmt.Sprintf("%s/%s", vectorStoresSuffix, vectorStoreID)
-	req, _ := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix), withBody(request),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+	req, _ := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix), withBody(request))
 
 	err = c.sendRequest(req, &response)
 	return
@@ -152,8 +148,7 @@ func (c *Client) DeleteVectorStore(
 	vectorStoreID string,
 ) (response VectorStoreDeleteResponse, err error) {
 	urlSuffix := fmt.Sprintf("%s/%s", vectorStoresSuffix, vectorStoreID)
-	req, _ := c.newRequest(ctx, http.MethodDelete, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+	req, _ := c.newRequest(ctx, http.MethodDelete, c.fullURL(urlSuffix))
 
 	err = c.sendRequest(req, &response)
 	return
@@ -164,29 +159,8 @@ func (c *Client) ListVectorStores(
 	ctx context.Context,
 	pagination Pagination,
 ) (response VectorStoresList, err error) {
-	urlValues := url.Values{}
-
-	if pagination.After != nil {
-		urlValues.Add("after", *pagination.After)
-	}
-	if pagination.Order != nil {
-		urlValues.Add("order", *pagination.Order)
-	}
-	if pagination.Limit != nil {
-		urlValues.Add("limit", fmt.Sprintf("%d", *pagination.Limit))
-	}
-	if pagination.Before != nil {
-		urlValues.Add("before", *pagination.Before)
-	}
-
-	encodedValues := ""
-	if len(urlValues) > 0 {
-		encodedValues = "?" + urlValues.Encode()
-	}
-
-	urlSuffix := fmt.Sprintf("%s%s", vectorStoresSuffix, encodedValues)
-	req, _ := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+	urlSuffix := withQuery(vectorStoresSuffix, pagination.values())
+	req, _ := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
 
 	err = c.sendRequest(req, &response)
 	return
@@ -200,8 +174,7 @@ func (c *Client) CreateVectorStoreFile(
 ) (response VectorStoreFile, err error) {
 	urlSuffix := fmt.Sprintf("%s/%s%s", vectorStoresSuffix, vectorStoreID, vectorStoresFilesSuffix)
 	req, _ := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix),
-		withBody(request),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBody(request))
 
 	err = c.sendRequest(req, &response)
 	return
@@ -214,8 +187,7 @@ func (c *Client) RetrieveVectorStoreFile(
 	fileID string,
 ) (response VectorStoreFile, err error) {
 	urlSuffix := fmt.Sprintf("%s/%s%s/%s", vectorStoresSuffix, vectorStoreID, vectorStoresFilesSuffix, fileID)
-	req, _ := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+	req, _ := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
 
 	err = c.sendRequest(req, &response)
 	return
@@ -228,8 +200,7 @@ func (c *Client) DeleteVectorStoreFile(
 	fileID string,
 ) (err error) {
 	urlSuffix := fmt.Sprintf("%s/%s%s/%s", vectorStoresSuffix, vectorStoreID, vectorStoresFilesSuffix, fileID)
-	req, _ := c.newRequest(ctx, http.MethodDelete, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+	req, _ := c.newRequest(ctx, http.MethodDelete, c.fullURL(urlSuffix))
 
 	err = c.sendRequest(req, nil)
 	return
@@ -241,28 +212,9 @@ func (c *Client) ListVectorStoreFiles(
 	vectorStoreID string,
 	pagination Pagination,
 ) (response VectorStoreFilesList, err error) {
-	urlValues := url.Values{}
-	if pagination.After != nil {
-		urlValues.Add("after", *pagination.After)
-	}
-	if pagination.Limit != nil {
-		urlValues.Add("limit", fmt.Sprintf("%d", *pagination.Limit))
-	}
-	if pagination.Before != nil {
-		urlValues.Add("before", *pagination.Before)
-	}
-	if pagination.Order != nil {
-		urlValues.Add("order", *pagination.Order)
-	}
-
-	encodedValues := ""
-	if len(urlValues) > 0 {
-		encodedValues = "?" + urlValues.Encode()
-	}
-
-	urlSuffix := fmt.Sprintf("%s/%s%s%s", vectorStoresSuffix, vectorStoreID, vectorStoresFilesSuffix, encodedValues)
-	req, _ := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+	urlSuffix := withQuery(fmt.Sprintf("%s/%s%s", vectorStoresSuffix, vectorStoreID, vectorStoresFilesSuffix),
+		pagination.values())
+	req, _ := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
 
 	err = c.sendRequest(req, &response)
 	return
@@ -276,8 +228,7 @@ func (c *Client) CreateVectorStoreFileBatch(
 ) (response VectorStoreFileBatch, err error) {
 	urlSuffix := fmt.Sprintf("%s/%s%s", vectorStoresSuffix, vectorStoreID, vectorStoresFileBatchesSuffix)
 	req, _ := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix),
-		withBody(request),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBody(request))
 
 	err = c.sendRequest(req, &response)
 	return
@@ -290,8 +241,7 @@ func (c *Client) RetrieveVectorStoreFileBatch(
 	batchID string,
 ) (response VectorStoreFileBatch, err error) {
 	urlSuffix := fmt.Sprintf("%s/%s%s/%s", vectorStoresSuffix, vectorStoreID, vectorStoresFileBatchesSuffix, batchID)
-	req, _ := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+	req, _ := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
 
 	err = c.sendRequest(req, &response)
 	return
@@ -305,8 +255,7 @@ func (c *Client) CancelVectorStoreFileBatch(
 ) (response VectorStoreFileBatch, err error) {
 	urlSuffix := fmt.Sprintf("%s/%s%s/%s%s", vectorStoresSuffix,
 		vectorStoreID, vectorStoresFileBatchesSuffix, batchID, "/cancel")
-	req, _ := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+	req, _ := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix))
 
 	err = c.sendRequest(req, &response)
 	return
@@ -319,29 +268,9 @@ func (c *Client) ListVectorStoreFilesInBatch(
 	batchID string,
 	pagination Pagination,
 ) (response VectorStoreFilesList, err error) {
-	urlValues := url.Values{}
-	if pagination.After != nil {
-		urlValues.Add("after", *pagination.After)
-	}
-	if pagination.Limit != nil {
-		urlValues.Add("limit", fmt.Sprintf("%d", *pagination.Limit))
-	}
-	if pagination.Before != nil {
-		urlValues.Add("before", *pagination.Before)
-	}
-	if pagination.Order != nil {
-		urlValues.Add("order", *pagination.Order)
-	}
-
-	encodedValues := ""
-	if len(urlValues) > 0 {
-		encodedValues = "?" + urlValues.Encode()
-	}
-
-	urlSuffix := fmt.Sprintf("%s/%s%s/%s%s%s", vectorStoresSuffix,
-		vectorStoreID, vectorStoresFileBatchesSuffix, batchID, "/files", encodedValues)
-	req, _ := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+	urlSuffix := withQuery(fmt.Sprintf("%s/%s%s/%s/files", vectorStoresSuffix,
+		vectorStoreID, vectorStoresFileBatchesSuffix, batchID), pagination.values())
+	req, _ := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
 
 	err = c.sendRequest(req, &response)
 	return
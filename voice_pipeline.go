@@ -0,0 +1,208 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// minSpokenSentence is the length below which sentences are merged with the
+// following one rather than synthesized alone, to avoid choppy speech.
+const minSpokenSentence = 20
+
+var ErrNoSpeech = errors.New("no speech in audio")
+
+// VoicePipelineConfig configures a VoicePipeline. Each request is a template
+// for its stage: the pipeline fills in the audio, the messages and the input
+// of each turn.
+type VoicePipelineConfig struct {
+	// Transcription sets the model, language and prompt of transcriptions.
+	Transcription AudioRequest
+	// Chat sets the model and parameters of replies. Its Messages, such as
+	// a system prompt, precede the conversation in every request.
+	Chat ChatCompletionRequest
+	// Speech sets the model, voice and format of the spoken replies.
+	Speech CreateSpeechRequest
+
+	// WholeReply synthesizes the reply once it is complete rather than
+	// sentence by sentence as it streams, trading latency for a more
+	// natural intonation.
+	WholeReply bool
+
+	// OnTranscript, when set, receives the transcript of each turn before
+	// the reply is generated.
+	OnTranscript func(transcript string)
+	// OnReplyDelta, when set, receives the text of the reply as it streams.
+	OnReplyDelta func(delta string)
+}
+
+// VoicePipeline answers spoken messages with spoken replies, chaining a
+// transcription, a streamed chat completion and speech synthesis, and keeps
+// the conversation history across turns:
+//
+//	pipeline := client.NewVoicePipeline(openai.VoicePipelineConfig{
+//		Transcription: openai.AudioRequest{Model: openai.Whisper1},
+//		Chat: openai.ChatCompletionRequest{
+//			Model:    openai.GPT4oMini,
+//			Messages: []openai.ChatCompletionMessage{openai.SystemMessage("Answer briefly.")},
+//		},
+//		Speech: openai.CreateSpeechRequest{Model: openai.TTSModel1, Voice: openai.VoiceAlloy},
+//	})
+//	turn, err := pipeline.Turn(ctx, "question.wav", recording, speaker)
+//
+// A VoicePipeline is not safe for concurrent use.
+type VoicePipeline struct {
+	client  *Client
+	config  VoicePipelineConfig
+	history []ChatCompletionMessage
+}
+
+// VoiceTurn is the result of a turn of a VoicePipeline.
+type VoiceTurn struct {
+	Transcription AudioResponse
+	Reply         ChatCompletionResponse
+}
+
+// Transcript returns the text of the spoken message.
+func (t VoiceTurn) Transcript() string {
+	return strings.TrimSpace(t.Transcription.Text)
+}
+
+// ReplyText returns the text of the reply.
+func (t VoiceTurn) ReplyText() string {
+	if len(t.Reply.Choices) == 0 {
+		return ""
+	}
+	return t.Reply.Choices[0].Message.Content
+}
+
+// NewVoicePipeline creates a VoicePipeline with an empty history.
+func (c *Client) NewVoicePipeline(config VoicePipelineConfig) *VoicePipeline {
+	return &VoicePipeline{client: c, config: config}
+}
+
+// Turn transcribes the audio read from audio, named name (e.g. "turn.wav"),
+// generates a reply given the conversation so far and writes it to out as
+// speech in the configured format. The transcript and the reply are then
+// added to the history. Audio without speech fails with ErrNoSpeech and
+// leaves the history unchanged.
+//
+// If synthesis fails, the turn is returned along with the error and the
+// reply is still added to the history, since it was generated.
+func (p *VoicePipeline) Turn(
+	ctx context.Context,
+	name string,
+	audio io.Reader,
+	out io.Writer,
+) (turn VoiceTurn, err error) {
+	transcription := p.config.Transcription
+	transcription.FilePath = name
+	transcription.Reader = audio
+	turn.Transcription, err = p.client.CreateTranscription(ctx, transcription)
+	if err != nil {
+		err = fmt.Errorf("error, transcribing audio: %w", err)
+		return
+	}
+	transcript := turn.Transcript()
+	if transcript == "" {
+		err = ErrNoSpeech
+		return
+	}
+	if p.config.OnTranscript != nil {
+		p.config.OnTranscript(transcript)
+	}
+
+	chat := p.config.Chat
+	chat.Messages = append(append(append([]ChatCompletionMessage(nil), chat.Messages...), p.history...),
+		UserMessage(transcript))
+	stream, err := p.client.CreateChatCompletionStream(ctx, chat)
+	if err != nil {
+		err = fmt.Errorf("error, generating reply: %w", err)
+		return
+	}
+	defer stream.Close()
+
+	acc := NewChatCompletionAccumulator()
+	var pending strings.Builder
+	var speakErr error
+	for stream.Next() {
+		chunk := stream.Current()
+		acc.Add(chunk)
+		for _, choice := range chunk.Choices {
+			delta := choice.Delta.Content
+			if choice.Index != 0 || delta == "" {
+				continue
+			}
+			if p.config.OnReplyDelta != nil {
+				p.config.OnReplyDelta(delta)
+			}
+			pending.WriteString(delta)
+			if p.config.WholeReply || speakErr != nil {
+				continue
+			}
+			if sentences, rest := splitSentences(pending.String()); sentences != "" {
+				pending.Reset()
+				pending.WriteString(rest)
+				speakErr = p.speak(ctx, sentences, out)
+			}
+		}
+	}
+	turn.Reply = acc.Response()
+	if err = stream.Err(); err != nil {
+		err = fmt.Errorf("error, generating reply: %w", err)
+		return
+	}
+
+	p.history = append(p.history, UserMessage(transcript), AssistantMessage(turn.ReplyText()))
+	if speakErr == nil {
+		speakErr = p.speak(ctx, pending.String(), out)
+	}
+	err = speakErr
+	return
+}
+
+// History returns the messages exchanged so far, without the messages of
+// VoicePipelineConfig.Chat.
+func (p *VoicePipeline) History() []ChatCompletionMessage {
+	return append([]ChatCompletionMessage(nil), p.history...)
+}
+
+// Reset clears the history.
+func (p *VoicePipeline) Reset() {
+	p.history = nil
+}
+
+// speak synthesizes text and writes the audio to out.
+func (p *VoicePipeline) speak(ctx context.Context, text string, out io.Writer) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+	request := p.config.Speech
+	request.Input = text
+	speech, err := p.client.CreateSpeech(ctx, request)
+	if err != nil {
+		return fmt.Errorf("error, synthesizing speech: %w", err)
+	}
+	defer speech.Close()
+	if _, err = io.Copy(out, speech); err != nil {
+		return fmt.Errorf("error, synthesizing speech: %w", err)
+	}
+	return nil
+}
+
+// splitSentences splits text after its last complete sentence at least
+// minSpokenSentence long, returning the sentences and the rest.
+func splitSentences(text string) (sentences, rest string) {
+	for i := len(text) - 2; i >= minSpokenSentence-1; i-- {
+		switch text[i] {
+		case '.', '!', '?', '\n':
+			if text[i+1] == ' ' || text[i+1] == '\n' {
+				return text[:i+1], text[i+1:]
+			}
+		}
+	}
+	return "", text
+}
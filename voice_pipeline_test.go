@@ -0,0 +1,116 @@
+package openai_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func voicePipelineServer(t *testing.T, transcript string, reply []string) (*openai.Client, *[]string, *[][]string) {
+	t.Helper()
+	client, server, teardown := setupOpenAITestServer()
+	t.Cleanup(teardown)
+
+	var spoken []string
+	var conversations [][]string
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `{"text":%q}`, transcript)
+	})
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		var request openai.ChatCompletionRequest
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		var messages []string
+		for _, message := range request.Messages {
+			messages = append(messages, message.Role+":"+message.Content)
+		}
+		conversations = append(conversations, messages)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, delta := range reply {
+			data, _ := json.Marshal(openai.ChatCompletionStreamResponse{Choices: []openai.ChatCompletionStreamChoice{
+				{Delta: openai.ChatCompletionStreamChoiceDelta{Content: delta}},
+			}})
+			fmt.Fprintf(w, "data: %s\n\n", data)
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	})
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, r *http.Request) {
+		var request openai.CreateSpeechRequest
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		spoken = append(spoken, request.Input)
+		fmt.Fprintf(w, "[%s]", request.Input)
+	})
+	return client, &spoken, &conversations
+}
+
+func TestVoicePipeline(t *testing.T) {
+	reply := []string{"Paris is the capital", " of France. It is", " known for the Eiffel Tower!", " Anything else?"}
+	client, spoken, conversations := voicePipelineServer(t, " What is the capital of France? ", reply)
+
+	var transcripts, deltas []string
+	pipeline := client.NewVoicePipeline(openai.VoicePipelineConfig{
+		Transcription: openai.AudioRequest{Model: openai.Whisper1},
+		Chat: openai.ChatCompletionRequest{
+			Model:    openai.GPT4oMini,
+			Messages: []openai.ChatCompletionMessage{openai.SystemMessage("Answer briefly.")},
+		},
+		Speech:       openai.CreateSpeechRequest{Model: openai.TTSModel1, Voice: openai.VoiceAlloy},
+		OnTranscript: func(transcript string) { transcripts = append(transcripts, transcript) },
+		OnReplyDelta: func(delta string) { deltas = append(deltas, delta) },
+	})
+
+	var out bytes.Buffer
+	turn, err := pipeline.Turn(context.Background(), "q.wav", strings.NewReader("audio"), &out)
+	checks.NoError(t, err, "Turn error")
+
+	if turn.Transcript() != "What is the capital of France?" || len(transcripts) != 1 {
+		t.Errorf("transcript = %q, callbacks %q", turn.Transcript(), transcripts)
+	}
+	wantReply := strings.Join(reply, "")
+	if turn.ReplyText() != wantReply || strings.Join(deltas, "") != wantReply {
+		t.Errorf("reply = %q, deltas %q", turn.ReplyText(), deltas)
+	}
+	wantSpoken := []string{"Paris is the capital of France.", "It is known for the Eiffel Tower!", "Anything else?"}
+	if fmt.Sprint(*spoken) != fmt.Sprint(wantSpoken) {
+		t.Errorf("spoken = %q, want %q", *spoken, wantSpoken)
+	}
+	if out.String() != "[Paris is the capital of France.][It is known for the Eiffel Tower!][Anything else?]" {
+		t.Errorf("audio = %q", out.String())
+	}
+
+	_, err = pipeline.Turn(context.Background(), "q.wav", strings.NewReader("audio"), io.Discard)
+	checks.NoError(t, err, "second Turn error")
+	second := (*conversations)[1]
+	if len(second) != 4 || second[0] != "system:Answer briefly." || second[2] != "assistant:"+wantReply {
+		t.Errorf("second turn messages = %q", second)
+	}
+	if len(pipeline.History()) != 4 {
+		t.Errorf("history has %d messages, want 4", len(pipeline.History()))
+	}
+}
+
+func TestVoicePipelineWholeReplyAndSilence(t *testing.T) {
+	client, spoken, _ := voicePipelineServer(t, "Hi.", []string{"Hello there. ", "How can I help?"})
+	pipeline := client.NewVoicePipeline(openai.VoicePipelineConfig{WholeReply: true})
+	_, err := pipeline.Turn(context.Background(), "q.wav", strings.NewReader("audio"), io.Discard)
+	checks.NoError(t, err, "Turn error")
+	if len(*spoken) != 1 || (*spoken)[0] != "Hello there. How can I help?" {
+		t.Errorf("spoken = %q", *spoken)
+	}
+
+	client, _, _ = voicePipelineServer(t, "  ", nil)
+	pipeline = client.NewVoicePipeline(openai.VoicePipelineConfig{})
+	_, err = pipeline.Turn(context.Background(), "q.wav", strings.NewReader("audio"), io.Discard)
+	checks.ErrorIs(t, err, openai.ErrNoSpeech, "silent audio accepted")
+	if len(pipeline.History()) != 0 {
+		t.Error("silent turn added to the history")
+	}
+}